@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	streamBlocks               = "blocks"
+	streamBlockMetadata        = "block-metadata"
+	streamCommitments          = "commitments"
+	streamLedgerUpdates        = "ledger-updates"
+	streamAcceptedTransactions = "accepted-transactions"
+)
+
+func followCmd() *cobra.Command {
+	var startSlot uint32
+
+	cmd := &cobra.Command{
+		Use:       fmt.Sprintf("follow <%s|%s|%s|%s|%s>", streamBlocks, streamBlockMetadata, streamCommitments, streamLedgerUpdates, streamAcceptedTransactions),
+		Short:     "Follow a stream of the node and print every message",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{streamBlocks, streamBlockMetadata, streamCommitments, streamLedgerUpdates, streamAcceptedTransactions},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				err := follow(ctx, nodeBridge, args[0], iotago.SlotIndex(startSlot))
+				if ierrors.Is(err, context.Canceled) {
+					return nil
+				}
+
+				return err
+			})
+		},
+	}
+	cmd.Flags().Uint32Var(&startSlot, "start-slot", 0, "the slot to start from for commitments and ledger updates (0 = latest)")
+
+	return cmd
+}
+
+func follow(ctx context.Context, nodeBridge nodebridge.NodeBridge, stream string, startSlot iotago.SlotIndex) error {
+	switch stream {
+	case streamBlocks:
+		return nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, _ []byte) error {
+			return printAPIObject(block.API, block)
+		})
+
+	case streamBlockMetadata:
+		return nodeBridge.ListenToBlockMetadata(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			return printAPIObject(nodeBridge.APIProvider().APIForSlot(blockMetadata.BlockID.Slot()), blockMetadata)
+		})
+
+	case streamCommitments:
+		return nodeBridge.ListenToCommitments(ctx, startSlot, 0, func(commitment *nodebridge.Commitment, _ []byte) error {
+			return printAPIObject(nodeBridge.APIProvider().APIForSlot(commitment.CommitmentID.Slot()), commitment.Commitment)
+		})
+
+	case streamLedgerUpdates:
+		return nodeBridge.ListenToLedgerUpdates(ctx, startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+			_, err := fmt.Fprintf(os.Stdout, "ledger update %s: %d created, %d consumed\n", update.CommitmentID.ToHex(), len(update.Created), len(update.Consumed))
+
+			return err
+		})
+
+	case streamAcceptedTransactions:
+		return nodeBridge.ListenToAcceptedTransactions(ctx, func(tx *nodebridge.AcceptedTransaction) error {
+			_, err := fmt.Fprintf(os.Stdout, "accepted transaction %s in slot %d: %d created, %d consumed\n", tx.TransactionID.ToHex(), tx.Slot, len(tx.Created), len(tx.Consumed))
+
+			return err
+		})
+
+	default:
+		return ierrors.Errorf("unknown stream %s", stream)
+	}
+}
@@ -0,0 +1,122 @@
+// inx-cli is a small command line tool on top of the NodeBridge to debug INX setups.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	inxAddress     string
+	timeout        time.Duration
+	connectRetries uint
+	verbose        bool
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:           "inx-cli",
+		Short:         "Debug INX setups by talking to a node via the NodeBridge",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	rootCmd.PersistentFlags().StringVar(&inxAddress, "inx-address", "localhost:9029", "the INX address of the node")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 5*time.Second, "the timeout of a single request")
+	rootCmd.PersistentFlags().UintVar(&connectRetries, "connect-retries", 1, "the maximum number of connection attempts")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "log the debug output of the NodeBridge")
+
+	rootCmd.AddCommand(
+		statusCmd(),
+		blockCmd(),
+		blockMetadataCmd(),
+		outputCmd(),
+		commitmentCmd(),
+		submitBlockCmd(),
+		followCmd(),
+		registerRouteCmd(),
+		unregisterRouteCmd(),
+	)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		cancel()
+		os.Exit(1) //nolint:gocritic // cancel is called explicitly
+	}
+}
+
+// withNodeBridge connects to the node and calls the given function with a running NodeBridge.
+func withNodeBridge(ctx context.Context, f func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error) error {
+	level := log.LevelWarning
+	if verbose {
+		level = log.LevelDebug
+	}
+	logger := log.NewLogger(log.WithName("inx-cli"), log.WithLevel(level), log.WithOutput(os.Stderr))
+
+	nodeBridge := nodebridge.New(logger, nodebridge.WithDefaultCallTimeout(timeout))
+	if err := nodeBridge.Connect(ctx, inxAddress, connectRetries); err != nil {
+		return ierrors.Wrapf(err, "failed to connect to %s", inxAddress)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go nodeBridge.Run(ctx)
+
+	return f(ctx, nodeBridge)
+}
+
+// requestContext returns a context that is canceled after the configured timeout.
+func requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, timeout)
+}
+
+// printAPIObject prints the given object as indented JSON encoded with the given API.
+func printAPIObject(api iotago.API, obj any) error {
+	data, err := api.JSONEncode(obj)
+	if err != nil {
+		return ierrors.Wrap(err, "failed to encode object")
+	}
+
+	return printRawJSON(data)
+}
+
+// printProtoMessage prints the given protobuf message as indented JSON.
+func printProtoMessage(message proto.Message) error {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return ierrors.Wrap(err, "failed to encode message")
+	}
+
+	return printRawJSON(data)
+}
+
+func printRawJSON(data []byte) error {
+	var out json.RawMessage = data
+
+	indented, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return ierrors.Wrap(err, "failed to indent JSON")
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(indented))
+
+	return err
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+func statusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Print the node status",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withNodeBridge(cmd.Context(), func(_ context.Context, nodeBridge nodebridge.NodeBridge) error {
+				nodeStatus := nodeBridge.NodeStatus()
+				if nodeStatus == nil {
+					return nodebridge.ErrUnavailable
+				}
+
+				return printProtoMessage(nodeStatus)
+			})
+		},
+	}
+}
+
+func blockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "block <blockID>",
+		Short: "Print the block with the given ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockID, err := iotago.BlockIDFromHexString(args[0])
+			if err != nil {
+				return ierrors.Wrapf(err, "invalid block ID %s", args[0])
+			}
+
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				block, err := nodeBridge.Block(ctx, blockID)
+				if err != nil {
+					return err
+				}
+
+				return printAPIObject(block.API, block)
+			})
+		},
+	}
+}
+
+func blockMetadataCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "block-metadata <blockID>",
+		Short: "Print the metadata of the block with the given ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockID, err := iotago.BlockIDFromHexString(args[0])
+			if err != nil {
+				return ierrors.Wrapf(err, "invalid block ID %s", args[0])
+			}
+
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				blockMetadata, err := nodeBridge.BlockMetadata(ctx, blockID)
+				if err != nil {
+					return err
+				}
+
+				return printAPIObject(nodeBridge.APIProvider().APIForSlot(blockID.Slot()), blockMetadata)
+			})
+		},
+	}
+}
+
+func outputCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "output <outputID>",
+		Short: "Print the output with the given ID including its metadata",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputID, err := iotago.OutputIDFromHexString(args[0])
+			if err != nil {
+				return ierrors.Wrapf(err, "invalid output ID %s", args[0])
+			}
+
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				output, err := nodeBridge.Output(ctx, outputID)
+				if err != nil {
+					return err
+				}
+
+				return printAPIObject(nodeBridge.APIProvider().APIForSlot(outputID.Slot()), &api.OutputWithMetadataResponse{
+					Output:        output.Output,
+					OutputIDProof: output.OutputIDProof,
+					Metadata:      output.Metadata,
+				})
+			})
+		},
+	}
+}
+
+func commitmentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "commitment <commitmentID|slot>",
+		Short: "Print the commitment with the given ID or of the given slot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				var commitment *nodebridge.Commitment
+				if slot, err := strconv.ParseUint(args[0], 10, 32); err == nil {
+					commitment, err = nodeBridge.Commitment(ctx, iotago.SlotIndex(slot))
+					if err != nil {
+						return err
+					}
+				} else {
+					commitmentID, err := iotago.CommitmentIDFromHexString(args[0])
+					if err != nil {
+						return ierrors.Wrapf(err, "invalid commitment ID or slot %s", args[0])
+					}
+
+					commitment, err = nodeBridge.CommitmentByID(ctx, commitmentID)
+					if err != nil {
+						return err
+					}
+				}
+
+				return printAPIObject(nodeBridge.APIProvider().APIForSlot(commitment.CommitmentID.Slot()), commitment.Commitment)
+			})
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+func registerRouteCmd() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "register-route <route> <bindAddress>",
+		Short: "Register an API route at the node that is proxied to the given bind address",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				if err := nodeBridge.RegisterAPIRoute(ctx, args[0], args[1], path); err != nil {
+					return err
+				}
+
+				_, err := fmt.Fprintf(os.Stdout, "registered route %s => %s%s\n", args[0], args[1], path)
+
+				return err
+			})
+		},
+	}
+	cmd.Flags().StringVar(&path, "path", "", "the path prefix on the bind address")
+
+	return cmd
+}
+
+func unregisterRouteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unregister-route <route>",
+		Short: "Unregister an API route at the node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				if err := nodeBridge.UnregisterAPIRoute(ctx, args[0]); err != nil {
+					return err
+				}
+
+				_, err := fmt.Fprintf(os.Stdout, "unregistered route %s\n", args[0])
+
+				return err
+			})
+		},
+	}
+}
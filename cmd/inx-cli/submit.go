@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+func submitBlockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "submit-block <file>",
+		Short: "Submit the block in the given file, either JSON (.json) or binary encoded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to read %s", args[0])
+			}
+
+			return withNodeBridge(cmd.Context(), func(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+				block, err := parseBlock(nodeBridge.APIProvider(), args[0], data)
+				if err != nil {
+					return err
+				}
+
+				ctx, cancel := requestContext(ctx)
+				defer cancel()
+
+				blockID, err := nodeBridge.SubmitBlock(ctx, block)
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintln(os.Stdout, blockID.ToHex())
+
+				return err
+			})
+		},
+	}
+}
+
+func parseBlock(apiProvider iotago.APIProvider, fileName string, data []byte) (*iotago.Block, error) {
+	if !strings.EqualFold(filepath.Ext(fileName), ".json") {
+		block, _, err := iotago.BlockFromBytes(apiProvider)(data)
+		if err != nil {
+			return nil, ierrors.Wrap(err, "failed to parse binary block")
+		}
+
+		return block, nil
+	}
+
+	block := &iotago.Block{}
+	if err := apiProvider.CommittedAPI().JSONDecode(data, block, serix.WithValidation()); err != nil {
+		return nil, ierrors.Wrap(err, "failed to parse JSON block")
+	}
+
+	// the API is not part of the JSON, so use the API of the version of the block
+	api, err := apiProvider.APIForVersion(block.Header.ProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+	block.API = api
+
+	return block, nil
+}
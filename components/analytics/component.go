@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/analytics"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+const PriorityStopAnalytics = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "Analytics",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsAnalytics.Enabled
+		},
+		Provide: provide,
+		Run:     run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	LatencyTracker    *analytics.LatencyTracker
+	ThroughputTracker *analytics.ThroughputTracker
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+// provide provides the LatencyTracker and the ThroughputTracker, so other components (e.g. Prometheus) can expose their statistics.
+// Components that depend on them should mark the dependencies as optional, because the component can be disabled.
+func provide(c *dig.Container) error {
+	if err := c.Provide(func(nodeBridge nodebridge.NodeBridge) *analytics.LatencyTracker {
+		return analytics.NewLatencyTracker(nodeBridge,
+			analytics.WithLatencyTrackerMaxTrackedBlocks(ParamsAnalytics.MaxTrackedBlocks),
+		)
+	}); err != nil {
+		return err
+	}
+
+	return c.Provide(func(nodeBridge nodebridge.NodeBridge) *analytics.ThroughputTracker {
+		return analytics.NewThroughputTracker(nodeBridge,
+			analytics.WithThroughputWindow(ParamsAnalytics.ThroughputWindow),
+		)
+	})
+}
+
+func run() error {
+	if err := Component.Daemon().BackgroundWorker("Analytics[Latency]", func(ctx context.Context) {
+		Component.LogInfo("Starting block latency analytics ...")
+		if err := deps.LatencyTracker.Run(ctx); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped block latency analytics due to an error (%s)", err)
+		}
+		Component.LogInfo("Stopped block latency analytics")
+	}, PriorityStopAnalytics); err != nil {
+		return err
+	}
+
+	return Component.Daemon().BackgroundWorker("Analytics[Throughput]", func(ctx context.Context) {
+		Component.LogInfo("Starting throughput analytics ...")
+		if err := deps.ThroughputTracker.Run(ctx); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped throughput analytics due to an error (%s)", err)
+		}
+		Component.LogInfo("Stopped throughput analytics")
+	}, PriorityStopAnalytics)
+}
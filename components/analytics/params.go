@@ -0,0 +1,26 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersAnalytics contains the definition of the parameters used by the analytics component.
+type ParametersAnalytics struct {
+	// Enabled defines whether the analytics component is enabled.
+	Enabled bool `default:"false" usage:"whether the analytics component is enabled"`
+	// MaxTrackedBlocks defines the maximum amount of blocks whose latencies are tracked at the same time.
+	MaxTrackedBlocks int `default:"100000" usage:"the maximum amount of blocks whose latencies are tracked at the same time"`
+	// ThroughputWindow defines the duration of the sliding window the throughput rates are calculated over.
+	ThroughputWindow time.Duration `default:"1m" usage:"the duration of the sliding window the throughput rates are calculated over"`
+}
+
+var ParamsAnalytics = &ParametersAnalytics{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"analytics": ParamsAnalytics,
+	},
+	Masked: nil,
+}
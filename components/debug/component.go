@@ -0,0 +1,57 @@
+package debug
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/inx-app/pkg/debugapi"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/workscore"
+)
+
+const PriorityStopDebug = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "Debug",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsDebug.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge nodebridge.NodeBridge
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func run() error {
+	server := debugapi.New(Component.Logger,
+		debugapi.WithAuthToken(ParamsDebug.AuthToken),
+		debugapi.WithStateProvider("bridge", func() any {
+			return deps.NodeBridge.DebugInfo()
+		}),
+		debugapi.WithHandler(workscore.RouteWorkScore, workscore.New(deps.NodeBridge.APIProvider()).Handler()),
+	)
+
+	return Component.Daemon().BackgroundWorker("Debug", func(ctx context.Context) {
+		Component.LogInfof("Starting debug API on %s ...", ParamsDebug.BindAddress)
+
+		if err := server.Run(ctx, ParamsDebug.BindAddress); err != nil {
+			Component.LogWarnf("Stopped debug API due to an error (%s)", err)
+
+			return
+		}
+		Component.LogInfo("Stopped debug API")
+	}, PriorityStopDebug)
+}
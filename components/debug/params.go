@@ -0,0 +1,24 @@
+package debug
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersDebug contains the definition of the parameters used by the debug API.
+type ParametersDebug struct {
+	// Enabled defines whether the debug API component is enabled.
+	Enabled bool `default:"false" usage:"whether the debug API component is enabled"`
+	// BindAddress defines the bind address on which the debug API listens on.
+	BindAddress string `default:"localhost:6060" usage:"the bind address on which the debug API listens on"`
+	// AuthToken defines the bearer token that is required to access the debug API.
+	AuthToken string `default:"" usage:"the bearer token that is required to access the debug API (if empty, the debug API is not protected)"`
+}
+
+var ParamsDebug = &ParametersDebug{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"debug": ParamsDebug,
+	},
+	Masked: []string{"debug.authToken"},
+}
@@ -0,0 +1,61 @@
+package extregistry
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/inx-app/pkg/extregistry"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+const PriorityStopExtensionRegistry = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "ExtensionRegistry",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsExtensionRegistry.Enabled
+		},
+		Provide: provide,
+		Run:     run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	Registry *extregistry.Registry
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+// provide provides the Registry, so other components can discover extensions with ListOtherExtensions.
+// Components that depend on it should mark the dependency as optional, because the component can be disabled.
+func provide(c *dig.Container) error {
+	return c.Provide(func(nodeBridge nodebridge.NodeBridge) (*extregistry.Registry, error) {
+		return extregistry.New(
+			Component.Logger,
+			ParamsExtensionRegistry.Directory,
+			Component.App().Info().Name,
+			Component.App().Info().Version,
+			ParamsExtensionRegistry.Routes,
+			extregistry.WithNodeBridge(nodeBridge),
+			extregistry.WithHeartbeatInterval(ParamsExtensionRegistry.HeartbeatInterval),
+			extregistry.WithStaleTimeout(ParamsExtensionRegistry.StaleTimeout),
+		)
+	})
+}
+
+func run() error {
+	return Component.Daemon().BackgroundWorker("ExtensionRegistry", func(ctx context.Context) {
+		Component.LogInfof("Announcing extension in registry %s ...", ParamsExtensionRegistry.Directory)
+		deps.Registry.Run(ctx)
+		Component.LogInfo("Removed extension from registry")
+	}, PriorityStopExtensionRegistry)
+}
@@ -0,0 +1,30 @@
+package extregistry
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersExtensionRegistry contains the definition of the parameters used by the extension registry.
+type ParametersExtensionRegistry struct {
+	// Enabled defines whether the extension announces itself in the extension registry.
+	Enabled bool `default:"false" usage:"whether the extension announces itself in the extension registry"`
+	// Directory defines the registry directory that is shared by the extensions of the node.
+	Directory string `default:"extensions" usage:"the registry directory that is shared by the extensions of the node"`
+	// Routes defines the API routes of the extension that are announced.
+	Routes []string `usage:"the API routes of the extension that are announced (e.g. \"indexer/v2\")"`
+	// HeartbeatInterval defines the interval in which the announcement is refreshed.
+	HeartbeatInterval time.Duration `default:"10s" usage:"the interval in which the announcement is refreshed"`
+	// StaleTimeout defines the duration after which announcements of other extensions without heartbeat are ignored.
+	StaleTimeout time.Duration `default:"1m" usage:"the duration after which announcements of other extensions without heartbeat are ignored"`
+}
+
+var ParamsExtensionRegistry = &ParametersExtensionRegistry{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"extensionRegistry": ParamsExtensionRegistry,
+	},
+	Masked: nil,
+}
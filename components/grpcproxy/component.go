@@ -0,0 +1,44 @@
+package grpcproxy
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/inx-app/components/inx"
+	"github.com/iotaledger/inx-app/pkg/grpcproxy"
+)
+
+const PriorityStopGRPCProxy = 1
+
+func init() {
+	Component = &app.Component{
+		Name:   "GRPCProxy",
+		Params: params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsGRPCProxy.Enabled
+		},
+		Run: run,
+	}
+}
+
+var Component *app.Component
+
+func run() error {
+	proxy := grpcproxy.New(Component.Logger, inx.ParamsINX.Address,
+		grpcproxy.WithAllowedMethods(ParamsGRPCProxy.AllowedMethods...),
+		grpcproxy.WithCallLogging(ParamsGRPCProxy.LogCalls),
+	)
+
+	return Component.Daemon().BackgroundWorker("GRPCProxy", func(ctx context.Context) {
+		Component.LogInfof("Starting INX debug proxy on %s ...", ParamsGRPCProxy.BindAddress)
+
+		if err := proxy.Run(ctx, ParamsGRPCProxy.BindAddress); err != nil {
+			Component.LogWarnf("Stopped INX debug proxy due to an error (%s)", err)
+
+			return
+		}
+		Component.LogInfo("Stopped INX debug proxy")
+	}, PriorityStopGRPCProxy)
+}
@@ -0,0 +1,31 @@
+package grpcproxy
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersGRPCProxy contains the definition of the parameters used by the INX debug proxy.
+type ParametersGRPCProxy struct {
+	// Enabled defines whether the INX debug proxy component is enabled.
+	Enabled bool `default:"false" usage:"whether the INX debug proxy component is enabled"`
+	// BindAddress defines the bind address on which the INX debug proxy listens on.
+	BindAddress string `default:"localhost:9030" usage:"the bind address on which the INX debug proxy listens on"`
+	// AllowedMethods defines the INX methods that are forwarded to the node.
+	AllowedMethods []string `usage:"the INX methods that are forwarded to the node (supports wildcards, e.g. \"Read*\")"`
+	// LogCalls defines whether every forwarded call is logged.
+	LogCalls bool `default:"true" usage:"whether every forwarded call is logged"`
+}
+
+var ParamsGRPCProxy = &ParametersGRPCProxy{
+	AllowedMethods: []string{
+		"Read*",
+		"Listen*",
+	},
+}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"grpcProxy": ParamsGRPCProxy,
+	},
+	Masked: nil,
+}
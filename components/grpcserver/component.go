@@ -0,0 +1,59 @@
+package grpcserver
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/inx-app/pkg/grpcserver"
+)
+
+const PriorityStopGRPCServer = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "GRPCServer",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsGRPCServer.Enabled
+		},
+		Provide: provide,
+		Run:     run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	Server *grpcserver.Server
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+// provide provides the Server, so the components of the extension can register their services.
+// The services must be registered in the configure stage, because the server is started in the run stage.
+func provide(c *dig.Container) error {
+	return c.Provide(func() *grpcserver.Server {
+		return grpcserver.New(Component.Logger,
+			grpcserver.WithReflection(ParamsGRPCServer.Reflection),
+			grpcserver.WithGracefulStopTimeout(ParamsGRPCServer.GracefulStopTimeout),
+		)
+	})
+}
+
+func run() error {
+	return Component.Daemon().BackgroundWorker("GRPCServer", func(ctx context.Context) {
+		Component.LogInfof("Starting gRPC server on %s ...", ParamsGRPCServer.BindAddress)
+
+		if err := deps.Server.Run(ctx, ParamsGRPCServer.BindAddress); err != nil {
+			Component.LogWarnf("Stopped gRPC server due to an error (%s)", err)
+
+			return
+		}
+		Component.LogInfo("Stopped gRPC server")
+	}, PriorityStopGRPCServer)
+}
@@ -0,0 +1,28 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersGRPCServer contains the definition of the parameters used by the gRPC server of the extension.
+type ParametersGRPCServer struct {
+	// Enabled defines whether the gRPC server component is enabled.
+	Enabled bool `default:"false" usage:"whether the gRPC server component is enabled"`
+	// BindAddress defines the bind address on which the gRPC server listens on.
+	BindAddress string `default:"localhost:9040" usage:"the bind address on which the gRPC server listens on"`
+	// Reflection defines whether the server reflection service is registered.
+	Reflection bool `default:"false" usage:"whether the server reflection service is registered (e.g. for grpcurl)"`
+	// GracefulStopTimeout defines the duration the server waits for running calls on shutdown.
+	GracefulStopTimeout time.Duration `default:"5s" usage:"the duration the server waits for running calls on shutdown"`
+}
+
+var ParamsGRPCServer = &ParametersGRPCServer{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"grpcServer": ParamsGRPCServer,
+	},
+	Masked: nil,
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/app/shutdown"
 	"github.com/iotaledger/hive.go/ierrors"
 	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
 )
 
 const PriorityDisconnectINX = 0
@@ -32,13 +33,36 @@ type dependencies struct {
 var (
 	Component *app.Component
 	deps      dependencies
+
+	// SupportedProtocolVersions are the protocol versions the extension was built for.
+	// Extensions set them before the app is run, so the bridge stops if the node activates another protocol version.
+	// If empty, all protocol versions are supported.
+	SupportedProtocolVersions []iotago.Version
 )
 
 func provide(c *dig.Container) error {
 	return c.Provide(func() (nodebridge.NodeBridge, error) {
+		consumerPanicPolicy, err := nodebridge.ParsePanicPolicy(ParamsINX.ConsumerPanicPolicy)
+		if err != nil {
+			return nil, err
+		}
+
 		nodeBridge := nodebridge.New(
 			Component.Logger,
-			nodebridge.WithTargetNetworkName(ParamsINX.TargetNetworkName),
+			nodebridge.WithTargetNetworkNames(targetNetworkNames()...),
+			nodebridge.WithSupportedProtocolVersions(SupportedProtocolVersions),
+			nodebridge.WithKeepalive(ParamsINX.Keepalive.PingInterval, ParamsINX.Keepalive.PingTimeout, ParamsINX.Keepalive.PermitWithoutStream),
+			nodebridge.WithPluginRetryPolicy(ParamsINX.PluginRetry.Interval, ParamsINX.PluginRetry.MaxWait, ParamsINX.PluginRetry.Jitter),
+			nodebridge.WithRetryPolicy(ParamsINX.Retry.MaxRetries, ParamsINX.Retry.Backoff, ParamsINX.Retry.Jitter),
+			nodebridge.WithReadCoalescing(ParamsINX.ReadCoalescing),
+			nodebridge.WithLocalBlockValidation(ParamsINX.LocalBlockValidation),
+			nodebridge.WithCompression(ParamsINX.Compression),
+			nodebridge.WithMaxRecvMsgSize(ParamsINX.MaxRecvMsgSize),
+			nodebridge.WithMaxSendMsgSize(ParamsINX.MaxSendMsgSize),
+			nodebridge.WithStreamDraining(ParamsINX.StreamDrain.BufferSize, ParamsINX.StreamDrain.GraceTimeout),
+			nodebridge.WithConsumerPanicPolicy(consumerPanicPolicy),
+			nodebridge.WithCallStatsLogInterval(ParamsINX.CallStats.LogInterval),
+			nodebridge.WithBlockIssuanceCache(ParamsINX.BlockIssuanceCacheTTL),
 		)
 
 		if err := nodeBridge.Connect(
@@ -64,3 +88,13 @@ func run() error {
 		}
 	}, PriorityDisconnectINX)
 }
+
+// targetNetworkNames returns the target network name and the additional target network names.
+func targetNetworkNames() []string {
+	targetNetworkNames := make([]string, 0, len(ParamsINX.TargetNetworkNames)+1)
+	if ParamsINX.TargetNetworkName != "" {
+		targetNetworkNames = append(targetNetworkNames, ParamsINX.TargetNetworkName)
+	}
+
+	return append(targetNetworkNames, ParamsINX.TargetNetworkNames...)
+}
@@ -1,13 +1,50 @@
 package inx
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
 type ParametersINX struct {
-	Address               string `default:"localhost:9029" usage:"the INX address to which to connect to"`
-	MaxConnectionAttempts uint   `default:"30" usage:"the amount of times the connection to INX will be attempted before it fails (1 attempt per second)"`
-	TargetNetworkName     string `default:"" usage:"the network name on which the node should operate on (optional)"`
+	Address               string        `default:"localhost:9029" usage:"the INX address to which to connect to"`
+	MaxConnectionAttempts uint          `default:"30" usage:"the amount of times the connection to INX will be attempted before it fails (1 attempt per second)"`
+	TargetNetworkName     string        `default:"" usage:"the network name on which the node should operate on (optional)"`
+	TargetNetworkNames    []string      `usage:"the network names or glob patterns (e.g. \"testnet-*\") on which the node may operate on, in addition to the target network name (optional)"`
+	ReadCoalescing        bool          `default:"false" usage:"whether concurrent identical reads of blocks, outputs and commitments share one request to the node"`
+	LocalBlockValidation  bool          `default:"false" usage:"whether blocks are validated locally before they are submitted to the node"`
+	Compression           string        `default:"" usage:"the compression of the INX connection (\"\" = none, \"gzip\")"`
+	MaxRecvMsgSize        int           `default:"0" usage:"the maximum size in bytes of a message received from the node (0 = gRPC default)"`
+	MaxSendMsgSize        int           `default:"0" usage:"the maximum size in bytes of a message sent to the node (0 = gRPC default)"`
+	ConsumerPanicPolicy   string        `default:"propagate" usage:"how panics in stream consumers are handled (\"propagate\", \"skip\" or \"terminate\")"`
+	BlockIssuanceCacheTTL time.Duration `default:"0s" usage:"the duration for which block issuance responses are cached within a commitment (0 = disabled)"`
+
+	Keepalive struct {
+		PingInterval        time.Duration `default:"0s" usage:"the interval after which the node is pinged if there was no activity on the connection (0 = gRPC default)"`
+		PingTimeout         time.Duration `default:"20s" usage:"the timeout after which the connection is closed if the node does not answer a ping"`
+		PermitWithoutStream bool          `default:"false" usage:"whether the node is also pinged if there are no active streams"`
+	} `name:"keepalive"`
+
+	PluginRetry struct {
+		Interval time.Duration `default:"1s" usage:"the interval in which the node is asked for a plugin that is not available yet"`
+		MaxWait  time.Duration `default:"0s" usage:"the maximum duration to wait for a plugin to become available (0 = fail if the plugin is missing after refreshing the routes once, negative = until shutdown)"`
+		Jitter   time.Duration `default:"0s" usage:"the maximum random jitter that is added to the retry interval"`
+	} `name:"pluginRetry"`
+
+	Retry struct {
+		MaxRetries uint          `default:"0" usage:"the maximum amount of retries of a failed call to the node (0 = disabled)"`
+		Backoff    time.Duration `default:"50ms" usage:"the duration between two retries of a failed call"`
+		Jitter     float64       `default:"0.1" usage:"the fraction of the backoff that is randomly added or subtracted"`
+	} `name:"retry"`
+
+	CallStats struct {
+		LogInterval time.Duration `default:"0s" usage:"the interval in which a summary of the call statistics is logged (0 = disabled)"`
+	} `name:"callStats"`
+
+	StreamDrain struct {
+		BufferSize   int           `default:"100" usage:"the amount of items that are buffered per stream to be delivered on shutdown"`
+		GraceTimeout time.Duration `default:"0s" usage:"the maximum duration to deliver the buffered items of a stream on shutdown (0 = disabled)"`
+	} `name:"streamDrain"`
 }
 
 var ParamsINX = &ParametersINX{}
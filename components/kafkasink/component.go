@@ -0,0 +1,152 @@
+package kafkasink
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/kvstore"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/sink"
+)
+
+const (
+	PriorityStopKafkaSink = 1
+)
+
+// checkpointKey is the key the last produced slot is stored under.
+var checkpointKey = []byte("kafkaSink.checkpoint")
+
+func init() {
+	Component = &app.Component{
+		Name:     "KafkaSink",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsKafkaSink.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge nodebridge.NodeBridge
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+// kafkaProducer implements sink.KafkaProducer on top of a kafka-go writer.
+type kafkaProducer struct {
+	writer *kafka.Writer
+}
+
+func (p *kafkaProducer) Produce(ctx context.Context, messages []*sink.KafkaMessage) error {
+	kafkaMessages := make([]kafka.Message, len(messages))
+	for i, message := range messages {
+		kafkaMessages[i] = kafka.Message{
+			Topic: message.Topic,
+			Key:   message.Key,
+			Value: message.Value,
+		}
+	}
+
+	return p.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func parseEncoding(encoding string) (sink.Encoding, error) {
+	switch encoding {
+	case "json":
+		return sink.EncodingJSON, nil
+	case "protobuf":
+		return sink.EncodingProtobuf, nil
+	default:
+		return 0, ierrors.Errorf("unknown encoding: %s", encoding)
+	}
+}
+
+func parsePartitioning(partitioning string) (sink.KafkaPartitioning, error) {
+	switch partitioning {
+	case "slot":
+		return sink.KafkaPartitioningBySlot, nil
+	case "address":
+		return sink.KafkaPartitioningByAddress, nil
+	default:
+		return 0, ierrors.Errorf("unknown partitioning: %s", partitioning)
+	}
+}
+
+func run() error {
+	encoding, err := parseEncoding(ParamsKafkaSink.Encoding)
+	if err != nil {
+		return err
+	}
+
+	partitioning, err := parsePartitioning(ParamsKafkaSink.Partitioning)
+	if err != nil {
+		return err
+	}
+
+	if len(ParamsKafkaSink.Brokers) == 0 {
+		return ierrors.New("no Kafka brokers configured")
+	}
+
+	return Component.Daemon().BackgroundWorker("KafkaSink", func(ctx context.Context) {
+		store, err := kvstore.NewBoltStore(ParamsKafkaSink.CheckpointPath)
+		if err != nil {
+			Component.LogErrorf("Failed to open the checkpoint database: %s", err)
+			return
+		}
+		defer func() {
+			if err := store.Close(); err != nil {
+				Component.LogWarnf("Failed to close the checkpoint database: %s", err)
+			}
+		}()
+
+		checkpoint := kvstore.NewSlotCheckpoint(store, checkpointKey)
+		startSlot, err := checkpoint.StartSlot()
+		if err != nil {
+			Component.LogErrorf("Failed to load the checkpoint: %s", err)
+			return
+		}
+
+		// the writer routes every message to the topic set in the message,
+		// and the hash balancer keeps messages with the same key in the same partition.
+		writer := &kafka.Writer{
+			Addr:         kafka.TCP(ParamsKafkaSink.Brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		}
+		defer func() {
+			if err := writer.Close(); err != nil {
+				Component.LogWarnf("Failed to close the Kafka writer: %s", err)
+			}
+		}()
+
+		kafkaSink := sink.NewKafkaSink(Component.Logger, deps.NodeBridge, &kafkaProducer{writer: writer},
+			sink.WithKafkaTopicPrefix(ParamsKafkaSink.TopicPrefix),
+			sink.WithKafkaEncoding(encoding),
+			sink.WithKafkaPartitioning(partitioning),
+			sink.WithKafkaStartSlot(startSlot),
+			sink.WithKafkaLedgerUpdates(ParamsKafkaSink.LedgerUpdates),
+			sink.WithKafkaAcceptedTransactions(ParamsKafkaSink.AcceptedTransactions),
+		)
+
+		hook := checkpoint.Hook(kafkaSink.Events().CheckpointReached, func(err error) {
+			Component.LogWarnf("Failed to store the checkpoint: %s", err)
+		})
+		defer hook.Unhook()
+
+		Component.LogInfof("Starting Kafka sink at slot %d ...", startSlot)
+		if err := kafkaSink.Run(ctx); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped Kafka sink due to an error (%s)", err)
+		}
+		Component.LogInfo("Stopped Kafka sink")
+	}, PriorityStopKafkaSink)
+}
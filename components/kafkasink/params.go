@@ -0,0 +1,33 @@
+package kafkasink
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersKafkaSink contains the definition of the parameters used by the Kafka sink.
+type ParametersKafkaSink struct {
+	// Enabled defines whether the Kafka sink component is enabled.
+	Enabled bool `default:"false" usage:"whether the Kafka sink component is enabled"`
+	// Brokers defines the addresses of the Kafka brokers.
+	Brokers []string `default:"localhost:9092" usage:"the addresses of the Kafka brokers"`
+	// TopicPrefix defines a prefix that is added to all topics.
+	TopicPrefix string `default:"iota." usage:"a prefix that is added to all topics"`
+	// Encoding defines the encoding of the produced messages.
+	Encoding string `default:"json" usage:"the encoding of the produced messages (\"json\" or \"protobuf\")"`
+	// Partitioning defines how the key of the produced messages is derived.
+	Partitioning string `default:"slot" usage:"how the key of the produced messages is derived (\"slot\" or \"address\")"`
+	// LedgerUpdates defines whether the created and consumed outputs are produced.
+	LedgerUpdates bool `default:"true" usage:"whether the created and consumed outputs are produced"`
+	// AcceptedTransactions defines whether the accepted transactions are produced.
+	AcceptedTransactions bool `default:"true" usage:"whether the accepted transactions are produced"`
+	// CheckpointPath defines the path of the database the last produced slot is stored in.
+	CheckpointPath string `default:"kafkasink.db" usage:"the path of the database the last produced slot is stored in"`
+}
+
+var ParamsKafkaSink = &ParametersKafkaSink{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"kafkaSink": ParamsKafkaSink,
+	},
+}
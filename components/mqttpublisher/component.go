@@ -0,0 +1,86 @@
+package mqttpublisher
+
+import (
+	"context"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/mqttpublisher"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+const (
+	PriorityStopMQTTPublisher = 1
+
+	// disconnectQuiesceMilliseconds is the time the client waits for pending messages when disconnecting from the broker.
+	disconnectQuiesceMilliseconds = 250
+)
+
+func init() {
+	Component = &app.Component{
+		Name:     "MQTTPublisher",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsMQTTPublisher.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge nodebridge.NodeBridge
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func run() error {
+	clientOptions := mqtt.NewClientOptions().
+		AddBroker(ParamsMQTTPublisher.Broker).
+		SetClientID(ParamsMQTTPublisher.ClientID).
+		SetUsername(ParamsMQTTPublisher.Username).
+		SetPassword(ParamsMQTTPublisher.Password).
+		SetConnectTimeout(ParamsMQTTPublisher.ConnectTimeout).
+		SetAutoReconnect(true)
+
+	return Component.Daemon().BackgroundWorker("MQTTPublisher", func(ctx context.Context) {
+		client := mqtt.NewClient(clientOptions)
+
+		Component.LogInfof("Connecting to MQTT broker %s ...", ParamsMQTTPublisher.Broker)
+		token := client.Connect()
+		if !token.WaitTimeout(ParamsMQTTPublisher.ConnectTimeout) {
+			Component.LogErrorf("Failed to connect to MQTT broker %s: timeout", ParamsMQTTPublisher.Broker)
+			return
+		}
+		if err := token.Error(); err != nil {
+			Component.LogErrorf("Failed to connect to MQTT broker %s: %s", ParamsMQTTPublisher.Broker, err)
+			return
+		}
+		defer client.Disconnect(disconnectQuiesceMilliseconds)
+
+		publisher := mqttpublisher.New(Component.Logger, deps.NodeBridge, client,
+			mqttpublisher.WithTopicPrefix(ParamsMQTTPublisher.TopicPrefix),
+			mqttpublisher.WithQoS(ParamsMQTTPublisher.QoS),
+			mqttpublisher.WithRetainCommitments(ParamsMQTTPublisher.RetainCommitments),
+			mqttpublisher.WithPublishTimeout(ParamsMQTTPublisher.PublishTimeout),
+			mqttpublisher.WithCommitmentQueueSize(ParamsMQTTPublisher.CommitmentQueueSize),
+			mqttpublisher.WithBlocks(ParamsMQTTPublisher.Streams.Blocks),
+			mqttpublisher.WithAcceptedBlocks(ParamsMQTTPublisher.Streams.AcceptedBlocks),
+			mqttpublisher.WithCommitments(ParamsMQTTPublisher.Streams.Commitments),
+			mqttpublisher.WithLedgerUpdates(ParamsMQTTPublisher.Streams.LedgerUpdates),
+		)
+
+		Component.LogInfo("Starting MQTT publisher ...")
+		if err := publisher.Run(ctx); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped MQTT publisher due to an error (%s)", err)
+		}
+		Component.LogInfo("Stopped MQTT publisher")
+	}, PriorityStopMQTTPublisher)
+}
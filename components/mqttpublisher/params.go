@@ -0,0 +1,50 @@
+package mqttpublisher
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersMQTTPublisher contains the definition of the parameters used by the MQTT publisher.
+type ParametersMQTTPublisher struct {
+	// Enabled defines whether the MQTT publisher component is enabled.
+	Enabled bool `default:"false" usage:"whether the MQTT publisher component is enabled"`
+	// Broker defines the URL of the MQTT broker the streams are published to.
+	Broker string `default:"tcp://localhost:1883" usage:"the URL of the MQTT broker the streams are published to"`
+	// ClientID defines the client ID that is used to connect to the broker.
+	ClientID string `default:"inx-app" usage:"the client ID that is used to connect to the broker"`
+	// Username defines the username that is used to connect to the broker.
+	Username string `default:"" usage:"the username that is used to connect to the broker"`
+	// Password defines the password that is used to connect to the broker.
+	Password string `default:"" usage:"the password that is used to connect to the broker"`
+	// ConnectTimeout defines the timeout for the connection to the broker.
+	ConnectTimeout time.Duration `default:"10s" usage:"the timeout for the connection to the broker"`
+
+	// TopicPrefix defines a prefix that is added to all topics.
+	TopicPrefix string `default:"" usage:"a prefix that is added to all topics (e.g. \"iota/\")"`
+	// QoS defines the MQTT quality of service level of the published messages.
+	QoS byte `default:"0" usage:"the MQTT quality of service level of the published messages"`
+	// RetainCommitments defines whether the commitment messages are retained by the broker.
+	RetainCommitments bool `default:"true" usage:"whether the commitment messages are retained by the broker"`
+	// PublishTimeout defines the timeout for a single publish to the broker.
+	PublishTimeout time.Duration `default:"5s" usage:"the timeout for a single publish to the broker"`
+	// CommitmentQueueSize defines the amount of commitments that are queued until they are published.
+	CommitmentQueueSize int `default:"100" usage:"the amount of commitments that are queued until they are published"`
+
+	Streams struct {
+		Blocks         bool `default:"false" usage:"whether all incoming blocks are published"`
+		AcceptedBlocks bool `default:"true" usage:"whether the metadata of accepted and confirmed blocks is published"`
+		Commitments    bool `default:"true" usage:"whether the latest and finalized commitments are published"`
+		LedgerUpdates  bool `default:"true" usage:"whether the created and consumed outputs of the ledger updates are published"`
+	} `name:"streams"`
+}
+
+var ParamsMQTTPublisher = &ParametersMQTTPublisher{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"mqttPublisher": ParamsMQTTPublisher,
+	},
+	Masked: []string{"mqttPublisher.password"},
+}
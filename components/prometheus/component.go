@@ -0,0 +1,104 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/analytics"
+	"github.com/iotaledger/inx-app/pkg/metrics"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+const (
+	PriorityStopPrometheus = 1
+
+	// MetricsPath is the path on which the metrics are served.
+	MetricsPath = "/metrics"
+)
+
+func init() {
+	Component = &app.Component{
+		Name:     "Prometheus",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsPrometheus.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge        nodebridge.NodeBridge
+	LatencyTracker    *analytics.LatencyTracker    `optional:"true"`
+	ThroughputTracker *analytics.ThroughputTracker `optional:"true"`
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func run() error {
+	bridgeCollector := metrics.NewBridgeCollector(deps.NodeBridge)
+
+	collectors := []prometheus.Collector{metrics.NewDatabaseSizeCollector(ParamsPrometheus.DatabasePaths...)}
+	if deps.LatencyTracker != nil {
+		collectors = append(collectors, metrics.NewLatencyCollector(deps.LatencyTracker))
+	}
+	if deps.ThroughputTracker != nil {
+		collectors = append(collectors, metrics.NewThroughputCollector(deps.ThroughputTracker))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, metrics.Handler(metrics.NewRegistry(bridgeCollector, collectors...)))
+
+	server := &http.Server{
+		Addr:              ParamsPrometheus.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return Component.Daemon().BackgroundWorker("Prometheus", func(ctx context.Context) {
+		defer bridgeCollector.Shutdown()
+
+		Component.LogInfof("Starting Prometheus metrics exporter on %s ...", ParamsPrometheus.BindAddress)
+
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !ierrors.Is(err, http.ErrServerClosed) {
+				Component.LogWarnf("Stopped Prometheus metrics exporter due to an error (%s)", err)
+			}
+		}()
+
+		if ParamsPrometheus.APIRoute != "" {
+			if err := deps.NodeBridge.RegisterAPIRoute(ctx, ParamsPrometheus.APIRoute, ParamsPrometheus.BindAddress, ""); err != nil {
+				Component.LogWarnf("Failed to register API route %s: %s", ParamsPrometheus.APIRoute, err)
+			} else {
+				Component.LogInfof("Registered API route %s", ParamsPrometheus.APIRoute)
+			}
+		}
+
+		<-ctx.Done()
+
+		if ParamsPrometheus.APIRoute != "" {
+			unregisterCtx, unregisterCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer unregisterCancel()
+
+			if err := deps.NodeBridge.UnregisterAPIRoute(unregisterCtx, ParamsPrometheus.APIRoute); err != nil {
+				Component.LogWarnf("Failed to unregister API route %s: %s", ParamsPrometheus.APIRoute, err)
+			}
+		}
+
+		if err := server.Shutdown(context.Background()); err != nil {
+			Component.LogWarn(err.Error())
+		}
+		Component.LogInfo("Stopped Prometheus metrics exporter")
+	}, PriorityStopPrometheus)
+}
@@ -0,0 +1,26 @@
+package prometheus
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersPrometheus contains the definition of the parameters used by the Prometheus metrics exporter.
+type ParametersPrometheus struct {
+	// Enabled defines whether the Prometheus metrics exporter component is enabled.
+	Enabled bool `default:"false" usage:"whether the Prometheus metrics exporter component is enabled"`
+	// BindAddress defines the bind address on which the Prometheus metrics exporter listens on.
+	BindAddress string `default:"localhost:9312" usage:"the bind address on which the Prometheus metrics exporter listens on"`
+	// APIRoute defines the API route under which the metrics are registered at the node, or empty to not register a route.
+	APIRoute string `default:"" usage:"the API route under which the metrics are registered at the node (e.g. \"metrics/v1\"), or empty to not register a route"`
+	// DatabasePaths defines the paths of the databases of the extension whose size on disk is reported.
+	DatabasePaths []string `usage:"the paths of the databases of the extension whose size on disk is reported"`
+}
+
+var ParamsPrometheus = &ParametersPrometheus{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"prometheus": ParamsPrometheus,
+	},
+	Masked: nil,
+}
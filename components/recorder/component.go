@@ -0,0 +1,157 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/recorder"
+	"github.com/iotaledger/inx-app/pkg/sink"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const PriorityStopRecorder = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "Recorder",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsRecorder.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge nodebridge.NodeBridge
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func run() error {
+	if ParamsRecorder.ObjectStorage.Enabled {
+		return runObjectStorage()
+	}
+
+	if err := os.MkdirAll(ParamsRecorder.Directory, 0o700); err != nil {
+		return ierrors.Wrapf(err, "failed to create recordings directory %s", ParamsRecorder.Directory)
+	}
+
+	// every run gets its own files, so restarts don't overwrite earlier recordings
+	timestamp := time.Now().Unix()
+
+	if ParamsRecorder.Blocks {
+		if err := startRecording(fmt.Sprintf("blocks-%d.rec", timestamp), func(ctx context.Context, r *recorder.Recorder) error {
+			return r.RecordBlocks(ctx)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if ParamsRecorder.LedgerUpdates {
+		if err := startRecording(fmt.Sprintf("ledger-updates-%d.rec", timestamp), func(ctx context.Context, r *recorder.Recorder) error {
+			return r.RecordLedgerUpdates(ctx, 0, 0)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startRecording(fileName string, record func(ctx context.Context, r *recorder.Recorder) error) error {
+	filePath := filepath.Join(ParamsRecorder.Directory, fileName)
+
+	return Component.Daemon().BackgroundWorker(fmt.Sprintf("Recorder[%s]", fileName), func(ctx context.Context) {
+		file, err := os.Create(filePath)
+		if err != nil {
+			Component.LogErrorf("Failed to create recording %s: %s", filePath, err)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		r := recorder.New(deps.NodeBridge, recorder.NewRecordWriter(file))
+
+		Component.LogInfof("Recording to %s ...", filePath)
+		if err := record(ctx, r); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped recording to %s due to an error (%s)", filePath, err)
+		}
+
+		if err := r.Flush(); err != nil {
+			Component.LogWarnf("Failed to flush recording %s: %s", filePath, err)
+		}
+		Component.LogInfof("Stopped recording to %s", filePath)
+	}, PriorityStopRecorder)
+}
+
+func runObjectStorage() error {
+	s3ObjectWriter, err := sink.NewS3ObjectWriter(
+		ParamsRecorder.ObjectStorage.Endpoint,
+		ParamsRecorder.ObjectStorage.Region,
+		ParamsRecorder.ObjectStorage.Bucket,
+		ParamsRecorder.ObjectStorage.AccessKeyID,
+		ParamsRecorder.ObjectStorage.SecretAccessKey,
+		sink.WithS3Prefix(ParamsRecorder.ObjectStorage.Prefix),
+		sink.WithS3SessionToken(ParamsRecorder.ObjectStorage.SessionToken),
+	)
+	if err != nil {
+		return err
+	}
+
+	var objectWriter sink.ObjectWriter = s3ObjectWriter
+	if ParamsRecorder.ObjectStorage.Gzip {
+		objectWriter = sink.NewGzipObjectWriter(objectWriter, gzip.DefaultCompression)
+	}
+
+	// every run gets its own objects, so restarts don't overwrite earlier recordings
+	timestamp := time.Now().Unix()
+	slotsPerObject := iotago.SlotIndex(ParamsRecorder.ObjectStorage.SlotsPerObject)
+
+	if ParamsRecorder.Blocks {
+		if err := startObjectStorageRecording(recorder.NewObjectStorageRecorder(deps.NodeBridge, objectWriter, fmt.Sprintf("blocks-%d", timestamp), slotsPerObject), "blocks", func(ctx context.Context, r *recorder.Recorder) error {
+			return r.RecordBlocks(ctx)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if ParamsRecorder.LedgerUpdates {
+		if err := startObjectStorageRecording(recorder.NewObjectStorageRecorder(deps.NodeBridge, objectWriter, fmt.Sprintf("ledger-updates-%d", timestamp), slotsPerObject), "ledger-updates", func(ctx context.Context, r *recorder.Recorder) error {
+			return r.RecordLedgerUpdates(ctx, 0, 0)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startObjectStorageRecording(r *recorder.Recorder, name string, record func(ctx context.Context, r *recorder.Recorder) error) error {
+	bucket := ParamsRecorder.ObjectStorage.Bucket
+
+	return Component.Daemon().BackgroundWorker(fmt.Sprintf("Recorder[%s]", name), func(ctx context.Context) {
+		Component.LogInfof("Recording %s to bucket %s ...", name, bucket)
+		if err := record(ctx, r); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped recording %s to bucket %s due to an error (%s)", name, bucket, err)
+		}
+
+		if err := r.Flush(); err != nil {
+			Component.LogWarnf("Failed to upload recording %s to bucket %s: %s", name, bucket, err)
+		}
+		Component.LogInfof("Stopped recording %s to bucket %s", name, bucket)
+	}, PriorityStopRecorder)
+}
@@ -0,0 +1,39 @@
+package recorder
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersRecorder contains the definition of the parameters used by the stream recorder.
+type ParametersRecorder struct {
+	// Enabled defines whether the stream recorder component is enabled.
+	Enabled bool `default:"false" usage:"whether the stream recorder component is enabled"`
+	// Directory defines the directory the recordings are written to.
+	Directory string `default:"recordings" usage:"the directory the recordings are written to"`
+	// Blocks defines whether the blocks are recorded.
+	Blocks bool `default:"true" usage:"whether the blocks are recorded"`
+	// LedgerUpdates defines whether the ledger updates are recorded.
+	LedgerUpdates bool `default:"true" usage:"whether the ledger updates are recorded"`
+
+	ObjectStorage struct {
+		Enabled         bool   `default:"false" usage:"whether the recordings are uploaded to an S3 compatible object storage instead of the directory"`
+		Endpoint        string `default:"https://s3.amazonaws.com" usage:"the endpoint of the object storage (e.g. \"https://storage.googleapis.com\" for GCS with HMAC keys)"`
+		Region          string `default:"us-east-1" usage:"the region of the bucket (\"auto\" for GCS)"`
+		Bucket          string `default:"" usage:"the bucket the recordings are uploaded to"`
+		Prefix          string `default:"recordings/" usage:"the prefix of the names of the uploaded recordings"`
+		AccessKeyID     string `default:"" usage:"the access key ID used to sign the uploads (if empty, the credentials are taken from the environment or the IAM role of the instance)"`
+		SecretAccessKey string `default:"" usage:"the secret access key used to sign the uploads"`
+		SessionToken    string `default:"" usage:"the session token of temporary credentials (e.g. issued by AWS STS)"`
+		SlotsPerObject  uint32 `default:"360" usage:"the amount of slots that are recorded to a single object"`
+		Gzip            bool   `default:"true" usage:"whether the recordings are compressed with gzip"`
+	} `name:"objectStorage"`
+}
+
+var ParamsRecorder = &ParametersRecorder{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"recorder": ParamsRecorder,
+	},
+	Masked: []string{"recorder.objectStorage.secretAccessKey", "recorder.objectStorage.sessionToken"},
+}
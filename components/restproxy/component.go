@@ -0,0 +1,63 @@
+package restproxy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/restproxy"
+)
+
+const PriorityStopRestProxy = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "RestProxy",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsRestProxy.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge nodebridge.NodeBridge
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func run() error {
+	e := httpserver.NewEcho(Component.Logger, func(err error, c echo.Context) {
+		Component.LogDebugf("Error in REST proxy request %s: %s", c.Request().RequestURI, err)
+	}, ParamsRestProxy.DebugRequestLoggerEnabled)
+	restproxy.New(deps.NodeBridge).RegisterRoutes(e, ParamsRestProxy.SubmitBlocks)
+
+	return Component.Daemon().BackgroundWorker("RestProxy", func(ctx context.Context) {
+		Component.LogInfof("Starting REST proxy on %s ...", ParamsRestProxy.BindAddress)
+
+		go func() {
+			if err := e.Start(ParamsRestProxy.BindAddress); err != nil && !ierrors.Is(err, http.ErrServerClosed) {
+				Component.LogWarnf("Stopped REST proxy due to an error (%s)", err)
+			}
+		}()
+
+		<-ctx.Done()
+
+		if err := e.Shutdown(context.Background()); err != nil {
+			Component.LogWarn(err.Error())
+		}
+		Component.LogInfo("Stopped REST proxy")
+	}, PriorityStopRestProxy)
+}
@@ -0,0 +1,26 @@
+package restproxy
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersRestProxy contains the definition of the parameters used by the REST proxy.
+type ParametersRestProxy struct {
+	// Enabled defines whether the REST proxy component is enabled.
+	Enabled bool `default:"false" usage:"whether the REST proxy component is enabled"`
+	// BindAddress defines the bind address on which the REST proxy listens on.
+	BindAddress string `default:"localhost:9092" usage:"the bind address on which the REST proxy listens on"`
+	// SubmitBlocks defines whether blocks can be submitted via the REST proxy.
+	SubmitBlocks bool `default:"false" usage:"whether blocks can be submitted via the REST proxy"`
+	// DebugRequestLoggerEnabled defines whether the debug logging for requests should be enabled.
+	DebugRequestLoggerEnabled bool `default:"false" usage:"whether the debug logging for requests should be enabled"`
+}
+
+var ParamsRestProxy = &ParametersRestProxy{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"restProxy": ParamsRestProxy,
+	},
+	Masked: nil,
+}
@@ -0,0 +1,83 @@
+package spammer
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/spammer"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/hexutil"
+)
+
+const PriorityStopSpammer = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "Spammer",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsSpammer.Enabled
+		},
+		Run: run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	NodeBridge nodebridge.NodeBridge
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func run() error {
+	accountID, err := iotago.AccountIDFromHexString(ParamsSpammer.AccountID)
+	if err != nil {
+		return ierrors.Wrap(err, "invalid spammer account ID")
+	}
+
+	privateKeyBytes, err := hexutil.DecodeHex(ParamsSpammer.PrivateKey)
+	if err != nil {
+		return ierrors.Wrap(err, "invalid spammer private key")
+	}
+	if len(privateKeyBytes) != ed25519.PrivateKeySize {
+		return ierrors.Errorf("invalid spammer private key length: %d", len(privateKeyBytes))
+	}
+
+	opts := []options.Option[spammer.Spammer]{
+		spammer.WithBlocksPerSecond(ParamsSpammer.BlocksPerSecond),
+		spammer.WithSpamType(spammer.SpamType(ParamsSpammer.SpamType)),
+		spammer.WithTag([]byte(ParamsSpammer.Tag)),
+		spammer.WithValueSpamMaxPending(ParamsSpammer.ValueSpamMaxPending),
+		spammer.WithValueSpamAcceptanceTimeout(ParamsSpammer.ValueSpamAcceptanceTimeout),
+	}
+	if ParamsSpammer.ValueSpamOutputID != "" {
+		outputID, err := iotago.OutputIDFromHexString(ParamsSpammer.ValueSpamOutputID)
+		if err != nil {
+			return ierrors.Wrap(err, "invalid spammer value spam output ID")
+		}
+		opts = append(opts, spammer.WithValueSpamOutputID(outputID))
+	}
+
+	s := spammer.New(Component.Logger, deps.NodeBridge, accountID, ed25519.PrivateKey(privateKeyBytes), opts...)
+
+	return Component.Daemon().BackgroundWorker("Spammer", func(ctx context.Context) {
+		Component.LogInfof("Starting spammer with %.2f blocks per second (%s) ...", ParamsSpammer.BlocksPerSecond, ParamsSpammer.SpamType)
+
+		if err := s.Run(ctx); err != nil {
+			Component.LogWarnf("Stopped spammer due to an error (%s)", err)
+
+			return
+		}
+		Component.LogInfof("Stopped spammer, issued %d blocks, %d failed", s.Issued(), s.Failed())
+	}, PriorityStopSpammer)
+}
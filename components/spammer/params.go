@@ -0,0 +1,38 @@
+package spammer
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersSpammer contains the definition of the parameters used by the spammer.
+type ParametersSpammer struct {
+	// Enabled defines whether the spammer component is enabled.
+	Enabled bool `default:"false" usage:"whether the spammer component is enabled"`
+	// BlocksPerSecond defines the rate in which blocks are issued.
+	BlocksPerSecond float64 `default:"1.0" usage:"the rate in which blocks are issued"`
+	// SpamType defines the kind of payload that is issued.
+	SpamType string `default:"taggedData" usage:"the kind of payload that is issued (empty, taggedData, value)"`
+	// Tag defines the tag of the tagged data payloads.
+	Tag string `default:"inx-spammer" usage:"the tag of the tagged data payloads"`
+	// AccountID defines the hex encoded ID of the account that issues the blocks.
+	AccountID string `default:"" usage:"the hex encoded ID of the account that issues the blocks"`
+	// PrivateKey defines the hex encoded Ed25519 private key of a block issuer key of the account.
+	PrivateKey string `default:"" usage:"the hex encoded Ed25519 private key of a block issuer key of the account"`
+	// ValueSpamOutputID defines the hex encoded ID of the output that is spent by the first value spam transaction.
+	ValueSpamOutputID string `default:"" usage:"the hex encoded ID of the basic output owned by the private key that is spent by the first value spam transaction"`
+	// ValueSpamMaxPending defines the amount of value spam transactions that are chained before waiting for their acceptance.
+	ValueSpamMaxPending int `default:"10" usage:"the amount of value spam transactions that are chained before waiting for their acceptance"`
+	// ValueSpamAcceptanceTimeout defines the time after which the spammer falls back to the last accepted output.
+	ValueSpamAcceptanceTimeout time.Duration `default:"1m" usage:"the time after which the spammer falls back to the last accepted output if the pending value spam transactions were not accepted"`
+}
+
+var ParamsSpammer = &ParametersSpammer{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"spammer": ParamsSpammer,
+	},
+	Masked: []string{"spammer.privateKey"},
+}
@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/webhooks"
+)
+
+const PriorityStopWebhooks = 1
+
+func init() {
+	Component = &app.Component{
+		Name:     "Webhooks",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(_ *dig.Container) bool {
+			return ParamsWebhooks.Enabled
+		},
+		Provide: provide,
+		Run:     run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	Dispatcher *webhooks.Dispatcher
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+// provide provides the Dispatcher, so other components (e.g. a notification service) can register subscriptions.
+func provide(c *dig.Container) error {
+	return c.Provide(func(nodeBridge nodebridge.NodeBridge) *webhooks.Dispatcher {
+		return webhooks.NewDispatcher(Component.Logger, nodeBridge,
+			webhooks.WithWorkers(ParamsWebhooks.Workers),
+			webhooks.WithQueueSize(ParamsWebhooks.QueueSize),
+			webhooks.WithMaxRetries(ParamsWebhooks.MaxRetries),
+			webhooks.WithRetryBackoff(ParamsWebhooks.RetryBackoff, ParamsWebhooks.MaxRetryBackoff),
+			webhooks.WithDeliveryTimeout(ParamsWebhooks.DeliveryTimeout),
+		)
+	})
+}
+
+func run() error {
+	return Component.Daemon().BackgroundWorker("Webhooks", func(ctx context.Context) {
+		unhook := deps.Dispatcher.Events().DeliveryFailed.Hook(func(notification *webhooks.Notification, err error) {
+			Component.LogWarnf("Failed to deliver %s notification %s of subscription %s: %s", notification.EventType, notification.ID, notification.SubscriptionID, err)
+		}).Unhook
+		defer unhook()
+
+		Component.LogInfo("Starting webhook dispatcher ...")
+		if err := deps.Dispatcher.Run(ctx); err != nil && !ierrors.Is(err, context.Canceled) {
+			Component.LogWarnf("Stopped webhook dispatcher due to an error (%s)", err)
+		}
+		Component.LogInfo("Stopped webhook dispatcher")
+	}, PriorityStopWebhooks)
+}
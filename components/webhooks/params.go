@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersWebhooks contains the definition of the parameters used by the webhooks component.
+type ParametersWebhooks struct {
+	// Enabled defines whether the webhooks component is enabled.
+	Enabled bool `default:"false" usage:"whether the webhooks component is enabled"`
+	// Workers defines the amount of notifications that are delivered in parallel.
+	Workers int `default:"4" usage:"the amount of notifications that are delivered in parallel"`
+	// QueueSize defines the amount of notifications that are queued for delivery.
+	QueueSize int `default:"1000" usage:"the amount of notifications that are queued for delivery"`
+	// MaxRetries defines the maximum amount of retries of a failed delivery.
+	MaxRetries int `default:"5" usage:"the maximum amount of retries of a failed delivery"`
+	// RetryBackoff defines the backoff before the first retry, it is doubled with every retry.
+	RetryBackoff time.Duration `default:"1s" usage:"the backoff before the first retry, it is doubled with every retry"`
+	// MaxRetryBackoff defines the maximum backoff between two retries.
+	MaxRetryBackoff time.Duration `default:"1m" usage:"the maximum backoff between two retries"`
+	// DeliveryTimeout defines the timeout of a single delivery attempt.
+	DeliveryTimeout time.Duration `default:"10s" usage:"the timeout of a single delivery attempt"`
+}
+
+var ParamsWebhooks = &ParametersWebhooks{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"webhooks": ParamsWebhooks,
+	},
+	Masked: nil,
+}
@@ -0,0 +1,276 @@
+package analytics
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultLatencyTrackerMaxTrackedBlocks is the default maximum amount of blocks that are tracked at the same time.
+	DefaultLatencyTrackerMaxTrackedBlocks = 100_000
+)
+
+// LatencyPercentiles are the percentiles of the latencies of the blocks of a slot.
+type LatencyPercentiles struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// SlotLatency are the acceptance and confirmation statistics of the blocks of a slot.
+type SlotLatency struct {
+	// Slot is the issuing slot of the blocks.
+	Slot iotago.SlotIndex `json:"slot"`
+	// Observed is the amount of blocks of the slot that were observed.
+	Observed int `json:"observed"`
+	// Accepted is the amount of observed blocks that were accepted.
+	Accepted int `json:"accepted"`
+	// Confirmed is the amount of observed blocks that were confirmed.
+	Confirmed int `json:"confirmed"`
+	// AcceptanceRate is the fraction of the observed blocks that were accepted.
+	AcceptanceRate float64 `json:"acceptanceRate"`
+	// AcceptanceLatency are the percentiles of the durations from the observation to the acceptance of the blocks.
+	AcceptanceLatency LatencyPercentiles `json:"acceptanceLatency"`
+	// ConfirmationLatency are the percentiles of the durations from the observation to the confirmation of the blocks.
+	ConfirmationLatency LatencyPercentiles `json:"confirmationLatency"`
+}
+
+// LatencyTrackerEvents are the events triggered by the LatencyTracker.
+type LatencyTrackerEvents struct {
+	// SlotAnalyzed is triggered with the statistics of a slot once the slot was finalized.
+	SlotAnalyzed *event.Event1[*SlotLatency]
+}
+
+type trackedBlock struct {
+	observedAt time.Time
+	accepted   bool
+	confirmed  bool
+}
+
+type slotLatencies struct {
+	observed     int
+	acceptance   []time.Duration
+	confirmation []time.Duration
+}
+
+// LatencyTracker measures the durations from the observation of blocks to their acceptance and confirmation.
+// The blocks are grouped by their issuing slot, and a slot is analyzed once it was finalized by the node.
+type LatencyTracker struct {
+	nodeBridge nodebridge.NodeBridge
+	clock      clock.Clock
+	events     *LatencyTrackerEvents
+
+	maxTrackedBlocks int
+
+	mutex              sync.Mutex
+	blocks             map[iotago.BlockID]*trackedBlock
+	slots              map[iotago.SlotIndex]*slotLatencies
+	latestAnalyzedSlot *SlotLatency
+}
+
+// WithLatencyTrackerMaxTrackedBlocks sets the maximum amount of blocks that are tracked at the same time.
+// Blocks that are observed while the limit is reached are not tracked.
+func WithLatencyTrackerMaxTrackedBlocks(maxTrackedBlocks int) options.Option[LatencyTracker] {
+	return func(t *LatencyTracker) {
+		t.maxTrackedBlocks = maxTrackedBlocks
+	}
+}
+
+// WithLatencyTrackerClock sets the clock that is used to timestamp the blocks, e.g. a clock.Mock in tests.
+func WithLatencyTrackerClock(timeSource clock.Clock) options.Option[LatencyTracker] {
+	return func(t *LatencyTracker) {
+		t.clock = timeSource
+	}
+}
+
+// NewLatencyTracker creates a new LatencyTracker.
+func NewLatencyTracker(nodeBridge nodebridge.NodeBridge, opts ...options.Option[LatencyTracker]) *LatencyTracker {
+	return options.Apply(&LatencyTracker{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &LatencyTrackerEvents{
+			SlotAnalyzed: event.New1[*SlotLatency](),
+		},
+		maxTrackedBlocks: DefaultLatencyTrackerMaxTrackedBlocks,
+		blocks:           make(map[iotago.BlockID]*trackedBlock),
+		slots:            make(map[iotago.SlotIndex]*slotLatencies),
+	}, opts)
+}
+
+// Events returns the events of the LatencyTracker.
+func (t *LatencyTracker) Events() *LatencyTrackerEvents {
+	return t.events
+}
+
+// LatestSlotLatency returns the statistics of the latest analyzed slot, or nil if no slot was analyzed yet.
+func (t *LatencyTracker) LatestSlotLatency() *SlotLatency {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.latestAnalyzedSlot
+}
+
+// Run tracks the blocks and their metadata and blocks until the given context is done or one of the streams failed.
+func (t *LatencyTracker) Run(ctx context.Context) error {
+	unhook := t.nodeBridge.Events().LatestFinalizedCommitmentChanged.Hook(func(commitment *nodebridge.Commitment) {
+		t.analyzeSlots(commitment.CommitmentID.Slot())
+	}).Unhook
+	defer unhook()
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		return t.nodeBridge.ListenToBlocks(groupCtx, func(block *iotago.Block, _ []byte) error {
+			blockID, err := block.ID()
+			if err != nil {
+				return err
+			}
+			t.observeBlock(blockID)
+
+			return nil
+		})
+	})
+
+	group.Go(func() error {
+		return t.nodeBridge.ListenToBlockMetadata(groupCtx, func(blockMetadata *api.BlockMetadataResponse) error {
+			t.processBlockMetadata(blockMetadata)
+
+			return nil
+		})
+	})
+
+	return group.Wait()
+}
+
+func (t *LatencyTracker) observeBlock(blockID iotago.BlockID) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, exists := t.blocks[blockID]; exists || len(t.blocks) >= t.maxTrackedBlocks {
+		return
+	}
+
+	t.blocks[blockID] = &trackedBlock{observedAt: t.clock.Now()}
+	t.slot(blockID.Slot()).observed++
+}
+
+func (t *LatencyTracker) processBlockMetadata(blockMetadata *api.BlockMetadataResponse) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	block, exists := t.blocks[blockMetadata.BlockID]
+	if !exists {
+		return
+	}
+
+	latency := t.clock.Now().Sub(block.observedAt)
+	slot := t.slot(blockMetadata.BlockID.Slot())
+
+	switch blockMetadata.BlockState {
+	case api.BlockStateAccepted:
+		if !block.accepted {
+			block.accepted = true
+			slot.acceptance = append(slot.acceptance, latency)
+		}
+
+	case api.BlockStateConfirmed, api.BlockStateFinalized:
+		// a block can skip the accepted state if the update was missed
+		if !block.accepted {
+			block.accepted = true
+			slot.acceptance = append(slot.acceptance, latency)
+		}
+		if !block.confirmed {
+			block.confirmed = true
+			slot.confirmation = append(slot.confirmation, latency)
+		}
+	}
+}
+
+// slot returns the latencies of the given slot. The mutex must be held by the caller.
+func (t *LatencyTracker) slot(slot iotago.SlotIndex) *slotLatencies {
+	latencies, exists := t.slots[slot]
+	if !exists {
+		latencies = &slotLatencies{}
+		t.slots[slot] = latencies
+	}
+
+	return latencies
+}
+
+// analyzeSlots analyzes all tracked slots up to the given finalized slot and stops tracking their blocks.
+func (t *LatencyTracker) analyzeSlots(finalizedSlot iotago.SlotIndex) {
+	var analyzed []*SlotLatency
+
+	t.mutex.Lock()
+	for blockID := range t.blocks {
+		if blockID.Slot() <= finalizedSlot {
+			delete(t.blocks, blockID)
+		}
+	}
+
+	for slot, latencies := range t.slots {
+		if slot > finalizedSlot {
+			continue
+		}
+		delete(t.slots, slot)
+
+		if latencies.observed == 0 {
+			continue
+		}
+
+		analyzed = append(analyzed, &SlotLatency{
+			Slot:                slot,
+			Observed:            latencies.observed,
+			Accepted:            len(latencies.acceptance),
+			Confirmed:           len(latencies.confirmation),
+			AcceptanceRate:      float64(len(latencies.acceptance)) / float64(latencies.observed),
+			AcceptanceLatency:   percentiles(latencies.acceptance),
+			ConfirmationLatency: percentiles(latencies.confirmation),
+		})
+	}
+
+	slices.SortFunc(analyzed, func(a *SlotLatency, b *SlotLatency) int {
+		return int(a.Slot) - int(b.Slot)
+	})
+
+	if len(analyzed) > 0 && (t.latestAnalyzedSlot == nil || analyzed[len(analyzed)-1].Slot > t.latestAnalyzedSlot.Slot) {
+		t.latestAnalyzedSlot = analyzed[len(analyzed)-1]
+	}
+	t.mutex.Unlock()
+
+	for _, slotLatency := range analyzed {
+		t.events.SlotAnalyzed.Trigger(slotLatency)
+	}
+}
+
+func percentiles(latencies []time.Duration) LatencyPercentiles {
+	if len(latencies) == 0 {
+		return LatencyPercentiles{}
+	}
+
+	slices.Sort(latencies)
+
+	percentile := func(p float64) time.Duration {
+		return latencies[int(math.Ceil(p*float64(len(latencies))))-1]
+	}
+
+	return LatencyPercentiles{
+		P50: percentile(0.5),
+		P90: percentile(0.9),
+		P99: percentile(0.99),
+		Max: latencies[len(latencies)-1],
+	}
+}
@@ -0,0 +1,286 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultThroughputWindow is the default duration of the sliding window the rates are calculated over.
+	DefaultThroughputWindow = time.Minute
+	// DefaultThroughputMaxTrackedInputs is the default maximum amount of transaction inputs that are tracked to detect conflicts.
+	DefaultThroughputMaxTrackedInputs = 100_000
+)
+
+// ThroughputStats are the rates of a ThroughputTracker over its sliding window.
+type ThroughputStats struct {
+	// Window is the duration of the sliding window the rates are calculated over.
+	Window time.Duration `json:"window"`
+	// BlocksPerSecond is the rate of observed blocks.
+	BlocksPerSecond float64 `json:"blocksPerSecond"`
+	// TransactionsPerSecond is the rate of accepted transactions.
+	TransactionsPerSecond float64 `json:"transactionsPerSecond"`
+	// ConflictingTransactionsPerSecond is the rate of observed transactions that lost against a conflicting accepted transaction.
+	ConflictingTransactionsPerSecond float64 `json:"conflictingTransactionsPerSecond"`
+	// BlocksTotal is the total amount of observed blocks.
+	BlocksTotal uint64 `json:"blocksTotal"`
+	// TransactionsTotal is the total amount of accepted transactions.
+	TransactionsTotal uint64 `json:"transactionsTotal"`
+	// ConflictingTransactionsTotal is the total amount of conflicting transactions.
+	ConflictingTransactionsTotal uint64 `json:"conflictingTransactionsTotal"`
+}
+
+// slidingCounter counts events in buckets of one second over a sliding window.
+type slidingCounter struct {
+	total   uint64
+	counts  []uint64
+	seconds []int64
+}
+
+func newSlidingCounter(window time.Duration) *slidingCounter {
+	buckets := max(1, int(window/time.Second))
+
+	return &slidingCounter{
+		counts:  make([]uint64, buckets),
+		seconds: make([]int64, buckets),
+	}
+}
+
+func (c *slidingCounter) add(now time.Time, count uint64) {
+	second := now.Unix()
+	index := int(second % int64(len(c.counts)))
+
+	if c.seconds[index] != second {
+		c.seconds[index] = second
+		c.counts[index] = 0
+	}
+	c.counts[index] += count
+	c.total += count
+}
+
+// rate returns the events per second over the window.
+func (c *slidingCounter) rate(now time.Time) float64 {
+	second := now.Unix()
+
+	var sum uint64
+	for i, bucketSecond := range c.seconds {
+		if second-bucketSecond < int64(len(c.counts)) {
+			sum += c.counts[i]
+		}
+	}
+
+	return float64(sum) / float64(len(c.counts))
+}
+
+type trackedSpender struct {
+	transactionID iotago.TransactionID
+	observedAt    time.Time
+}
+
+// ThroughputTracker tracks the rates of blocks, accepted transactions and conflicting transactions over a sliding window.
+// It is fed by the streams of the node bridge and doesn't query the node.
+// A transaction is counted as conflicting if it was observed in a block and an accepted transaction spent one of its inputs.
+type ThroughputTracker struct {
+	nodeBridge nodebridge.NodeBridge
+	clock      clock.Clock
+
+	window           time.Duration
+	maxTrackedInputs int
+
+	mutex                   sync.Mutex
+	blocks                  *slidingCounter
+	transactions            *slidingCounter
+	conflictingTransactions *slidingCounter
+	spenders                map[iotago.OutputID][]*trackedSpender
+}
+
+// WithThroughputWindow sets the duration of the sliding window the rates are calculated over.
+// The window has a resolution of one second.
+func WithThroughputWindow(window time.Duration) options.Option[ThroughputTracker] {
+	return func(t *ThroughputTracker) {
+		t.window = window
+	}
+}
+
+// WithThroughputMaxTrackedInputs sets the maximum amount of transaction inputs that are tracked to detect conflicts.
+func WithThroughputMaxTrackedInputs(maxTrackedInputs int) options.Option[ThroughputTracker] {
+	return func(t *ThroughputTracker) {
+		t.maxTrackedInputs = maxTrackedInputs
+	}
+}
+
+// WithThroughputClock sets the clock that is used for the sliding window, e.g. a clock.Mock in tests.
+func WithThroughputClock(timeSource clock.Clock) options.Option[ThroughputTracker] {
+	return func(t *ThroughputTracker) {
+		t.clock = timeSource
+	}
+}
+
+// NewThroughputTracker creates a new ThroughputTracker.
+func NewThroughputTracker(nodeBridge nodebridge.NodeBridge, opts ...options.Option[ThroughputTracker]) *ThroughputTracker {
+	t := options.Apply(&ThroughputTracker{
+		nodeBridge:       nodeBridge,
+		clock:            clock.System,
+		window:           DefaultThroughputWindow,
+		maxTrackedInputs: DefaultThroughputMaxTrackedInputs,
+		spenders:         make(map[iotago.OutputID][]*trackedSpender),
+	}, opts)
+
+	t.blocks = newSlidingCounter(t.window)
+	t.transactions = newSlidingCounter(t.window)
+	t.conflictingTransactions = newSlidingCounter(t.window)
+
+	return t
+}
+
+// Stats returns the current rates and totals.
+func (t *ThroughputTracker) Stats() *ThroughputStats {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+
+	return &ThroughputStats{
+		Window:                           t.window,
+		BlocksPerSecond:                  t.blocks.rate(now),
+		TransactionsPerSecond:            t.transactions.rate(now),
+		ConflictingTransactionsPerSecond: t.conflictingTransactions.rate(now),
+		BlocksTotal:                      t.blocks.total,
+		TransactionsTotal:                t.transactions.total,
+		ConflictingTransactionsTotal:     t.conflictingTransactions.total,
+	}
+}
+
+// Run tracks the blocks and the accepted transactions and blocks until the given context is done or one of the streams failed.
+func (t *ThroughputTracker) Run(ctx context.Context) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		return t.nodeBridge.ListenToBlocks(groupCtx, func(block *iotago.Block, _ []byte) error {
+			return t.observeBlock(block)
+		})
+	})
+
+	group.Go(func() error {
+		return t.nodeBridge.ListenToAcceptedTransactions(groupCtx, func(tx *nodebridge.AcceptedTransaction) error {
+			t.processAcceptedTransaction(tx)
+
+			return nil
+		})
+	})
+
+	group.Go(func() error {
+		ticker := t.clock.NewTicker(t.window)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-groupCtx.Done():
+				return nil
+			case <-ticker.C():
+				t.pruneSpenders()
+			}
+		}
+	})
+
+	return group.Wait()
+}
+
+func (t *ThroughputTracker) observeBlock(block *iotago.Block) error {
+	var transaction *iotago.Transaction
+	if body, isBasicBlock := block.Body.(*iotago.BasicBlockBody); isBasicBlock {
+		if signedTransaction, isSignedTransaction := body.Payload.(*iotago.SignedTransaction); isSignedTransaction {
+			transaction = signedTransaction.Transaction
+		}
+	}
+
+	var transactionID iotago.TransactionID
+	if transaction != nil {
+		var err error
+		if transactionID, err = transaction.ID(); err != nil {
+			return err
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	t.blocks.add(now, 1)
+
+	if transaction == nil || len(t.spenders) >= t.maxTrackedInputs {
+		return nil
+	}
+
+	for _, input := range transaction.Inputs() {
+		outputID := input.OutputID()
+
+		// the same transaction can be attached several times
+		known := false
+		for _, spender := range t.spenders[outputID] {
+			if spender.transactionID == transactionID {
+				known = true
+
+				break
+			}
+		}
+		if !known {
+			t.spenders[outputID] = append(t.spenders[outputID], &trackedSpender{transactionID: transactionID, observedAt: now})
+		}
+	}
+
+	return nil
+}
+
+func (t *ThroughputTracker) processAcceptedTransaction(tx *nodebridge.AcceptedTransaction) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	t.transactions.add(now, 1)
+
+	conflicting := make(map[iotago.TransactionID]struct{})
+	for _, consumed := range tx.Consumed {
+		for _, spender := range t.spenders[consumed.OutputID] {
+			if spender.transactionID != tx.TransactionID {
+				conflicting[spender.transactionID] = struct{}{}
+			}
+		}
+		delete(t.spenders, consumed.OutputID)
+	}
+
+	if len(conflicting) > 0 {
+		t.conflictingTransactions.add(now, uint64(len(conflicting)))
+	}
+}
+
+// pruneSpenders stops tracking the inputs of transactions that were observed before the window,
+// e.g. because they were never accepted.
+func (t *ThroughputTracker) pruneSpenders() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	threshold := t.clock.Now().Add(-t.window)
+	for outputID, spenders := range t.spenders {
+		remaining := spenders[:0]
+		for _, spender := range spenders {
+			if spender.observedAt.After(threshold) {
+				remaining = append(remaining, spender)
+			}
+		}
+
+		if len(remaining) == 0 {
+			delete(t.spenders, outputID)
+		} else {
+			t.spenders[outputID] = remaining
+		}
+	}
+}
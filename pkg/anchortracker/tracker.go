@@ -0,0 +1,242 @@
+package anchortracker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Anchor is the tracked state of an anchor.
+type Anchor struct {
+	// AnchorID is the ID of the anchor.
+	AnchorID iotago.AnchorID
+	// OutputID is the ID of the current output of the anchor.
+	OutputID iotago.OutputID
+	// StateIndex is the index of the state of the anchor.
+	StateIndex uint32
+	// StateController is the bech32 encoded address of the state controller.
+	StateController string
+	// Governor is the bech32 encoded address of the governor.
+	Governor string
+	// StateMetadata are the entries of the state metadata feature of the anchor, e.g. the committed L2 state.
+	StateMetadata iotago.StateMetadataFeatureEntries
+	// TransactionID is the ID of the transaction that created the current output of the anchor.
+	TransactionID iotago.TransactionID
+	// SlotBooked is the slot in which the current output of the anchor was booked.
+	SlotBooked iotago.SlotIndex
+}
+
+// Events are the events of the Tracker.
+// The transition events are triggered with the previous and the new state of the anchor.
+type Events struct {
+	// AnchorCreated is triggered if a new anchor was created.
+	AnchorCreated *event.Event1[*Anchor]
+	// StateIndexChanged is triggered if the state of an anchor was transitioned by its state controller.
+	StateIndexChanged *event.Event2[*Anchor, *Anchor]
+	// StateControllerChanged is triggered if the governor changed the state controller of an anchor.
+	StateControllerChanged *event.Event2[*Anchor, *Anchor]
+	// GovernorChanged is triggered if the governor of an anchor changed.
+	GovernorChanged *event.Event2[*Anchor, *Anchor]
+	// AnchorDestroyed is triggered with the last state of an anchor that was destroyed.
+	AnchorDestroyed *event.Event1[*Anchor]
+}
+
+// Tracker follows the anchor outputs in the ledger updates, so L2 and bridge extensions can follow the state of their anchors.
+// Anchors that were created before the tracker started are tracked from their first transition on.
+type Tracker struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	events     *Events
+
+	// the anchors that are tracked, or nil if all anchors are tracked.
+	anchorIDs map[iotago.AnchorID]struct{}
+
+	anchorsMutex sync.RWMutex
+	anchors      map[iotago.AnchorID]*Anchor
+}
+
+// WithAnchorIDs only tracks the anchors with the given IDs.
+func WithAnchorIDs(anchorIDs ...iotago.AnchorID) options.Option[Tracker] {
+	return func(t *Tracker) {
+		t.anchorIDs = make(map[iotago.AnchorID]struct{}, len(anchorIDs))
+		for _, anchorID := range anchorIDs {
+			t.anchorIDs[anchorID] = struct{}{}
+		}
+	}
+}
+
+// New creates a new Tracker.
+func New(logger log.Logger, nodeBridge nodebridge.NodeBridge, opts ...options.Option[Tracker]) *Tracker {
+	return options.Apply(&Tracker{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		events: &Events{
+			AnchorCreated:          event.New1[*Anchor](),
+			StateIndexChanged:      event.New2[*Anchor, *Anchor](),
+			StateControllerChanged: event.New2[*Anchor, *Anchor](),
+			GovernorChanged:        event.New2[*Anchor, *Anchor](),
+			AnchorDestroyed:        event.New1[*Anchor](),
+		},
+		anchors: make(map[iotago.AnchorID]*Anchor),
+	}, opts)
+}
+
+// Events returns the events of the Tracker.
+func (t *Tracker) Events() *Events {
+	return t.events
+}
+
+// Run applies the ledger updates from the given slot on until the given context is done.
+// If startSlot is zero, the ledger updates are applied from the latest commitment on.
+func (t *Tracker) Run(ctx context.Context, startSlot iotago.SlotIndex) error {
+	if err := t.nodeBridge.ListenToLedgerUpdates(ctx, startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+		t.ApplyLedgerUpdate(update)
+
+		return nil
+	}); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyLedgerUpdate applies the anchor transitions of the given ledger update.
+func (t *Tracker) ApplyLedgerUpdate(update *nodebridge.LedgerUpdate) {
+	hrp := update.API.ProtocolParameters().Bech32HRP()
+
+	consumed := make(map[iotago.AnchorID]*Anchor)
+	for _, output := range update.Consumed {
+		if anchor := t.newAnchor(hrp, output); anchor != nil {
+			consumed[anchor.AnchorID] = anchor
+		}
+	}
+
+	type transition struct {
+		previous *Anchor
+		current  *Anchor
+	}
+	var created, destroyed []*Anchor
+	var transitions []*transition
+
+	t.anchorsMutex.Lock()
+
+	for _, output := range update.Created {
+		anchor := t.newAnchor(hrp, output)
+		if anchor == nil {
+			continue
+		}
+
+		previous, wasConsumed := consumed[anchor.AnchorID]
+		if wasConsumed {
+			delete(consumed, anchor.AnchorID)
+		} else {
+			previous = t.anchors[anchor.AnchorID]
+		}
+		t.anchors[anchor.AnchorID] = anchor
+
+		if previous == nil {
+			created = append(created, anchor)
+		} else {
+			transitions = append(transitions, &transition{previous: previous, current: anchor})
+		}
+	}
+
+	// anchors that were consumed without being created again were destroyed
+	for anchorID, anchor := range consumed {
+		delete(t.anchors, anchorID)
+		destroyed = append(destroyed, anchor)
+	}
+
+	t.anchorsMutex.Unlock()
+
+	for _, anchor := range created {
+		t.events.AnchorCreated.Trigger(anchor)
+	}
+	for _, transition := range transitions {
+		if transition.current.StateIndex != transition.previous.StateIndex {
+			t.events.StateIndexChanged.Trigger(transition.previous, transition.current)
+		}
+		if transition.current.StateController != transition.previous.StateController {
+			t.events.StateControllerChanged.Trigger(transition.previous, transition.current)
+		}
+		if transition.current.Governor != transition.previous.Governor {
+			t.events.GovernorChanged.Trigger(transition.previous, transition.current)
+		}
+	}
+	for _, anchor := range destroyed {
+		t.events.AnchorDestroyed.Trigger(anchor)
+	}
+}
+
+// Anchor returns the tracked state of the given anchor.
+func (t *Tracker) Anchor(anchorID iotago.AnchorID) (*Anchor, bool) {
+	t.anchorsMutex.RLock()
+	defer t.anchorsMutex.RUnlock()
+
+	anchor, exists := t.anchors[anchorID]
+
+	return anchor, exists
+}
+
+// Anchors returns the tracked state of all anchors.
+func (t *Tracker) Anchors() []*Anchor {
+	t.anchorsMutex.RLock()
+	defer t.anchorsMutex.RUnlock()
+
+	anchors := make([]*Anchor, 0, len(t.anchors))
+	for _, anchor := range t.anchors {
+		anchors = append(anchors, anchor)
+	}
+
+	return anchors
+}
+
+// newAnchor returns the state of the given output, or nil if the output is not an output of a tracked anchor.
+func (t *Tracker) newAnchor(hrp iotago.NetworkPrefix, output *nodebridge.Output) *Anchor {
+	anchorOutput, isAnchor := output.Output.(*iotago.AnchorOutput)
+	if !isAnchor {
+		return nil
+	}
+
+	anchorID := anchorOutput.AnchorID
+	if anchorID.Empty() {
+		// the anchor was created in this output
+		anchorID = iotago.AnchorIDFromOutputID(output.OutputID)
+	}
+
+	if t.anchorIDs != nil {
+		if _, tracked := t.anchorIDs[anchorID]; !tracked {
+			return nil
+		}
+	}
+
+	anchor := &Anchor{
+		AnchorID:   anchorID,
+		OutputID:   output.OutputID,
+		StateIndex: anchorOutput.StateIndex,
+	}
+
+	unlockConditions := anchorOutput.UnlockConditionSet()
+	if stateController := unlockConditions.StateControllerAddress(); stateController != nil {
+		anchor.StateController = stateController.Address.Bech32(hrp)
+	}
+	if governor := unlockConditions.GovernorAddress(); governor != nil {
+		anchor.Governor = governor.Address.Bech32(hrp)
+	}
+	if stateMetadata := anchorOutput.FeatureSet().StateMetadata(); stateMetadata != nil {
+		anchor.StateMetadata = stateMetadata.Entries
+	}
+	if output.Metadata != nil && output.Metadata.Included != nil {
+		anchor.TransactionID = output.Metadata.Included.TransactionID
+		anchor.SlotBooked = output.Metadata.Included.Slot
+	}
+
+	return anchor
+}
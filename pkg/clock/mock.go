@@ -0,0 +1,182 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Mock is a Clock that only moves if it is set or advanced, so tests can fast-forward deterministically.
+// Timers and tickers fire synchronously while the time is moved.
+type Mock struct {
+	mutex   sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	waiters []*mockWaiter
+}
+
+// NewMock creates a new Mock that starts at the given time.
+func NewMock(now time.Time) *Mock {
+	m := &Mock{now: now}
+	m.changed = sync.NewCond(&m.mutex)
+
+	return m
+}
+
+// Now returns the current time of the Mock.
+func (m *Mock) Now() time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.now
+}
+
+// NewTimer creates a new Timer that fires once the Mock is moved past the given duration.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	return m.addWaiter(d, 0)
+}
+
+// NewTicker creates a new Ticker that fires every time the Mock is moved past the given interval.
+func (m *Mock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	return &mockTicker{mockWaiter: m.addWaiter(d, d)}
+}
+
+// Advance moves the Mock forward by the given duration and fires all due timers and tickers.
+func (m *Mock) Advance(d time.Duration) {
+	m.Set(m.Now().Add(d))
+}
+
+// Set moves the Mock to the given time and fires all due timers and tickers.
+// Moving the Mock backwards doesn't fire anything.
+func (m *Mock) Set(now time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.now = now
+
+	active := m.waiters[:0]
+	for _, waiter := range m.waiters {
+		if waiter.fire(now) {
+			active = append(active, waiter)
+		}
+	}
+	m.waiters = active
+}
+
+// Waiters returns the amount of active timers and tickers.
+func (m *Mock) Waiters() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.waiters)
+}
+
+// WaitForWaiters blocks until at least the given amount of timers and tickers is active or the context is done.
+// This is used to make sure a component is waiting on the Mock before it is moved.
+func (m *Mock) WaitForWaiters(ctx context.Context, count int) error {
+	stop := context.AfterFunc(ctx, func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.changed.Broadcast()
+	})
+	defer stop()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for len(m.waiters) < count {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		m.changed.Wait()
+	}
+
+	return nil
+}
+
+func (m *Mock) addWaiter(d time.Duration, interval time.Duration) *mockWaiter {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	waiter := &mockWaiter{
+		mock:     m,
+		c:        make(chan time.Time, 1),
+		deadline: m.now.Add(d),
+		interval: interval,
+	}
+
+	if !waiter.fire(m.now) {
+		return waiter
+	}
+	m.waiters = append(m.waiters, waiter)
+	m.changed.Broadcast()
+
+	return waiter
+}
+
+func (m *Mock) removeWaiter(waiter *mockWaiter) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, w := range m.waiters {
+		if w == waiter {
+			m.waiters = append(m.waiters[:i], m.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// mockWaiter is a timer (interval 0) or ticker of the Mock.
+type mockWaiter struct {
+	mock     *Mock
+	c        chan time.Time
+	deadline time.Time
+	interval time.Duration
+}
+
+// fire delivers the time if the deadline passed and returns whether the waiter is still active.
+func (w *mockWaiter) fire(now time.Time) bool {
+	if now.Before(w.deadline) {
+		return true
+	}
+
+	// like the tickers of the time package, ticks are dropped if the receiver is too slow
+	select {
+	case w.c <- now:
+	default:
+	}
+
+	if w.interval == 0 {
+		return false
+	}
+
+	for !now.Before(w.deadline) {
+		w.deadline = w.deadline.Add(w.interval)
+	}
+
+	return true
+}
+
+func (w *mockWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *mockWaiter) Stop() bool {
+	return w.mock.removeWaiter(w)
+}
+
+// mockTicker adapts the Stop method of the mockWaiter to the Ticker interface.
+type mockTicker struct {
+	*mockWaiter
+}
+
+func (t *mockTicker) Stop() {
+	t.mockWaiter.Stop()
+}
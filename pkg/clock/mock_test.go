@@ -0,0 +1,189 @@
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/clock"
+)
+
+var testStartTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// assertFired checks that the channel delivered the given time.
+func assertFired(t *testing.T, c <-chan time.Time, expected time.Time) {
+	t.Helper()
+
+	select {
+	case fired := <-c:
+		if !fired.Equal(expected) {
+			t.Fatalf("fired at %s, expected %s", fired, expected)
+		}
+	default:
+		t.Fatalf("did not fire, expected %s", expected)
+	}
+}
+
+// assertNotFired checks that the channel didn't deliver anything.
+func assertNotFired(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case fired := <-c:
+		t.Fatalf("unexpectedly fired at %s", fired)
+	default:
+	}
+}
+
+func TestMockTimer(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	timer := mock.NewTimer(10 * time.Second)
+	if waiters := mock.Waiters(); waiters != 1 {
+		t.Fatalf("expected 1 waiter, got %d", waiters)
+	}
+
+	mock.Advance(9 * time.Second)
+	assertNotFired(t, timer.C())
+
+	mock.Advance(time.Second)
+	assertFired(t, timer.C(), testStartTime.Add(10*time.Second))
+
+	// a timer only fires once
+	mock.Advance(time.Minute)
+	assertNotFired(t, timer.C())
+
+	if waiters := mock.Waiters(); waiters != 0 {
+		t.Fatalf("expected no waiters, got %d", waiters)
+	}
+	if timer.Stop() {
+		t.Fatal("stopping a fired timer must return false")
+	}
+}
+
+func TestMockTimerStop(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	timer := mock.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Fatal("stopping an active timer must return true")
+	}
+
+	mock.Advance(time.Second)
+	assertNotFired(t, timer.C())
+}
+
+func TestMockTimerNonPositiveDuration(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	// like the timers of the time package, the timer fires right away
+	timer := mock.NewTimer(0)
+	assertFired(t, timer.C(), testStartTime)
+
+	if waiters := mock.Waiters(); waiters != 0 {
+		t.Fatalf("expected no waiters, got %d", waiters)
+	}
+}
+
+func TestMockTicker(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	ticker := mock.NewTicker(time.Second)
+
+	mock.Advance(500 * time.Millisecond)
+	assertNotFired(t, ticker.C())
+
+	mock.Advance(500 * time.Millisecond)
+	assertFired(t, ticker.C(), testStartTime.Add(time.Second))
+
+	// the ticks in between are dropped, like for a slow receiver of a ticker of the time package
+	mock.Advance(3 * time.Second)
+	assertFired(t, ticker.C(), testStartTime.Add(4*time.Second))
+	assertNotFired(t, ticker.C())
+
+	// the ticker keeps its interval
+	mock.Advance(999 * time.Millisecond)
+	assertNotFired(t, ticker.C())
+	mock.Advance(time.Millisecond)
+	assertFired(t, ticker.C(), testStartTime.Add(5*time.Second))
+
+	ticker.Stop()
+	if waiters := mock.Waiters(); waiters != 0 {
+		t.Fatalf("expected no waiters, got %d", waiters)
+	}
+
+	mock.Advance(time.Second)
+	assertNotFired(t, ticker.C())
+}
+
+func TestMockSetBackwards(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	timer := mock.NewTimer(time.Second)
+
+	mock.Set(testStartTime.Add(-time.Hour))
+	assertNotFired(t, timer.C())
+
+	if now := mock.Now(); !now.Equal(testStartTime.Add(-time.Hour)) {
+		t.Fatalf("expected %s, got %s", testStartTime.Add(-time.Hour), now)
+	}
+
+	// the deadline doesn't move with the clock
+	mock.Set(testStartTime.Add(time.Second))
+	assertFired(t, timer.C(), testStartTime.Add(time.Second))
+}
+
+func TestMockWaitForWaiters(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fired := make(chan time.Time, 1)
+	go func() {
+		timer := mock.NewTimer(time.Second)
+		fired <- <-timer.C()
+	}()
+
+	if err := mock.WaitForWaiters(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the timer is registered, so advancing the clock must fire it
+	mock.Advance(time.Second)
+
+	select {
+	case firedAt := <-fired:
+		if !firedAt.Equal(testStartTime.Add(time.Second)) {
+			t.Fatalf("fired at %s, expected %s", firedAt, testStartTime.Add(time.Second))
+		}
+	case <-ctx.Done():
+		t.Fatal("timer did not fire")
+	}
+}
+
+func TestMockWaitForWaitersContextDone(t *testing.T) {
+	t.Parallel()
+
+	mock := clock.NewMock(testStartTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := mock.WaitForWaiters(ctx, 1); !ierrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %s, got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,197 @@
+package debugapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// RoutePprof serves the pprof profiles.
+	RoutePprof = "/debug/pprof/"
+	// RouteVars serves the expvar variables.
+	RouteVars = "/debug/vars"
+	// RouteGoroutines serves a dump of the stacks of all goroutines.
+	RouteGoroutines = "/debug/goroutines"
+	// RouteMemStats serves the memory statistics of the runtime.
+	RouteMemStats = "/debug/memstats"
+	// RouteState serves the state of the registered state providers, e.g. /debug/state/bridge.
+	RouteState = "/debug/state/"
+)
+
+// Server serves pprof profiles, expvar variables, goroutine dumps and the state of registered
+// state providers, to diagnose stuck streams and memory growth in production extensions.
+type Server struct {
+	// the logger used to log events.
+	log.Logger
+
+	authToken      string
+	stateProviders map[string]func() any
+	handlers       map[string]http.Handler
+}
+
+// WithAuthToken protects all routes with the given bearer token.
+// If the token is empty, the routes are not protected.
+func WithAuthToken(authToken string) options.Option[Server] {
+	return func(s *Server) {
+		s.authToken = authToken
+	}
+}
+
+// WithStateProvider registers a function that returns the state of a component,
+// which is served as JSON on RouteState + name.
+func WithStateProvider(name string, provider func() any) options.Option[Server] {
+	return func(s *Server) {
+		s.stateProviders[name] = provider
+	}
+}
+
+// WithHandler registers an additional handler for the given route,
+// e.g. debug endpoints of other packages, which is protected by the auth token as well.
+func WithHandler(route string, handler http.Handler) options.Option[Server] {
+	return func(s *Server) {
+		s.handlers[route] = handler
+	}
+}
+
+// New creates a new debug Server.
+func New(logger log.Logger, opts ...options.Option[Server]) *Server {
+	return options.Apply(&Server{
+		Logger:         logger,
+		stateProviders: make(map[string]func() any),
+		handlers:       make(map[string]http.Handler),
+	}, opts)
+}
+
+// Handler returns the HTTP handler that serves all debug routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(RoutePprof, pprof.Index)
+	mux.HandleFunc(RoutePprof+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(RoutePprof+"profile", pprof.Profile)
+	mux.HandleFunc(RoutePprof+"symbol", pprof.Symbol)
+	mux.HandleFunc(RoutePprof+"trace", pprof.Trace)
+	mux.Handle(RouteVars, expvar.Handler())
+	mux.HandleFunc(RouteGoroutines, s.handleGoroutines)
+	mux.HandleFunc(RouteMemStats, s.handleMemStats)
+	mux.HandleFunc(RouteState, s.handleState)
+	for route, handler := range s.handlers {
+		mux.Handle(route, handler)
+	}
+
+	return s.authenticate(mux)
+}
+
+// Run serves the debug routes on the given bind address until the given context is done.
+func (s *Server) Run(ctx context.Context, bindAddress string) error {
+	if s.authToken == "" {
+		s.LogWarnf("Debug API on %s is not protected by an auth token", bindAddress)
+	}
+
+	server := &http.Server{
+		Addr:              bindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	if err := server.Shutdown(context.Background()); err != nil {
+		return err
+	}
+
+	if err := <-serverErr; err != nil && !ierrors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if s.authToken == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleGoroutines(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if err := runtimepprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		s.LogWarnf("failed to write goroutine dump: %s", err)
+	}
+}
+
+func (s *Server) handleMemStats(w http.ResponseWriter, _ *http.Request) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	s.writeJSON(w, &memStats)
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, RouteState)
+	if name == "" {
+		names := make([]string, 0, len(s.stateProviders))
+		for providerName := range s.stateProviders {
+			names = append(names, providerName)
+		}
+		sort.Strings(names)
+
+		s.writeJSON(w, names)
+
+		return
+	}
+
+	provider, exists := s.stateProviders[name]
+	if !exists {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	s.writeJSON(w, provider())
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(obj); err != nil {
+		s.LogWarnf("failed to write debug response: %s", err)
+	}
+}
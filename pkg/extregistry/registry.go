@@ -0,0 +1,255 @@
+package extregistry
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+const (
+	// DefaultHeartbeatInterval is the default interval in which the announcement of the extension is refreshed.
+	DefaultHeartbeatInterval = 10 * time.Second
+	// DefaultStaleTimeout is the default duration after which an announcement without heartbeat is ignored.
+	DefaultStaleTimeout = 1 * time.Minute
+
+	fileExtension = ".json"
+)
+
+var (
+	// ErrInvalidExtensionName is returned if the name of the extension can't be used as registry entry.
+	ErrInvalidExtensionName = ierrors.New("invalid extension name")
+)
+
+// Extension is the announcement of an extension in the registry.
+type Extension struct {
+	// Name is the unique name of the extension, e.g. "inx-indexer".
+	Name string `json:"name"`
+	// Version is the version of the extension.
+	Version string `json:"version"`
+	// Routes are the API routes the extension registered at the node, e.g. "indexer/v2".
+	Routes []string `json:"routes"`
+	// UpdatedAt is the time of the latest heartbeat of the extension.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Registry announces an extension in a registry directory that is shared by the extensions of a node,
+// and lists the other extensions that announced themselves, so extensions can discover each other's routes.
+// The node has no registry for extensions, so the announcements are exchanged via files, one per extension.
+type Registry struct {
+	// the logger used to log events.
+	log.Logger
+
+	directory  string
+	self       *Extension
+	nodeBridge nodebridge.NodeBridge
+
+	heartbeatInterval time.Duration
+	staleTimeout      time.Duration
+}
+
+// WithNodeBridge only lists routes of other extensions that are currently supported by the node,
+// which filters announcements of extensions whose routes were unregistered.
+func WithNodeBridge(nodeBridge nodebridge.NodeBridge) options.Option[Registry] {
+	return func(r *Registry) {
+		r.nodeBridge = nodeBridge
+	}
+}
+
+// WithHeartbeatInterval sets the interval in which the announcement of the extension is refreshed.
+func WithHeartbeatInterval(interval time.Duration) options.Option[Registry] {
+	return func(r *Registry) {
+		r.heartbeatInterval = interval
+	}
+}
+
+// WithStaleTimeout sets the duration after which an announcement without heartbeat is ignored.
+func WithStaleTimeout(timeout time.Duration) options.Option[Registry] {
+	return func(r *Registry) {
+		r.staleTimeout = timeout
+	}
+}
+
+// New creates a new Registry for the given extension in the given registry directory.
+func New(logger log.Logger, directory string, name string, version string, routes []string, opts ...options.Option[Registry]) (*Registry, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name != filepath.Clean(name) {
+		return nil, ierrors.Wrapf(ErrInvalidExtensionName, "name %q", name)
+	}
+
+	return options.Apply(&Registry{
+		Logger:    logger,
+		directory: directory,
+		self: &Extension{
+			Name:    name,
+			Version: version,
+			Routes:  routes,
+		},
+		heartbeatInterval: DefaultHeartbeatInterval,
+		staleTimeout:      DefaultStaleTimeout,
+	}, opts), nil
+}
+
+// Run announces the extension, refreshes the announcement in the configured interval
+// and removes it once the given context is done.
+func (r *Registry) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Register(); err != nil {
+			r.LogWarnf("Failed to announce extension %s in registry: %s", r.self.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := r.Unregister(); err != nil {
+				r.LogWarnf("Failed to remove extension %s from registry: %s", r.self.Name, err)
+			}
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Register announces the extension in the registry directory.
+func (r *Registry) Register() error {
+	if err := os.MkdirAll(r.directory, 0o700); err != nil {
+		return ierrors.Wrapf(err, "failed to create registry directory %s", r.directory)
+	}
+
+	announcement := *r.self
+	announcement.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(&announcement, "", "  ")
+	if err != nil {
+		return ierrors.Wrap(err, "failed to marshal announcement")
+	}
+
+	// write to a temporary file first, so other extensions never read a partial announcement
+	tmpFile := r.filePath(r.self.Name) + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0o600); err != nil {
+		return ierrors.Wrapf(err, "failed to write announcement %s", tmpFile)
+	}
+
+	if err := os.Rename(tmpFile, r.filePath(r.self.Name)); err != nil {
+		return ierrors.Wrapf(err, "failed to write announcement %s", r.filePath(r.self.Name))
+	}
+
+	return nil
+}
+
+// Unregister removes the announcement of the extension from the registry directory.
+func (r *Registry) Unregister() error {
+	if err := os.Remove(r.filePath(r.self.Name)); err != nil && !os.IsNotExist(err) {
+		return ierrors.Wrapf(err, "failed to remove announcement %s", r.filePath(r.self.Name))
+	}
+
+	return nil
+}
+
+// ListOtherExtensions returns the other extensions that announced themselves in the registry directory, sorted by name.
+// Stale announcements and announcements that can't be read are skipped.
+func (r *Registry) ListOtherExtensions(ctx context.Context) ([]*Extension, error) {
+	entries, err := os.ReadDir(r.directory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, ierrors.Wrapf(err, "failed to read registry directory %s", r.directory)
+	}
+
+	var supportedRoutes map[string]struct{}
+	if r.nodeBridge != nil {
+		routes, err := r.nodeBridge.SupportedRoutes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		supportedRoutes = make(map[string]struct{}, len(routes))
+		for _, route := range routes {
+			supportedRoutes[route] = struct{}{}
+		}
+	}
+
+	extensions := make([]*Extension, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExtension) || entry.Name() == r.self.Name+fileExtension {
+			continue
+		}
+
+		extension, err := r.readAnnouncement(filepath.Join(r.directory, entry.Name()))
+		if err != nil {
+			r.LogDebugf("Skipping announcement %s: %s", entry.Name(), err)
+
+			continue
+		}
+
+		if time.Since(extension.UpdatedAt) > r.staleTimeout {
+			continue
+		}
+
+		if supportedRoutes != nil {
+			routes := make([]string, 0, len(extension.Routes))
+			for _, route := range extension.Routes {
+				if _, supported := supportedRoutes[route]; supported {
+					routes = append(routes, route)
+				}
+			}
+			extension.Routes = routes
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Name < extensions[j].Name
+	})
+
+	return extensions, nil
+}
+
+// ExtensionByRoute returns the other extension that announced the given route, or false if there is none.
+func (r *Registry) ExtensionByRoute(ctx context.Context, route string) (*Extension, bool, error) {
+	extensions, err := r.ListOtherExtensions(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, extension := range extensions {
+		for _, extensionRoute := range extension.Routes {
+			if extensionRoute == route {
+				return extension, true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (r *Registry) readAnnouncement(path string) (*Extension, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := &Extension{}
+	if err := json.Unmarshal(data, extension); err != nil {
+		return nil, err
+	}
+
+	return extension, nil
+}
+
+func (r *Registry) filePath(name string) string {
+	return filepath.Join(r.directory, name+fileExtension)
+}
@@ -0,0 +1,606 @@
+package faucet
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/inx-app/pkg/ledgermirror"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+const (
+	// DefaultBatchInterval is the default interval in which the queued requests are batched into a transaction.
+	DefaultBatchInterval = 2 * time.Second
+	// DefaultMaxBatchSize is the default maximum amount of requests per transaction.
+	// One output of the transaction is reserved for the remainder.
+	DefaultMaxBatchSize = iotago.MaxOutputsCount - 1
+	// DefaultConfirmationTimeoutSlots is the default amount of committed slots after which
+	// a submitted transaction that was not committed is replaced.
+	DefaultConfirmationTimeoutSlots = 10
+	// DefaultMaxAttempts is the default maximum amount of transactions a request is included in before it fails.
+	DefaultMaxAttempts = 3
+)
+
+var (
+	// ErrAmountBelowMinDeposit is returned if the requested amount is below the minimum storage deposit of a basic output.
+	ErrAmountBelowMinDeposit = ierrors.New("amount below minimum storage deposit")
+	// ErrAttemptsExceeded is returned if none of the transactions that contained the request was committed.
+	ErrAttemptsExceeded = ierrors.New("attempts exceeded")
+)
+
+// RequestState is the state of a Request.
+type RequestState int
+
+const (
+	// RequestStateQueued is the state of a request that waits to be included in a transaction.
+	RequestStateQueued RequestState = iota
+	// RequestStateSubmitted is the state of a request whose transaction was submitted to the node.
+	RequestStateSubmitted
+	// RequestStateCommitted is the state of a request whose transaction was committed.
+	RequestStateCommitted
+	// RequestStateFailed is the state of a request that could not be fulfilled.
+	RequestStateFailed
+)
+
+// String returns the name of the state.
+func (s RequestState) String() string {
+	switch s {
+	case RequestStateQueued:
+		return "queued"
+	case RequestStateSubmitted:
+		return "submitted"
+	case RequestStateCommitted:
+		return "committed"
+	case RequestStateFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Request is a request for funds.
+// The fields are updated by the FundsManager, the request must only be read in the status callback.
+type Request struct {
+	// Address is the address the funds are sent to.
+	Address iotago.Address
+	// Amount is the requested base token amount.
+	Amount iotago.BaseToken
+	// State is the current state of the request.
+	State RequestState
+	// TransactionID is the ID of the latest transaction that contained the request.
+	TransactionID iotago.TransactionID
+	// BlockID is the ID of the block of the latest transaction that contained the request.
+	BlockID iotago.BlockID
+	// Err is the reason why the request failed.
+	Err error
+
+	attempts int
+	callback func(request *Request)
+}
+
+// pendingTransaction is a submitted transaction that was not committed yet.
+type pendingTransaction struct {
+	transactionID iotago.TransactionID
+	inputs        map[iotago.OutputID]struct{}
+	requests      []*Request
+	// the latest committed slot when the transaction was submitted.
+	submittedSlot iotago.SlotIndex
+	// whether the transaction was not committed within the confirmation timeout and its requests were queued again.
+	timedOut bool
+	// the timed out transactions that were replaced by this one, with the requests they contained.
+	// They spend the same inputs, so they can still be committed instead of this transaction.
+	superseded map[iotago.TransactionID][]*Request
+}
+
+// paidRequests returns the requests that were paid if the inputs were spent by the given transaction,
+// and false if the transaction is neither this transaction nor one it superseded.
+func (p *pendingTransaction) paidRequests(transactionID iotago.TransactionID) ([]*Request, bool) {
+	if transactionID == p.transactionID {
+		return p.requests, true
+	}
+
+	requests, isSuperseded := p.superseded[transactionID]
+
+	return requests, isSuperseded
+}
+
+// FundsManager sends funds from the faucet address to the enqueued addresses.
+// It tracks the outputs of the faucet address via the ledger mirror and batches the queued requests into transactions.
+//
+// Only one transaction is pending at a time, and every transaction spends all outputs of the faucet address.
+// If a transaction conflicts, or is not committed within the confirmation timeout, its requests are queued again.
+// The next transaction spends the same inputs, so only one of both can ever be committed.
+// If a timed out transaction is committed after all, its requests are paid and are not included in a transaction again.
+type FundsManager struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	mirror     *ledgermirror.Mirror
+	clock      clock.Clock
+
+	// the account that issues the blocks and receives the remaining mana.
+	accountID iotago.AccountID
+	// the key that signs the blocks and unlocks the outputs of the faucet address.
+	privateKey ed25519.PrivateKey
+	address    *iotago.Ed25519Address
+
+	batchInterval            time.Duration
+	maxBatchSize             int
+	confirmationTimeoutSlots iotago.SlotIndex
+	maxAttempts              int
+
+	mutex   sync.Mutex
+	queue   []*Request
+	pending *pendingTransaction
+
+	unhook func()
+}
+
+// WithBatchInterval sets the interval in which the queued requests are batched into a transaction.
+func WithBatchInterval(batchInterval time.Duration) options.Option[FundsManager] {
+	return func(m *FundsManager) {
+		m.batchInterval = batchInterval
+	}
+}
+
+// WithMaxBatchSize sets the maximum amount of requests per transaction.
+func WithMaxBatchSize(maxBatchSize int) options.Option[FundsManager] {
+	return func(m *FundsManager) {
+		m.maxBatchSize = maxBatchSize
+	}
+}
+
+// WithConfirmationTimeoutSlots sets the amount of committed slots after which a pending transaction is replaced.
+func WithConfirmationTimeoutSlots(slots iotago.SlotIndex) options.Option[FundsManager] {
+	return func(m *FundsManager) {
+		m.confirmationTimeoutSlots = slots
+	}
+}
+
+// WithMaxAttempts sets the maximum amount of transactions a request is included in before it fails.
+func WithMaxAttempts(maxAttempts int) options.Option[FundsManager] {
+	return func(m *FundsManager) {
+		m.maxAttempts = maxAttempts
+	}
+}
+
+// WithClock sets the clock that is used for the batch interval and the issuing time, e.g. a clock.Mock in tests.
+func WithClock(timeSource clock.Clock) options.Option[FundsManager] {
+	return func(m *FundsManager) {
+		m.clock = timeSource
+	}
+}
+
+// NewFundsManager creates a new FundsManager for the faucet address of the given private key.
+// The blocks are issued by the given account, the private key must be a block issuer key of that account.
+func NewFundsManager(logger log.Logger, nodeBridge nodebridge.NodeBridge, mirror *ledgermirror.Mirror, accountID iotago.AccountID, privateKey ed25519.PrivateKey, opts ...options.Option[FundsManager]) *FundsManager {
+	m := options.Apply(&FundsManager{
+		Logger:                   logger,
+		nodeBridge:               nodeBridge,
+		mirror:                   mirror,
+		clock:                    clock.System,
+		accountID:                accountID,
+		privateKey:               privateKey,
+		address:                  iotago.Ed25519AddressFromPubKey(privateKey.Public().(ed25519.PublicKey)),
+		batchInterval:            DefaultBatchInterval,
+		maxBatchSize:             DefaultMaxBatchSize,
+		confirmationTimeoutSlots: DefaultConfirmationTimeoutSlots,
+		maxAttempts:              DefaultMaxAttempts,
+	}, opts)
+
+	m.unhook = mirror.Events().LedgerUpdateApplied.Hook(m.applyLedgerUpdate).Unhook
+
+	return m
+}
+
+// Shutdown unhooks the FundsManager from the mirror.
+func (m *FundsManager) Shutdown() {
+	m.unhook()
+}
+
+// Address returns the faucet address.
+func (m *FundsManager) Address() *iotago.Ed25519Address {
+	return m.address
+}
+
+// Balance returns the base token balance of the faucet address in the ledger mirror.
+func (m *FundsManager) Balance(ctx context.Context) (iotago.BaseToken, error) {
+	return m.mirror.Storage().Balance(ctx, m.bech32Address())
+}
+
+// QueueSize returns the amount of queued requests.
+func (m *FundsManager) QueueSize() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return len(m.queue)
+}
+
+// Enqueue queues a request for the given amount to the given address.
+// The callback is called on every change of the state of the request, it may be nil.
+func (m *FundsManager) Enqueue(address iotago.Address, amount iotago.BaseToken, callback func(request *Request)) *Request {
+	request := &Request{
+		Address:  address,
+		Amount:   amount,
+		State:    RequestStateQueued,
+		callback: callback,
+	}
+
+	m.mutex.Lock()
+	m.queue = append(m.queue, request)
+	m.mutex.Unlock()
+
+	m.notify(request)
+
+	return request
+}
+
+// Run batches the queued requests in the configured interval until the given context is done.
+func (m *FundsManager) Run(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.batchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		if err := m.processQueue(ctx); err != nil && ctx.Err() == nil {
+			m.LogWarnf("failed to process faucet queue: %s", err)
+		}
+	}
+}
+
+// processQueue submits a transaction for the queued requests if no transaction is pending.
+func (m *FundsManager) processQueue(ctx context.Context) error {
+	m.mutex.Lock()
+	if (m.pending != nil && !m.pending.timedOut) || len(m.queue) == 0 {
+		m.mutex.Unlock()
+
+		return nil
+	}
+	m.mutex.Unlock()
+
+	inputs, err := m.unspentOutputs(ctx)
+	if err != nil {
+		return err
+	}
+
+	blockIssuance, err := m.nodeBridge.BlockIssuance(ctx, nodebridge.UniformBlockIssuanceParents(iotago.BasicBlockMaxParents))
+	if err != nil {
+		return ierrors.Wrap(err, "failed to request block issuance")
+	}
+
+	commitmentID, err := blockIssuance.LatestCommitment.ID()
+	if err != nil {
+		return ierrors.Wrap(err, "failed to compute commitment ID")
+	}
+
+	issuingTime := m.clock.Now()
+	apiForTime := m.nodeBridge.APIProvider().APIForTime(issuingTime)
+
+	m.mutex.Lock()
+	requests, failed, err := m.selectRequests(apiForTime, inputs)
+	m.mutex.Unlock()
+
+	for _, request := range failed {
+		m.notify(request)
+	}
+	if err != nil || len(requests) == 0 {
+		return err
+	}
+
+	block, transactionID, err := m.buildBlock(apiForTime, issuingTime, commitmentID, blockIssuance.LatestFinalizedSlot, blockIssuance.LatestCommitment.ReferenceManaCost, inputs, requests)
+	if err == nil {
+		var blockID iotago.BlockID
+		if blockID, err = m.nodeBridge.SubmitBlock(ctx, block); err == nil {
+			m.submitted(transactionID, blockID, inputs, requests, blockIssuance.LatestCommitment.Slot)
+
+			return nil
+		}
+	}
+
+	// put the requests back in front of the queue, they are retried in the next interval
+	m.mutex.Lock()
+	m.queue = append(requests, m.queue...)
+	m.mutex.Unlock()
+
+	return err
+}
+
+// unspentOutputs returns the unspent outputs of the faucet address from the ledger mirror.
+func (m *FundsManager) unspentOutputs(ctx context.Context) ([]*nodebridge.Output, error) {
+	outputIDs, err := m.mirror.Storage().UnspentOutputIDs(ctx, m.bech32Address())
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to read unspent outputs of the faucet address")
+	}
+	if len(outputIDs) > iotago.MaxInputsCount {
+		outputIDs = outputIDs[:iotago.MaxInputsCount]
+	}
+
+	outputs := make([]*nodebridge.Output, 0, len(outputIDs))
+	for _, outputID := range outputIDs {
+		record, err := m.mirror.Storage().Output(ctx, outputID)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "failed to read output %s", outputID.ToHex())
+		}
+
+		var output iotago.TxEssenceOutput
+		if _, err := m.nodeBridge.APIProvider().APIForSlot(record.SlotBooked).Decode(record.RawOutputData, &output); err != nil {
+			return nil, ierrors.Wrapf(err, "failed to decode output %s", outputID.ToHex())
+		}
+
+		// only plain basic outputs can be spent without further unlock conditions
+		basicOutput, isBasic := output.(*iotago.BasicOutput)
+		if !isBasic || len(basicOutput.UnlockConditions) != 1 {
+			continue
+		}
+
+		outputs = append(outputs, &nodebridge.Output{
+			OutputID:      outputID,
+			Output:        output,
+			RawOutputData: record.RawOutputData,
+		})
+	}
+
+	return outputs, nil
+}
+
+// selectRequests removes the requests that fit into the funds of the given inputs from the queue.
+// Requests below the minimum storage deposit are removed from the queue and returned as failed.
+// The mutex must be held by the caller.
+func (m *FundsManager) selectRequests(apiForTime iotago.API, inputs []*nodebridge.Output) ([]*Request, []*Request, error) {
+	var available iotago.BaseToken
+	for _, input := range inputs {
+		available += input.Output.BaseTokenAmount()
+	}
+
+	minDeposit, err := apiForTime.StorageScoreStructure().MinDeposit(m.basicOutput(m.address, 0))
+	if err != nil {
+		return nil, nil, ierrors.Wrap(err, "failed to compute minimum storage deposit")
+	}
+
+	var selected, failed, remaining []*Request
+	var total iotago.BaseToken
+	for _, request := range m.queue {
+		switch {
+		case request.Amount < minDeposit:
+			request.State = RequestStateFailed
+			request.Err = ierrors.Wrapf(ErrAmountBelowMinDeposit, "requested %d, minimum %d", request.Amount, minDeposit)
+			failed = append(failed, request)
+		case len(selected) < m.maxBatchSize && total+request.Amount+minDeposit <= available:
+			// the remainder output must keep the minimum storage deposit
+			total += request.Amount
+			selected = append(selected, request)
+		default:
+			remaining = append(remaining, request)
+		}
+	}
+	m.queue = remaining
+
+	if len(selected) == 0 && len(remaining) > 0 {
+		m.LogDebugf("insufficient faucet funds for the queued requests, available: %d", available)
+	}
+
+	return selected, failed, nil
+}
+
+// buildBlock builds a block with a transaction that sends the requested amounts and the remainder back to the faucet address.
+func (m *FundsManager) buildBlock(apiForTime iotago.API, issuingTime time.Time, commitmentID iotago.CommitmentID, latestFinalizedSlot iotago.SlotIndex, referenceManaCost iotago.Mana, inputs []*nodebridge.Output, requests []*Request) (*iotago.Block, iotago.TransactionID, error) {
+	creationSlot := apiForTime.TimeProvider().SlotFromTime(issuingTime)
+
+	transactionBuilder := builder.NewTransactionBuilder(apiForTime, iotago.NewInMemoryAddressSignerFromEd25519PrivateKeys(m.privateKey)).
+		AddCommitmentInput(&iotago.CommitmentInput{CommitmentID: commitmentID}).
+		SetCreationSlot(creationSlot)
+
+	var remainder iotago.BaseToken
+	for _, input := range inputs {
+		remainder += input.Output.BaseTokenAmount()
+		transactionBuilder.AddInput(&builder.TxInput{
+			UnlockTarget: m.address,
+			InputID:      input.OutputID,
+			Input:        input.Output,
+		})
+	}
+
+	// the remainder is the first output, it stores the remaining mana
+	for _, request := range requests {
+		remainder -= request.Amount
+	}
+	transactionBuilder.AddOutput(m.basicOutput(m.address, remainder))
+	for _, request := range requests {
+		transactionBuilder.AddOutput(m.basicOutput(request.Address, request.Amount))
+	}
+
+	signedTransaction, err := transactionBuilder.
+		AllotMinRequiredManaAndStoreRemainingManaInOutput(creationSlot, referenceManaCost, m.accountID, 0).
+		Build()
+	if err != nil {
+		return nil, iotago.EmptyTransactionID, ierrors.Wrap(err, "failed to build faucet transaction")
+	}
+
+	transactionID, err := signedTransaction.Transaction.ID()
+	if err != nil {
+		return nil, iotago.EmptyTransactionID, ierrors.Wrap(err, "failed to compute faucet transaction ID")
+	}
+
+	block, err := builder.NewBasicBlockBuilder(apiForTime).
+		IssuingTime(issuingTime).
+		SlotCommitmentID(commitmentID).
+		LatestFinalizedSlot(latestFinalizedSlot).
+		Payload(signedTransaction).
+		CalculateAndSetMaxBurnedMana(referenceManaCost).
+		Sign(m.accountID, m.privateKey).
+		Build()
+	if err != nil {
+		return nil, iotago.EmptyTransactionID, ierrors.Wrap(err, "failed to build faucet block")
+	}
+
+	return block, transactionID, nil
+}
+
+// submitted marks the given requests as submitted and remembers the transaction as pending.
+func (m *FundsManager) submitted(transactionID iotago.TransactionID, blockID iotago.BlockID, inputs []*nodebridge.Output, requests []*Request, latestCommittedSlot iotago.SlotIndex) {
+	pending := &pendingTransaction{
+		transactionID: transactionID,
+		inputs:        make(map[iotago.OutputID]struct{}, len(inputs)),
+		requests:      requests,
+		submittedSlot: latestCommittedSlot,
+		superseded:    make(map[iotago.TransactionID][]*Request),
+	}
+	for _, input := range inputs {
+		pending.inputs[input.OutputID] = struct{}{}
+	}
+
+	m.mutex.Lock()
+	if previous := m.pending; previous != nil {
+		// the previous transaction timed out, but can still be committed instead of the new one
+		for transactionID, supersededRequests := range previous.superseded {
+			pending.superseded[transactionID] = supersededRequests
+		}
+		pending.superseded[previous.transactionID] = previous.requests
+		for outputID := range previous.inputs {
+			pending.inputs[outputID] = struct{}{}
+		}
+	}
+	m.pending = pending
+	for _, request := range requests {
+		request.State = RequestStateSubmitted
+		request.TransactionID = transactionID
+		request.BlockID = blockID
+		request.attempts++
+	}
+	m.mutex.Unlock()
+
+	for _, request := range requests {
+		m.notify(request)
+	}
+}
+
+// applyLedgerUpdate resolves the pending transaction if it or a transaction it superseded was committed,
+// if it conflicted or if it timed out.
+func (m *FundsManager) applyLedgerUpdate(update *nodebridge.LedgerUpdate) {
+	m.mutex.Lock()
+
+	pending := m.pending
+	if pending == nil {
+		m.mutex.Unlock()
+
+		return
+	}
+
+	var paid []*Request
+	var committedTransactionID iotago.TransactionID
+	committed := false
+	conflicting := false
+	for _, output := range update.Consumed {
+		if _, isInput := pending.inputs[output.OutputID]; !isInput || output.Metadata == nil || output.Metadata.Spent == nil {
+			continue
+		}
+
+		if requests, isPaid := pending.paidRequests(output.Metadata.Spent.TransactionID); isPaid {
+			committed = true
+			committedTransactionID = output.Metadata.Spent.TransactionID
+			paid = requests
+		} else {
+			conflicting = true
+		}
+	}
+
+	timedOut := !pending.timedOut && update.CommitmentID.Slot() >= pending.submittedSlot+m.confirmationTimeoutSlots
+	if !committed && !conflicting && !timedOut {
+		m.mutex.Unlock()
+
+		return
+	}
+
+	paidRequests := make(map[*Request]struct{}, len(paid))
+	for _, request := range paid {
+		request.State = RequestStateCommitted
+		request.TransactionID = committedTransactionID
+		paidRequests[request] = struct{}{}
+	}
+
+	var notified []*Request
+	notified = append(notified, paid...)
+
+	if timedOut && !committed && !conflicting {
+		// the transaction is kept until it is replaced, so it is still recognized if it is committed later
+		pending.timedOut = true
+	} else {
+		m.pending = nil
+	}
+
+	var retried []*Request
+	if !pending.timedOut || timedOut {
+		// the requests of a transaction that timed out before were already queued again
+		for _, request := range pending.requests {
+			if _, isPaid := paidRequests[request]; isPaid {
+				continue
+			}
+
+			switch {
+			case request.attempts >= m.maxAttempts:
+				request.State = RequestStateFailed
+				request.Err = ierrors.Wrapf(ErrAttemptsExceeded, "no transaction was committed after %d attempts", request.attempts)
+			default:
+				// the requests are put back in front of the queue, the next transaction spends the same inputs
+				request.State = RequestStateQueued
+				retried = append(retried, request)
+			}
+			notified = append(notified, request)
+		}
+	}
+
+	// remove the paid requests that were queued again after their transaction timed out
+	queue := make([]*Request, 0, len(retried)+len(m.queue))
+	queue = append(queue, retried...)
+	for _, request := range m.queue {
+		if _, isPaid := paidRequests[request]; !isPaid {
+			queue = append(queue, request)
+		}
+	}
+	m.queue = queue
+
+	m.mutex.Unlock()
+
+	switch {
+	case committed && committedTransactionID != pending.transactionID:
+		m.LogInfof("faucet transaction %s was committed after it was superseded, retrying %d requests", committedTransactionID.ToHex(), len(retried))
+	case !committed:
+		m.LogInfof("faucet transaction %s was not committed (conflicting: %t), retrying %d requests", pending.transactionID.ToHex(), conflicting, len(retried))
+	}
+	for _, request := range notified {
+		m.notify(request)
+	}
+}
+
+func (m *FundsManager) basicOutput(address iotago.Address, amount iotago.BaseToken) *iotago.BasicOutput {
+	return &iotago.BasicOutput{
+		Amount: amount,
+		UnlockConditions: iotago.BasicOutputUnlockConditions{
+			&iotago.AddressUnlockCondition{Address: address},
+		},
+	}
+}
+
+func (m *FundsManager) bech32Address() string {
+	return m.address.Bech32(m.nodeBridge.APIProvider().CommittedAPI().ProtocolParameters().Bech32HRP())
+}
+
+func (m *FundsManager) notify(request *Request) {
+	if request.callback != nil {
+		request.callback(request)
+	}
+}
@@ -0,0 +1,315 @@
+package faucet
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+const (
+	testConfirmationTimeoutSlots = 10
+	testMaxAttempts              = 3
+
+	// foreignTransaction is the index of a transaction that wasn't issued by the FundsManager.
+	foreignTransaction = -1
+)
+
+// fundsManagerHarness drives the FundsManager like processQueue and the ledger mirror do,
+// and records the payments of the requests.
+type fundsManagerHarness struct {
+	t       *testing.T
+	manager *FundsManager
+
+	// the outputs of the faucet address, they are spent by every transaction.
+	inputs         []*nodebridge.Output
+	transactionIDs []iotago.TransactionID
+	requests       []*Request
+	// the transactions the requests were paid by, a request must only be paid once.
+	payments map[*Request][]iotago.TransactionID
+}
+
+func newFundsManagerHarness(t *testing.T) *fundsManagerHarness {
+	t.Helper()
+
+	return &fundsManagerHarness{
+		t: t,
+		manager: &FundsManager{
+			Logger:                   log.NewLogger(log.WithName("faucet")),
+			confirmationTimeoutSlots: testConfirmationTimeoutSlots,
+			maxAttempts:              testMaxAttempts,
+		},
+		inputs: []*nodebridge.Output{
+			{OutputID: tpkg.RandOutputID(0)},
+			{OutputID: tpkg.RandOutputID(1)},
+		},
+		payments: make(map[*Request][]iotago.TransactionID),
+	}
+}
+
+// enqueue queues the given amount of requests.
+func (h *fundsManagerHarness) enqueue(count int) {
+	for range count {
+		h.requests = append(h.requests, h.manager.Enqueue(tpkg.RandEd25519Address(), 1_000_000, func(request *Request) {
+			if request.State == RequestStateCommitted {
+				h.payments[request] = append(h.payments[request], request.TransactionID)
+			}
+		}))
+	}
+}
+
+// submit includes all queued requests in a new transaction that spends the inputs, like processQueue.
+func (h *fundsManagerHarness) submit(latestCommittedSlot iotago.SlotIndex) {
+	h.manager.mutex.Lock()
+	requests := h.manager.queue
+	h.manager.queue = nil
+	h.manager.mutex.Unlock()
+
+	if len(requests) == 0 {
+		h.t.Fatal("no queued requests to submit")
+	}
+
+	transactionID := tpkg.RandTransactionID()
+	h.transactionIDs = append(h.transactionIDs, transactionID)
+	h.manager.submitted(transactionID, tpkg.RandBlockID(), h.inputs, requests, latestCommittedSlot)
+}
+
+// commit applies a ledger update of the given slot in which the inputs are spent by the transaction with the given index.
+func (h *fundsManagerHarness) commit(slot iotago.SlotIndex, transactionIndex int) {
+	transactionID := tpkg.RandTransactionID()
+	if transactionIndex != foreignTransaction {
+		transactionID = h.transactionIDs[transactionIndex]
+	}
+
+	consumed := make([]*nodebridge.Output, 0, len(h.inputs))
+	for _, input := range h.inputs {
+		consumed = append(consumed, &nodebridge.Output{
+			OutputID: input.OutputID,
+			Metadata: &api.OutputMetadata{
+				OutputID: input.OutputID,
+				Spent:    &api.OutputConsumptionMetadata{Slot: slot, TransactionID: transactionID},
+			},
+		})
+	}
+
+	h.manager.applyLedgerUpdate(&nodebridge.LedgerUpdate{
+		CommitmentID: iotago.NewCommitmentID(slot, tpkg.Rand32ByteArray()),
+		Consumed:     consumed,
+	})
+
+	if transactionIndex == foreignTransaction {
+		// the faucet address gets new outputs after the conflict
+		h.inputs = []*nodebridge.Output{{OutputID: tpkg.RandOutputID(0)}}
+	}
+}
+
+// commitSlot applies a ledger update of the given slot that doesn't touch the inputs.
+func (h *fundsManagerHarness) commitSlot(slot iotago.SlotIndex) {
+	h.manager.applyLedgerUpdate(&nodebridge.LedgerUpdate{
+		CommitmentID: iotago.NewCommitmentID(slot, tpkg.Rand32ByteArray()),
+	})
+}
+
+func TestFundsManagerApplyLedgerUpdate(t *testing.T) {
+	t.Parallel()
+
+	type expectedRequest struct {
+		state RequestState
+		// the index of the transaction that paid the request, or foreignTransaction if it wasn't paid.
+		paidBy   int
+		attempts int
+	}
+
+	tests := []struct {
+		name     string
+		steps    func(h *fundsManagerHarness)
+		expected []expectedRequest
+		// the amount of requests that are queued at the end.
+		queued int
+		// whether a transaction is still pending at the end.
+		pending bool
+	}{
+		{
+			name: "commit",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(2)
+				h.submit(0)
+				h.commit(1, 0)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateCommitted, paidBy: 0, attempts: 1},
+				{state: RequestStateCommitted, paidBy: 0, attempts: 1},
+			},
+		},
+		{
+			name: "timeout then late commit of the old transaction",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(2)
+				h.submit(0)
+				h.commitSlot(testConfirmationTimeoutSlots)
+				h.submit(testConfirmationTimeoutSlots)
+				h.commit(testConfirmationTimeoutSlots+1, 0)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateCommitted, paidBy: 0, attempts: 2},
+				{state: RequestStateCommitted, paidBy: 0, attempts: 2},
+			},
+		},
+		{
+			name: "timeout then late commit of the old transaction retries the new requests",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(1)
+				h.submit(0)
+				h.commitSlot(testConfirmationTimeoutSlots)
+				h.enqueue(1)
+				h.submit(testConfirmationTimeoutSlots)
+				h.commit(testConfirmationTimeoutSlots+1, 0)
+				h.submit(testConfirmationTimeoutSlots + 1)
+				h.commit(testConfirmationTimeoutSlots+2, 2)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateCommitted, paidBy: 0, attempts: 2},
+				{state: RequestStateCommitted, paidBy: 2, attempts: 2},
+			},
+		},
+		{
+			name: "timeout then commit of the new transaction",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(2)
+				h.submit(0)
+				h.commitSlot(testConfirmationTimeoutSlots)
+				h.submit(testConfirmationTimeoutSlots)
+				h.commit(testConfirmationTimeoutSlots+1, 1)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateCommitted, paidBy: 1, attempts: 2},
+				{state: RequestStateCommitted, paidBy: 1, attempts: 2},
+			},
+		},
+		{
+			name: "timeout without a new transaction then late commit",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(1)
+				h.submit(0)
+				h.commitSlot(testConfirmationTimeoutSlots)
+				h.commit(testConfirmationTimeoutSlots+1, 0)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateCommitted, paidBy: 0, attempts: 1},
+			},
+		},
+		{
+			name: "conflict then commit of the new transaction",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(2)
+				h.submit(0)
+				h.commit(1, foreignTransaction)
+				h.submit(1)
+				h.commit(2, 1)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateCommitted, paidBy: 1, attempts: 2},
+				{state: RequestStateCommitted, paidBy: 1, attempts: 2},
+			},
+		},
+		{
+			name: "conflict is retried",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(1)
+				h.submit(0)
+				h.commit(1, foreignTransaction)
+			},
+			expected: []expectedRequest{
+				{state: RequestStateQueued, paidBy: foreignTransaction, attempts: 1},
+			},
+			queued: 1,
+		},
+		{
+			name: "max attempts",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(1)
+				for attempt := range testMaxAttempts {
+					slot := iotago.SlotIndex(attempt * testConfirmationTimeoutSlots)
+					h.submit(slot)
+					h.commitSlot(slot + testConfirmationTimeoutSlots)
+				}
+			},
+			expected: []expectedRequest{
+				{state: RequestStateFailed, paidBy: foreignTransaction, attempts: testMaxAttempts},
+			},
+			// the last transaction is kept, so it is still recognized if it is committed later
+			pending: true,
+		},
+		{
+			name: "max attempts after conflicts",
+			steps: func(h *fundsManagerHarness) {
+				h.enqueue(1)
+				for attempt := range testMaxAttempts {
+					slot := iotago.SlotIndex(attempt)
+					h.submit(slot)
+					h.commit(slot+1, foreignTransaction)
+				}
+			},
+			expected: []expectedRequest{
+				{state: RequestStateFailed, paidBy: foreignTransaction, attempts: testMaxAttempts},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := newFundsManagerHarness(t)
+			test.steps(h)
+
+			if len(h.requests) != len(test.expected) {
+				t.Fatalf("expected %d requests, got %d", len(test.expected), len(h.requests))
+			}
+
+			for i, expected := range test.expected {
+				request := h.requests[i]
+
+				if request.State != expected.state {
+					t.Errorf("request %d: expected state %s, got %s", i, expected.state, request.State)
+				}
+				if request.attempts != expected.attempts {
+					t.Errorf("request %d: expected %d attempts, got %d", i, expected.attempts, request.attempts)
+				}
+				if expected.state == RequestStateFailed && !ierrors.Is(request.Err, ErrAttemptsExceeded) {
+					t.Errorf("request %d: expected %s, got %v", i, ErrAttemptsExceeded, request.Err)
+				}
+
+				payments := h.payments[request]
+				if expected.paidBy == foreignTransaction {
+					if len(payments) != 0 {
+						t.Errorf("request %d: expected no payment, got %d", i, len(payments))
+					}
+
+					continue
+				}
+
+				if len(payments) != 1 {
+					t.Fatalf("request %d: expected exactly one payment, got %d", i, len(payments))
+				}
+				if payments[0] != h.transactionIDs[expected.paidBy] {
+					t.Errorf("request %d: paid by the wrong transaction", i)
+				}
+				if request.TransactionID != h.transactionIDs[expected.paidBy] {
+					t.Errorf("request %d: transaction ID doesn't match the paying transaction", i)
+				}
+			}
+
+			if queueSize := h.manager.QueueSize(); queueSize != test.queued {
+				t.Errorf("expected %d queued requests, got %d", test.queued, queueSize)
+			}
+			if pending := h.manager.pending != nil; pending != test.pending {
+				t.Errorf("expected pending %t, got %t", test.pending, pending)
+			}
+		})
+	}
+}
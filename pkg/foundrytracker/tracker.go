@@ -0,0 +1,243 @@
+package foundrytracker
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Foundry is the tracked state of a foundry and its native token.
+type Foundry struct {
+	// TokenID is the ID of the native token controlled by the foundry, which equals the foundry ID.
+	TokenID iotago.NativeTokenID
+	// OutputID is the ID of the current output of the foundry.
+	OutputID iotago.OutputID
+	// SerialNumber is the serial number of the foundry.
+	SerialNumber uint32
+	// MintedTokens is the amount of tokens that were minted.
+	MintedTokens *big.Int
+	// MeltedTokens is the amount of tokens that were melted.
+	MeltedTokens *big.Int
+	// MaximumSupply is the maximum supply of the token.
+	MaximumSupply *big.Int
+	// Metadata are the entries of the immutable metadata feature of the foundry, e.g. the IRC30 token metadata.
+	Metadata iotago.MetadataFeatureEntries
+	// SlotBooked is the slot in which the current output of the foundry was booked.
+	SlotBooked iotago.SlotIndex
+}
+
+// CirculatingSupply returns the amount of minted tokens that were not melted yet.
+func (f *Foundry) CirculatingSupply() *big.Int {
+	return new(big.Int).Sub(f.MintedTokens, f.MeltedTokens)
+}
+
+// Events are the events of the Tracker.
+type Events struct {
+	// FoundryCreated is triggered if a new foundry was created.
+	FoundryCreated *event.Event1[*Foundry]
+	// TokensMinted is triggered with the foundry and the amount of newly minted tokens.
+	TokensMinted *event.Event2[*Foundry, *big.Int]
+	// TokensMelted is triggered with the foundry and the amount of newly melted tokens.
+	TokensMelted *event.Event2[*Foundry, *big.Int]
+	// FoundryDestroyed is triggered with the last state of a foundry that was destroyed.
+	FoundryDestroyed *event.Event1[*Foundry]
+}
+
+// Tracker follows the foundry outputs in the ledger updates and maintains the circulating supply per native token.
+// Foundries that were created before the tracker started are tracked from their first change on.
+type Tracker struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	events     *Events
+
+	foundriesMutex sync.RWMutex
+	foundries      map[iotago.NativeTokenID]*Foundry
+}
+
+// New creates a new Tracker.
+func New(logger log.Logger, nodeBridge nodebridge.NodeBridge) *Tracker {
+	return &Tracker{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		events: &Events{
+			FoundryCreated:   event.New1[*Foundry](),
+			TokensMinted:     event.New2[*Foundry, *big.Int](),
+			TokensMelted:     event.New2[*Foundry, *big.Int](),
+			FoundryDestroyed: event.New1[*Foundry](),
+		},
+		foundries: make(map[iotago.NativeTokenID]*Foundry),
+	}
+}
+
+// Events returns the events of the Tracker.
+func (t *Tracker) Events() *Events {
+	return t.events
+}
+
+// Run applies the ledger updates from the given slot on until the given context is done.
+// If startSlot is zero, the ledger updates are applied from the latest commitment on.
+func (t *Tracker) Run(ctx context.Context, startSlot iotago.SlotIndex) error {
+	if err := t.nodeBridge.ListenToLedgerUpdates(ctx, startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+		return t.ApplyLedgerUpdate(update)
+	}); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyLedgerUpdate applies the foundry changes of the given ledger update.
+func (t *Tracker) ApplyLedgerUpdate(update *nodebridge.LedgerUpdate) error {
+	consumed := make(map[iotago.NativeTokenID]*Foundry)
+	for _, output := range update.Consumed {
+		foundry, err := newFoundry(output)
+		if err != nil {
+			return err
+		}
+		if foundry != nil {
+			consumed[foundry.TokenID] = foundry
+		}
+	}
+
+	created := make([]*Foundry, 0)
+	for _, output := range update.Created {
+		foundry, err := newFoundry(output)
+		if err != nil {
+			return err
+		}
+		if foundry != nil {
+			created = append(created, foundry)
+		}
+	}
+
+	type mintOrMelt struct {
+		foundry *Foundry
+		amount  *big.Int
+	}
+	var createdFoundries, destroyedFoundries []*Foundry
+	var mints, melts []*mintOrMelt
+
+	t.foundriesMutex.Lock()
+
+	for _, foundry := range created {
+		previous, wasConsumed := consumed[foundry.TokenID]
+		if wasConsumed {
+			delete(consumed, foundry.TokenID)
+		} else {
+			previous = t.foundries[foundry.TokenID]
+		}
+		t.foundries[foundry.TokenID] = foundry
+
+		if previous == nil {
+			createdFoundries = append(createdFoundries, foundry)
+			previous = &Foundry{MintedTokens: new(big.Int), MeltedTokens: new(big.Int)}
+		}
+
+		if minted := new(big.Int).Sub(foundry.MintedTokens, previous.MintedTokens); minted.Sign() > 0 {
+			mints = append(mints, &mintOrMelt{foundry: foundry, amount: minted})
+		}
+		if melted := new(big.Int).Sub(foundry.MeltedTokens, previous.MeltedTokens); melted.Sign() > 0 {
+			melts = append(melts, &mintOrMelt{foundry: foundry, amount: melted})
+		}
+	}
+
+	// foundries that were consumed without being created again were destroyed
+	for tokenID, foundry := range consumed {
+		delete(t.foundries, tokenID)
+		destroyedFoundries = append(destroyedFoundries, foundry)
+	}
+
+	t.foundriesMutex.Unlock()
+
+	for _, foundry := range createdFoundries {
+		t.events.FoundryCreated.Trigger(foundry)
+	}
+	for _, mint := range mints {
+		t.events.TokensMinted.Trigger(mint.foundry, mint.amount)
+	}
+	for _, melt := range melts {
+		t.events.TokensMelted.Trigger(melt.foundry, melt.amount)
+	}
+	for _, foundry := range destroyedFoundries {
+		t.events.FoundryDestroyed.Trigger(foundry)
+	}
+
+	return nil
+}
+
+// Foundry returns the tracked state of the foundry of the given native token.
+func (t *Tracker) Foundry(tokenID iotago.NativeTokenID) (*Foundry, bool) {
+	t.foundriesMutex.RLock()
+	defer t.foundriesMutex.RUnlock()
+
+	foundry, exists := t.foundries[tokenID]
+
+	return foundry, exists
+}
+
+// CirculatingSupply returns the circulating supply of the given native token.
+func (t *Tracker) CirculatingSupply(tokenID iotago.NativeTokenID) (*big.Int, bool) {
+	foundry, exists := t.Foundry(tokenID)
+	if !exists {
+		return nil, false
+	}
+
+	return foundry.CirculatingSupply(), true
+}
+
+// Foundries returns the tracked state of all foundries.
+func (t *Tracker) Foundries() []*Foundry {
+	t.foundriesMutex.RLock()
+	defer t.foundriesMutex.RUnlock()
+
+	foundries := make([]*Foundry, 0, len(t.foundries))
+	for _, foundry := range t.foundries {
+		foundries = append(foundries, foundry)
+	}
+
+	return foundries
+}
+
+// newFoundry returns the state of the given output, or nil if the output is not a foundry output.
+func newFoundry(output *nodebridge.Output) (*Foundry, error) {
+	foundryOutput, isFoundry := output.Output.(*iotago.FoundryOutput)
+	if !isFoundry {
+		return nil, nil
+	}
+
+	tokenID, err := foundryOutput.NativeTokenID()
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to compute token ID of foundry output %s", output.OutputID.ToHex())
+	}
+
+	tokenScheme, isSimpleTokenScheme := foundryOutput.TokenScheme.(*iotago.SimpleTokenScheme)
+	if !isSimpleTokenScheme {
+		return nil, ierrors.Errorf("unsupported token scheme of foundry output %s", output.OutputID.ToHex())
+	}
+
+	foundry := &Foundry{
+		TokenID:       tokenID,
+		OutputID:      output.OutputID,
+		SerialNumber:  foundryOutput.SerialNumber,
+		MintedTokens:  new(big.Int).Set(tokenScheme.MintedTokens),
+		MeltedTokens:  new(big.Int).Set(tokenScheme.MeltedTokens),
+		MaximumSupply: new(big.Int).Set(tokenScheme.MaximumSupply),
+	}
+
+	if metadata := foundryOutput.ImmutableFeatureSet().Metadata(); metadata != nil {
+		foundry.Metadata = metadata.Entries
+	}
+	if output.Metadata != nil && output.Metadata.Included != nil {
+		foundry.SlotBooked = output.Metadata.Included.Slot
+	}
+
+	return foundry, nil
+}
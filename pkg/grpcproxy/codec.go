@@ -0,0 +1,47 @@
+package grpcproxy
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// frame is a raw gRPC message that is forwarded without decoding it.
+type frame struct {
+	payload []byte
+}
+
+// rawCodec passes frames through unchanged and falls back to protobuf for all other messages,
+// so services that are served by the proxy itself (e.g. reflection) keep working.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	if f, ok := v.(*frame); ok {
+		return f.payload, nil
+	}
+
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, ierrors.Errorf("failed to marshal, message is %T, want proto.Message", v)
+	}
+
+	return proto.Marshal(message)
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	if f, ok := v.(*frame); ok {
+		f.payload = append(f.payload[:0], data...)
+		return nil
+	}
+
+	message, ok := v.(proto.Message)
+	if !ok {
+		return ierrors.Errorf("failed to unmarshal, message is %T, want proto.Message", v)
+	}
+
+	return proto.Unmarshal(data, message)
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,222 @@
+package grpcproxy
+
+import (
+	"context"
+	"io"
+	"net"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+)
+
+// DefaultAllowedMethods are the INX methods that are allowed by default.
+// Mutating methods like SubmitBlock, ForceCommitUntil and the API route registration are not allowed.
+var DefaultAllowedMethods = []string{
+	"Read*",
+	"Listen*",
+}
+
+// Proxy re-exposes the INX gRPC surface of a node on a local address for debugging, e.g. with grpcurl.
+// It forwards the calls without decoding them and supports server reflection.
+type Proxy struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeAddress    string
+	allowedMethods []string
+	logCalls       bool
+
+	nodeConn *grpc.ClientConn
+	server   *grpc.Server
+}
+
+// WithAllowedMethods sets the INX methods that are forwarded to the node.
+// The patterns are matched against the method name (e.g. "ReadBlock") with path.Match, so "Read*" is allowed.
+// "*" allows all methods.
+func WithAllowedMethods(allowedMethods ...string) options.Option[Proxy] {
+	return func(p *Proxy) {
+		p.allowedMethods = allowedMethods
+	}
+}
+
+// WithCallLogging sets whether every forwarded call is logged.
+func WithCallLogging(enabled bool) options.Option[Proxy] {
+	return func(p *Proxy) {
+		p.logCalls = enabled
+	}
+}
+
+// New creates a new Proxy that forwards to the INX interface of the node at the given address.
+func New(logger log.Logger, nodeAddress string, opts ...options.Option[Proxy]) *Proxy {
+	return options.Apply(&Proxy{
+		Logger:         logger,
+		nodeAddress:    nodeAddress,
+		allowedMethods: DefaultAllowedMethods,
+		logCalls:       true,
+	}, opts)
+}
+
+// Run serves the proxy on the given bind address until the given context is done.
+func (p *Proxy) Run(ctx context.Context, bindAddress string) error {
+	//nolint:staticcheck // grpc.Dial is the dialer used by the node bridge as well
+	nodeConn, err := grpc.Dial(p.nodeAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+	)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to dial node %s", p.nodeAddress)
+	}
+	p.nodeConn = nodeConn
+	defer func() { _ = nodeConn.Close() }()
+
+	p.server = grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(p.forward),
+	)
+
+	// the reflection service advertises the forwarded INX service in addition to the services of the proxy
+	reflection.Register(&reflectionServer{Server: p.server})
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", bindAddress)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to listen on %s", bindAddress)
+	}
+
+	go func() {
+		<-ctx.Done()
+		p.server.GracefulStop()
+	}()
+
+	p.LogInfof("INX debug proxy listening on %s, forwarding to %s", bindAddress, p.nodeAddress)
+
+	if err := p.server.Serve(listener); err != nil && !ierrors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Proxy) isMethodAllowed(fullMethod string) bool {
+	service, method, found := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !found || service != inx.INX_ServiceDesc.ServiceName {
+		return false
+	}
+
+	for _, pattern := range p.allowedMethods {
+		if matched, err := path.Match(pattern, method); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forward forwards a call to the node by passing the raw frames in both directions.
+func (p *Proxy) forward(_ any, serverStream grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(serverStream)
+	if !ok {
+		return status.Error(codes.Internal, "failed to determine the method of the call")
+	}
+
+	if !p.isMethodAllowed(fullMethod) {
+		p.LogWarnf("Rejected call to %s, method not allowed", fullMethod)
+
+		return status.Errorf(codes.PermissionDenied, "method %s is not allowed by the proxy", fullMethod)
+	}
+
+	start := time.Now()
+
+	ctx, cancel := context.WithCancel(serverStream.Context())
+	defer cancel()
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md.Copy())
+	}
+
+	clientStream, err := p.nodeConn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, fullMethod)
+	if err != nil {
+		return err
+	}
+
+	// forward the messages of the caller to the node
+	go func() {
+		for {
+			f := &frame{}
+			if err := serverStream.RecvMsg(f); err != nil {
+				if ierrors.Is(err, io.EOF) {
+					_ = clientStream.CloseSend()
+				} else {
+					cancel()
+				}
+
+				return
+			}
+
+			if err := clientStream.SendMsg(f); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	// forward the messages of the node to the caller
+	var messages int
+	err = func() error {
+		header, err := clientStream.Header()
+		if err != nil {
+			return err
+		}
+		if err := serverStream.SendHeader(header); err != nil {
+			return err
+		}
+
+		for {
+			f := &frame{}
+			if err := clientStream.RecvMsg(f); err != nil {
+				if ierrors.Is(err, io.EOF) {
+					return nil
+				}
+
+				return err
+			}
+
+			if err := serverStream.SendMsg(f); err != nil {
+				return err
+			}
+			messages++
+		}
+	}()
+	serverStream.SetTrailer(clientStream.Trailer())
+
+	if p.logCalls {
+		p.LogInfof("%s: %s, %d message(s), took %s", fullMethod, status.Code(err), messages, time.Since(start).Truncate(time.Millisecond))
+	}
+
+	return err
+}
+
+// reflectionServer is the grpc server of the proxy, which additionally reports the forwarded INX service.
+type reflectionServer struct {
+	*grpc.Server
+}
+
+func (s *reflectionServer) GetServiceInfo() map[string]grpc.ServiceInfo {
+	serviceInfo := s.Server.GetServiceInfo()
+	serviceInfo[inx.INX_ServiceDesc.ServiceName] = grpc.ServiceInfo{
+		Metadata: inx.INX_ServiceDesc.Metadata,
+	}
+
+	return serviceInfo
+}
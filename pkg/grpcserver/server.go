@@ -0,0 +1,219 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"time"
+
+	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultGracefulStopTimeout is the default duration the server waits for running calls on shutdown.
+	DefaultGracefulStopTimeout = 5 * time.Second
+)
+
+// Server is a gRPC server for extensions that expose their own API alongside their REST routes.
+// It records Prometheus metrics of all calls, recovers from panics in the handlers,
+// serves the gRPC health service and stops gracefully when its context is done.
+//
+// The services must be registered before the server is run.
+type Server struct {
+	// the logger used to log events.
+	log.Logger
+
+	server       *grpc.Server
+	healthServer *health.Server
+
+	serverOptions       []grpc.ServerOption
+	unaryInterceptors   []grpc.UnaryServerInterceptor
+	streamInterceptors  []grpc.StreamServerInterceptor
+	metrics             bool
+	panicRecovery       bool
+	reflection          bool
+	gracefulStopTimeout time.Duration
+}
+
+// WithServerOptions adds options to the gRPC server, e.g. grpc.Creds or grpc.MaxRecvMsgSize.
+func WithServerOptions(serverOptions ...grpc.ServerOption) options.Option[Server] {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, serverOptions...)
+	}
+}
+
+// WithUnaryInterceptors adds interceptors for the unary calls, e.g. for authentication.
+// They are called after the metrics and panic recovery interceptors.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) options.Option[Server] {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds interceptors for the streaming calls, e.g. for authentication.
+// They are called after the metrics and panic recovery interceptors.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) options.Option[Server] {
+	return func(s *Server) {
+		s.streamInterceptors = append(s.streamInterceptors, interceptors...)
+	}
+}
+
+// WithMetrics sets whether Prometheus metrics of all calls are recorded.
+// The metrics are collected by grpcprometheus.DefaultServerMetrics, which is part of the metrics.NewRegistry.
+func WithMetrics(enabled bool) options.Option[Server] {
+	return func(s *Server) {
+		s.metrics = enabled
+	}
+}
+
+// WithPanicRecovery sets whether panics in the handlers are recovered and returned as internal error to the caller.
+func WithPanicRecovery(enabled bool) options.Option[Server] {
+	return func(s *Server) {
+		s.panicRecovery = enabled
+	}
+}
+
+// WithReflection sets whether the server reflection service is registered, e.g. for grpcurl.
+func WithReflection(enabled bool) options.Option[Server] {
+	return func(s *Server) {
+		s.reflection = enabled
+	}
+}
+
+// WithGracefulStopTimeout sets the duration the server waits for running calls on shutdown
+// before the remaining calls are canceled.
+func WithGracefulStopTimeout(timeout time.Duration) options.Option[Server] {
+	return func(s *Server) {
+		s.gracefulStopTimeout = timeout
+	}
+}
+
+// New creates a new Server.
+func New(logger log.Logger, opts ...options.Option[Server]) *Server {
+	s := options.Apply(&Server{
+		Logger:              logger,
+		healthServer:        health.NewServer(),
+		metrics:             true,
+		panicRecovery:       true,
+		reflection:          false,
+		gracefulStopTimeout: DefaultGracefulStopTimeout,
+	}, opts)
+
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+
+	if s.metrics {
+		unaryInterceptors = append(unaryInterceptors, grpcprometheus.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, grpcprometheus.StreamServerInterceptor)
+	}
+
+	if s.panicRecovery {
+		recoveryHandler := grpcrecovery.WithRecoveryHandler(func(p any) error {
+			s.LogErrorf("Recovered from panic in gRPC handler: %v", p)
+
+			return status.Error(codes.Internal, "internal error")
+		})
+		unaryInterceptors = append(unaryInterceptors, grpcrecovery.UnaryServerInterceptor(recoveryHandler))
+		streamInterceptors = append(streamInterceptors, grpcrecovery.StreamServerInterceptor(recoveryHandler))
+	}
+
+	serverOptions := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(append(unaryInterceptors, s.unaryInterceptors...)...),
+		grpc.ChainStreamInterceptor(append(streamInterceptors, s.streamInterceptors...)...),
+	}, s.serverOptions...)
+
+	s.server = grpc.NewServer(serverOptions...)
+	healthpb.RegisterHealthServer(s.server, s.healthServer)
+
+	if s.reflection {
+		reflection.Register(s.server)
+	}
+
+	return s
+}
+
+// RegisterService registers a service and marks it as serving in the health service.
+// It is called with the generated service description, e.g. s.RegisterService(&pb.MyService_ServiceDesc, impl).
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.server.RegisterService(desc, impl)
+	s.healthServer.SetServingStatus(desc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+// SetServingStatus sets the status of a service in the health service, e.g. while the node is not synced.
+// The empty service name is the status of the whole server.
+func (s *Server) SetServingStatus(service string, serving bool) {
+	servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		servingStatus = healthpb.HealthCheckResponse_SERVING
+	}
+
+	s.healthServer.SetServingStatus(service, servingStatus)
+}
+
+// GRPCServer returns the underlying gRPC server.
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.server
+}
+
+// Run serves the registered services on the given bind address until the given context is done.
+func (s *Server) Run(ctx context.Context, bindAddress string) error {
+	if s.metrics {
+		// initializes the metrics of all registered methods, so they are exported before the first call
+		grpcprometheus.Register(s.server)
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", bindAddress)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to listen on %s", bindAddress)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		<-ctx.Done()
+		s.stop()
+	}()
+
+	s.LogInfof("gRPC server listening on %s", bindAddress)
+
+	if err := s.server.Serve(listener); err != nil && !ierrors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	// wait until the running calls are finished or canceled
+	<-stopped
+
+	return nil
+}
+
+// stop reports all services as not serving and waits for the running calls until the graceful stop timeout.
+func (s *Server) stop() {
+	s.healthServer.Shutdown()
+
+	gracefullyStopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(gracefullyStopped)
+	}()
+
+	timer := time.NewTimer(s.gracefulStopTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-gracefullyStopped:
+	case <-timer.C:
+		s.LogWarnf("gRPC server did not stop gracefully within %s, canceling the running calls", s.gracefulStopTimeout)
+		s.server.Stop()
+	}
+}
@@ -0,0 +1,139 @@
+package httpserver
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+	iotaapi "github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// QueryParameterProtocolVersion is the query parameter that selects the API of a protocol version.
+	QueryParameterProtocolVersion = "protocolVersion"
+
+	// apiContextKey is the key of the API that is injected into the echo context by the APIMiddleware.
+	apiContextKey = "api"
+)
+
+type apiRequestContextKey struct{}
+
+// APIForBlockID returns the API for the issuing slot of the given block.
+func APIForBlockID(apiProvider iotago.APIProvider, blockID iotago.BlockID) iotago.API {
+	return apiProvider.APIForSlot(blockID.Slot())
+}
+
+// APIForOutputID returns the API for the slot in which the given output was created.
+func APIForOutputID(apiProvider iotago.APIProvider, outputID iotago.OutputID) iotago.API {
+	return apiProvider.APIForSlot(outputID.CreationSlot())
+}
+
+// APIForTransactionID returns the API for the creation slot of the given transaction.
+func APIForTransactionID(apiProvider iotago.APIProvider, transactionID iotago.TransactionID) iotago.API {
+	return apiProvider.APIForSlot(transactionID.Slot())
+}
+
+// APIForCommitmentID returns the API for the slot of the given commitment.
+func APIForCommitmentID(apiProvider iotago.APIProvider, commitmentID iotago.CommitmentID) iotago.API {
+	return apiProvider.APIForSlot(commitmentID.Slot())
+}
+
+// APIForBlockVersion returns the API for the given protocol version of a block.
+// It returns ErrInvalidParameter if the version is not supported by the node.
+func APIForBlockVersion(apiProvider iotago.APIProvider, version iotago.Version) (iotago.API, error) {
+	apiForVersion, err := apiProvider.APIForVersion(version)
+	if err != nil {
+		return nil, ierrors.Wrapf(ErrInvalidParameter, "unsupported protocol version %d: %s", version, err)
+	}
+
+	return apiForVersion, nil
+}
+
+// APIForRawBlock returns the API for the protocol version of the given serialized block,
+// which is the first byte of the block. It should be used to decode blocks submitted by clients.
+func APIForRawBlock(apiProvider iotago.APIProvider, rawBlock []byte) (iotago.API, error) {
+	if len(rawBlock) == 0 {
+		return nil, ierrors.Wrap(ErrInvalidParameter, "empty block")
+	}
+
+	return APIForBlockVersion(apiProvider, iotago.Version(rawBlock[0]))
+}
+
+// APIMiddleware returns a middleware that injects the API that matches the request into the echo context
+// and the context of the request, so handlers don't have to select the API themselves.
+// The API is selected by the "slot", "epoch" or "protocolVersion" query parameter (in this order),
+// and is the API of the latest committed slot if none of them is given.
+// Invalid query parameters are rejected with ErrInvalidParameter.
+func APIMiddleware(apiProvider iotago.APIProvider) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			apiForRequest, err := selectRequestAPI(c, apiProvider)
+			if err != nil {
+				return err
+			}
+
+			c.Set(apiContextKey, apiForRequest)
+			c.SetRequest(c.Request().WithContext(ContextWithAPI(c.Request().Context(), apiForRequest)))
+
+			return next(c)
+		}
+	}
+}
+
+func selectRequestAPI(c echo.Context, apiProvider iotago.APIProvider) (iotago.API, error) {
+	switch {
+	case c.QueryParam(iotaapi.ParameterSlot) != "":
+		slot, err := ParseSlotQueryParam(c, iotaapi.ParameterSlot)
+		if err != nil {
+			return nil, err
+		}
+
+		return apiProvider.APIForSlot(slot), nil
+
+	case c.QueryParam(iotaapi.ParameterEpoch) != "":
+		epoch, err := ParseEpochQueryParam(c, iotaapi.ParameterEpoch)
+		if err != nil {
+			return nil, err
+		}
+
+		return apiProvider.APIForEpoch(epoch), nil
+
+	case c.QueryParam(QueryParameterProtocolVersion) != "":
+		versionParam := c.QueryParam(QueryParameterProtocolVersion)
+
+		version, err := strconv.ParseUint(versionParam, 10, 8)
+		if err != nil {
+			return nil, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, error: %s", versionParam, err)
+		}
+
+		return APIForBlockVersion(apiProvider, iotago.Version(version))
+
+	default:
+		return apiProvider.CommittedAPI(), nil
+	}
+}
+
+// RequestAPI returns the API that was injected by the APIMiddleware, or nil if the middleware is not used.
+func RequestAPI(c echo.Context) iotago.API {
+	apiForRequest, ok := c.Get(apiContextKey).(iotago.API)
+	if !ok {
+		return nil
+	}
+
+	return apiForRequest
+}
+
+// ContextWithAPI returns a copy of the given context that carries the given API.
+func ContextWithAPI(ctx context.Context, apiForRequest iotago.API) context.Context {
+	return context.WithValue(ctx, apiRequestContextKey{}, apiForRequest)
+}
+
+// APIFromContext returns the API that is carried by the given context, e.g. the API injected by the APIMiddleware.
+func APIFromContext(ctx context.Context) (iotago.API, bool) {
+	apiForRequest, ok := ctx.Value(apiRequestContextKey{}).(iotago.API)
+
+	return apiForRequest, ok
+}
@@ -0,0 +1,133 @@
+package keys
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// KeyFileVersion is the version of the encrypted key file format.
+	KeyFileVersion = 1
+
+	// the scrypt parameters used to derive the encryption key from the passphrase.
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltSize     = 32
+)
+
+var (
+	// ErrInvalidKeyFile is returned if the key file can't be decrypted.
+	ErrInvalidKeyFile = ierrors.New("invalid key file")
+)
+
+// keyFile is an Ed25519 seed that is encrypted with AES-256-GCM and a key derived from a passphrase with scrypt.
+type keyFile struct {
+	Version    int    `json:"version"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// FileProvider returns a Provider that loads the private key from the encrypted key file at the given path.
+// The file is read on every call, so the key can be rotated by replacing the file.
+func FileProvider(path string, passphrase []byte) Provider {
+	return ProviderFunc(func(_ context.Context) (ed25519.PrivateKey, error) {
+		return ReadKeyFile(path, passphrase)
+	})
+}
+
+// WriteKeyFile encrypts the seed of the given private key with the passphrase and writes it to the given path.
+func WriteKeyFile(path string, privateKey ed25519.PrivateKey, passphrase []byte) error {
+	file := &keyFile{
+		Version: KeyFileVersion,
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+		Salt:    make([]byte, saltSize),
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return ierrors.Wrap(err, "failed to generate salt")
+	}
+
+	aead, err := file.aead(passphrase)
+	if err != nil {
+		return err
+	}
+
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return ierrors.Wrap(err, "failed to generate nonce")
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, privateKey.Seed(), nil)
+
+	fileBytes, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, fileBytes, 0o600)
+}
+
+// ReadKeyFile reads the encrypted key file at the given path and decrypts it with the passphrase.
+func ReadKeyFile(path string, passphrase []byte) (ed25519.PrivateKey, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ierrors.Wrapf(ErrKeyNotFound, "key file %s does not exist", path)
+		}
+
+		return nil, ierrors.Wrapf(err, "failed to read key file %s", path)
+	}
+
+	file := &keyFile{}
+	if err := json.Unmarshal(fileBytes, file); err != nil {
+		return nil, ierrors.Wrapf(ErrInvalidKeyFile, "failed to decode key file %s", path)
+	}
+	if file.Version != KeyFileVersion {
+		return nil, ierrors.Wrapf(ErrInvalidKeyFile, "unsupported key file version %d", file.Version)
+	}
+
+	aead, err := file.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, ierrors.Wrapf(ErrInvalidKeyFile, "invalid nonce length: %d", len(file.Nonce))
+	}
+
+	seed, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ierrors.Wrap(ErrInvalidKeyFile, "wrong passphrase or corrupted key file")
+	}
+
+	return privateKeyFromBytes(seed)
+}
+
+// aead derives the encryption key from the passphrase with the scrypt parameters of the file.
+func (f *keyFile) aead(passphrase []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, f.Salt, f.N, f.R, f.P, scryptKeyLen)
+	if err != nil {
+		return nil, ierrors.Wrap(ErrInvalidKeyFile, "failed to derive key")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
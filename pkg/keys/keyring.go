@@ -0,0 +1,113 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Events are the events of the Keyring.
+type Events struct {
+	// KeyRotated is triggered with the new public key after the key of the provider changed.
+	KeyRotated *event.Event1[ed25519.PublicKey]
+	// ReloadFailed is triggered if the key could not be reloaded from the provider.
+	ReloadFailed *event.Event1[error]
+}
+
+// Keyring holds the current key of a Provider and reloads it to pick up rotated keys.
+type Keyring struct {
+	provider Provider
+	events   *Events
+
+	keyMutex sync.RWMutex
+	key      ed25519.PrivateKey
+}
+
+// NewKeyring creates a new Keyring and loads the current key from the given provider.
+func NewKeyring(ctx context.Context, provider Provider) (*Keyring, error) {
+	key, err := provider.PrivateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keyring{
+		provider: provider,
+		events: &Events{
+			KeyRotated:   event.New1[ed25519.PublicKey](),
+			ReloadFailed: event.New1[error](),
+		},
+		key: key,
+	}, nil
+}
+
+// Events returns the events of the Keyring.
+func (k *Keyring) Events() *Events {
+	return k.events
+}
+
+// PrivateKey returns the current private key.
+func (k *Keyring) PrivateKey() ed25519.PrivateKey {
+	k.keyMutex.RLock()
+	defer k.keyMutex.RUnlock()
+
+	return k.key
+}
+
+// PublicKey returns the public key of the current private key.
+func (k *Keyring) PublicKey() ed25519.PublicKey {
+	//nolint:forcetypeassert // ed25519.PrivateKey always returns an ed25519.PublicKey
+	return k.PrivateKey().Public().(ed25519.PublicKey)
+}
+
+// Address returns the Ed25519 address of the current key.
+func (k *Keyring) Address() *iotago.Ed25519Address {
+	return iotago.Ed25519AddressFromPubKey(k.PublicKey())
+}
+
+// AddressSigner returns an AddressSigner with the current key, e.g. for the transaction builder.
+func (k *Keyring) AddressSigner() iotago.AddressSigner {
+	return iotago.NewInMemoryAddressSignerFromEd25519PrivateKeys(k.PrivateKey())
+}
+
+// Reload loads the key from the provider and triggers KeyRotated if it changed.
+func (k *Keyring) Reload(ctx context.Context) error {
+	key, err := k.provider.PrivateKey(ctx)
+	if err != nil {
+		k.events.ReloadFailed.Trigger(err)
+
+		return err
+	}
+
+	k.keyMutex.Lock()
+	rotated := !bytes.Equal(k.key, key)
+	k.key = key
+	k.keyMutex.Unlock()
+
+	if rotated {
+		//nolint:forcetypeassert // ed25519.PrivateKey always returns an ed25519.PublicKey
+		k.events.KeyRotated.Trigger(key.Public().(ed25519.PublicKey))
+	}
+
+	return nil
+}
+
+// Run reloads the key in the given interval until the given context is done.
+// Failed reloads keep the current key and are reported by ReloadFailed.
+func (k *Keyring) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = k.Reload(ctx)
+		}
+	}
+}
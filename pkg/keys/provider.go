@@ -0,0 +1,92 @@
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrKeyNotFound is returned if the provider has no key.
+	ErrKeyNotFound = ierrors.New("key not found")
+	// ErrInvalidKey is returned if the loaded key is not a valid Ed25519 private key or seed.
+	ErrInvalidKey = ierrors.New("invalid key")
+)
+
+// Provider loads an Ed25519 private key, e.g. the block issuer key of an account.
+type Provider interface {
+	// PrivateKey loads the current private key.
+	PrivateKey(ctx context.Context) (ed25519.PrivateKey, error)
+}
+
+// ProviderFunc is a function that implements Provider.
+type ProviderFunc func(ctx context.Context) (ed25519.PrivateKey, error)
+
+// PrivateKey calls the function.
+func (f ProviderFunc) PrivateKey(ctx context.Context) (ed25519.PrivateKey, error) {
+	return f(ctx)
+}
+
+// EnvProvider returns a Provider that loads the hex encoded private key or seed from the given environment variable.
+// The variable is read on every call, so the key can be rotated by changing the environment of the process.
+func EnvProvider(name string) Provider {
+	return ProviderFunc(func(_ context.Context) (ed25519.PrivateKey, error) {
+		value, exists := os.LookupEnv(name)
+		if !exists || value == "" {
+			return nil, ierrors.Wrapf(ErrKeyNotFound, "environment variable %s not set", name)
+		}
+
+		return ParsePrivateKey(value)
+	})
+}
+
+// KMSClient decrypts data with a key that is managed by a key management service.
+type KMSClient interface {
+	// Decrypt decrypts the given ciphertext.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// KMSProvider returns a Provider that decrypts the given ciphertext of the private key or seed with the KMS client,
+// so the key is only stored encrypted by a key of the key management service (envelope encryption).
+// The ciphertext function is called on every call, e.g. to read the ciphertext from a file or a secret store.
+func KMSProvider(client KMSClient, ciphertext func(ctx context.Context) ([]byte, error)) Provider {
+	return ProviderFunc(func(ctx context.Context) (ed25519.PrivateKey, error) {
+		encryptedKey, err := ciphertext(ctx)
+		if err != nil {
+			return nil, ierrors.Wrap(err, "failed to read encrypted key")
+		}
+
+		keyBytes, err := client.Decrypt(ctx, encryptedKey)
+		if err != nil {
+			return nil, ierrors.Wrap(err, "failed to decrypt key")
+		}
+
+		return privateKeyFromBytes(keyBytes)
+	})
+}
+
+// ParsePrivateKey parses a hex encoded Ed25519 private key or seed, with or without 0x prefix.
+func ParsePrivateKey(value string) (ed25519.PrivateKey, error) {
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	if err != nil {
+		return nil, ierrors.Wrap(ErrInvalidKey, "key is not hex encoded")
+	}
+
+	return privateKeyFromBytes(keyBytes)
+}
+
+// privateKeyFromBytes returns the private key of the given private key or seed bytes.
+func privateKeyFromBytes(keyBytes []byte) (ed25519.PrivateKey, error) {
+	switch len(keyBytes) {
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(keyBytes), nil
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(keyBytes), nil
+	default:
+		return nil, ierrors.Wrapf(ErrInvalidKey, "invalid key length: %d", len(keyBytes))
+	}
+}
@@ -0,0 +1,130 @@
+package kvstore
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// boltBucket is the bucket in which all data of the store is kept.
+var boltBucket = []byte("kvstore")
+
+// boltStore is a Store backed by a BoltDB file.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens or creates a Store backed by the BoltDB file at the given path.
+func NewBoltStore(path string) (Store, error) {
+	db, err := bolt.Open(path, os.FileMode(0o600), &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to open bolt database %s", path)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+
+		return err
+	}); err != nil {
+		_ = db.Close()
+
+		return nil, ierrors.Wrapf(err, "failed to create bucket in bolt database %s", path)
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) Get(key []byte) ([]byte, error) {
+	var value []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(boltBucket).Get(key)
+		if data == nil {
+			return ErrKeyNotFound
+		}
+		// the data is only valid during the transaction
+		value = copyBytes(data)
+
+		return nil
+	}); err != nil {
+		return nil, s.mapError(err)
+	}
+
+	return value, nil
+}
+
+func (s *boltStore) Has(key []byte) (bool, error) {
+	var exists bool
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(boltBucket).Get(key) != nil
+
+		return nil
+	}); err != nil {
+		return false, s.mapError(err)
+	}
+
+	return exists, nil
+}
+
+func (s *boltStore) Set(key []byte, value []byte) error {
+	return s.mapError(s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put(key, value)
+	}))
+}
+
+func (s *boltStore) Delete(key []byte) error {
+	return s.mapError(s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete(key)
+	}))
+}
+
+func (s *boltStore) Iterate(prefix []byte, consumer IterateConsumer) error {
+	return s.mapError(s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltBucket).Cursor()
+		for key, value := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, value = cursor.Next() {
+			if !consumer(copyBytes(key), copyBytes(value)) {
+				break
+			}
+		}
+
+		return nil
+	}))
+}
+
+func (s *boltStore) Batch() Batch {
+	return newBatch(func(mutations []*batchMutation) error {
+		return s.mapError(s.db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(boltBucket)
+			for _, mutation := range mutations {
+				if mutation.delete {
+					if err := bucket.Delete(mutation.key); err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				if err := bucket.Put(mutation.key, mutation.value); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}))
+	})
+}
+
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *boltStore) mapError(err error) error {
+	if ierrors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return ErrStoreClosed
+	}
+
+	return err
+}
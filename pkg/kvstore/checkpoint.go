@@ -0,0 +1,67 @@
+package kvstore
+
+import (
+	"encoding/binary"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// SlotCheckpoint persists the latest processed slot of a subsystem under a key of a Store.
+type SlotCheckpoint struct {
+	store Store
+	key   []byte
+}
+
+// NewSlotCheckpoint creates a new SlotCheckpoint that is stored under the given key.
+func NewSlotCheckpoint(store Store, key []byte) *SlotCheckpoint {
+	return &SlotCheckpoint{
+		store: store,
+		key:   key,
+	}
+}
+
+// Load returns the stored slot.
+// Returns false if no slot was stored yet.
+func (c *SlotCheckpoint) Load() (iotago.SlotIndex, bool, error) {
+	value, err := c.store.Get(c.key)
+	if err != nil {
+		if ierrors.Is(err, ErrKeyNotFound) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	if len(value) != iotago.SlotIndexLength {
+		return 0, false, ierrors.Errorf("invalid checkpoint length %d", len(value))
+	}
+
+	return iotago.SlotIndex(binary.LittleEndian.Uint32(value)), true, nil
+}
+
+// Store stores the given slot.
+func (c *SlotCheckpoint) Store(slot iotago.SlotIndex) error {
+	return c.store.Set(c.key, binary.LittleEndian.AppendUint32(nil, uint32(slot)))
+}
+
+// StartSlot returns the slot after the stored slot, or zero if no slot was stored yet.
+func (c *SlotCheckpoint) StartSlot() (iotago.SlotIndex, error) {
+	slot, exists, err := c.Load()
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	return slot + 1, nil
+}
+
+// Hook stores every slot that is triggered by the given event, e.g. the checkpoint events of the sinks.
+// Errors are passed to the onError function. The returned hook can be used to unhook the event.
+func (c *SlotCheckpoint) Hook(slotEvent *event.Event1[iotago.SlotIndex], onError func(err error)) *event.Hook[func(iotago.SlotIndex)] {
+	return slotEvent.Hook(func(slot iotago.SlotIndex) {
+		if err := c.Store(slot); err != nil && onError != nil {
+			onError(err)
+		}
+	})
+}
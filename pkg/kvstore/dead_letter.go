@@ -0,0 +1,113 @@
+package kvstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// storedDeadLetter is the stored form of a nodebridge.DeadLetter.
+type storedDeadLetter struct {
+	Stream string           `json:"stream"`
+	Slot   iotago.SlotIndex `json:"slot"`
+	Data   []byte           `json:"data"`
+	Error  string           `json:"error"`
+	Time   time.Time        `json:"time"`
+}
+
+// DeadLetterStore persists the dead letters of the streams under a prefix of a Store, so they can be reprocessed later.
+// The dead letters are iterated ordered by stream and slot.
+type DeadLetterStore struct {
+	store  Store
+	prefix []byte
+	seq    atomic.Uint64
+}
+
+// NewDeadLetterStore creates a new DeadLetterStore that stores the dead letters under the given prefix.
+func NewDeadLetterStore(store Store, prefix []byte) *DeadLetterStore {
+	return &DeadLetterStore{
+		store:  store,
+		prefix: prefix,
+	}
+}
+
+// Handler returns a nodebridge.DeadLetterHandler that adds the dead letters to the store.
+// Errors are passed to the onError function.
+func (s *DeadLetterStore) Handler(onError func(err error)) nodebridge.DeadLetterHandler {
+	return func(letter *nodebridge.DeadLetter) {
+		if err := s.Add(letter); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Add stores the given dead letter.
+func (s *DeadLetterStore) Add(letter *nodebridge.DeadLetter) error {
+	stored := &storedDeadLetter{
+		Stream: letter.Stream,
+		Slot:   letter.Slot,
+		Data:   letter.Data,
+		Time:   letter.Time,
+	}
+	if letter.Err != nil {
+		stored.Error = letter.Err.Error()
+	}
+
+	value, err := json.Marshal(stored)
+	if err != nil {
+		return ierrors.Wrap(err, "failed to encode dead letter")
+	}
+
+	// the key is ordered by stream and slot, the time and the sequence number keep it unique
+	key := append(copyBytes(s.prefix), letter.Stream...)
+	key = append(key, '/')
+	key = binary.BigEndian.AppendUint32(key, uint32(letter.Slot))
+	key = binary.BigEndian.AppendUint64(key, uint64(letter.Time.UnixNano()))
+	key = binary.BigEndian.AppendUint64(key, s.seq.Add(1))
+
+	return s.store.Set(key, value)
+}
+
+// Iterate calls the consumer for all stored dead letters of the given stream, or of all streams if stream is empty.
+// The key can be used to delete the dead letter after it was reprocessed. Returning false stops the iteration.
+func (s *DeadLetterStore) Iterate(stream string, consumer func(key []byte, letter *nodebridge.DeadLetter) bool) error {
+	prefix := copyBytes(s.prefix)
+	if stream != "" {
+		prefix = append(append(prefix, stream...), '/')
+	}
+
+	var innerErr error
+	if err := s.store.Iterate(prefix, func(key []byte, value []byte) bool {
+		stored := &storedDeadLetter{}
+		if err := json.Unmarshal(value, stored); err != nil {
+			innerErr = ierrors.Wrap(err, "failed to decode dead letter")
+			return false
+		}
+
+		letter := &nodebridge.DeadLetter{
+			Stream: stored.Stream,
+			Slot:   stored.Slot,
+			Data:   stored.Data,
+			Time:   stored.Time,
+		}
+		if stored.Error != "" {
+			letter.Err = ierrors.New(stored.Error)
+		}
+
+		return consumer(key, letter)
+	}); err != nil {
+		return err
+	}
+
+	return innerErr
+}
+
+// Delete deletes the dead letter with the given key.
+func (s *DeadLetterStore) Delete(key []byte) error {
+	return s.store.Delete(key)
+}
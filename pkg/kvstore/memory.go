@@ -0,0 +1,136 @@
+package kvstore
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+)
+
+// memoryStore is a Store that keeps all data in memory.
+type memoryStore struct {
+	mutex  sync.RWMutex
+	data   map[string][]byte
+	closed bool
+}
+
+// NewMemoryStore creates a new Store that keeps all data in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		data: make(map[string][]byte),
+	}
+}
+
+func (s *memoryStore) Get(key []byte) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	value, exists := s.data[string(key)]
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+
+	return copyBytes(value), nil
+}
+
+func (s *memoryStore) Has(key []byte) (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+
+	_, exists := s.data[string(key)]
+
+	return exists, nil
+}
+
+func (s *memoryStore) Set(key []byte, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	s.data[string(key)] = copyBytes(value)
+
+	return nil
+}
+
+func (s *memoryStore) Delete(key []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	delete(s.data, string(key))
+
+	return nil
+}
+
+func (s *memoryStore) Iterate(prefix []byte, consumer IterateConsumer) error {
+	// collect the matching pairs first, so the lock is not held while the consumer runs
+	s.mutex.RLock()
+	if s.closed {
+		s.mutex.RUnlock()
+		return ErrStoreClosed
+	}
+
+	keys := make([]string, 0)
+	for key := range s.data {
+		if bytes.HasPrefix([]byte(key), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = copyBytes(s.data[key])
+	}
+	s.mutex.RUnlock()
+
+	for i, key := range keys {
+		if !consumer([]byte(key), values[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore) Batch() Batch {
+	return newBatch(func(mutations []*batchMutation) error {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if s.closed {
+			return ErrStoreClosed
+		}
+
+		for _, mutation := range mutations {
+			if mutation.delete {
+				delete(s.data, string(mutation.key))
+				continue
+			}
+			s.data[string(mutation.key)] = mutation.value
+		}
+
+		return nil
+	})
+}
+
+func (s *memoryStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	s.data = nil
+
+	return nil
+}
@@ -0,0 +1,173 @@
+//go:build rocksdb
+
+package kvstore
+
+import (
+	"sync"
+
+	"github.com/linxGnu/grocksdb"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// rocksDBStore is a Store backed by a RocksDB database.
+// It is only available if the application is built with the "rocksdb" build tag, because RocksDB requires cgo.
+type rocksDBStore struct {
+	// the mutex guards the database against being used while it is closed.
+	mutex  sync.RWMutex
+	db     *grocksdb.DB
+	opts   *grocksdb.Options
+	ro     *grocksdb.ReadOptions
+	wo     *grocksdb.WriteOptions
+	closed bool
+}
+
+// NewRocksDBStore opens or creates a Store backed by the RocksDB database at the given path.
+func NewRocksDBStore(path string) (Store, error) {
+	opts := grocksdb.NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+
+	db, err := grocksdb.OpenDb(opts, path)
+	if err != nil {
+		opts.Destroy()
+
+		return nil, ierrors.Wrapf(err, "failed to open rocksdb database %s", path)
+	}
+
+	return &rocksDBStore{
+		db:   db,
+		opts: opts,
+		ro:   grocksdb.NewDefaultReadOptions(),
+		wo:   grocksdb.NewDefaultWriteOptions(),
+	}, nil
+}
+
+func (s *rocksDBStore) Get(key []byte) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	data, err := s.db.Get(s.ro, key)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Free()
+
+	if !data.Exists() {
+		return nil, ErrKeyNotFound
+	}
+
+	// the data is only valid until the slice is freed
+	return copyBytes(data.Data()), nil
+}
+
+func (s *rocksDBStore) Has(key []byte) (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+
+	data, err := s.db.Get(s.ro, key)
+	if err != nil {
+		return false, err
+	}
+	defer data.Free()
+
+	return data.Exists(), nil
+}
+
+func (s *rocksDBStore) Set(key []byte, value []byte) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	return s.db.Put(s.wo, key, value)
+}
+
+func (s *rocksDBStore) Delete(key []byte) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	return s.db.Delete(s.wo, key)
+}
+
+func (s *rocksDBStore) Iterate(prefix []byte, consumer IterateConsumer) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	iterator := s.db.NewIterator(s.ro)
+	defer iterator.Close()
+
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		key := iterator.Key()
+		value := iterator.Value()
+		keyCopy, valueCopy := copyBytes(key.Data()), copyBytes(value.Data())
+		key.Free()
+		value.Free()
+
+		if !consumer(keyCopy, valueCopy) {
+			break
+		}
+	}
+
+	return iterator.Err()
+}
+
+func (s *rocksDBStore) Batch() Batch {
+	return newBatch(func(mutations []*batchMutation) error {
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+
+		if s.closed {
+			return ErrStoreClosed
+		}
+
+		writeBatch := grocksdb.NewWriteBatch()
+		defer writeBatch.Destroy()
+
+		for _, mutation := range mutations {
+			if mutation.delete {
+				writeBatch.Delete(mutation.key)
+
+				continue
+			}
+
+			writeBatch.Put(mutation.key, mutation.value)
+		}
+
+		return s.db.Write(s.wo, writeBatch)
+	})
+}
+
+func (s *rocksDBStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	s.db.Close()
+	s.ro.Destroy()
+	s.wo.Destroy()
+	s.opts.Destroy()
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package kvstore
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrKeyNotFound is returned if the key does not exist in the store.
+	ErrKeyNotFound = ierrors.New("key not found")
+	// ErrStoreClosed is returned if the store is used after it was closed.
+	ErrStoreClosed = ierrors.New("store closed")
+	// ErrBatchCommitted is returned if a batch is used after it was committed or canceled.
+	ErrBatchCommitted = ierrors.New("batch already committed")
+)
+
+// IterateConsumer is called for every key/value pair during an iteration.
+// Returning false stops the iteration.
+type IterateConsumer func(key []byte, value []byte) bool
+
+// Store is a simple key/value store used for checkpoints, caches and trackers.
+// Keys are iterated in lexicographical order.
+type Store interface {
+	// Get returns the value for the given key.
+	// Returns ErrKeyNotFound if the key does not exist.
+	Get(key []byte) ([]byte, error)
+	// Has returns true if the given key exists.
+	Has(key []byte) (bool, error)
+	// Set sets the value for the given key.
+	Set(key []byte, value []byte) error
+	// Delete deletes the given key.
+	Delete(key []byte) error
+	// Iterate calls the consumer for all key/value pairs with the given prefix.
+	// The consumer must not modify the store during the iteration.
+	Iterate(prefix []byte, consumer IterateConsumer) error
+	// Batch returns a new batch that applies several mutations atomically.
+	Batch() Batch
+	// Close closes the store.
+	Close() error
+}
+
+// Batch collects mutations that are applied atomically on Commit.
+type Batch interface {
+	// Set sets the value for the given key.
+	Set(key []byte, value []byte) error
+	// Delete deletes the given key.
+	Delete(key []byte) error
+	// Commit applies all mutations of the batch.
+	Commit() error
+	// Cancel discards all mutations of the batch.
+	Cancel()
+}
+
+// batchMutation is a single mutation of a batch.
+type batchMutation struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// batch collects the mutations and passes them to the commit function of the store.
+type batch struct {
+	commit    func(mutations []*batchMutation) error
+	mutations []*batchMutation
+	done      bool
+}
+
+func newBatch(commit func(mutations []*batchMutation) error) *batch {
+	return &batch{commit: commit}
+}
+
+func (b *batch) Set(key []byte, value []byte) error {
+	if b.done {
+		return ErrBatchCommitted
+	}
+	b.mutations = append(b.mutations, &batchMutation{key: copyBytes(key), value: copyBytes(value)})
+
+	return nil
+}
+
+func (b *batch) Delete(key []byte) error {
+	if b.done {
+		return ErrBatchCommitted
+	}
+	b.mutations = append(b.mutations, &batchMutation{key: copyBytes(key), delete: true})
+
+	return nil
+}
+
+func (b *batch) Commit() error {
+	if b.done {
+		return ErrBatchCommitted
+	}
+	b.done = true
+
+	return b.commit(b.mutations)
+}
+
+func (b *batch) Cancel() {
+	b.done = true
+	b.mutations = nil
+}
+
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
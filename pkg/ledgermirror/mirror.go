@@ -0,0 +1,232 @@
+package ledgermirror
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultBootstrapBatchSize is the default amount of unspent outputs that are stored per batch while bootstrapping.
+	DefaultBootstrapBatchSize = 1000
+)
+
+// Events are the events of the Mirror.
+type Events struct {
+	// LedgerUpdateApplied is triggered after a ledger update was applied to the storage,
+	// so trackers can derive their state from the ledger updates of the mirror.
+	LedgerUpdateApplied *event.Event1[*nodebridge.LedgerUpdate]
+}
+
+// Mirror applies the ledger updates of the node to a Storage,
+// so extensions can query outputs and balances of addresses from their own database.
+type Mirror struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	storage    Storage
+	events     *Events
+
+	bootstrapBatchSize int
+}
+
+// WithBootstrapBatchSize sets the amount of unspent outputs that are stored per batch while bootstrapping,
+// which bounds the amount of outputs that are kept in memory.
+func WithBootstrapBatchSize(batchSize int) options.Option[Mirror] {
+	return func(m *Mirror) {
+		m.bootstrapBatchSize = batchSize
+	}
+}
+
+// New creates a new Mirror.
+func New(logger log.Logger, nodeBridge nodebridge.NodeBridge, storage Storage, opts ...options.Option[Mirror]) *Mirror {
+	return options.Apply(&Mirror{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		storage:    storage,
+		events: &Events{
+			LedgerUpdateApplied: event.New1[*nodebridge.LedgerUpdate](),
+		},
+		bootstrapBatchSize: DefaultBootstrapBatchSize,
+	}, opts)
+}
+
+// Events returns the events of the mirror.
+func (m *Mirror) Events() *Events {
+	return m.events
+}
+
+// Storage returns the storage of the mirror.
+func (m *Mirror) Storage() Storage {
+	return m.storage
+}
+
+// Run applies the ledger updates after the stored checkpoint until the given context is done.
+// If there is no checkpoint yet, the mirror is bootstrapped from the unspent outputs of the node first,
+// so the balances contain the outputs that were created before the mirror started.
+func (m *Mirror) Run(ctx context.Context) error {
+	checkpoint, exists, err := m.storage.Checkpoint(ctx)
+	if err != nil {
+		return ierrors.Wrap(err, "failed to read checkpoint")
+	}
+
+	if !exists {
+		if checkpoint, err = m.bootstrap(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	startSlot := checkpoint + 1
+	m.LogInfof("Resuming ledger mirror at slot %d", startSlot)
+
+	if err := m.nodeBridge.ListenToLedgerUpdates(ctx, startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+		record, err := newLedgerUpdateRecord(update)
+		if err != nil {
+			return err
+		}
+
+		if err := m.storage.ApplyLedgerUpdate(ctx, record); err != nil {
+			return ierrors.Wrapf(err, "failed to apply ledger update %s", update.CommitmentID)
+		}
+		m.events.LedgerUpdateApplied.Trigger(update)
+
+		return nil
+	}); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// bootstrap stores the unspent outputs of the node as the initial state of the mirror
+// and returns the slot of the ledger state they belong to.
+// The outputs are stored in batches while they are received, and the checkpoint is only stored after the last batch,
+// so an interrupted bootstrap is started again from scratch.
+func (m *Mirror) bootstrap(ctx context.Context) (iotago.SlotIndex, error) {
+	m.LogInfo("Bootstrapping ledger mirror from the unspent outputs of the node ...")
+
+	// remove the outputs of an interrupted bootstrap
+	if err := m.storage.Reset(ctx); err != nil {
+		return 0, ierrors.Wrap(err, "failed to reset storage")
+	}
+
+	hrp := m.nodeBridge.APIProvider().CommittedAPI().ProtocolParameters().Bech32HRP()
+	batchSize := max(m.bootstrapBatchSize, 1)
+
+	var ledgerCommitmentID iotago.CommitmentID
+	var count int
+	batch := make([]*OutputRecord, 0, batchSize)
+	storeBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := m.storage.StoreUnspentOutputs(ctx, batch); err != nil {
+			return ierrors.Wrap(err, "failed to store unspent outputs")
+		}
+		count += len(batch)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	if err := m.nodeBridge.UnspentOutputs(ctx, func(output *nodebridge.Output) error {
+		latestCommitmentID := output.Metadata.LatestCommitmentID
+		if count == 0 && len(batch) == 0 {
+			ledgerCommitmentID = latestCommitmentID
+		} else if latestCommitmentID != ledgerCommitmentID {
+			return ierrors.Errorf("ledger state changed while reading the unspent outputs: expected commitment %s, got %s", ledgerCommitmentID, latestCommitmentID)
+		}
+
+		batch = append(batch, newOutputRecord(hrp, output))
+		if len(batch) < batchSize {
+			return nil
+		}
+
+		return storeBatch()
+	}); err != nil {
+		return 0, ierrors.Wrap(err, "failed to read unspent outputs")
+	}
+
+	if err := storeBatch(); err != nil {
+		return 0, err
+	}
+
+	if count == 0 {
+		ledgerCommitmentID = m.nodeBridge.LatestCommitment().CommitmentID
+	}
+	slot := ledgerCommitmentID.Slot()
+
+	if err := m.storage.SetCheckpoint(ctx, slot); err != nil {
+		return 0, ierrors.Wrapf(err, "failed to store checkpoint of commitment %s", ledgerCommitmentID)
+	}
+
+	m.LogInfof("Bootstrapped ledger mirror with %d unspent outputs at slot %d", count, slot)
+
+	return slot, nil
+}
+
+func newLedgerUpdateRecord(update *nodebridge.LedgerUpdate) (*LedgerUpdateRecord, error) {
+	hrp := update.API.ProtocolParameters().Bech32HRP()
+
+	record := &LedgerUpdateRecord{
+		Slot:     update.CommitmentID.Slot(),
+		Created:  make([]*OutputRecord, 0, len(update.Created)),
+		Consumed: make([]*OutputRecord, 0, len(update.Consumed)),
+	}
+
+	for _, output := range update.Created {
+		record.Created = append(record.Created, newOutputRecord(hrp, output))
+	}
+
+	for _, output := range update.Consumed {
+		if output.Metadata.Spent == nil {
+			return nil, ierrors.Errorf("consumed output %s has no spent metadata", output.OutputID.ToHex())
+		}
+
+		outputRecord := newOutputRecord(hrp, output)
+		outputRecord.Spent = &SpentRecord{
+			TransactionID: output.Metadata.Spent.TransactionID,
+			SlotSpent:     output.Metadata.Spent.Slot,
+		}
+		record.Consumed = append(record.Consumed, outputRecord)
+	}
+
+	return record, nil
+}
+
+func newOutputRecord(hrp iotago.NetworkPrefix, output *nodebridge.Output) *OutputRecord {
+	var slotBooked iotago.SlotIndex
+	if output.Metadata.Included != nil {
+		slotBooked = output.Metadata.Included.Slot
+	}
+
+	return &OutputRecord{
+		OutputID:      output.OutputID,
+		Address:       outputAddress(hrp, output),
+		Amount:        output.Output.BaseTokenAmount(),
+		SlotBooked:    slotBooked,
+		RawOutputData: output.RawOutputData,
+	}
+}
+
+// outputAddress returns the bech32 encoded address of the address unlock condition of the output,
+// or empty if the output has none.
+func outputAddress(hrp iotago.NetworkPrefix, output *nodebridge.Output) string {
+	addressUnlock := output.Output.UnlockConditionSet().Address()
+	if addressUnlock == nil {
+		return ""
+	}
+
+	return addressUnlock.Address.Bech32(hrp)
+}
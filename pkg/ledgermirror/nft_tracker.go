@@ -0,0 +1,363 @@
+package ledgermirror
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/kvstore"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	nftStoreKeySlot    = []byte("slot")
+	nftStoreKeyOwner   = []byte("owner/")
+	nftStoreKeyHistory = []byte("history/")
+)
+
+// NFTTransfer is a change of the owner of an NFT.
+type NFTTransfer struct {
+	// NFTID is the ID of the NFT.
+	NFTID iotago.NFTID `json:"nftId"`
+	// From is the bech32 encoded address of the previous owner, or empty if the NFT was minted.
+	From string `json:"from,omitempty"`
+	// To is the bech32 encoded address of the new owner, or empty if the NFT was burned.
+	To string `json:"to,omitempty"`
+	// OutputID is the ID of the new output of the NFT, or empty if the NFT was burned.
+	OutputID iotago.OutputID `json:"outputId"`
+	// TransactionID is the ID of the transaction that transferred the NFT.
+	TransactionID iotago.TransactionID `json:"transactionId"`
+	// Slot is the slot of the ledger update that contained the transfer.
+	Slot iotago.SlotIndex `json:"slot"`
+}
+
+// IsMint returns true if the NFT was minted.
+func (t *NFTTransfer) IsMint() bool {
+	return t.From == ""
+}
+
+// IsBurn returns true if the NFT was burned.
+func (t *NFTTransfer) IsBurn() bool {
+	return t.To == ""
+}
+
+// NFTTrackerEvents are the events of the NFTTracker.
+type NFTTrackerEvents struct {
+	// Transferred is triggered for every mint, transfer and burn of an NFT.
+	Transferred *event.Event1[*NFTTransfer]
+}
+
+// NFTTracker follows the ledger updates applied by a Mirror and maps the IDs of the NFTs to their current owners.
+// NFTs that were created before the tracker started are tracked from their first change on.
+type NFTTracker struct {
+	// the logger used to log events.
+	log.Logger
+
+	events *NFTTrackerEvents
+
+	store       kvstore.Store
+	storePrefix []byte
+
+	mutex      sync.RWMutex
+	owners     map[iotago.NFTID]string
+	history    map[iotago.NFTID][]*NFTTransfer
+	latestSlot iotago.SlotIndex
+
+	unhook func()
+}
+
+// WithNFTHistoryStore persists the owners and the transfer history under the given prefix of the store,
+// instead of keeping them in memory.
+func WithNFTHistoryStore(store kvstore.Store, prefix []byte) options.Option[NFTTracker] {
+	return func(t *NFTTracker) {
+		t.store = store
+		t.storePrefix = prefix
+	}
+}
+
+// NewNFTTracker creates a new NFTTracker that follows the ledger updates applied by the given mirror.
+func NewNFTTracker(logger log.Logger, mirror *Mirror, opts ...options.Option[NFTTracker]) (*NFTTracker, error) {
+	t := options.Apply(&NFTTracker{
+		Logger: logger,
+		events: &NFTTrackerEvents{
+			Transferred: event.New1[*NFTTransfer](),
+		},
+		owners:  make(map[iotago.NFTID]string),
+		history: make(map[iotago.NFTID][]*NFTTransfer),
+	}, opts)
+
+	if t.store != nil {
+		if err := t.loadStore(); err != nil {
+			return nil, err
+		}
+	}
+
+	t.unhook = mirror.Events().LedgerUpdateApplied.Hook(func(update *nodebridge.LedgerUpdate) {
+		if err := t.ApplyLedgerUpdate(update); err != nil {
+			t.LogErrorf("failed to apply ledger update %s to NFT tracker: %s", update.CommitmentID, err)
+		}
+	}).Unhook
+
+	return t, nil
+}
+
+// Events returns the events of the NFTTracker.
+func (t *NFTTracker) Events() *NFTTrackerEvents {
+	return t.events
+}
+
+// Shutdown unhooks the tracker from the mirror.
+func (t *NFTTracker) Shutdown() {
+	t.unhook()
+}
+
+// Owner returns the bech32 encoded address of the current owner of the given NFT.
+func (t *NFTTracker) Owner(nftID iotago.NFTID) (string, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	owner, exists := t.owners[nftID]
+
+	return owner, exists
+}
+
+// NFTsOwnedBy returns the IDs of the NFTs that are owned by the given bech32 encoded address.
+func (t *NFTTracker) NFTsOwnedBy(address string) []iotago.NFTID {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	nftIDs := make([]iotago.NFTID, 0)
+	for nftID, owner := range t.owners {
+		if owner == address {
+			nftIDs = append(nftIDs, nftID)
+		}
+	}
+
+	return nftIDs
+}
+
+// History returns the transfers of the given NFT in the order they happened.
+func (t *NFTTracker) History(nftID iotago.NFTID) ([]*NFTTransfer, error) {
+	if t.store == nil {
+		t.mutex.RLock()
+		defer t.mutex.RUnlock()
+
+		return append([]*NFTTransfer{}, t.history[nftID]...), nil
+	}
+
+	transfers := make([]*NFTTransfer, 0)
+	var decodeErr error
+	if err := t.store.Iterate(t.storeKey(nftStoreKeyHistory, nftID[:]), func(_ []byte, value []byte) bool {
+		transfer := &NFTTransfer{}
+		if decodeErr = json.Unmarshal(value, transfer); decodeErr != nil {
+			return false
+		}
+		transfers = append(transfers, transfer)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, ierrors.Wrapf(decodeErr, "failed to decode transfer of NFT %s", nftID.ToHex())
+	}
+
+	return transfers, nil
+}
+
+// ApplyLedgerUpdate applies the NFT transfers of the given ledger update.
+// Updates for slots that are not newer than the latest applied slot are ignored.
+func (t *NFTTracker) ApplyLedgerUpdate(update *nodebridge.LedgerUpdate) error {
+	slot := update.CommitmentID.Slot()
+	hrp := update.API.ProtocolParameters().Bech32HRP()
+
+	consumed := make(map[iotago.NFTID]*nodebridge.Output)
+	for _, output := range update.Consumed {
+		if nftID, isNFT := outputNFTID(output); isNFT {
+			consumed[nftID] = output
+		}
+	}
+
+	t.mutex.Lock()
+
+	if slot <= t.latestSlot && t.latestSlot != 0 {
+		t.mutex.Unlock()
+
+		return nil
+	}
+
+	// the new owners of the NFTs of the update, empty if the NFT was burned
+	owners := make(map[iotago.NFTID]string)
+	transfers := make([]*NFTTransfer, 0)
+	for _, output := range update.Created {
+		nftID, isNFT := outputNFTID(output)
+		if !isNFT {
+			continue
+		}
+
+		var from string
+		if consumedOutput, wasConsumed := consumed[nftID]; wasConsumed {
+			delete(consumed, nftID)
+			from = outputAddress(hrp, consumedOutput)
+		} else {
+			from = t.owners[nftID]
+		}
+
+		to := outputAddress(hrp, output)
+		owners[nftID] = to
+		if from == to {
+			// the NFT output changed without a change of the owner
+			continue
+		}
+
+		transfer := &NFTTransfer{
+			NFTID:    nftID,
+			From:     from,
+			To:       to,
+			OutputID: output.OutputID,
+			Slot:     slot,
+		}
+		if output.Metadata != nil && output.Metadata.Included != nil {
+			transfer.TransactionID = output.Metadata.Included.TransactionID
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	// NFTs that were consumed without being created again were burned
+	for nftID, output := range consumed {
+		owners[nftID] = ""
+		transfer := &NFTTransfer{
+			NFTID: nftID,
+			From:  outputAddress(hrp, output),
+			Slot:  slot,
+		}
+		if output.Metadata != nil && output.Metadata.Spent != nil {
+			transfer.TransactionID = output.Metadata.Spent.TransactionID
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := t.storeUpdate(slot, owners, transfers); err != nil {
+		t.mutex.Unlock()
+
+		return err
+	}
+
+	for nftID, owner := range owners {
+		if owner == "" {
+			delete(t.owners, nftID)
+		} else {
+			t.owners[nftID] = owner
+		}
+	}
+	if t.store == nil {
+		for _, transfer := range transfers {
+			t.history[transfer.NFTID] = append(t.history[transfer.NFTID], transfer)
+		}
+	}
+	t.latestSlot = slot
+
+	t.mutex.Unlock()
+
+	for _, transfer := range transfers {
+		t.events.Transferred.Trigger(transfer)
+	}
+
+	return nil
+}
+
+// storeUpdate persists the given owners, transfers and the slot atomically, if a store is configured.
+func (t *NFTTracker) storeUpdate(slot iotago.SlotIndex, owners map[iotago.NFTID]string, transfers []*NFTTransfer) error {
+	if t.store == nil {
+		return nil
+	}
+
+	batch := t.store.Batch()
+	for i, transfer := range transfers {
+		value, err := json.Marshal(transfer)
+		if err != nil {
+			batch.Cancel()
+			return err
+		}
+
+		historyKey := binary.BigEndian.AppendUint32(append([]byte{}, transfer.NFTID[:]...), uint32(slot))
+		historyKey = binary.BigEndian.AppendUint32(historyKey, uint32(i))
+		if err := batch.Set(t.storeKey(nftStoreKeyHistory, historyKey), value); err != nil {
+			batch.Cancel()
+			return err
+		}
+	}
+
+	for nftID, owner := range owners {
+		var err error
+		ownerKey := t.storeKey(nftStoreKeyOwner, nftID[:])
+		if owner == "" {
+			err = batch.Delete(ownerKey)
+		} else {
+			err = batch.Set(ownerKey, []byte(owner))
+		}
+		if err != nil {
+			batch.Cancel()
+			return err
+		}
+	}
+
+	if err := batch.Set(t.storeKey(nftStoreKeySlot, nil), binary.BigEndian.AppendUint32(nil, uint32(slot))); err != nil {
+		batch.Cancel()
+		return err
+	}
+
+	return batch.Commit()
+}
+
+// loadStore loads the owners and the latest applied slot from the store.
+func (t *NFTTracker) loadStore() error {
+	slotBytes, err := t.store.Get(t.storeKey(nftStoreKeySlot, nil))
+	switch {
+	case err == nil:
+		if len(slotBytes) != 4 {
+			return ierrors.New("invalid stored NFT tracker slot")
+		}
+		t.latestSlot = iotago.SlotIndex(binary.BigEndian.Uint32(slotBytes))
+	case !ierrors.Is(err, kvstore.ErrKeyNotFound):
+		return err
+	}
+
+	ownerPrefix := t.storeKey(nftStoreKeyOwner, nil)
+
+	return t.store.Iterate(ownerPrefix, func(key []byte, value []byte) bool {
+		var nftID iotago.NFTID
+		copy(nftID[:], key[len(ownerPrefix):])
+		t.owners[nftID] = string(value)
+
+		return true
+	})
+}
+
+func (t *NFTTracker) storeKey(kind []byte, suffix []byte) []byte {
+	key := make([]byte, 0, len(t.storePrefix)+len(kind)+len(suffix))
+	key = append(key, t.storePrefix...)
+	key = append(key, kind...)
+
+	return append(key, suffix...)
+}
+
+// outputNFTID returns the ID of the NFT of the given output, or false if the output is not an NFT output.
+func outputNFTID(output *nodebridge.Output) (iotago.NFTID, bool) {
+	nftOutput, isNFT := output.Output.(*iotago.NFTOutput)
+	if !isNFT {
+		return iotago.NFTID{}, false
+	}
+
+	if nftOutput.NFTID.Empty() {
+		// the NFT was minted in this output
+		return iotago.NFTIDFromOutputID(output.OutputID), true
+	}
+
+	return nftOutput.NFTID, true
+}
@@ -0,0 +1,384 @@
+package ledgermirror
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// postgresMigrations are the schema migrations of the PostgresStorage.
+// Migrations are applied in order and must never be changed once released, only appended.
+var postgresMigrations = []string{
+	// 1: initial schema
+	`CREATE TABLE IF NOT EXISTS ledger_outputs (
+		output_id      BYTEA PRIMARY KEY,
+		address        TEXT NOT NULL,
+		amount         BIGINT NOT NULL,
+		slot_booked    BIGINT NOT NULL,
+		raw_output     BYTEA,
+		spent_tx_id    BYTEA,
+		slot_spent     BIGINT
+	);
+	CREATE INDEX IF NOT EXISTS ledger_outputs_unspent_address_idx ON ledger_outputs (address) WHERE spent_tx_id IS NULL;
+	CREATE TABLE IF NOT EXISTS ledger_balances (
+		address TEXT PRIMARY KEY,
+		amount  BIGINT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS ledger_checkpoint (
+		id   SMALLINT PRIMARY KEY CHECK (id = 1),
+		slot BIGINT NOT NULL
+	);`,
+}
+
+// PostgresStorage is a Storage backed by PostgreSQL.
+// It works on a *sql.DB, so the application chooses the driver (e.g. github.com/lib/pq or github.com/jackc/pgx/v5/stdlib).
+// Amounts are stored as BIGINT, which covers the total supply of the base token.
+type PostgresStorage struct {
+	db *sql.DB
+}
+
+var _ SnapshotStorage = &PostgresStorage{}
+
+// NewPostgresStorage creates a new PostgresStorage and applies the pending schema migrations.
+func NewPostgresStorage(ctx context.Context, db *sql.DB) (*PostgresStorage, error) {
+	s := &PostgresStorage{db: db}
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// migrate applies all schema migrations that were not applied yet.
+func (s *PostgresStorage) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS ledger_schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return ierrors.Wrap(err, "failed to create schema migrations table")
+	}
+
+	var version int
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM ledger_schema_migrations`).Scan(&version); err != nil {
+		return ierrors.Wrap(err, "failed to read schema version")
+	}
+
+	for i := version; i < len(postgresMigrations); i++ {
+		if err := s.withTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, postgresMigrations[i]); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO ledger_schema_migrations (version) VALUES ($1)`, i+1)
+
+			return err
+		}); err != nil {
+			return ierrors.Wrapf(err, "failed to apply schema migration %d", i+1)
+		}
+	}
+
+	return nil
+}
+
+// Checkpoint returns the slot of the latest applied ledger update.
+func (s *PostgresStorage) Checkpoint(ctx context.Context) (iotago.SlotIndex, bool, error) {
+	var slot int64
+	if err := s.db.QueryRowContext(ctx, `SELECT slot FROM ledger_checkpoint WHERE id = 1`).Scan(&slot); err != nil {
+		if ierrors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return iotago.SlotIndex(slot), true, nil
+}
+
+// ApplyLedgerUpdate atomically applies the given ledger update.
+// Updates for slots that are not newer than the checkpoint are ignored, so replayed updates are harmless.
+func (s *PostgresStorage) ApplyLedgerUpdate(ctx context.Context, update *LedgerUpdateRecord) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var checkpoint int64
+		err := tx.QueryRowContext(ctx, `SELECT slot FROM ledger_checkpoint WHERE id = 1 FOR UPDATE`).Scan(&checkpoint)
+		switch {
+		case err == nil:
+			if iotago.SlotIndex(checkpoint) >= update.Slot {
+				return nil
+			}
+		case !ierrors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		for _, output := range update.Created {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO ledger_outputs (output_id, address, amount, slot_booked, raw_output) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (output_id) DO NOTHING`,
+				output.OutputID[:], output.Address, int64(output.Amount), int64(output.SlotBooked), output.RawOutputData,
+			); err != nil {
+				return ierrors.Wrapf(err, "failed to store output %s", output.OutputID.ToHex())
+			}
+
+			if err := addToBalance(ctx, tx, output.Address, int64(output.Amount)); err != nil {
+				return err
+			}
+		}
+
+		for _, output := range update.Consumed {
+			var address string
+			var amount int64
+			err := tx.QueryRowContext(ctx,
+				`UPDATE ledger_outputs SET spent_tx_id = $2, slot_spent = $3 WHERE output_id = $1 AND spent_tx_id IS NULL RETURNING address, amount`,
+				output.OutputID[:], output.Spent.TransactionID[:], int64(output.Spent.SlotSpent),
+			).Scan(&address, &amount)
+
+			switch {
+			case err == nil:
+				if err := addToBalance(ctx, tx, address, -amount); err != nil {
+					return err
+				}
+			case ierrors.Is(err, sql.ErrNoRows):
+				// the output is unknown, e.g. because it was not contained in an imported snapshot, so it was never part of a balance
+				if _, err := tx.ExecContext(ctx,
+					`INSERT INTO ledger_outputs (output_id, address, amount, slot_booked, raw_output, spent_tx_id, slot_spent) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (output_id) DO NOTHING`,
+					output.OutputID[:], output.Address, int64(output.Amount), int64(output.SlotBooked), output.RawOutputData, output.Spent.TransactionID[:], int64(output.Spent.SlotSpent),
+				); err != nil {
+					return ierrors.Wrapf(err, "failed to store spent output %s", output.OutputID.ToHex())
+				}
+			default:
+				return ierrors.Wrapf(err, "failed to mark output %s as spent", output.OutputID.ToHex())
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO ledger_checkpoint (id, slot) VALUES (1, $1) ON CONFLICT (id) DO UPDATE SET slot = EXCLUDED.slot`, int64(update.Slot))
+
+		return err
+	})
+}
+
+// Output returns the stored output with the given ID.
+func (s *PostgresStorage) Output(ctx context.Context, outputID iotago.OutputID) (*OutputRecord, error) {
+	record, err := scanOutputRecord(s.db.QueryRowContext(ctx,
+		`SELECT output_id, address, amount, slot_booked, raw_output, spent_tx_id, slot_spent FROM ledger_outputs WHERE output_id = $1`,
+		outputID[:],
+	))
+	if err != nil {
+		if ierrors.Is(err, sql.ErrNoRows) {
+			return nil, ierrors.Wrapf(ErrOutputNotFound, "output %s", outputID.ToHex())
+		}
+
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ForEachOutput calls the consumer for every stored output, spent and unspent.
+func (s *PostgresStorage) ForEachOutput(ctx context.Context, consumer func(output *OutputRecord) error) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT output_id, address, amount, slot_booked, raw_output, spent_tx_id, slot_spent FROM ledger_outputs ORDER BY output_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record, err := scanOutputRecord(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := consumer(record); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// ImportSnapshot atomically replaces the stored state with the given outputs and checkpoint.
+func (s *PostgresStorage) ImportSnapshot(ctx context.Context, checkpoint iotago.SlotIndex, hasCheckpoint bool, nextOutput func() (*OutputRecord, error)) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM ledger_outputs; DELETE FROM ledger_balances; DELETE FROM ledger_checkpoint;`); err != nil {
+			return ierrors.Wrap(err, "failed to clear storage")
+		}
+
+		for {
+			output, err := nextOutput()
+			if err != nil {
+				if ierrors.Is(err, io.EOF) {
+					break
+				}
+
+				return err
+			}
+
+			var spentTxID []byte
+			var slotSpent sql.NullInt64
+			if output.Spent != nil {
+				spentTxID = output.Spent.TransactionID[:]
+				slotSpent = sql.NullInt64{Int64: int64(output.Spent.SlotSpent), Valid: true}
+			}
+
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO ledger_outputs (output_id, address, amount, slot_booked, raw_output, spent_tx_id, slot_spent) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				output.OutputID[:], output.Address, int64(output.Amount), int64(output.SlotBooked), output.RawOutputData, spentTxID, slotSpent,
+			); err != nil {
+				return ierrors.Wrapf(err, "failed to store output %s", output.OutputID.ToHex())
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO ledger_balances (address, amount) SELECT address, SUM(amount) FROM ledger_outputs WHERE spent_tx_id IS NULL AND address <> '' GROUP BY address`,
+		); err != nil {
+			return ierrors.Wrap(err, "failed to derive balances")
+		}
+
+		if !hasCheckpoint {
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO ledger_checkpoint (id, slot) VALUES (1, $1)`, int64(checkpoint))
+
+		return err
+	})
+}
+
+// UnspentOutputIDs returns the IDs of the unspent outputs of the given bech32 address.
+func (s *PostgresStorage) UnspentOutputIDs(ctx context.Context, address string) (iotago.OutputIDs, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT output_id FROM ledger_outputs WHERE address = $1 AND spent_tx_id IS NULL ORDER BY output_id`, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outputIDs := make(iotago.OutputIDs, 0)
+	for rows.Next() {
+		var rawOutputID []byte
+		if err := rows.Scan(&rawOutputID); err != nil {
+			return nil, err
+		}
+
+		var outputID iotago.OutputID
+		copy(outputID[:], rawOutputID)
+		outputIDs = append(outputIDs, outputID)
+	}
+
+	return outputIDs, rows.Err()
+}
+
+// Balance returns the base token balance of the unspent outputs of the given bech32 address.
+func (s *PostgresStorage) Balance(ctx context.Context, address string) (iotago.BaseToken, error) {
+	var amount int64
+	if err := s.db.QueryRowContext(ctx, `SELECT amount FROM ledger_balances WHERE address = $1`, address).Scan(&amount); err != nil {
+		if ierrors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return iotago.BaseToken(amount), nil
+}
+
+// Reset removes all outputs, balances and the checkpoint.
+func (s *PostgresStorage) Reset(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM ledger_outputs; DELETE FROM ledger_balances; DELETE FROM ledger_checkpoint;`)
+
+	return err
+}
+
+// StoreUnspentOutputs atomically stores the given unspent outputs and adds them to the balances of their addresses.
+// Outputs that are already stored are ignored.
+func (s *PostgresStorage) StoreUnspentOutputs(ctx context.Context, outputs []*OutputRecord) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, output := range outputs {
+			result, err := tx.ExecContext(ctx,
+				`INSERT INTO ledger_outputs (output_id, address, amount, slot_booked, raw_output) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (output_id) DO NOTHING`,
+				output.OutputID[:], output.Address, int64(output.Amount), int64(output.SlotBooked), output.RawOutputData,
+			)
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to store output %s", output.OutputID.ToHex())
+			}
+
+			inserted, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if inserted == 0 {
+				continue
+			}
+
+			if err := addToBalance(ctx, tx, output.Address, int64(output.Amount)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetCheckpoint sets the slot of the latest applied ledger update.
+func (s *PostgresStorage) SetCheckpoint(ctx context.Context, slot iotago.SlotIndex) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO ledger_checkpoint (id, slot) VALUES (1, $1) ON CONFLICT (id) DO UPDATE SET slot = EXCLUDED.slot`, int64(slot))
+
+	return err
+}
+
+func (s *PostgresStorage) withTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// scanOutputRecord scans a row of ledger_outputs into an OutputRecord.
+func scanOutputRecord(row interface{ Scan(dest ...any) error }) (*OutputRecord, error) {
+	var rawOutputID []byte
+	var address string
+	var amount, slotBooked int64
+	var rawOutput, spentTxID []byte
+	var slotSpent sql.NullInt64
+
+	if err := row.Scan(&rawOutputID, &address, &amount, &slotBooked, &rawOutput, &spentTxID, &slotSpent); err != nil {
+		return nil, err
+	}
+
+	record := &OutputRecord{
+		Address:       address,
+		Amount:        iotago.BaseToken(amount),
+		SlotBooked:    iotago.SlotIndex(slotBooked),
+		RawOutputData: rawOutput,
+	}
+	copy(record.OutputID[:], rawOutputID)
+
+	if spentTxID != nil {
+		var transactionID iotago.TransactionID
+		copy(transactionID[:], spentTxID)
+
+		record.Spent = &SpentRecord{
+			TransactionID: transactionID,
+			SlotSpent:     iotago.SlotIndex(slotSpent.Int64),
+		}
+	}
+
+	return record, nil
+}
+
+func addToBalance(ctx context.Context, tx *sql.Tx, address string, delta int64) error {
+	if address == "" {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO ledger_balances (address, amount) VALUES ($1, $2) ON CONFLICT (address) DO UPDATE SET amount = ledger_balances.amount + EXCLUDED.amount`,
+		address, delta,
+	); err != nil {
+		return ierrors.Wrapf(err, "failed to update balance of %s", address)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package ledgermirror
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// SnapshotVersion is the version of the snapshot format written by Export.
+	SnapshotVersion byte = 1
+
+	// snapshotMagic identifies a ledger mirror snapshot.
+	snapshotMagic = "ILMS"
+
+	snapshotEntryEnd    byte = 0
+	snapshotEntryOutput byte = 1
+
+	// maxSnapshotOutputLength is the maximum length of the raw data of an output in a snapshot.
+	maxSnapshotOutputLength = 64 * 1024 * 1024
+)
+
+var (
+	// ErrSnapshotNotSupported is returned if the storage of the mirror doesn't implement SnapshotStorage.
+	ErrSnapshotNotSupported = ierrors.New("storage does not support snapshots")
+	// ErrInvalidSnapshot is returned if a snapshot can't be parsed.
+	ErrInvalidSnapshot = ierrors.New("invalid snapshot")
+	// ErrUnsupportedSnapshotVersion is returned if a snapshot was written with an unknown version of the format.
+	ErrUnsupportedSnapshotVersion = ierrors.New("unsupported snapshot version")
+)
+
+// SnapshotStorage is a Storage that can export and import its complete state.
+type SnapshotStorage interface {
+	Storage
+
+	// ForEachOutput calls the consumer for every stored output, spent and unspent.
+	ForEachOutput(ctx context.Context, consumer func(output *OutputRecord) error) error
+	// ImportSnapshot atomically replaces the stored state with the outputs returned by nextOutput and the given checkpoint.
+	// nextOutput returns io.EOF if there are no more outputs. The balances are derived from the unspent outputs.
+	ImportSnapshot(ctx context.Context, checkpoint iotago.SlotIndex, hasCheckpoint bool, nextOutput func() (*OutputRecord, error)) error
+}
+
+// Export writes a snapshot of the state of the mirror to the given writer,
+// so it can be restored with Import instead of replaying the ledger updates from an old slot.
+// The mirror should not be running while exporting, otherwise the snapshot may not be consistent.
+func (m *Mirror) Export(ctx context.Context, w io.Writer) error {
+	storage, ok := m.storage.(SnapshotStorage)
+	if !ok {
+		return ErrSnapshotNotSupported
+	}
+
+	checkpoint, hasCheckpoint, err := storage.Checkpoint(ctx)
+	if err != nil {
+		return ierrors.Wrap(err, "failed to read checkpoint")
+	}
+
+	writer := bufio.NewWriter(w)
+
+	header := make([]byte, 0, len(snapshotMagic)+1+1+4)
+	header = append(header, snapshotMagic...)
+	header = append(header, SnapshotVersion, boolToByte(hasCheckpoint))
+	header = binary.LittleEndian.AppendUint32(header, uint32(checkpoint))
+	if _, err := writer.Write(header); err != nil {
+		return err
+	}
+
+	var count uint64
+	if err := storage.ForEachOutput(ctx, func(output *OutputRecord) error {
+		count++
+
+		return writeSnapshotOutput(writer, output)
+	}); err != nil {
+		return ierrors.Wrap(err, "failed to export outputs")
+	}
+
+	// the end marker contains the amount of outputs, so truncated snapshots are detected
+	footer := binary.LittleEndian.AppendUint64([]byte{snapshotEntryEnd}, count)
+	if _, err := writer.Write(footer); err != nil {
+		return err
+	}
+
+	m.LogInfof("Exported ledger mirror snapshot with %d outputs", count)
+
+	return writer.Flush()
+}
+
+// Import replaces the state of the mirror with the snapshot read from the given reader.
+// Import must be called before Run, the mirror resumes at the slot after the checkpoint of the snapshot.
+func (m *Mirror) Import(ctx context.Context, r io.Reader) error {
+	storage, ok := m.storage.(SnapshotStorage)
+	if !ok {
+		return ErrSnapshotNotSupported
+	}
+
+	reader := bufio.NewReader(r)
+
+	header := make([]byte, len(snapshotMagic)+1+1+4)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return ierrors.Wrap(ErrInvalidSnapshot, "truncated header")
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return ierrors.Wrap(ErrInvalidSnapshot, "unknown file format")
+	}
+	if version := header[len(snapshotMagic)]; version != SnapshotVersion {
+		return ierrors.Wrapf(ErrUnsupportedSnapshotVersion, "version %d", version)
+	}
+	hasCheckpoint := header[len(snapshotMagic)+1] != 0
+	checkpoint := iotago.SlotIndex(binary.LittleEndian.Uint32(header[len(snapshotMagic)+2:]))
+
+	var count uint64
+	if err := storage.ImportSnapshot(ctx, checkpoint, hasCheckpoint, func() (*OutputRecord, error) {
+		output, err := readSnapshotEntry(reader, count)
+		if err != nil {
+			return nil, err
+		}
+		count++
+
+		return output, nil
+	}); err != nil {
+		return ierrors.Wrap(err, "failed to import snapshot")
+	}
+
+	m.LogInfof("Imported ledger mirror snapshot with %d outputs at slot %d", count, checkpoint)
+
+	return nil
+}
+
+func writeSnapshotOutput(w *bufio.Writer, output *OutputRecord) error {
+	if len(output.Address) > 0xFFFF {
+		return ierrors.Wrapf(ErrInvalidSnapshot, "address of output %s too long", output.OutputID.ToHex())
+	}
+
+	buf := make([]byte, 0, 1+iotago.OutputIDLength+2+len(output.Address)+8+4+4+len(output.RawOutputData)+1+iotago.TransactionIDLength+4)
+	buf = append(buf, snapshotEntryOutput)
+	buf = append(buf, output.OutputID[:]...)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(output.Address)))
+	buf = append(buf, output.Address...)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(output.Amount))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(output.SlotBooked))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(output.RawOutputData)))
+	buf = append(buf, output.RawOutputData...)
+
+	buf = append(buf, boolToByte(output.Spent != nil))
+	if output.Spent != nil {
+		buf = append(buf, output.Spent.TransactionID[:]...)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(output.Spent.SlotSpent))
+	}
+
+	_, err := w.Write(buf)
+
+	return err
+}
+
+// readSnapshotEntry reads the next output of the snapshot.
+// Returns io.EOF after the end marker, if the amount of read outputs matches.
+func readSnapshotEntry(r *bufio.Reader, readCount uint64) (*OutputRecord, error) {
+	entry, err := r.ReadByte()
+	if err != nil {
+		return nil, ierrors.Wrap(ErrInvalidSnapshot, "missing end marker")
+	}
+
+	switch entry {
+	case snapshotEntryEnd:
+		var count [8]byte
+		if _, err := io.ReadFull(r, count[:]); err != nil {
+			return nil, ierrors.Wrap(ErrInvalidSnapshot, "truncated end marker")
+		}
+		if expected := binary.LittleEndian.Uint64(count[:]); expected != readCount {
+			return nil, ierrors.Wrapf(ErrInvalidSnapshot, "expected %d outputs, got %d", expected, readCount)
+		}
+
+		return nil, io.EOF
+
+	case snapshotEntryOutput:
+		output, err := readSnapshotOutput(r)
+		if err != nil {
+			return nil, ierrors.Wrapf(ErrInvalidSnapshot, "truncated output %d: %s", readCount, err)
+		}
+
+		return output, nil
+
+	default:
+		return nil, ierrors.Wrapf(ErrInvalidSnapshot, "unknown entry %d", entry)
+	}
+}
+
+func readSnapshotOutput(r *bufio.Reader) (*OutputRecord, error) {
+	output := &OutputRecord{}
+	if _, err := io.ReadFull(r, output.OutputID[:]); err != nil {
+		return nil, err
+	}
+
+	var addressLength uint16
+	if err := binary.Read(r, binary.LittleEndian, &addressLength); err != nil {
+		return nil, err
+	}
+	address := make([]byte, addressLength)
+	if _, err := io.ReadFull(r, address); err != nil {
+		return nil, err
+	}
+	output.Address = string(address)
+
+	var fixed struct {
+		Amount          uint64
+		SlotBooked      uint32
+		RawOutputLength uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &fixed); err != nil {
+		return nil, err
+	}
+	if fixed.RawOutputLength > maxSnapshotOutputLength {
+		return nil, ierrors.Errorf("raw output too large: %d bytes", fixed.RawOutputLength)
+	}
+	output.Amount = iotago.BaseToken(fixed.Amount)
+	output.SlotBooked = iotago.SlotIndex(fixed.SlotBooked)
+
+	output.RawOutputData = make([]byte, fixed.RawOutputLength)
+	if _, err := io.ReadFull(r, output.RawOutputData); err != nil {
+		return nil, err
+	}
+
+	spent, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if spent != 0 {
+		output.Spent = &SpentRecord{}
+		if _, err := io.ReadFull(r, output.Spent.TransactionID[:]); err != nil {
+			return nil, err
+		}
+
+		var slotSpent uint32
+		if err := binary.Read(r, binary.LittleEndian, &slotSpent); err != nil {
+			return nil, err
+		}
+		output.Spent.SlotSpent = iotago.SlotIndex(slotSpent)
+	}
+
+	return output, nil
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+
+	return 0
+}
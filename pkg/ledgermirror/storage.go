@@ -0,0 +1,72 @@
+package ledgermirror
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrOutputNotFound is returned if the output is not known to the storage.
+	ErrOutputNotFound = ierrors.New("output not found")
+)
+
+// OutputRecord is the stored representation of an output.
+type OutputRecord struct {
+	// OutputID is the ID of the output.
+	OutputID iotago.OutputID
+	// Address is the bech32 encoded address of the address unlock condition, or empty if the output has none.
+	Address string
+	// Amount is the base token amount of the output.
+	Amount iotago.BaseToken
+	// SlotBooked is the slot in which the output was booked.
+	SlotBooked iotago.SlotIndex
+	// RawOutputData is the raw binary output data.
+	RawOutputData []byte
+	// Spent is the spend of the output, or nil if the output is unspent.
+	Spent *SpentRecord
+}
+
+// SpentRecord is the stored representation of the spend of an output.
+type SpentRecord struct {
+	// TransactionID is the ID of the transaction that spent the output.
+	TransactionID iotago.TransactionID
+	// SlotSpent is the slot in which the output was spent.
+	SlotSpent iotago.SlotIndex
+}
+
+// LedgerUpdateRecord contains the changes of a single ledger update.
+type LedgerUpdateRecord struct {
+	// Slot is the slot of the commitment of the ledger update.
+	Slot iotago.SlotIndex
+	// Created are the outputs created in the slot.
+	Created []*OutputRecord
+	// Consumed are the outputs consumed in the slot, including their spend.
+	Consumed []*OutputRecord
+}
+
+// Storage persists the state of the ledger mirror.
+type Storage interface {
+	// Checkpoint returns the slot of the latest applied ledger update.
+	// Returns false if no ledger update was applied yet.
+	Checkpoint(ctx context.Context) (iotago.SlotIndex, bool, error)
+	// ApplyLedgerUpdate atomically stores the created outputs, marks the consumed outputs as spent,
+	// updates the balances of the affected addresses and moves the checkpoint to the slot of the update.
+	ApplyLedgerUpdate(ctx context.Context, update *LedgerUpdateRecord) error
+	// Output returns the stored output with the given ID.
+	// Returns ErrOutputNotFound if the output is unknown.
+	Output(ctx context.Context, outputID iotago.OutputID) (*OutputRecord, error)
+	// UnspentOutputIDs returns the IDs of the unspent outputs of the given bech32 address.
+	UnspentOutputIDs(ctx context.Context, address string) (iotago.OutputIDs, error)
+	// Balance returns the base token balance of the unspent outputs of the given bech32 address.
+	Balance(ctx context.Context, address string) (iotago.BaseToken, error)
+
+	// Reset removes all outputs, balances and the checkpoint, e.g. before the storage is bootstrapped again.
+	Reset(ctx context.Context) error
+	// StoreUnspentOutputs atomically stores the given unspent outputs and adds them to the balances of their addresses,
+	// without moving the checkpoint, so the storage can be bootstrapped in batches.
+	StoreUnspentOutputs(ctx context.Context, outputs []*OutputRecord) error
+	// SetCheckpoint sets the slot of the latest applied ledger update, e.g. after the last batch of the bootstrap.
+	SetCheckpoint(ctx context.Context, slot iotago.SlotIndex) error
+}
@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+const (
+	namespace = "inx"
+	subsystem = "bridge"
+)
+
+// BridgeCollector collects the metrics of a node bridge and the status of its node.
+type BridgeCollector struct {
+	nodeBridge nodebridge.NodeBridge
+
+	latestCommittedSlot *prometheus.Desc
+	finalizedSlot       *prometheus.Desc
+	pruningEpoch        *prometheus.Desc
+	nodeHealthy         *prometheus.Desc
+	nodeSynced          *prometheus.Desc
+	connected           *prometheus.Desc
+	commitmentLag       *prometheus.Desc
+	finalizationLag     *prometheus.Desc
+
+	consumerPanics    *prometheus.CounterVec
+	streamsDrained    *prometheus.CounterVec
+	connectionChanges *prometheus.CounterVec
+
+	unhooks []func()
+}
+
+var _ prometheus.Collector = &BridgeCollector{}
+
+// NewBridgeCollector creates a new BridgeCollector and hooks to the events of the given node bridge.
+func NewBridgeCollector(nodeBridge nodebridge.NodeBridge) *BridgeCollector {
+	c := &BridgeCollector{
+		nodeBridge: nodeBridge,
+
+		latestCommittedSlot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "latest_committed_slot"),
+			"The slot of the latest commitment of the node.",
+			nil, nil,
+		),
+		finalizedSlot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "latest_finalized_slot"),
+			"The slot of the latest finalized commitment of the node.",
+			nil, nil,
+		),
+		pruningEpoch: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "pruning_epoch"),
+			"The pruning epoch of the node.",
+			nil, nil,
+		),
+		nodeHealthy: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "node_healthy"),
+			"Whether the node is healthy.",
+			nil, nil,
+		),
+		nodeSynced: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "node_synced"),
+			"Whether the node is bootstrapped and healthy.",
+			nil, nil,
+		),
+		connected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "connected"),
+			"Whether the INX connection to the node is ready.",
+			nil, nil,
+		),
+		commitmentLag: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "commitment_lag_slots"),
+			"The amount of slots the latest commitment of the node lags behind the current slot.",
+			nil, nil,
+		),
+		finalizationLag: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "finalization_lag_slots"),
+			"The amount of slots the latest finalized commitment of the node lags behind the current slot.",
+			nil, nil,
+		),
+
+		consumerPanics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "consumer_panics_total",
+			Help:      "The amount of recovered panics in stream consumers.",
+		}, []string{"stream"}),
+		streamsDrained: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "streams_drained_total",
+			Help:      "The amount of streams that were drained on shutdown.",
+		}, []string{"stream"}),
+		connectionChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "connection_state_changes_total",
+			Help:      "The amount of connectivity state changes of the INX connection.",
+		}, []string{"state"}),
+	}
+
+	events := nodeBridge.Events()
+	c.unhooks = []func(){
+		events.ConsumerPanicked.Hook(func(stream string, _ any) {
+			c.consumerPanics.WithLabelValues(stream).Inc()
+		}).Unhook,
+		events.StreamDrained.Hook(func(stream string) {
+			c.streamsDrained.WithLabelValues(stream).Inc()
+		}).Unhook,
+		events.ConnectionStateChanged.Hook(func(state connectivity.State) {
+			c.connectionChanges.WithLabelValues(state.String()).Inc()
+		}).Unhook,
+	}
+
+	return c
+}
+
+// Shutdown unhooks the collector from the events of the node bridge.
+func (c *BridgeCollector) Shutdown() {
+	for _, unhook := range c.unhooks {
+		unhook()
+	}
+}
+
+// Describe sends the descriptors of all metrics of the collector.
+func (c *BridgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.latestCommittedSlot
+	ch <- c.finalizedSlot
+	ch <- c.pruningEpoch
+	ch <- c.nodeHealthy
+	ch <- c.nodeSynced
+	ch <- c.connected
+	ch <- c.commitmentLag
+	ch <- c.finalizationLag
+
+	c.consumerPanics.Describe(ch)
+	c.streamsDrained.Describe(ch)
+	c.connectionChanges.Describe(ch)
+}
+
+// Collect sends the current values of all metrics of the collector.
+func (c *BridgeCollector) Collect(ch chan<- prometheus.Metric) {
+	if latestCommitment := c.nodeBridge.LatestCommitment(); latestCommitment != nil {
+		ch <- prometheus.MustNewConstMetric(c.latestCommittedSlot, prometheus.GaugeValue, float64(latestCommitment.CommitmentID.Slot()))
+	}
+	if finalizedCommitment := c.nodeBridge.LatestFinalizedCommitment(); finalizedCommitment != nil {
+		ch <- prometheus.MustNewConstMetric(c.finalizedSlot, prometheus.GaugeValue, float64(finalizedCommitment.CommitmentID.Slot()))
+	}
+	ch <- prometheus.MustNewConstMetric(c.pruningEpoch, prometheus.GaugeValue, float64(c.nodeBridge.PruningEpoch()))
+	ch <- prometheus.MustNewConstMetric(c.nodeHealthy, prometheus.GaugeValue, boolToFloat(c.nodeBridge.IsNodeHealthy()))
+	ch <- prometheus.MustNewConstMetric(c.nodeSynced, prometheus.GaugeValue, boolToFloat(c.nodeBridge.IsNodeSynced()))
+	ch <- prometheus.MustNewConstMetric(c.connected, prometheus.GaugeValue, boolToFloat(c.nodeBridge.ConnectionState() == connectivity.Ready))
+
+	if lag := nodebridge.NewSlotLagMonitor(c.nodeBridge).Check(); lag != nil {
+		ch <- prometheus.MustNewConstMetric(c.commitmentLag, prometheus.GaugeValue, float64(lag.CommitmentLag))
+		ch <- prometheus.MustNewConstMetric(c.finalizationLag, prometheus.GaugeValue, float64(lag.FinalizationLag))
+	}
+
+	c.consumerPanics.Collect(ch)
+	c.streamsDrained.Collect(ch)
+	c.connectionChanges.Collect(ch)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DatabaseSizeCollector collects the size on disk of the databases of the extension.
+// The node does not expose the size of its own database or its memory usage over INX,
+// so only the databases that are accessible by the extension can be reported.
+type DatabaseSizeCollector struct {
+	paths []string
+
+	size *prometheus.Desc
+}
+
+var _ prometheus.Collector = &DatabaseSizeCollector{}
+
+// NewDatabaseSizeCollector creates a new DatabaseSizeCollector for the databases at the given paths.
+// A path can either be a single database file or a directory, in which case the sizes of all files in it are summed up.
+func NewDatabaseSizeCollector(paths ...string) *DatabaseSizeCollector {
+	return &DatabaseSizeCollector{
+		paths: paths,
+
+		size: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "database", "size_bytes"),
+			"The size of the database on disk in bytes.",
+			[]string{"path"}, nil,
+		),
+	}
+}
+
+// Describe sends the descriptors of all metrics of the collector.
+func (c *DatabaseSizeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+}
+
+// Collect sends the current values of all metrics of the collector.
+// Databases that do not exist (yet) are skipped.
+func (c *DatabaseSizeCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, path := range c.paths {
+		size, err := pathSize(path)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.size, err)
+
+			continue
+		}
+		if size < 0 {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(size), path)
+	}
+}
+
+// pathSize returns the size of the file or the summed up size of all files in the directory at the given path,
+// or -1 if the path does not exist.
+func pathSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return -1, nil
+		}
+
+		return 0, err
+	}
+
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	var size int64
+	if err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.Type().IsRegular() {
+			entryInfo, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += entryInfo.Size()
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
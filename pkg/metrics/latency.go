@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/inx-app/pkg/analytics"
+)
+
+const (
+	analyticsSubsystem = "analytics"
+)
+
+// LatencyCollector collects the acceptance and confirmation statistics of the latest analyzed slot of a LatencyTracker.
+type LatencyCollector struct {
+	tracker *analytics.LatencyTracker
+
+	analyzedSlot        *prometheus.Desc
+	acceptanceRate      *prometheus.Desc
+	acceptanceLatency   *prometheus.Desc
+	confirmationLatency *prometheus.Desc
+}
+
+var _ prometheus.Collector = &LatencyCollector{}
+
+// NewLatencyCollector creates a new LatencyCollector.
+func NewLatencyCollector(tracker *analytics.LatencyTracker) *LatencyCollector {
+	return &LatencyCollector{
+		tracker: tracker,
+
+		analyzedSlot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, analyticsSubsystem, "analyzed_slot"),
+			"The latest slot whose block latencies were analyzed.",
+			nil, nil,
+		),
+		acceptanceRate: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, analyticsSubsystem, "acceptance_rate"),
+			"The fraction of the observed blocks of the latest analyzed slot that were accepted.",
+			nil, nil,
+		),
+		acceptanceLatency: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, analyticsSubsystem, "acceptance_latency_seconds"),
+			"The durations from the observation to the acceptance of the blocks of the latest analyzed slot.",
+			[]string{"quantile"}, nil,
+		),
+		confirmationLatency: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, analyticsSubsystem, "confirmation_latency_seconds"),
+			"The durations from the observation to the confirmation of the blocks of the latest analyzed slot.",
+			[]string{"quantile"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *LatencyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.analyzedSlot
+	ch <- c.acceptanceRate
+	ch <- c.acceptanceLatency
+	ch <- c.confirmationLatency
+}
+
+// Collect implements prometheus.Collector.
+func (c *LatencyCollector) Collect(ch chan<- prometheus.Metric) {
+	slotLatency := c.tracker.LatestSlotLatency()
+	if slotLatency == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.analyzedSlot, prometheus.GaugeValue, float64(slotLatency.Slot))
+	ch <- prometheus.MustNewConstMetric(c.acceptanceRate, prometheus.GaugeValue, slotLatency.AcceptanceRate)
+
+	collectPercentiles := func(desc *prometheus.Desc, percentiles analytics.LatencyPercentiles) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, percentiles.P50.Seconds(), "0.5")
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, percentiles.P90.Seconds(), "0.9")
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, percentiles.P99.Seconds(), "0.99")
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, percentiles.Max.Seconds(), "1")
+	}
+	collectPercentiles(c.acceptanceLatency, slotLatency.AcceptanceLatency)
+	collectPercentiles(c.confirmationLatency, slotLatency.ConfirmationLatency)
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"net/http"
+
+	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewRegistry creates a new registry that contains the gRPC client metrics of the INX connection,
+// the gRPC server metrics of the API of the extension (if it is served with a grpcserver.Server),
+// the metrics of the given bridge collector, the metrics of the Go runtime and the process (e.g. memory usage)
+// and the metrics of the given additional collectors.
+func NewRegistry(bridgeCollector *BridgeCollector, additionalCollectors ...prometheus.Collector) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		grpcprometheus.DefaultClientMetrics,
+		grpcprometheus.DefaultServerMetrics,
+		bridgeCollector,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	registry.MustRegister(additionalCollectors...)
+
+	return registry
+}
+
+// Handler returns an HTTP handler that serves the metrics of the given registry in the Prometheus text format.
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	})
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/inx-app/pkg/analytics"
+)
+
+// ThroughputCollector collects the rates and totals of a ThroughputTracker.
+type ThroughputCollector struct {
+	tracker *analytics.ThroughputTracker
+
+	rate  *prometheus.Desc
+	total *prometheus.Desc
+}
+
+var _ prometheus.Collector = &ThroughputCollector{}
+
+// NewThroughputCollector creates a new ThroughputCollector.
+func NewThroughputCollector(tracker *analytics.ThroughputTracker) *ThroughputCollector {
+	return &ThroughputCollector{
+		tracker: tracker,
+
+		rate: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, analyticsSubsystem, "per_second"),
+			"The rate of blocks, accepted transactions and conflicting transactions over the sliding window.",
+			[]string{"type"}, nil,
+		),
+		total: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, analyticsSubsystem, "total"),
+			"The total amount of blocks, accepted transactions and conflicting transactions.",
+			[]string{"type"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ThroughputCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rate
+	ch <- c.total
+}
+
+// Collect implements prometheus.Collector.
+func (c *ThroughputCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.tracker.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.rate, prometheus.GaugeValue, stats.BlocksPerSecond, "blocks")
+	ch <- prometheus.MustNewConstMetric(c.rate, prometheus.GaugeValue, stats.TransactionsPerSecond, "transactions")
+	ch <- prometheus.MustNewConstMetric(c.rate, prometheus.GaugeValue, stats.ConflictingTransactionsPerSecond, "conflicting_transactions")
+
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.CounterValue, float64(stats.BlocksTotal), "blocks")
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.CounterValue, float64(stats.TransactionsTotal), "transactions")
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.CounterValue, float64(stats.ConflictingTransactionsTotal), "conflicting_transactions")
+}
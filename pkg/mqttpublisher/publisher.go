@@ -0,0 +1,333 @@
+package mqttpublisher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultPublishTimeout is the default timeout for a single publish to the broker.
+	DefaultPublishTimeout = 5 * time.Second
+	// DefaultCommitmentQueueSize is the default amount of commitments that are queued until they are published.
+	DefaultCommitmentQueueSize = 100
+)
+
+var (
+	// ErrPublishTimeout is returned if the broker did not acknowledge a message in time.
+	ErrPublishTimeout = ierrors.New("publish timeout")
+)
+
+// Publisher republishes selected streams of the node bridge to an MQTT broker
+// using the topic scheme of the IOTA event API.
+type Publisher struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	client     mqtt.Client
+
+	topicPrefix       string
+	qos               byte
+	retainCommitments bool
+	publishTimeout    time.Duration
+
+	commitmentQueueSize int
+
+	publishBlocks         bool
+	publishAcceptedBlocks bool
+	publishCommitments    bool
+	publishLedgerUpdates  bool
+}
+
+// WithTopicPrefix sets a prefix that is added to all topics (e.g. "iota/").
+func WithTopicPrefix(topicPrefix string) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.topicPrefix = topicPrefix
+	}
+}
+
+// WithQoS sets the MQTT quality of service level of the published messages.
+func WithQoS(qos byte) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.qos = qos
+	}
+}
+
+// WithRetainCommitments sets whether the commitment messages are retained by the broker,
+// so new subscribers immediately receive the latest commitments.
+func WithRetainCommitments(retain bool) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.retainCommitments = retain
+	}
+}
+
+// WithPublishTimeout sets the timeout for a single publish to the broker.
+func WithPublishTimeout(timeout time.Duration) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.publishTimeout = timeout
+	}
+}
+
+// WithCommitmentQueueSize sets the amount of commitments that are queued until they are published.
+// Commitments are dropped if the queue is full, so a slow broker doesn't block the events of the node bridge.
+func WithCommitmentQueueSize(size int) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.commitmentQueueSize = size
+	}
+}
+
+// WithBlocks sets whether all incoming blocks are published.
+func WithBlocks(enabled bool) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.publishBlocks = enabled
+	}
+}
+
+// WithAcceptedBlocks sets whether the metadata of accepted and confirmed blocks is published.
+func WithAcceptedBlocks(enabled bool) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.publishAcceptedBlocks = enabled
+	}
+}
+
+// WithCommitments sets whether the latest and finalized commitments are published.
+func WithCommitments(enabled bool) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.publishCommitments = enabled
+	}
+}
+
+// WithLedgerUpdates sets whether the created and consumed outputs of the ledger updates are published.
+func WithLedgerUpdates(enabled bool) options.Option[Publisher] {
+	return func(p *Publisher) {
+		p.publishLedgerUpdates = enabled
+	}
+}
+
+// New creates a new Publisher that publishes to the given connected MQTT client.
+// By default, accepted blocks, commitments and ledger updates are published.
+func New(logger log.Logger, nodeBridge nodebridge.NodeBridge, client mqtt.Client, opts ...options.Option[Publisher]) *Publisher {
+	return options.Apply(&Publisher{
+		Logger:                logger,
+		nodeBridge:            nodeBridge,
+		client:                client,
+		qos:                   0,
+		retainCommitments:     true,
+		publishTimeout:        DefaultPublishTimeout,
+		commitmentQueueSize:   DefaultCommitmentQueueSize,
+		publishBlocks:         false,
+		publishAcceptedBlocks: true,
+		publishCommitments:    true,
+		publishLedgerUpdates:  true,
+	}, opts)
+}
+
+// Run publishes the selected streams until the given context is done or one of the streams fails.
+func (p *Publisher) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errChan := make(chan error, 4)
+
+	runStream := func(name string, listen func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := listen(ctx); err != nil && ctx.Err() == nil {
+				errChan <- ierrors.Wrapf(err, "failed to publish %s", name)
+				cancel()
+			}
+		}()
+	}
+
+	if p.publishBlocks {
+		runStream("blocks", p.listenToBlocks)
+	}
+	if p.publishAcceptedBlocks {
+		runStream("accepted blocks", p.listenToAcceptedBlocks)
+	}
+	if p.publishLedgerUpdates {
+		runStream("ledger updates", p.listenToLedgerUpdates)
+	}
+	if p.publishCommitments {
+		commitments := make(chan *queuedCommitment, max(p.commitmentQueueSize, 1))
+
+		unhook := p.hookCommitments(commitments)
+		defer unhook()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			p.publishQueuedCommitments(ctx, commitments)
+		}()
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+
+	select {
+	case err := <-errChan:
+		return err
+	default:
+		return nil
+	}
+}
+
+// queuedCommitment is a commitment that waits to be published to the given topic.
+type queuedCommitment struct {
+	topic      string
+	commitment *nodebridge.Commitment
+}
+
+// hookCommitments queues the latest and finalized commitments, so the events of the node bridge
+// are not blocked while the broker acknowledges the messages.
+func (p *Publisher) hookCommitments(commitments chan<- *queuedCommitment) (unhook func()) {
+	enqueue := func(topic string, commitment *nodebridge.Commitment) {
+		select {
+		case commitments <- &queuedCommitment{topic: topic, commitment: commitment}:
+		default:
+			p.LogWarnf("dropped commitment %s, the commitment queue is full", commitment.CommitmentID)
+		}
+	}
+
+	latestHook := p.nodeBridge.Events().LatestCommitmentChanged.Hook(func(commitment *nodebridge.Commitment) {
+		enqueue(api.EventAPITopicCommitmentsLatest, commitment)
+	})
+	finalizedHook := p.nodeBridge.Events().LatestFinalizedCommitmentChanged.Hook(func(commitment *nodebridge.Commitment) {
+		enqueue(api.EventAPITopicCommitmentsFinalized, commitment)
+	})
+
+	return func() {
+		latestHook.Unhook()
+		finalizedHook.Unhook()
+	}
+}
+
+// publishQueuedCommitments publishes the queued commitments until the given context is done.
+func (p *Publisher) publishQueuedCommitments(ctx context.Context, commitments <-chan *queuedCommitment) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued := <-commitments:
+			p.publishCommitment(queued.topic, queued.commitment)
+		}
+	}
+}
+
+func (p *Publisher) publishCommitment(topic string, commitment *nodebridge.Commitment) {
+	apiForSlot := p.nodeBridge.APIProvider().APIForSlot(commitment.CommitmentID.Slot())
+
+	if err := p.publishJSON(apiForSlot, topic, commitment.Commitment, p.retainCommitments); err != nil {
+		p.LogWarnf("failed to publish commitment %s: %s", commitment.CommitmentID, err)
+		return
+	}
+
+	if commitment.RawCommitmentData != nil {
+		if err := p.publish(topic+api.EventAPITopicSuffixRaw, commitment.RawCommitmentData, p.retainCommitments); err != nil {
+			p.LogWarnf("failed to publish raw commitment %s: %s", commitment.CommitmentID, err)
+		}
+	}
+}
+
+func (p *Publisher) listenToBlocks(ctx context.Context) error {
+	return p.nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+		if err := p.publishJSON(block.API, api.EventAPITopicBlocks, block, false); err != nil {
+			return err
+		}
+
+		return p.publish(api.EventAPITopicBlocks+api.EventAPITopicSuffixRaw, rawData, false)
+	})
+}
+
+func (p *Publisher) listenToAcceptedBlocks(ctx context.Context) error {
+	return p.nodeBridge.ListenToBlockMetadata(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+		var topic string
+		switch blockMetadata.BlockState {
+		case api.BlockStateAccepted:
+			topic = api.EventAPITopicBlockMetadataAccepted
+		case api.BlockStateConfirmed:
+			topic = api.EventAPITopicBlockMetadataConfirmed
+		default:
+			return nil
+		}
+
+		apiForSlot := p.nodeBridge.APIProvider().APIForSlot(blockMetadata.BlockID.Slot())
+		if err := p.publishJSON(apiForSlot, topic, blockMetadata, false); err != nil {
+			return err
+		}
+
+		return p.publishJSON(apiForSlot, api.EndpointWithNamedParameterValue(api.EventAPITopicBlockMetadata, api.ParameterBlockID, blockMetadata.BlockID.ToHex()), blockMetadata, false)
+	})
+}
+
+func (p *Publisher) listenToLedgerUpdates(ctx context.Context) error {
+	return p.nodeBridge.ListenToLedgerUpdates(ctx, 0, 0, func(update *nodebridge.LedgerUpdate) error {
+		for _, output := range update.Consumed {
+			if err := p.publishOutput(update.API, output); err != nil {
+				return err
+			}
+		}
+		for _, output := range update.Created {
+			if err := p.publishOutput(update.API, output); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *Publisher) publishOutput(apiForSlot iotago.API, output *nodebridge.Output) error {
+	response := &api.OutputWithMetadataResponse{
+		Output:        output.Output,
+		OutputIDProof: output.OutputIDProof,
+		Metadata:      output.Metadata,
+	}
+
+	if err := p.publishJSON(apiForSlot, api.EndpointWithNamedParameterValue(api.EventAPITopicOutputs, api.ParameterOutputID, output.OutputID.ToHex()), response, false); err != nil {
+		return err
+	}
+
+	if addressUnlock := output.Output.UnlockConditionSet().Address(); addressUnlock != nil {
+		topic := api.EndpointWithNamedParameterValue(api.EventAPITopicOutputsByUnlockConditionAndAddress, api.ParameterCondition, string(api.EventAPIUnlockConditionAddress))
+		topic = api.EndpointWithNamedParameterValue(topic, api.ParameterAddress, addressUnlock.Address.Bech32(apiForSlot.ProtocolParameters().Bech32HRP()))
+
+		if err := p.publishJSON(apiForSlot, topic, response, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Publisher) publishJSON(apiForSlot iotago.API, topic string, obj any, retain bool) error {
+	payload, err := apiForSlot.JSONEncode(obj)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to encode payload for topic %s", topic)
+	}
+
+	return p.publish(topic, payload, retain)
+}
+
+func (p *Publisher) publish(topic string, payload []byte, retain bool) error {
+	token := p.client.Publish(p.topicPrefix+topic, p.qos, retain, payload)
+	if !token.WaitTimeout(p.publishTimeout) {
+		return ierrors.Wrapf(ErrPublishTimeout, "topic %s", topic)
+	}
+
+	return token.Error()
+}
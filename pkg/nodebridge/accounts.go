@@ -9,9 +9,13 @@ import (
 
 // ReadIsCandidate returns true if the given account is a candidate.
 func (n *nodeBridge) ReadIsCandidate(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error) {
+	if err := n.checkEpochPruned(n.apiProvider.APIForSlot(slot).TimeProvider().EpochFromSlot(slot)); err != nil {
+		return false, err
+	}
+
 	result, err := n.client.ReadIsCandidate(ctx, inx.NewAccountInfoRequest(id, slot))
 	if err != nil {
-		return false, err
+		return false, n.wrapINXError(err, "failed to read candidacy of account %s in slot %d", id.ToHex(), slot)
 	}
 
 	return result.GetValue(), nil
@@ -19,9 +23,13 @@ func (n *nodeBridge) ReadIsCandidate(ctx context.Context, id iotago.AccountID, s
 
 // ReadIsCommitteeMember returns true if the given account is a committee member.
 func (n *nodeBridge) ReadIsCommitteeMember(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error) {
+	if err := n.checkEpochPruned(n.apiProvider.APIForSlot(slot).TimeProvider().EpochFromSlot(slot)); err != nil {
+		return false, err
+	}
+
 	result, err := n.client.ReadIsCommitteeMember(ctx, inx.NewAccountInfoRequest(id, slot))
 	if err != nil {
-		return false, err
+		return false, n.wrapINXError(err, "failed to read committee membership of account %s in slot %d", id.ToHex(), slot)
 	}
 
 	return result.GetValue(), nil
@@ -29,9 +37,13 @@ func (n *nodeBridge) ReadIsCommitteeMember(ctx context.Context, id iotago.Accoun
 
 // ReadIsValidatorAccount returns true if the given account is a validator account.
 func (n *nodeBridge) ReadIsValidatorAccount(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error) {
+	if err := n.checkEpochPruned(n.apiProvider.APIForSlot(slot).TimeProvider().EpochFromSlot(slot)); err != nil {
+		return false, err
+	}
+
 	result, err := n.client.ReadIsValidatorAccount(ctx, inx.NewAccountInfoRequest(id, slot))
 	if err != nil {
-		return false, err
+		return false, n.wrapINXError(err, "failed to read validator status of account %s in slot %d", id.ToHex(), slot)
 	}
 
 	return result.GetValue(), nil
@@ -11,6 +11,10 @@ import (
 
 // RegisterAPIRoute registers the given API route.
 func (n *nodeBridge) RegisterAPIRoute(ctx context.Context, route string, bindAddress string, path string) error {
+	if err := n.checkCapability(CapabilityRegisterAPIRoute); err != nil {
+		return err
+	}
+
 	bindAddressParts := strings.Split(bindAddress, ":")
 	if len(bindAddressParts) != 2 {
 		return ierrors.Errorf("invalid address %s", bindAddress)
@@ -28,16 +32,26 @@ func (n *nodeBridge) RegisterAPIRoute(ctx context.Context, route string, bindAdd
 	}
 
 	_, err = n.client.RegisterAPIRoute(ctx, apiReq)
+	if err == nil {
+		n.trackRegisteredAPIRoute(route, true)
+	}
 
-	return err
+	return n.wrapINXError(n.trackCapability(CapabilityRegisterAPIRoute, err), "failed to register API route %s", route)
 }
 
 // UnregisterAPIRoute unregisters the given API route.
 func (n *nodeBridge) UnregisterAPIRoute(ctx context.Context, route string) error {
+	if err := n.checkCapability(CapabilityRegisterAPIRoute); err != nil {
+		return err
+	}
+
 	apiReq := &inx.APIRouteRequest{
 		Route: route,
 	}
 	_, err := n.client.UnregisterAPIRoute(ctx, apiReq)
+	if err == nil {
+		n.trackRegisteredAPIRoute(route, false)
+	}
 
-	return err
+	return n.wrapINXError(n.trackCapability(CapabilityRegisterAPIRoute, err), "failed to unregister API route %s", route)
 }
@@ -0,0 +1,93 @@
+package nodebridge
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrInvalidAttestationSignature is returned if the signature of an attestation is invalid.
+	ErrInvalidAttestationSignature = ierrors.New("invalid attestation signature")
+)
+
+// Attestations returns the attestations of the given slot, which are derived from the latest
+// accepted validation block of every issuer in the slot, ordered by issuer.
+// INX does not expose the attestations that were committed by the node, so the result may
+// differ from the committed attestations if the node did not consider all accepted validation blocks.
+func (n *nodeBridge) Attestations(ctx context.Context, slot iotago.SlotIndex) (iotago.Attestations, error) {
+	if err := n.checkSlotPruned(slot); err != nil {
+		return nil, err
+	}
+
+	stream, err := n.client.ReadAcceptedBlocks(ctx, &inx.SlotRequest{Slot: uint32(slot)})
+	if err != nil {
+		return nil, n.wrapSlotPrunedError(slot, n.wrapINXError(err, "failed to read accepted blocks of slot %d", slot))
+	}
+
+	latestAttestations := make(map[iotago.AccountID]*iotago.Attestation)
+	if err := ListenToStream(ctx, stream.Recv, func(blockWithMetadata *inx.BlockWithMetadata) error {
+		block, err := blockWithMetadata.GetBlock().UnwrapBlock(n.apiProvider)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to unwrap block")
+		}
+
+		if _, isValidationBlock := block.Body.(*iotago.ValidationBlockBody); !isValidationBlock {
+			return nil
+		}
+
+		attestation := iotago.NewAttestation(block.API, block)
+		if latest, exists := latestAttestations[block.Header.IssuerID]; !exists || attestation.Compare(latest) > 0 {
+			latestAttestations[block.Header.IssuerID] = attestation
+		}
+
+		return nil
+	}); err != nil {
+		return nil, n.wrapINXError(err, "failed to read accepted blocks of slot %d", slot)
+	}
+
+	attestations := make(iotago.Attestations, 0, len(latestAttestations))
+	for _, attestation := range latestAttestations {
+		attestations = append(attestations, attestation)
+	}
+	slices.SortFunc(attestations, func(a *iotago.Attestation, b *iotago.Attestation) int {
+		return bytes.Compare(a.Header.IssuerID[:], b.Header.IssuerID[:])
+	})
+
+	return attestations, nil
+}
+
+// AttestationsByCommitmentID returns the attestations of the slot of the given commitment,
+// after checking that the commitment is known to the node.
+func (n *nodeBridge) AttestationsByCommitmentID(ctx context.Context, commitmentID iotago.CommitmentID) (iotago.Attestations, error) {
+	commitment, err := n.CommitmentByID(ctx, commitmentID)
+	if err != nil {
+		return nil, err
+	}
+	if commitment == nil {
+		return nil, ierrors.Wrapf(ErrCommitmentMissing, "commitment %s", commitmentID)
+	}
+
+	return n.Attestations(ctx, commitmentID.Slot())
+}
+
+// VerifyAttestations verifies the signatures of the given attestations.
+// It does not check if the signing keys are block issuer keys of the issuing accounts.
+func VerifyAttestations(attestations iotago.Attestations) error {
+	for _, attestation := range attestations {
+		valid, err := attestation.VerifySignature()
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to verify signature of attestation of issuer %s", attestation.Header.IssuerID)
+		}
+
+		if !valid {
+			return ierrors.Wrapf(ErrInvalidAttestationSignature, "issuer %s", attestation.Header.IssuerID)
+		}
+	}
+
+	return nil
+}
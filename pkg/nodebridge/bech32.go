@@ -0,0 +1,33 @@
+package nodebridge
+
+import (
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrBech32HRPMismatch is returned if a bech32 address does not belong to the network of the node.
+	ErrBech32HRPMismatch = ierrors.New("bech32 address does not match the network of the node")
+)
+
+// Bech32Address encodes the given address as bech32 string with the HRP of the network of the node.
+func (n *nodeBridge) Bech32Address(address iotago.Address) string {
+	return address.Bech32(n.Bech32HRP())
+}
+
+// ParseBech32Address parses the given bech32 address.
+// Returns ErrBech32HRPMismatch if the HRP of the address is not the one of the network of the node.
+func (n *nodeBridge) ParseBech32Address(bech32Address string) (iotago.Address, error) {
+	hrp, address, err := iotago.ParseBech32(strings.ToLower(bech32Address))
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "invalid bech32 address %s", bech32Address)
+	}
+
+	if expectedHRP := n.Bech32HRP(); hrp != expectedHRP {
+		return nil, ierrors.Wrapf(ErrBech32HRPMismatch, "address %s, expected prefix: %s", bech32Address, expectedHRP)
+	}
+
+	return address, nil
+}
@@ -0,0 +1,123 @@
+package nodebridge
+
+import (
+	"bytes"
+	"context"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// BlockFilter returns true if the given block should be passed to the consumer.
+type BlockFilter func(block *iotago.Block) bool
+
+// BlockFilterPayloadTypes passes basic blocks that contain a payload of one of the given types.
+func BlockFilterPayloadTypes(payloadTypes ...iotago.PayloadType) BlockFilter {
+	return func(block *iotago.Block) bool {
+		payload := BlockPayload(block)
+		if payload == nil {
+			return false
+		}
+
+		for _, payloadType := range payloadTypes {
+			if payload.PayloadType() == payloadType {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// BlockFilterTransactions passes basic blocks that contain a signed transaction.
+func BlockFilterTransactions() BlockFilter {
+	return BlockFilterPayloadTypes(iotago.PayloadSignedTransaction)
+}
+
+// BlockFilterValidationBlocks passes validation blocks.
+func BlockFilterValidationBlocks() BlockFilter {
+	return func(block *iotago.Block) bool {
+		_, isValidationBlock := block.Body.(*iotago.ValidationBlockBody)
+
+		return isValidationBlock
+	}
+}
+
+// BlockFilterTaggedDataTagPrefix passes basic blocks that contain tagged data with a tag that starts with the given prefix,
+// either as the payload of the block or as the payload of a signed transaction.
+func BlockFilterTaggedDataTagPrefix(tagPrefix []byte) BlockFilter {
+	return func(block *iotago.Block) bool {
+		taggedData := BlockTaggedData(block)
+
+		return taggedData != nil && bytes.HasPrefix(taggedData.Tag, tagPrefix)
+	}
+}
+
+// BlockFilterAny passes blocks that pass any of the given filters.
+func BlockFilterAny(filters ...BlockFilter) BlockFilter {
+	return func(block *iotago.Block) bool {
+		for _, filter := range filters {
+			if filter(block) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// BlockFilterAll passes blocks that pass all of the given filters.
+func BlockFilterAll(filters ...BlockFilter) BlockFilter {
+	return func(block *iotago.Block) bool {
+		for _, filter := range filters {
+			if !filter(block) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// BlockPayload returns the payload of the given block, or nil if the block is not a basic block or has no payload.
+func BlockPayload(block *iotago.Block) iotago.ApplicationPayload {
+	basicBlockBody, isBasicBlock := block.Body.(*iotago.BasicBlockBody)
+	if !isBasicBlock {
+		return nil
+	}
+
+	return basicBlockBody.Payload
+}
+
+// BlockTaggedData returns the tagged data of the given block, either the payload of the block
+// or the payload of its signed transaction. Returns nil if the block contains no tagged data.
+func BlockTaggedData(block *iotago.Block) *iotago.TaggedData {
+	switch payload := BlockPayload(block).(type) {
+	case *iotago.TaggedData:
+		return payload
+	case *iotago.SignedTransaction:
+		if payload.Transaction == nil {
+			return nil
+		}
+
+		taggedData, isTaggedData := payload.Transaction.Payload.(*iotago.TaggedData)
+		if !isTaggedData {
+			return nil
+		}
+
+		return taggedData
+	default:
+		return nil
+	}
+}
+
+// ListenToFilteredBlocks listens to blocks and only passes the blocks to the consumer that pass the given filter.
+// INX has no server-side filters for blocks, so all blocks are transferred and filtered after deserialization.
+func (n *nodeBridge) ListenToFilteredBlocks(ctx context.Context, filter BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return n.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+		if !filter(block) {
+			return nil
+		}
+
+		return consumer(block, rawData)
+	})
+}
@@ -3,17 +3,187 @@ package nodebridge
 import (
 	"context"
 
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
 	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
 	"github.com/iotaledger/iota.go/v4/api"
 )
 
-// BlockIssuance requests the necessary data to issue a block.
-func (n *nodeBridge) BlockIssuance(ctx context.Context, maxParentCount uint32) (*api.IssuanceBlockHeaderResponse, error) {
-	resp, err := n.client.ReadBlockIssuance(ctx, &inx.BlockIssuanceRequest{MaxStrongParentsCount: maxParentCount, MaxShallowLikeParentsCount: maxParentCount, MaxWeakParentsCount: maxParentCount})
+var (
+	// ErrInvalidParentCount is returned if the requested amount of parents exceeds the limits of the protocol.
+	ErrInvalidParentCount = ierrors.New("invalid parent count")
+)
+
+// RequestTipsOptions define the tips that are requested by RequestTips.
+type RequestTipsOptions struct {
+	strongParentsCount      uint32
+	weakParentsCount        uint32
+	shallowLikeParentsCount uint32
+	validationBlock         bool
+	deduplicate             bool
+}
+
+// WithStrongParentsCount sets the maximum amount of strong parents.
+func WithStrongParentsCount(count uint32) options.Option[RequestTipsOptions] {
+	return func(o *RequestTipsOptions) {
+		o.strongParentsCount = count
+	}
+}
+
+// WithWeakParentsCount sets the maximum amount of weak parents (semi-lazy tips).
+// A count of zero disallows weak parents.
+func WithWeakParentsCount(count uint32) options.Option[RequestTipsOptions] {
+	return func(o *RequestTipsOptions) {
+		o.weakParentsCount = count
+	}
+}
+
+// WithShallowLikeParentsCount sets the maximum amount of shallow like parents.
+// A count of zero disallows shallow like parents.
+func WithShallowLikeParentsCount(count uint32) options.Option[RequestTipsOptions] {
+	return func(o *RequestTipsOptions) {
+		o.shallowLikeParentsCount = count
+	}
+}
+
+// WithOnlyStrongParents only requests strong parents.
+func WithOnlyStrongParents() options.Option[RequestTipsOptions] {
+	return func(o *RequestTipsOptions) {
+		o.weakParentsCount = 0
+		o.shallowLikeParentsCount = 0
+	}
+}
+
+// WithValidationBlockParents sets whether the tips are requested for a validation block,
+// which allows the higher parent limits of validation blocks.
+func WithValidationBlockParents(enabled bool) options.Option[RequestTipsOptions] {
+	return func(o *RequestTipsOptions) {
+		o.validationBlock = enabled
+	}
+}
+
+// WithParentsDeduplication removes duplicates within the parent sets and
+// removes weak parents that are also contained in the strong or shallow like parents.
+func WithParentsDeduplication(enabled bool) options.Option[RequestTipsOptions] {
+	return func(o *RequestTipsOptions) {
+		o.deduplicate = enabled
+	}
+}
+
+// RequestTips requests tips from the node that can be used as parents for a new block.
+// By default, the maximum amount of parents is requested for every parent type and the result is deduplicated.
+// Returns ErrInvalidParentCount if the parent counts exceed the limits of the protocol.
+func (n *nodeBridge) RequestTips(ctx context.Context, opts ...options.Option[RequestTipsOptions]) (*api.IssuanceBlockHeaderResponse, error) {
+	tipsOptions := options.Apply(&RequestTipsOptions{
+		strongParentsCount:      iotago.BasicBlockMaxParents,
+		weakParentsCount:        iotago.BasicBlockMaxParents,
+		shallowLikeParentsCount: iotago.BasicBlockMaxParents,
+		deduplicate:             true,
+	}, opts)
+
+	parents := BlockIssuanceParents{
+		MaxStrongParents:      tipsOptions.strongParentsCount,
+		MaxWeakParents:        tipsOptions.weakParentsCount,
+		MaxShallowLikeParents: tipsOptions.shallowLikeParentsCount,
+		ForValidationBlock:    tipsOptions.validationBlock,
+	}
+	if err := parents.validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := n.readBlockIssuance(ctx, parents.MaxStrongParents, parents.MaxWeakParents, parents.MaxShallowLikeParents)
 	if err != nil {
 		return nil, err
 	}
 
+	if tipsOptions.deduplicate {
+		deduplicateParents(response)
+	}
+
+	return response, nil
+}
+
+// BlockIssuanceParents define the maximum amount of parents per parent type that are requested by BlockIssuance.
+type BlockIssuanceParents struct {
+	// MaxStrongParents is the maximum amount of strong parents, at least one strong parent is required.
+	MaxStrongParents uint32
+	// MaxWeakParents is the maximum amount of weak parents. Zero disallows weak parents.
+	MaxWeakParents uint32
+	// MaxShallowLikeParents is the maximum amount of shallow like parents. Zero disallows shallow like parents.
+	MaxShallowLikeParents uint32
+	// ForValidationBlock allows the parent limits of validation blocks instead of the limits of basic blocks.
+	ForValidationBlock bool
+}
+
+// UniformBlockIssuanceParents requests the same maximum amount of parents for every parent type.
+func UniformBlockIssuanceParents(maxParentCount uint32) BlockIssuanceParents {
+	return BlockIssuanceParents{
+		MaxStrongParents:      maxParentCount,
+		MaxWeakParents:        maxParentCount,
+		MaxShallowLikeParents: maxParentCount,
+	}
+}
+
+// validate checks the parent counts against the limits of the protocol for the block type.
+func (p BlockIssuanceParents) validate() error {
+	if p.MaxStrongParents < 1 {
+		return ierrors.Wrap(ErrInvalidParentCount, "at least one strong parent is required")
+	}
+
+	maxParents := uint32(iotago.BasicBlockMaxParents)
+	if p.ForValidationBlock {
+		maxParents = iotago.ValidationBlockMaxParents
+	}
+
+	if p.MaxStrongParents > maxParents || p.MaxWeakParents > maxParents || p.MaxShallowLikeParents > maxParents {
+		return ierrors.Wrapf(ErrInvalidParentCount, "at most %d parents per parent type are allowed, got %d strong, %d weak and %d shallow like parents",
+			maxParents, p.MaxStrongParents, p.MaxWeakParents, p.MaxShallowLikeParents)
+	}
+
+	return nil
+}
+
+// BlockIssuance requests the necessary data to issue a block with the given maximum amount of parents per parent type.
+// Returns ErrInvalidParentCount if the parent counts exceed the limits of the protocol.
+func (n *nodeBridge) BlockIssuance(ctx context.Context, parents BlockIssuanceParents) (*api.IssuanceBlockHeaderResponse, error) {
+	if err := parents.validate(); err != nil {
+		return nil, err
+	}
+
+	return n.readBlockIssuance(ctx, parents.MaxStrongParents, parents.MaxWeakParents, parents.MaxShallowLikeParents)
+}
+
+func (n *nodeBridge) readBlockIssuance(ctx context.Context, maxStrongParentsCount uint32, maxWeakParentsCount uint32, maxShallowLikeParentsCount uint32) (*api.IssuanceBlockHeaderResponse, error) {
+	if n.blockIssuanceCache == nil {
+		return n.readBlockIssuanceFromNode(ctx, maxStrongParentsCount, maxWeakParentsCount, maxShallowLikeParentsCount)
+	}
+
+	key := blockIssuanceCacheKey{
+		maxStrongParentsCount:      maxStrongParentsCount,
+		maxWeakParentsCount:        maxWeakParentsCount,
+		maxShallowLikeParentsCount: maxShallowLikeParentsCount,
+	}
+
+	if response, exists := n.blockIssuanceCache.get(key); exists {
+		return response, nil
+	}
+
+	response, err := n.readBlockIssuanceFromNode(ctx, maxStrongParentsCount, maxWeakParentsCount, maxShallowLikeParentsCount)
+	if err != nil {
+		return nil, err
+	}
+	n.blockIssuanceCache.set(key, response)
+
+	return response, nil
+}
+
+func (n *nodeBridge) readBlockIssuanceFromNode(ctx context.Context, maxStrongParentsCount uint32, maxWeakParentsCount uint32, maxShallowLikeParentsCount uint32) (*api.IssuanceBlockHeaderResponse, error) {
+	resp, err := n.client.ReadBlockIssuance(ctx, &inx.BlockIssuanceRequest{MaxStrongParentsCount: maxStrongParentsCount, MaxShallowLikeParentsCount: maxShallowLikeParentsCount, MaxWeakParentsCount: maxWeakParentsCount})
+	if err != nil {
+		return nil, n.wrapINXError(err, "failed to read block issuance")
+	}
+
 	latestCommitment, err := resp.UnwrapLatestCommitment(n.APIProvider().CommittedAPI())
 	if err != nil {
 		return nil, err
@@ -28,3 +198,26 @@ func (n *nodeBridge) BlockIssuance(ctx context.Context, maxParentCount uint32) (
 		LatestCommitment:             latestCommitment,
 	}, nil
 }
+
+// deduplicateParents removes duplicates within the parent sets and removes weak parents
+// that are also contained in the strong or shallow like parents, because they have to be disjoint.
+func deduplicateParents(response *api.IssuanceBlockHeaderResponse) {
+	response.StrongParents = response.StrongParents.RemoveDupsAndSort()
+	response.ShallowLikeParents = response.ShallowLikeParents.RemoveDupsAndSort()
+
+	otherParents := make(map[iotago.BlockID]struct{}, len(response.StrongParents)+len(response.ShallowLikeParents))
+	for _, parent := range response.StrongParents {
+		otherParents[parent] = struct{}{}
+	}
+	for _, parent := range response.ShallowLikeParents {
+		otherParents[parent] = struct{}{}
+	}
+
+	weakParents := make(iotago.BlockIDs, 0, len(response.WeakParents))
+	for _, parent := range response.WeakParents.RemoveDupsAndSort() {
+		if _, exists := otherParents[parent]; !exists {
+			weakParents = append(weakParents, parent)
+		}
+	}
+	response.WeakParents = weakParents
+}
@@ -0,0 +1,102 @@
+package nodebridge
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// WithBlockIssuanceCache caches the responses of BlockIssuance and RequestTips for the given duration,
+// so issuers that submit bursts of blocks don't read the block issuance from the node for every single block.
+// The cache is invalidated whenever the latest commitment of the node changes.
+// If ttl is zero, the responses are not cached.
+func WithBlockIssuanceCache(ttl time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		if ttl <= 0 {
+			n.blockIssuanceCache = nil
+
+			return
+		}
+
+		n.blockIssuanceCache = &blockIssuanceCache{
+			ttl:     ttl,
+			entries: make(map[blockIssuanceCacheKey]*blockIssuanceCacheEntry),
+		}
+	}
+}
+
+// InvalidateBlockIssuanceCache removes all cached block issuance responses.
+func (n *nodeBridge) InvalidateBlockIssuanceCache() {
+	if n.blockIssuanceCache == nil {
+		return
+	}
+
+	n.blockIssuanceCache.invalidate()
+}
+
+type blockIssuanceCacheKey struct {
+	maxStrongParentsCount      uint32
+	maxWeakParentsCount        uint32
+	maxShallowLikeParentsCount uint32
+}
+
+type blockIssuanceCacheEntry struct {
+	response *api.IssuanceBlockHeaderResponse
+	expires  time.Time
+}
+
+type blockIssuanceCache struct {
+	ttl time.Duration
+
+	mutex   sync.Mutex
+	entries map[blockIssuanceCacheKey]*blockIssuanceCacheEntry
+}
+
+// get returns a copy of the cached response for the given key, so callers can modify it.
+func (c *blockIssuanceCache) get(key blockIssuanceCacheKey) (*api.IssuanceBlockHeaderResponse, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	return cloneBlockIssuance(entry.response), true
+}
+
+// set stores a copy of the given response, so callers can modify it.
+func (c *blockIssuanceCache) set(key blockIssuanceCacheKey, response *api.IssuanceBlockHeaderResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = &blockIssuanceCacheEntry{
+		response: cloneBlockIssuance(response),
+		expires:  time.Now().Add(c.ttl),
+	}
+}
+
+func (c *blockIssuanceCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	clear(c.entries)
+}
+
+func cloneBlockIssuance(response *api.IssuanceBlockHeaderResponse) *api.IssuanceBlockHeaderResponse {
+	cloned := *response
+	cloned.StrongParents = slices.Clone(response.StrongParents)
+	cloned.WeakParents = slices.Clone(response.WeakParents)
+	cloned.ShallowLikeParents = slices.Clone(response.ShallowLikeParents)
+
+	return &cloned
+}
@@ -0,0 +1,88 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// DefaultBlockMetadataStates are the block states of the transitions most consumers are interested in.
+var DefaultBlockMetadataStates = []api.BlockState{
+	api.BlockStateAccepted,
+	api.BlockStateConfirmed,
+	api.BlockStateDropped,
+}
+
+// ListenToFilteredBlockMetadata listens to block metadata changes and only passes the changes
+// to one of the given states to the consumer, e.g. DefaultBlockMetadataStates.
+func (n *nodeBridge) ListenToFilteredBlockMetadata(ctx context.Context, states []api.BlockState, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	filter := make(map[api.BlockState]struct{}, len(states))
+	for _, state := range states {
+		filter[state] = struct{}{}
+	}
+
+	return n.ListenToBlockMetadata(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+		if _, passes := filter[blockMetadata.BlockState]; !passes {
+			return nil
+		}
+
+		return consumer(blockMetadata)
+	})
+}
+
+// BlockMetadataEvents are triggered per state of the block metadata changes.
+type BlockMetadataEvents struct {
+	// BlockPending is triggered if a block was booked but not yet accepted.
+	BlockPending *event.Event1[*api.BlockMetadataResponse]
+	// BlockAccepted is triggered if a block was accepted.
+	BlockAccepted *event.Event1[*api.BlockMetadataResponse]
+	// BlockConfirmed is triggered if a block was confirmed.
+	BlockConfirmed *event.Event1[*api.BlockMetadataResponse]
+	// BlockFinalized is triggered if the slot of a block was finalized.
+	BlockFinalized *event.Event1[*api.BlockMetadataResponse]
+	// BlockDropped is triggered if a block was dropped, e.g. due to congestion.
+	BlockDropped *event.Event1[*api.BlockMetadataResponse]
+	// BlockOrphaned is triggered if a block was orphaned.
+	BlockOrphaned *event.Event1[*api.BlockMetadataResponse]
+}
+
+// NewBlockMetadataEvents creates new BlockMetadataEvents.
+func NewBlockMetadataEvents() *BlockMetadataEvents {
+	return &BlockMetadataEvents{
+		BlockPending:   event.New1[*api.BlockMetadataResponse](),
+		BlockAccepted:  event.New1[*api.BlockMetadataResponse](),
+		BlockConfirmed: event.New1[*api.BlockMetadataResponse](),
+		BlockFinalized: event.New1[*api.BlockMetadataResponse](),
+		BlockDropped:   event.New1[*api.BlockMetadataResponse](),
+		BlockOrphaned:  event.New1[*api.BlockMetadataResponse](),
+	}
+}
+
+// Trigger triggers the event of the state of the given block metadata.
+func (e *BlockMetadataEvents) Trigger(blockMetadata *api.BlockMetadataResponse) {
+	switch blockMetadata.BlockState {
+	case api.BlockStatePending:
+		e.BlockPending.Trigger(blockMetadata)
+	case api.BlockStateAccepted:
+		e.BlockAccepted.Trigger(blockMetadata)
+	case api.BlockStateConfirmed:
+		e.BlockConfirmed.Trigger(blockMetadata)
+	case api.BlockStateFinalized:
+		e.BlockFinalized.Trigger(blockMetadata)
+	case api.BlockStateDropped:
+		e.BlockDropped.Trigger(blockMetadata)
+	case api.BlockStateOrphaned:
+		e.BlockOrphaned.Trigger(blockMetadata)
+	}
+}
+
+// Listen listens to the block metadata changes of the given node bridge and triggers the events
+// until the given context is done.
+func (e *BlockMetadataEvents) Listen(ctx context.Context, nodeBridge NodeBridge) error {
+	return nodeBridge.ListenToBlockMetadata(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+		e.Trigger(blockMetadata)
+
+		return nil
+	})
+}
@@ -0,0 +1,117 @@
+package nodebridge
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// The fields of a block that are reported by a BlockValidationError.
+const (
+	BlockFieldBlock                   = "block"
+	BlockFieldProtocolVersion         = "header.protocolVersion"
+	BlockFieldNetworkID               = "header.networkId"
+	BlockFieldSlotCommitmentID        = "header.slotCommitmentId"
+	BlockFieldStrongParents           = "body.strongParents"
+	BlockFieldWeakParents             = "body.weakParents"
+	BlockFieldShallowLikeParents      = "body.shallowLikeParents"
+	BlockFieldPayload                 = "body.payload"
+	BlockFieldTransactionCreationSlot = "body.payload.transaction.creationSlot"
+	BlockFieldCommitmentInput         = "body.payload.transaction.contextInputs"
+	BlockFieldSignature               = "signature"
+)
+
+// BlockValidationError is returned if a block failed the local validation.
+// It names the field of the block that is invalid and matches ErrBlockInvalid and the reason of the failure,
+// which is an error of iota.go (e.g. iotago.ErrCommitmentTooOld) if available.
+type BlockValidationError struct {
+	// Field is the field of the block that is invalid, e.g. BlockFieldSlotCommitmentID.
+	Field string
+	// Err is the reason of the failure.
+	Err error
+}
+
+func newBlockValidationError(field string, err error) *BlockValidationError {
+	return &BlockValidationError{
+		Field: field,
+		Err:   err,
+	}
+}
+
+func (e *BlockValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid field %s: %s", ErrBlockInvalid, e.Field, e.Err)
+}
+
+func (e *BlockValidationError) Unwrap() []error {
+	return []error{ErrBlockInvalid, e.Err}
+}
+
+// blockFieldsByError maps the errors of the syntactic validation of iota.go to the field of the block they are about.
+var blockFieldsByError = []struct {
+	err   error
+	field string
+}{
+	{iotago.ErrBlockMaxSizeExceeded, BlockFieldBlock},
+	{iotago.ErrInvalidBlockVersion, BlockFieldProtocolVersion},
+	{iotago.ErrBlockNetworkIDInvalid, BlockFieldNetworkID},
+	{iotago.ErrWeakParentsInvalid, BlockFieldWeakParents},
+	{iotago.ErrCommitmentTooOld, BlockFieldSlotCommitmentID},
+	{iotago.ErrCommitmentTooRecent, BlockFieldSlotCommitmentID},
+	{iotago.ErrTransactionCreationSlotTooRecent, BlockFieldTransactionCreationSlot},
+	{iotago.ErrCommitmentInputTooOld, BlockFieldCommitmentInput},
+	{iotago.ErrCommitmentInputTooRecent, BlockFieldCommitmentInput},
+	{iotago.ErrCommitmentInputNewerThanCommitment, BlockFieldCommitmentInput},
+}
+
+// blockFieldForError returns the field of the block the given validation error is about.
+func blockFieldForError(err error) string {
+	for _, entry := range blockFieldsByError {
+		if ierrors.Is(err, entry.err) {
+			return entry.field
+		}
+	}
+
+	return BlockFieldBlock
+}
+
+// WithLocalBlockValidation sets whether SubmitBlock validates blocks locally before they are sent to the node.
+// The block is checked for its size, protocol version, network ID, commitment age, parents, payload and signature,
+// and a BlockValidationError that names the invalid field is returned instead of the opaque error of the node.
+func WithLocalBlockValidation(enabled bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.localBlockValidation = enabled
+	}
+}
+
+// validateBlockParentIDs checks the amount of parents per parent type and that they are sorted and unique.
+func validateBlockParentIDs(block *iotago.Block) error {
+	maxParents := iotago.BasicBlockMaxParents
+	if _, isValidationBlock := block.Body.(*iotago.ValidationBlockBody); isValidationBlock {
+		maxParents = iotago.ValidationBlockMaxParents
+	}
+
+	for _, parents := range []struct {
+		field      string
+		parentIDs  iotago.BlockIDs
+		minParents int
+	}{
+		{BlockFieldStrongParents, block.Body.StrongParentIDs(), 1},
+		{BlockFieldWeakParents, block.Body.WeakParentIDs(), 0},
+		{BlockFieldShallowLikeParents, block.Body.ShallowLikeParentIDs(), 0},
+	} {
+		if len(parents.parentIDs) < parents.minParents || len(parents.parentIDs) > maxParents {
+			return newBlockValidationError(parents.field, ierrors.Errorf("expected between %d and %d parents, got %d", parents.minParents, maxParents, len(parents.parentIDs)))
+		}
+
+		for i := 1; i < len(parents.parentIDs); i++ {
+			if bytes.Compare(parents.parentIDs[i-1][:], parents.parentIDs[i][:]) >= 0 {
+				return newBlockValidationError(parents.field, ierrors.New("parents must be sorted lexically and unique"))
+			}
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,9 @@ package nodebridge
 import (
 	"context"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
 	"github.com/iotaledger/iota.go/v4/api"
@@ -12,7 +15,7 @@ import (
 func (n *nodeBridge) ActiveRootBlocks(ctx context.Context) (map[iotago.BlockID]iotago.CommitmentID, error) {
 	response, err := n.client.ReadActiveRootBlocks(ctx, &inx.NoParams{})
 	if err != nil {
-		return nil, err
+		return nil, n.wrapINXError(err, "failed to read active root blocks")
 	}
 
 	return response.Unwrap(), nil
@@ -20,6 +23,16 @@ func (n *nodeBridge) ActiveRootBlocks(ctx context.Context) (map[iotago.BlockID]i
 
 // SubmitBlock submits the given block.
 func (n *nodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+	if err := n.checkCapability(CapabilitySubmitBlock); err != nil {
+		return iotago.BlockID{}, err
+	}
+
+	if n.localBlockValidation {
+		if err := n.validateBlockSyntax(block); err != nil {
+			return iotago.BlockID{}, err
+		}
+	}
+
 	blk, err := inx.WrapBlock(block)
 	if err != nil {
 		return iotago.BlockID{}, err
@@ -27,7 +40,7 @@ func (n *nodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (iota
 
 	response, err := n.client.SubmitBlock(ctx, blk)
 	if err != nil {
-		return iotago.BlockID{}, err
+		return iotago.BlockID{}, n.wrapINXError(n.trackCapability(CapabilitySubmitBlock, err), "failed to submit block")
 	}
 
 	return response.Unwrap(), nil
@@ -35,36 +48,68 @@ func (n *nodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (iota
 
 // Block returns the block for the given block ID.
 func (n *nodeBridge) Block(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, error) {
-	inxBlock, err := n.client.ReadBlock(ctx, inx.NewBlockId(blockID))
-	if err != nil {
+	if err := n.checkSlotPruned(blockID.Slot()); err != nil {
 		return nil, err
 	}
 
-	return inxBlock.UnwrapBlock(n.apiProvider)
+	return coalesceRead(ctx, n, "block:"+blockID.ToHex(), func(ctx context.Context) (*iotago.Block, error) {
+		inxBlock, err := n.client.ReadBlock(ctx, inx.NewBlockId(blockID))
+		if err != nil {
+			return nil, n.wrapSlotPrunedError(blockID.Slot(), n.wrapINXError(err, "failed to read block %s", blockID))
+		}
+
+		return inxBlock.UnwrapBlock(n.apiProvider)
+	})
 }
 
 // BlockMetadata returns the block metadata for the given block ID.
 func (n *nodeBridge) BlockMetadata(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error) {
+	if err := n.checkSlotPruned(blockID.Slot()); err != nil {
+		return nil, err
+	}
+
+	return coalesceRead(ctx, n, "blockMetadata:"+blockID.ToHex(), func(ctx context.Context) (*api.BlockMetadataResponse, error) {
+		inxBlockMetadata, err := n.client.ReadBlockMetadata(ctx, inx.NewBlockId(blockID))
+		if err != nil {
+			return nil, n.wrapSlotPrunedError(blockID.Slot(), n.wrapINXError(err, "failed to read block metadata %s", blockID))
+		}
+
+		return inxBlockMetadata.Unwrap(), nil
+	})
+}
+
+// RawBlockMetadata returns the block metadata for the given block ID as received from the node,
+// together with its protobuf encoding, e.g. to forward it without re-serialization.
+func (n *nodeBridge) RawBlockMetadata(ctx context.Context, blockID iotago.BlockID) (*inx.BlockMetadata, []byte, error) {
+	if err := n.checkSlotPruned(blockID.Slot()); err != nil {
+		return nil, nil, err
+	}
+
 	inxBlockMetadata, err := n.client.ReadBlockMetadata(ctx, inx.NewBlockId(blockID))
 	if err != nil {
-		return nil, err
+		return nil, nil, n.wrapSlotPrunedError(blockID.Slot(), n.wrapINXError(err, "failed to read block metadata %s", blockID))
+	}
+
+	rawData, err := proto.Marshal(inxBlockMetadata)
+	if err != nil {
+		return nil, nil, ierrors.Wrapf(err, "failed to encode block metadata %s", blockID)
 	}
 
-	return inxBlockMetadata.Unwrap(), nil
+	return inxBlockMetadata, rawData, nil
 }
 
 // ListenToBlocks listens to blocks.
 func (n *nodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error {
 	stream, err := n.client.ListenToBlocks(ctx, &inx.NoParams{})
 	if err != nil {
-		return err
+		return n.wrapINXError(err, "failed to listen to blocks")
 	}
 
-	if err := ListenToStream(ctx, stream.Recv, func(block *inx.Block) error {
+	if err := listenToStream(ctx, n, StreamNameBlocks, stream.Recv, func(block *inx.Block) error {
 		return consumer(block.MustUnwrapBlock(n.apiProvider), block.GetBlock().GetData())
 	}); err != nil {
 		n.LogErrorf("ListenToBlocks failed: %s", err.Error())
-		return err
+		return n.wrapINXError(err, "ListenToBlocks failed")
 	}
 
 	return nil
@@ -74,14 +119,37 @@ func (n *nodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *io
 func (n *nodeBridge) ListenToBlockMetadata(ctx context.Context, consumer func(*api.BlockMetadataResponse) error) error {
 	stream, err := n.client.ListenToBlockMetadata(ctx, &inx.NoParams{})
 	if err != nil {
-		return err
+		return n.wrapINXError(err, "failed to listen to block metadata")
 	}
 
-	if err := ListenToStream(ctx, stream.Recv, func(inxBlockMetadata *inx.BlockMetadata) error {
+	if err := listenToStream(ctx, n, StreamNameBlockMetadata, stream.Recv, func(inxBlockMetadata *inx.BlockMetadata) error {
 		return consumer(inxBlockMetadata.Unwrap())
 	}); err != nil {
 		n.LogErrorf("ListenToBlockMetadata failed: %s", err.Error())
-		return err
+		return n.wrapINXError(err, "ListenToBlockMetadata failed")
+	}
+
+	return nil
+}
+
+// ListenToRawBlockMetadata listens to block metadata changes and passes the block metadata as received from the node,
+// together with its protobuf encoding.
+func (n *nodeBridge) ListenToRawBlockMetadata(ctx context.Context, consumer func(blockMetadata *inx.BlockMetadata, rawData []byte) error) error {
+	stream, err := n.client.ListenToBlockMetadata(ctx, &inx.NoParams{})
+	if err != nil {
+		return n.wrapINXError(err, "failed to listen to block metadata")
+	}
+
+	if err := listenToStream(ctx, n, StreamNameBlockMetadata, stream.Recv, func(inxBlockMetadata *inx.BlockMetadata) error {
+		rawData, err := proto.Marshal(inxBlockMetadata)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to encode block metadata")
+		}
+
+		return consumer(inxBlockMetadata, rawData)
+	}); err != nil {
+		n.LogErrorf("ListenToRawBlockMetadata failed: %s", err.Error())
+		return n.wrapINXError(err, "ListenToRawBlockMetadata failed")
 	}
 
 	return nil
@@ -0,0 +1,217 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// borrowedBlockPool reuses the messages of the block stream to reduce the GC pressure at high block rates.
+// The pooled messages keep their nested messages and buffers, which are overwritten by the borrowedBlockCodec.
+var borrowedBlockPool = sync.Pool{
+	New: func() any {
+		return &inx.Block{
+			BlockId: &inx.BlockId{},
+			Block:   &inx.RawBlock{},
+		}
+	},
+}
+
+const (
+	// the field numbers of the inx.Block message.
+	blockFieldBlockID protowire.Number = 1
+	blockFieldBlock   protowire.Number = 2
+
+	// the field numbers of the inx.BlockId and inx.RawBlock messages.
+	blockIDFieldID    protowire.Number = 1
+	rawBlockFieldData protowire.Number = 1
+)
+
+// borrowedBlockCodec is the gRPC codec of the borrowed block stream.
+// The default codec resets the message before unmarshaling, so the nested messages and the data buffer
+// would be allocated again for every block. This codec decodes the blocks into the existing nested messages
+// and buffers of the pooled message instead, and falls back to the default behavior for all other messages.
+type borrowedBlockCodec struct{}
+
+func (borrowedBlockCodec) Marshal(v any) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, ierrors.Errorf("failed to marshal, message is %T, want proto.Message", v)
+	}
+
+	return proto.Marshal(message)
+}
+
+func (borrowedBlockCodec) Unmarshal(data []byte, v any) error {
+	switch message := v.(type) {
+	case *inx.Block:
+		return unmarshalBorrowedBlock(data, message)
+	case proto.Message:
+		return proto.Unmarshal(data, message)
+	default:
+		return ierrors.Errorf("failed to unmarshal, message is %T, want proto.Message", v)
+	}
+}
+
+// Name returns the name of the default codec, so the content-subtype of the stream doesn't change.
+func (borrowedBlockCodec) Name() string {
+	return "proto"
+}
+
+// unmarshalBorrowedBlock decodes the serialized inx.Block into the given message.
+// The bytes of the block ID and the block data are copied into the existing buffers of the message.
+func unmarshalBorrowedBlock(data []byte, block *inx.Block) error {
+	if block.BlockId == nil {
+		block.BlockId = &inx.BlockId{}
+	}
+	if block.Block == nil {
+		block.Block = &inx.RawBlock{}
+	}
+	block.BlockId.Id = block.BlockId.Id[:0]
+	block.Block.Data = block.Block.Data[:0]
+
+	for len(data) > 0 {
+		fieldNumber, fieldType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if fieldType != protowire.BytesType || (fieldNumber != blockFieldBlockID && fieldNumber != blockFieldBlock) {
+			// skip unknown fields like proto.Unmarshal with DiscardUnknown
+			n = protowire.ConsumeFieldValue(fieldNumber, fieldType, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var err error
+		switch fieldNumber {
+		case blockFieldBlockID:
+			block.BlockId.Id, err = appendBytesField(block.BlockId.Id, value, blockIDFieldID)
+		case blockFieldBlock:
+			block.Block.Data, err = appendBytesField(block.Block.Data, value, rawBlockFieldData)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendBytesField copies the bytes field with the given number of the serialized message into the buffer.
+// Like proto.Unmarshal, the last occurrence of the field wins.
+func appendBytesField(buffer []byte, message []byte, fieldNumber protowire.Number) ([]byte, error) {
+	for len(message) > 0 {
+		number, fieldType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		if number != fieldNumber || fieldType != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(number, fieldType, message)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			message = message[n:]
+
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		buffer = append(buffer[:0], value...)
+	}
+
+	return buffer, nil
+}
+
+// BorrowedBlock is a pooled block of the block stream.
+// The consumer must call Release once it is done with the block, the block and its data must not be used afterwards.
+type BorrowedBlock struct {
+	// message is returned to the pool on release, while the BorrowedBlock itself is never reused,
+	// so a second Release can't return a message that was already borrowed again.
+	message  *inx.Block
+	released atomic.Bool
+}
+
+func borrowBlock() *BorrowedBlock {
+	//nolint:forcetypeassert // the pool only contains blocks
+	return &BorrowedBlock{
+		message: borrowedBlockPool.Get().(*inx.Block),
+	}
+}
+
+// ID returns the ID of the block.
+func (b *BorrowedBlock) ID() iotago.BlockID {
+	return b.message.UnwrapBlockID()
+}
+
+// Data returns the raw serialized block.
+// The data is only valid until the block is released.
+func (b *BorrowedBlock) Data() []byte {
+	return b.message.GetBlock().GetData()
+}
+
+// Block deserializes the block. The returned block doesn't reference the data of the BorrowedBlock.
+func (b *BorrowedBlock) Block(apiProvider iotago.APIProvider) (*iotago.Block, error) {
+	return b.message.UnwrapBlock(apiProvider)
+}
+
+// Release returns the block to the pool. Calling Release more than once has no effect.
+func (b *BorrowedBlock) Release() {
+	if !b.released.CompareAndSwap(false, true) {
+		return
+	}
+
+	// the nested messages and buffers are kept, they are overwritten when the message is borrowed again
+	borrowedBlockPool.Put(b.message)
+}
+
+// ListenToBorrowedBlocks listens to blocks that are taken from a pool.
+// The consumer must call Release on every block once it is done with it, which may happen after the consumer returned.
+func (n *nodeBridge) ListenToBorrowedBlocks(ctx context.Context, consumer func(block *BorrowedBlock) error) error {
+	stream, err := n.client.ListenToBlocks(ctx, &inx.NoParams{}, grpc.ForceCodec(borrowedBlockCodec{}))
+	if err != nil {
+		return n.wrapINXError(err, "failed to listen to blocks")
+	}
+
+	if err := listenToStream(ctx, n, StreamNameBlocks, func() (*BorrowedBlock, error) {
+		block := borrowBlock()
+		if err := stream.RecvMsg(block.message); err != nil {
+			block.Release()
+
+			return nil, err
+		}
+
+		return block, nil
+	}, consumer); err != nil {
+		n.LogErrorf("ListenToBorrowedBlocks failed: %s", err.Error())
+		return n.wrapINXError(err, "ListenToBorrowedBlocks failed")
+	}
+
+	return nil
+}
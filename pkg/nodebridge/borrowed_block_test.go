@@ -0,0 +1,70 @@
+package nodebridge
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	inx "github.com/iotaledger/inx/go"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+func TestBorrowedBlockCodecReusesBuffers(t *testing.T) {
+	t.Parallel()
+
+	codec := borrowedBlockCodec{}
+
+	marshalBlock := func(data []byte) (*inx.Block, []byte) {
+		message := &inx.Block{
+			BlockId: inx.NewBlockId(tpkg.RandBlockID()),
+			Block:   &inx.RawBlock{Data: data},
+		}
+
+		serialized, err := proto.Marshal(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return message, serialized
+	}
+
+	block := &inx.Block{}
+
+	expected, serialized := marshalBlock(tpkg.RandBytes(1024))
+	if err := codec.Unmarshal(serialized, block); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(expected, block) {
+		t.Fatalf("unexpected block after first unmarshal: %v", block)
+	}
+
+	blockID, rawBlock, data := block.GetBlockId(), block.GetBlock(), block.GetBlock().GetData()
+
+	// a smaller block must be decoded into the same nested messages and buffer
+	expected, serialized = marshalBlock(tpkg.RandBytes(512))
+	if err := codec.Unmarshal(serialized, block); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(expected, block) {
+		t.Fatalf("unexpected block after second unmarshal: %v", block)
+	}
+
+	if block.GetBlockId() != blockID || block.GetBlock() != rawBlock {
+		t.Fatal("nested messages were not reused")
+	}
+	if &block.GetBlock().GetData()[0] != &data[0] {
+		t.Fatal("data buffer was not reused")
+	}
+	if !bytes.Equal(block.GetBlock().GetData(), expected.GetBlock().GetData()) {
+		t.Fatal("data was not overwritten")
+	}
+}
+
+func TestBorrowedBlockCodecMalformed(t *testing.T) {
+	t.Parallel()
+
+	if err := (borrowedBlockCodec{}).Unmarshal([]byte{0x0a, 0x05, 0x01}, &inx.Block{}); err == nil {
+		t.Fatal("expected an error for a truncated message")
+	}
+}
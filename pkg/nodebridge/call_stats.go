@@ -0,0 +1,221 @@
+package nodebridge
+
+import (
+	"context"
+	"path"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// callStatsLatencySamples is the amount of latest latencies per method or consumer the percentiles are computed from.
+	callStatsLatencySamples = 1024
+)
+
+// WithCallStatsLogInterval enables a periodic log summary of the call statistics in the given interval.
+// If interval is zero, no summary is logged, which is the default.
+func WithCallStatsLogInterval(interval time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.callStatsLogInterval = interval
+	}
+}
+
+// Stats are the call statistics of the bridge.
+type Stats struct {
+	// Methods are the statistics of the unary calls to the node per INX method, e.g. "ReadBlock".
+	Methods map[string]*CallStats `json:"methods"`
+	// Consumers are the statistics of the stream consumers per stream name, e.g. StreamNameBlocks.
+	Consumers map[string]*CallStats `json:"consumers"`
+}
+
+// CallStats are the statistics of the calls of a single INX method or stream consumer.
+type CallStats struct {
+	// Calls is the amount of calls.
+	Calls uint64 `json:"calls"`
+	// Errors is the amount of calls that returned an error.
+	Errors uint64 `json:"errors"`
+	// P50 is the median latency of the latest calls.
+	P50 time.Duration `json:"p50"`
+	// P90 is the 90th percentile of the latency of the latest calls.
+	P90 time.Duration `json:"p90"`
+	// P99 is the 99th percentile of the latency of the latest calls.
+	P99 time.Duration `json:"p99"`
+}
+
+// callStatsRecorder records the calls of a single INX method or stream consumer.
+type callStatsRecorder struct {
+	mutex     sync.Mutex
+	calls     uint64
+	errors    uint64
+	latencies []time.Duration
+	next      int
+}
+
+func (r *callStatsRecorder) record(latency time.Duration, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls++
+	if err != nil {
+		r.errors++
+	}
+
+	// the latencies are kept in a ring buffer of the latest samples
+	if len(r.latencies) < callStatsLatencySamples {
+		r.latencies = append(r.latencies, latency)
+		return
+	}
+	r.latencies[r.next] = latency
+	r.next = (r.next + 1) % callStatsLatencySamples
+}
+
+func (r *callStatsRecorder) stats() *CallStats {
+	r.mutex.Lock()
+	latencies := slices.Clone(r.latencies)
+	stats := &CallStats{
+		Calls:  r.calls,
+		Errors: r.errors,
+	}
+	r.mutex.Unlock()
+
+	if len(latencies) == 0 {
+		return stats
+	}
+
+	slices.Sort(latencies)
+	percentile := func(p int) time.Duration {
+		return latencies[(len(latencies)-1)*p/100]
+	}
+	stats.P50 = percentile(50)
+	stats.P90 = percentile(90)
+	stats.P99 = percentile(99)
+
+	return stats
+}
+
+// callStatsRegistry holds the recorders of the methods and consumers.
+type callStatsRegistry struct {
+	mutex     sync.RWMutex
+	methods   map[string]*callStatsRecorder
+	consumers map[string]*callStatsRecorder
+}
+
+func newCallStatsRegistry() *callStatsRegistry {
+	return &callStatsRegistry{
+		methods:   make(map[string]*callStatsRecorder),
+		consumers: make(map[string]*callStatsRecorder),
+	}
+}
+
+func (r *callStatsRegistry) recorder(recorders map[string]*callStatsRecorder, name string) *callStatsRecorder {
+	r.mutex.RLock()
+	recorder, exists := recorders[name]
+	r.mutex.RUnlock()
+	if exists {
+		return recorder
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if recorder, exists = recorders[name]; !exists {
+		recorder = &callStatsRecorder{}
+		recorders[name] = recorder
+	}
+
+	return recorder
+}
+
+func (r *callStatsRegistry) recordMethod(method string, latency time.Duration, err error) {
+	r.recorder(r.methods, method).record(latency, err)
+}
+
+func (r *callStatsRegistry) recordConsumer(streamName string, latency time.Duration, err error) {
+	r.recorder(r.consumers, streamName).record(latency, err)
+}
+
+func (r *callStatsRegistry) stats() *Stats {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	stats := &Stats{
+		Methods:   make(map[string]*CallStats, len(r.methods)),
+		Consumers: make(map[string]*CallStats, len(r.consumers)),
+	}
+	for method, recorder := range r.methods {
+		stats.Methods[method] = recorder.stats()
+	}
+	for streamName, recorder := range r.consumers {
+		stats.Consumers[streamName] = recorder.stats()
+	}
+
+	return stats
+}
+
+// Stats returns the call counts, error counts and latency percentiles per INX method and stream consumer.
+func (n *nodeBridge) Stats() *Stats {
+	return n.callStats.stats()
+}
+
+// callStatsUnaryClientInterceptor records the statistics of all unary calls, including their retries.
+func (n *nodeBridge) callStatsUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		n.callStats.recordMethod(path.Base(method), time.Since(start), err)
+
+		return err
+	}
+}
+
+// callStatsConsumer wraps the consumer of a stream to record its statistics.
+func callStatsConsumer[K any](n *nodeBridge, streamName string, consumerFunc func(K) error) func(K) error {
+	return func(item K) error {
+		start := time.Now()
+		err := consumerFunc(item)
+		n.callStats.recordConsumer(streamName, time.Since(start), err)
+
+		return err
+	}
+}
+
+// logCallStats logs a summary of the call statistics in the configured interval until the context is done.
+func (n *nodeBridge) logCallStats(ctx context.Context) {
+	if n.callStatsLogInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(n.callStatsLogInterval)
+	defer ticker.Stop()
+
+	logSummary := func(kind string, callStats map[string]*CallStats) {
+		names := make([]string, 0, len(callStats))
+		for name := range callStats {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			s := callStats[name]
+			n.LogInfof("%s %s: calls: %d, errors: %d, p50: %s, p90: %s, p99: %s", kind, name, s.Calls, s.Errors, s.P50, s.P90, s.P99)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := n.Stats()
+		logSummary("method", stats.Methods)
+		logSummary("consumer", stats.Consumers)
+	}
+}
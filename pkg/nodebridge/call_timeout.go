@@ -0,0 +1,44 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type callTimeoutContextKey struct{}
+
+// ContextWithCallTimeout returns a context that overrides the default call timeout of the bridge
+// for all unary calls that are issued with the returned context.
+// A timeout of zero disables the default call timeout for these calls.
+func ContextWithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutContextKey{}, timeout)
+}
+
+func callTimeoutFromContext(ctx context.Context, defaultTimeout time.Duration) time.Duration {
+	if timeout, ok := ctx.Value(callTimeoutContextKey{}).(time.Duration); ok {
+		return timeout
+	}
+
+	return defaultTimeout
+}
+
+// callTimeoutUnaryClientInterceptor adds a deadline to all unary calls if the context of the caller has none.
+func (n *nodeBridge) callTimeoutUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, hasDeadline := ctx.Deadline(); hasDeadline {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		timeout := callTimeoutFromContext(ctx, n.defaultCallTimeout)
+		if timeout <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return invoker(ctxTimeout, method, req, reply, cc, opts...)
+	}
+}
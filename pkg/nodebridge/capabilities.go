@@ -0,0 +1,113 @@
+package nodebridge
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+var (
+	// ErrReadOnly is returned if a mutating operation is called on a bridge in read-only mode.
+	ErrReadOnly = ierrors.New("node bridge is in read-only mode")
+	// ErrCapabilityNotSupported is returned if the node does not allow the requested operation via INX.
+	ErrCapabilityNotSupported = ierrors.New("capability not supported by the node")
+	// ErrManagementDisabled is returned if a node management operation is called on a bridge without WithManagementEnabled.
+	ErrManagementDisabled = ierrors.New("node management operations are disabled")
+)
+
+// Capability is an operation of the node bridge that mutates the state of the node.
+type Capability string
+
+const (
+	// CapabilitySubmitBlock allows to submit blocks to the node.
+	CapabilitySubmitBlock Capability = "submitBlock"
+	// CapabilityForceCommit allows to force the node to commit slots.
+	CapabilityForceCommit Capability = "forceCommit"
+	// CapabilityRegisterAPIRoute allows to register and unregister API routes at the node.
+	CapabilityRegisterAPIRoute Capability = "registerAPIRoute"
+	// CapabilityManagement allows to trigger maintenance operations like pruning and snapshot creation on the node.
+	CapabilityManagement Capability = "management"
+)
+
+// AllCapabilities are all capabilities of the node bridge.
+var AllCapabilities = []Capability{
+	CapabilitySubmitBlock,
+	CapabilityForceCommit,
+	CapabilityRegisterAPIRoute,
+	CapabilityManagement,
+}
+
+// WithReadOnly sets the bridge to read-only mode, which refuses all mutating operations with ErrReadOnly.
+func WithReadOnly(readOnly bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.readOnly = readOnly
+	}
+}
+
+// IsReadOnly returns true if the bridge is in read-only mode.
+func (n *nodeBridge) IsReadOnly() bool {
+	return n.readOnly
+}
+
+// Capabilities returns the capabilities that are currently supported.
+// The node configuration does not announce the capabilities of the node,
+// so a capability is considered unsupported after the node refused the operation
+// with "Unimplemented" or "PermissionDenied".
+func (n *nodeBridge) Capabilities() []Capability {
+	capabilities := make([]Capability, 0, len(AllCapabilities))
+	for _, capability := range AllCapabilities {
+		if n.HasCapability(capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+
+	return capabilities
+}
+
+// HasCapability returns true if the given capability is currently supported.
+func (n *nodeBridge) HasCapability(capability Capability) bool {
+	return n.checkCapability(capability) == nil
+}
+
+// checkCapability returns an error if the given capability is not supported.
+func (n *nodeBridge) checkCapability(capability Capability) error {
+	if n.readOnly {
+		return ierrors.Wrapf(ErrReadOnly, "capability %s not available", capability)
+	}
+
+	if capability == CapabilityManagement && !n.managementEnabled {
+		return ierrors.Wrapf(ErrManagementDisabled, "capability %s not available", capability)
+	}
+
+	n.unsupportedCapabilitiesMutex.RLock()
+	defer n.unsupportedCapabilitiesMutex.RUnlock()
+
+	if _, unsupported := n.unsupportedCapabilities[capability]; unsupported {
+		return ierrors.Wrapf(ErrCapabilityNotSupported, "capability %s not available", capability)
+	}
+
+	return nil
+}
+
+// trackCapability marks the given capability as unsupported
+// if the node refused the operation because it is restricted.
+func (n *nodeBridge) trackCapability(capability Capability, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch status.Code(err) {
+	case codes.Unimplemented, codes.PermissionDenied:
+		n.unsupportedCapabilitiesMutex.Lock()
+		n.unsupportedCapabilities[capability] = struct{}{}
+		n.unsupportedCapabilitiesMutex.Unlock()
+
+		n.LogWarnf("Node does not support capability %s: %s", capability, err)
+
+		return ierrors.Join(err, ErrCapabilityNotSupported)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,54 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// WithReadCoalescing enables the coalescing of identical in-flight reads of Block, BlockMetadata, Output and Commitment.
+// Concurrent callers requesting the same item share one round trip to the node and receive the same result,
+// so the returned objects must not be modified by the callers.
+func WithReadCoalescing(enabled bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.readCoalescing = enabled
+	}
+}
+
+// coalesceRead executes the given read once for all concurrent callers with the same key, if read coalescing is enabled.
+// The shared read is not canceled if a single caller gives up, but it keeps the deadline of the caller that started it.
+func coalesceRead[T any](ctx context.Context, n *nodeBridge, key string, read func(ctx context.Context) (T, error)) (T, error) {
+	if !n.readCoalescing {
+		return read(ctx)
+	}
+
+	resultChan := n.readGroup.DoChan(key, func() (any, error) {
+		readCtx := context.WithoutCancel(ctx)
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+			var cancel context.CancelFunc
+			readCtx, cancel = context.WithDeadline(readCtx, deadline)
+			defer cancel()
+		}
+
+		return read(readCtx)
+	})
+
+	var result T
+	select {
+	case <-ctx.Done():
+		return result, ctx.Err()
+
+	case res := <-resultChan:
+		if res.Err != nil {
+			return result, res.Err
+		}
+
+		value, ok := res.Val.(T)
+		if !ok {
+			return result, ierrors.Errorf("unexpected result type %T of coalesced read %s", res.Val, key)
+		}
+
+		return value, nil
+	}
+}
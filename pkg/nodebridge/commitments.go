@@ -2,6 +2,7 @@ package nodebridge
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/iotaledger/hive.go/ierrors"
 	"github.com/iotaledger/hive.go/lo"
@@ -9,9 +10,52 @@ import (
 	iotago "github.com/iotaledger/iota.go/v4"
 )
 
+var (
+	ErrCommitmentChainDivergence = ierrors.New("commitment chain divergence")
+	ErrCommitmentIDMismatch      = ierrors.New("commitment ID mismatch")
+)
+
 type Commitment struct {
+	// CommitmentID is the ID of the commitment.
 	CommitmentID iotago.CommitmentID
-	Commitment   *iotago.Commitment
+	// Commitment is the actual commitment.
+	Commitment *iotago.Commitment
+	// RawCommitmentData is the raw binary commitment data as received from the node.
+	RawCommitmentData []byte
+}
+
+// ComputeID computes the ID of the commitment.
+// If the raw commitment data is available, the ID is derived from it without re-serializing the commitment.
+func (c *Commitment) ComputeID() (iotago.CommitmentID, error) {
+	if len(c.RawCommitmentData) > 0 {
+		return iotago.CommitmentIDRepresentingData(c.Commitment.Slot, c.RawCommitmentData), nil
+	}
+
+	return c.Commitment.ID()
+}
+
+// VerifyID checks if the computed ID of the commitment matches the CommitmentID.
+func (c *Commitment) VerifyID() error {
+	computedID, err := c.ComputeID()
+	if err != nil {
+		return ierrors.Wrapf(err, "unable to compute ID of commitment %s", c.CommitmentID)
+	}
+
+	if computedID != c.CommitmentID {
+		return ierrors.Wrapf(ErrCommitmentIDMismatch, "expected: %s, computed: %s", c.CommitmentID, computedID)
+	}
+
+	return nil
+}
+
+// Bytes returns the serialized commitment.
+// If the raw commitment data is available, it is returned without re-serializing the commitment.
+func (c *Commitment) Bytes(api iotago.API) ([]byte, error) {
+	if len(c.RawCommitmentData) > 0 {
+		return c.RawCommitmentData, nil
+	}
+
+	return api.Encode(c.Commitment)
 }
 
 func commitmentFromINXCommitment(inxCommitment *inx.Commitment, api iotago.API) (*Commitment, error) {
@@ -26,46 +70,71 @@ func commitmentFromINXCommitment(inxCommitment *inx.Commitment, api iotago.API)
 	}
 
 	return &Commitment{
-		CommitmentID: inxCommitment.GetCommitmentId().Unwrap(),
-		Commitment:   commitment,
+		CommitmentID:      inxCommitment.GetCommitmentId().Unwrap(),
+		Commitment:        commitment,
+		RawCommitmentData: inxCommitment.GetCommitment().GetData(),
 	}, nil
 }
 
 // ForceCommitUntil forces the node to commit until the given slot.
 func (n *nodeBridge) ForceCommitUntil(ctx context.Context, slot iotago.SlotIndex) error {
-	return lo.Return2(n.client.ForceCommitUntil(ctx, inx.WrapSlotRequest(slot)))
+	if err := n.checkCapability(CapabilityForceCommit); err != nil {
+		return err
+	}
+
+	err := lo.Return2(n.client.ForceCommitUntil(ctx, inx.WrapSlotRequest(slot)))
+
+	return n.wrapINXError(n.trackCapability(CapabilityForceCommit, err), "failed to force commit until slot %d", slot)
 }
 
 // Commitment returns the commitment for the given slot.
 func (n *nodeBridge) Commitment(ctx context.Context, slot iotago.SlotIndex) (*Commitment, error) {
-	req := &inx.CommitmentRequest{
-		CommitmentSlot: uint32(slot),
-	}
-
-	inxCommitment, err := n.client.ReadCommitment(ctx, req)
-	if err != nil {
+	if err := n.checkSlotPruned(slot); err != nil {
 		return nil, err
 	}
 
-	return commitmentFromINXCommitment(inxCommitment, n.apiProvider.APIForSlot(slot))
+	return coalesceRead(ctx, n, fmt.Sprintf("commitment:%d", slot), func(ctx context.Context) (*Commitment, error) {
+		req := &inx.CommitmentRequest{
+			CommitmentSlot: uint32(slot),
+		}
+
+		inxCommitment, err := n.client.ReadCommitment(ctx, req)
+		if err != nil {
+			return nil, n.wrapSlotPrunedError(slot, n.wrapINXError(err, "failed to read commitment for slot %d", slot))
+		}
+
+		return commitmentFromINXCommitment(inxCommitment, n.apiProvider.APIForSlot(slot))
+	})
 }
 
 // CommitmentByID returns the commitment for the given commitment ID.
 func (n *nodeBridge) CommitmentByID(ctx context.Context, id iotago.CommitmentID) (*Commitment, error) {
-	req := &inx.CommitmentRequest{
-		CommitmentId: inx.NewCommitmentId(id),
-	}
-
-	inxCommitment, err := n.client.ReadCommitment(ctx, req)
-	if err != nil {
+	if err := n.checkSlotPruned(id.Slot()); err != nil {
 		return nil, err
 	}
 
-	return commitmentFromINXCommitment(inxCommitment, n.apiProvider.APIForSlot(id.Index()))
+	return coalesceRead(ctx, n, "commitmentID:"+id.ToHex(), func(ctx context.Context) (*Commitment, error) {
+		req := &inx.CommitmentRequest{
+			CommitmentId: inx.NewCommitmentId(id),
+		}
+
+		inxCommitment, err := n.client.ReadCommitment(ctx, req)
+		if err != nil {
+			return nil, n.wrapSlotPrunedError(id.Slot(), n.wrapINXError(err, "failed to read commitment %s", id))
+		}
+
+		return commitmentFromINXCommitment(inxCommitment, n.apiProvider.APIForSlot(id.Index()))
+	})
 }
 
 // ListenToCommitments listens to commitments.
 func (n *nodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
+	if startSlot > 0 {
+		if err := n.checkSlotPruned(startSlot); err != nil {
+			return err
+		}
+	}
+
 	req := &inx.SlotRangeRequest{
 		StartSlot: uint32(startSlot),
 		EndSlot:   uint32(endSlot),
@@ -73,10 +142,10 @@ func (n *nodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot
 
 	stream, err := n.client.ListenToCommitments(ctx, req)
 	if err != nil {
-		return err
+		return n.wrapINXError(err, "failed to listen to commitments")
 	}
 
-	if err := ListenToStream(ctx, stream.Recv, func(inxCommitment *inx.Commitment) error {
+	if err := listenToStream(ctx, n, StreamNameCommitments, stream.Recv, func(inxCommitment *inx.Commitment) error {
 		commitmentID := inxCommitment.GetCommitmentId().Unwrap()
 
 		commitment, err := inxCommitment.UnwrapCommitment(n.apiProvider.APIForSlot(commitmentID.Slot()))
@@ -85,12 +154,95 @@ func (n *nodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot
 		}
 
 		return consumer(&Commitment{
-			CommitmentID: commitmentID,
-			Commitment:   commitment,
+			CommitmentID:      commitmentID,
+			Commitment:        commitment,
+			RawCommitmentData: inxCommitment.GetCommitment().GetData(),
 		}, inxCommitment.GetCommitment().GetData())
 	}); err != nil {
 		n.LogErrorf("ListenToCommitments failed: %s", err.Error())
-		return err
+		return n.wrapINXError(err, "ListenToCommitments failed")
+	}
+
+	return nil
+}
+
+// VerifyCommitmentChain walks the commitments from fromSlot to toSlot (inclusive) and verifies
+// that every commitment matches its ID, protocol version and slot, and links to the commitment of the previous slot,
+// including the commitment at fromSlot-1 if fromSlot is after the genesis slot.
+// The roots of the commitments are not verified, because INX only exposes the RootsID and not the roots it is derived from.
+// It returns ErrCommitmentChainDivergence wrapped with the details of the first divergence found.
+func (n *nodeBridge) VerifyCommitmentChain(ctx context.Context, fromSlot iotago.SlotIndex, toSlot iotago.SlotIndex) error {
+	if fromSlot > toSlot {
+		return ierrors.Errorf("invalid slot range, fromSlot: %d, toSlot: %d", fromSlot, toSlot)
+	}
+
+	var previous *Commitment
+	if fromSlot > n.apiProvider.CommittedAPI().ProtocolParameters().GenesisSlot() {
+		// the first commitment of the range must link to the commitment before the range
+		anchor, err := n.Commitment(ctx, fromSlot-1)
+		if err != nil {
+			return ierrors.Wrapf(err, "unable to read commitment for slot %d", fromSlot-1)
+		}
+		if anchor == nil {
+			return ierrors.Wrapf(ErrCommitmentChainDivergence, "commitment for slot %d is missing", fromSlot-1)
+		}
+
+		if err := verifyCommitment(anchor, fromSlot-1, n.apiProvider.APIForSlot(fromSlot-1).Version()); err != nil {
+			return err
+		}
+
+		previous = anchor
+	}
+
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		commitment, err := n.Commitment(ctx, slot)
+		if err != nil {
+			return ierrors.Wrapf(err, "unable to read commitment for slot %d", slot)
+		}
+		if commitment == nil {
+			return ierrors.Wrapf(ErrCommitmentChainDivergence, "commitment for slot %d is missing", slot)
+		}
+
+		if err := verifyCommitment(commitment, slot, n.apiProvider.APIForSlot(slot).Version()); err != nil {
+			return err
+		}
+
+		if previous != nil {
+			if commitment.Commitment.PreviousCommitmentID != previous.CommitmentID {
+				return ierrors.Wrapf(ErrCommitmentChainDivergence, "commitment %s does not link to the previous commitment %s, got %s", commitment.CommitmentID, previous.CommitmentID, commitment.Commitment.PreviousCommitmentID)
+			}
+
+			if commitment.Commitment.CumulativeWeight < previous.Commitment.CumulativeWeight {
+				return ierrors.Wrapf(ErrCommitmentChainDivergence, "cumulative weight of commitment %s decreased, previous: %d, current: %d", commitment.CommitmentID, previous.Commitment.CumulativeWeight, commitment.Commitment.CumulativeWeight)
+			}
+		}
+
+		previous = commitment
+
+		if slot == toSlot {
+			// prevent overflow of the slot index
+			break
+		}
+	}
+
+	return nil
+}
+
+func verifyCommitment(commitment *Commitment, slot iotago.SlotIndex, version iotago.Version) error {
+	if commitment.Commitment.Slot != slot || commitment.CommitmentID.Slot() != slot {
+		return ierrors.Wrapf(ErrCommitmentChainDivergence, "commitment %s has an invalid slot, expected: %d, got: %d", commitment.CommitmentID, slot, commitment.Commitment.Slot)
+	}
+
+	if commitment.Commitment.ProtocolVersion != version {
+		return ierrors.Wrapf(ErrCommitmentChainDivergence, "commitment %s has an invalid protocol version, expected: %d, got: %d", commitment.CommitmentID, version, commitment.Commitment.ProtocolVersion)
+	}
+
+	if err := commitment.VerifyID(); err != nil {
+		return ierrors.Join(ErrCommitmentChainDivergence, err)
 	}
 
 	return nil
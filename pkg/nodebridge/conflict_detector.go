@@ -0,0 +1,248 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// Conflict is a watched transaction that lost against a conflicting transaction that spent the same inputs.
+type Conflict struct {
+	// Slot is the slot of the accepted transaction.
+	Slot iotago.SlotIndex
+	// AcceptedTransactionID is the ID of the transaction that was accepted.
+	AcceptedTransactionID iotago.TransactionID
+	// RejectedTransactionID is the ID of the watched transaction that conflicts with the accepted transaction.
+	RejectedTransactionID iotago.TransactionID
+	// Outputs are the inputs of the rejected transaction that were spent by the accepted transaction.
+	Outputs []*Output
+}
+
+// TransactionFailure is a watched transaction that failed.
+type TransactionFailure struct {
+	// TransactionID is the ID of the failed transaction.
+	TransactionID iotago.TransactionID
+	// Reason is the reason why the transaction failed.
+	Reason api.TransactionFailureReason
+	// Details are the details of the failure, if the node provides them.
+	Details string
+}
+
+// ConflictDetectorEvents are the events triggered by the ConflictDetector.
+type ConflictDetectorEvents struct {
+	// ConflictDetected is triggered if an accepted transaction spent inputs of a watched transaction.
+	ConflictDetected *event.Event1[*Conflict]
+	// TransactionFailed is triggered if the node reports a watched transaction as failed.
+	TransactionFailed *event.Event1[*TransactionFailure]
+	// TransactionAccepted is triggered if a watched transaction was accepted.
+	TransactionAccepted *event.Event1[*AcceptedTransaction]
+}
+
+// ConflictDetector watches pending transactions, e.g. the transactions issued by a wallet backend,
+// and reports if they lose against conflicting transactions or fail, so the funds can be marked as unsafe.
+type ConflictDetector struct {
+	nodeBridge NodeBridge
+	events     *ConflictDetectorEvents
+
+	watchedMutex sync.Mutex
+	// the inputs of the watched transactions.
+	watched map[iotago.TransactionID][]iotago.OutputID
+	// the watched transactions that spend an output.
+	spenders map[iotago.OutputID]map[iotago.TransactionID]struct{}
+}
+
+// NewConflictDetector creates a new ConflictDetector.
+func NewConflictDetector(nodeBridge NodeBridge) *ConflictDetector {
+	return &ConflictDetector{
+		nodeBridge: nodeBridge,
+		events: &ConflictDetectorEvents{
+			ConflictDetected:    event.New1[*Conflict](),
+			TransactionFailed:   event.New1[*TransactionFailure](),
+			TransactionAccepted: event.New1[*AcceptedTransaction](),
+		},
+		watched:  make(map[iotago.TransactionID][]iotago.OutputID),
+		spenders: make(map[iotago.OutputID]map[iotago.TransactionID]struct{}),
+	}
+}
+
+// Events returns the events of the ConflictDetector.
+func (d *ConflictDetector) Events() *ConflictDetectorEvents {
+	return d.events
+}
+
+// Watch watches the transaction with the given ID that spends the given inputs.
+func (d *ConflictDetector) Watch(transactionID iotago.TransactionID, inputs []iotago.OutputID) {
+	d.watchedMutex.Lock()
+	defer d.watchedMutex.Unlock()
+
+	d.unwatch(transactionID)
+
+	d.watched[transactionID] = inputs
+	for _, outputID := range inputs {
+		if d.spenders[outputID] == nil {
+			d.spenders[outputID] = make(map[iotago.TransactionID]struct{})
+		}
+		d.spenders[outputID][transactionID] = struct{}{}
+	}
+}
+
+// WatchTransaction watches the given signed transaction.
+func (d *ConflictDetector) WatchTransaction(signedTransaction *iotago.SignedTransaction) error {
+	transactionID, err := signedTransaction.Transaction.ID()
+	if err != nil {
+		return err
+	}
+
+	inputs := make([]iotago.OutputID, 0, len(signedTransaction.Transaction.TransactionEssence.Inputs))
+	for _, input := range signedTransaction.Transaction.TransactionEssence.Inputs {
+		utxoInput, isUTXOInput := input.(*iotago.UTXOInput)
+		if !isUTXOInput {
+			continue
+		}
+		inputs = append(inputs, utxoInput.OutputID())
+	}
+
+	d.Watch(transactionID, inputs)
+
+	return nil
+}
+
+// Unwatch stops watching the transaction with the given ID.
+func (d *ConflictDetector) Unwatch(transactionID iotago.TransactionID) {
+	d.watchedMutex.Lock()
+	defer d.watchedMutex.Unlock()
+
+	d.unwatch(transactionID)
+}
+
+// Watched returns the IDs of the watched transactions.
+func (d *ConflictDetector) Watched() []iotago.TransactionID {
+	d.watchedMutex.Lock()
+	defer d.watchedMutex.Unlock()
+
+	transactionIDs := make([]iotago.TransactionID, 0, len(d.watched))
+	for transactionID := range d.watched {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+
+	return transactionIDs
+}
+
+// Run listens to the accepted transactions and checks the state of the watched transactions
+// on every new commitment until the given context is done.
+func (d *ConflictDetector) Run(ctx context.Context) error {
+	commitments := d.nodeBridge.Events().SubscribeLatestCommitment(1)
+	defer commitments.Unsubscribe()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-commitments.Values():
+				if !ok {
+					return
+				}
+				d.CheckTransactions(ctx)
+			}
+		}
+	}()
+
+	if err := d.nodeBridge.ListenToAcceptedTransactions(ctx, func(tx *AcceptedTransaction) error {
+		d.ApplyAcceptedTransaction(tx)
+
+		return nil
+	}); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyAcceptedTransaction reports the watched transactions that conflict with the given accepted transaction.
+func (d *ConflictDetector) ApplyAcceptedTransaction(tx *AcceptedTransaction) {
+	conflicts := make(map[iotago.TransactionID]*Conflict)
+	var accepted bool
+
+	d.watchedMutex.Lock()
+
+	for _, output := range tx.Consumed {
+		for transactionID := range d.spenders[output.OutputID] {
+			if transactionID == tx.TransactionID {
+				continue
+			}
+
+			conflict, exists := conflicts[transactionID]
+			if !exists {
+				conflict = &Conflict{
+					Slot:                  tx.Slot,
+					AcceptedTransactionID: tx.TransactionID,
+					RejectedTransactionID: transactionID,
+				}
+				conflicts[transactionID] = conflict
+			}
+			conflict.Outputs = append(conflict.Outputs, output)
+		}
+	}
+
+	for transactionID := range conflicts {
+		d.unwatch(transactionID)
+	}
+	if _, accepted = d.watched[tx.TransactionID]; accepted {
+		d.unwatch(tx.TransactionID)
+	}
+
+	d.watchedMutex.Unlock()
+
+	for _, conflict := range conflicts {
+		d.events.ConflictDetected.Trigger(conflict)
+	}
+	if accepted {
+		d.events.TransactionAccepted.Trigger(tx)
+	}
+}
+
+// CheckTransactions requests the metadata of the watched transactions and reports the failed ones.
+func (d *ConflictDetector) CheckTransactions(ctx context.Context) {
+	for _, transactionID := range d.Watched() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		metadata, err := d.nodeBridge.TransactionMetadata(ctx, transactionID)
+		if err != nil || metadata.TransactionState != api.TransactionStateFailed {
+			// the transaction might not be known to the node yet
+			continue
+		}
+
+		d.watchedMutex.Lock()
+		_, watched := d.watched[transactionID]
+		d.unwatch(transactionID)
+		d.watchedMutex.Unlock()
+
+		if !watched {
+			// the transaction was reported by ApplyAcceptedTransaction in the meantime
+			continue
+		}
+
+		d.events.TransactionFailed.Trigger(&TransactionFailure{
+			TransactionID: transactionID,
+			Reason:        metadata.TransactionFailureReason,
+			Details:       metadata.TransactionFailureDetails,
+		})
+	}
+}
+
+// unwatch removes the given transaction, the watchedMutex must be held by the caller.
+func (d *ConflictDetector) unwatch(transactionID iotago.TransactionID) {
+	for _, outputID := range d.watched[transactionID] {
+		delete(d.spenders[outputID], transactionID)
+		if len(d.spenders[outputID]) == 0 {
+			delete(d.spenders, outputID)
+		}
+	}
+	delete(d.watched, transactionID)
+}
@@ -0,0 +1,160 @@
+package nodebridge
+
+import (
+	"context"
+	"net"
+	"time"
+
+	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// CompressionNone disables the compression of the INX connection.
+	CompressionNone = ""
+	// CompressionGzip compresses the messages of the INX connection with gzip.
+	CompressionGzip = gzip.Name
+)
+
+var (
+	// ErrUnknownCompressor is returned if the configured compressor is not registered.
+	ErrUnknownCompressor = ierrors.New("unknown compressor")
+)
+
+// WithKeepalive configures the keepalive pings of the INX connection.
+// After a duration of pingInterval without activity, the client pings the node to check if the connection is still alive.
+// If the node does not answer within pingTimeout, the connection is closed.
+// If permitWithoutStream is true, the pings are also sent if there are no active streams.
+// If pingInterval is zero, the default keepalive settings of gRPC are used.
+func WithKeepalive(pingInterval time.Duration, pingTimeout time.Duration, permitWithoutStream bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.keepaliveParams = &keepalive.ClientParameters{
+			Time:                pingInterval,
+			Timeout:             pingTimeout,
+			PermitWithoutStream: permitWithoutStream,
+		}
+	}
+}
+
+// WithCompression enables the compression of all messages sent to the node with the given compressor,
+// which reduces the bandwidth if the extension runs remote from the node.
+// CompressionGzip is supported out of the box, other compressors (e.g. snappy) can be used
+// after registering them with encoding.RegisterCompressor. The node needs to support the compressor as well.
+func WithCompression(compressor string) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.compressor = compressor
+	}
+}
+
+// WithMaxRecvMsgSize sets the maximum size in bytes of a message received from the node.
+// If maxRecvMsgSize is zero, the default limit of gRPC is used.
+func WithMaxRecvMsgSize(maxRecvMsgSize int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.maxRecvMsgSize = maxRecvMsgSize
+	}
+}
+
+// WithMaxSendMsgSize sets the maximum size in bytes of a message sent to the node.
+// If maxSendMsgSize is zero, the default limit of gRPC is used.
+func WithMaxSendMsgSize(maxSendMsgSize int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.maxSendMsgSize = maxSendMsgSize
+	}
+}
+
+// WithContextDialer sets the dialer that is used to establish the connection to the node instead of a TCP connection,
+// e.g. to connect to an INX server that runs in the same process.
+func WithContextDialer(dialer func(ctx context.Context, address string) (net.Conn, error)) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.contextDialer = dialer
+	}
+}
+
+// dialOptions returns the options used to establish the gRPC connection to the node.
+func (n *nodeBridge) dialOptions() []grpc.DialOption {
+	unaryInterceptors := []grpc.UnaryClientInterceptor{n.callTimeoutUnaryClientInterceptor(), n.callStatsUnaryClientInterceptor()}
+	unaryInterceptors = append(unaryInterceptors, n.retryUnaryClientInterceptors()...)
+	unaryInterceptors = append(unaryInterceptors, grpcprometheus.UnaryClientInterceptor)
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
+		grpc.WithStreamInterceptor(grpcprometheus.StreamClientInterceptor),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+
+	if n.keepaliveParams != nil && n.keepaliveParams.Time > 0 {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(*n.keepaliveParams))
+	}
+
+	if n.contextDialer != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(n.contextDialer))
+	}
+
+	if n.compressor != CompressionNone {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(n.compressor)))
+	}
+
+	if n.maxRecvMsgSize > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(n.maxRecvMsgSize)))
+	}
+
+	if n.maxSendMsgSize > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(n.maxSendMsgSize)))
+	}
+
+	return dialOptions
+}
+
+// checkCompressor checks if the configured compressor is registered.
+func (n *nodeBridge) checkCompressor() error {
+	if n.compressor == CompressionNone || encoding.GetCompressor(n.compressor) != nil {
+		return nil
+	}
+
+	return ierrors.Wrapf(ErrUnknownCompressor, "compressor %q is not registered", n.compressor)
+}
+
+// ConnectionState returns the current connectivity state of the INX connection.
+func (n *nodeBridge) ConnectionState() connectivity.State {
+	if n.conn == nil {
+		return connectivity.Idle
+	}
+
+	return n.conn.GetState()
+}
+
+// watchConnectionState triggers the ConnectionStateChanged event on every
+// connectivity state change of the INX connection until the context is done.
+func (n *nodeBridge) watchConnectionState(ctx context.Context) {
+	state := n.conn.GetState()
+	wasReady := state == connectivity.Ready
+	for n.conn.WaitForStateChange(ctx, state) {
+		newState := n.conn.GetState()
+
+		if newState == connectivity.Ready {
+			if wasReady {
+				n.reconnects.Add(1)
+				go n.refreshNodeConfigAfterReconnect(ctx)
+			}
+			wasReady = true
+		}
+
+		switch newState {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			n.LogWarnf("INX connection state changed: %s -> %s", state, newState)
+		default:
+			n.LogDebugf("INX connection state changed: %s -> %s", state, newState)
+		}
+
+		n.events.ConnectionStateChanged.Trigger(newState)
+		state = newState
+	}
+}
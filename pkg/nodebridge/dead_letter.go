@@ -0,0 +1,116 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// DeadLetter is an item of a stream that could not be processed by its consumer.
+type DeadLetter struct {
+	// Stream is the name of the stream the item belongs to, e.g. StreamNameBlocks.
+	Stream string
+	// Slot is the slot the item belongs to.
+	Slot iotago.SlotIndex
+	// Data is the raw protobuf encoded INX message of the item.
+	// For ledger updates, this is the batch end marker, which identifies the slot of the ledger update to request again.
+	// A ledger update is passed to the handler once for the whole batch, if any of its operations failed.
+	Data []byte
+	// Err is the error returned by the consumer.
+	Err error
+	// Time is the time the item failed.
+	Time time.Time
+}
+
+// DeadLetterHandler is called for every item of a stream that could not be processed by its consumer.
+type DeadLetterHandler func(letter *DeadLetter)
+
+// WithDeadLetterHandler enables the dead-letter handling of the streams.
+// If a consumer returns an error for an item, the item is passed to the given handler and the stream continues,
+// instead of aborting the stream. Context errors and recovered panics with PanicPolicyTerminateStream still abort the stream.
+func WithDeadLetterHandler(handler DeadLetterHandler) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.deadLetterHandler = handler
+	}
+}
+
+// deadLetterConsumer wraps the given consumer with the configured dead-letter handler.
+func deadLetterConsumer[K any](n *nodeBridge, streamName string, consumerFunc func(K) error) func(K) error {
+	if n.deadLetterHandler == nil {
+		return consumerFunc
+	}
+
+	return func(item K) error {
+		err := consumerFunc(item)
+		if err == nil || ierrors.Is(err, ErrConsumerPanicked) || ierrors.Is(err, context.Canceled) || ierrors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		letter := &DeadLetter{
+			Stream: streamName,
+			Err:    err,
+			Time:   time.Now(),
+		}
+		letter.Slot, letter.Data = deadLetterPayload(item)
+
+		n.LogWarnf("Consumer of stream %s failed for an item of slot %d, passing it to the dead-letter handler: %s", streamName, letter.Slot, err)
+		n.deadLetterHandler(letter)
+
+		return nil
+	}
+}
+
+// deadLetterPayload returns the slot and the raw data of the given stream item.
+func deadLetterPayload(item any) (iotago.SlotIndex, []byte) {
+	slot := streamItemSlot(item)
+
+	var message proto.Message
+	switch typedItem := item.(type) {
+	case *BorrowedBlock:
+		message = typedItem.message
+	case proto.Message:
+		message = typedItem
+	default:
+		return slot, nil
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return slot, nil
+	}
+
+	return slot, data
+}
+
+// streamItemSlot returns the slot the given stream item belongs to.
+// For ledger updates, only the batch markers carry a slot.
+func streamItemSlot(item any) iotago.SlotIndex {
+	switch typedItem := item.(type) {
+	case *inx.Block:
+		return typedItem.UnwrapBlockID().Slot()
+	case *BorrowedBlock:
+		return typedItem.ID().Slot()
+	case *inx.BlockMetadata:
+		if blockID := typedItem.GetBlockId(); blockID != nil {
+			return blockID.Unwrap().Slot()
+		}
+	case *inx.Commitment:
+		if commitmentID := typedItem.GetCommitmentId(); commitmentID != nil {
+			return commitmentID.Unwrap().Slot()
+		}
+	case *inx.LedgerUpdate:
+		if commitmentID := typedItem.GetBatchMarker().GetCommitmentId(); commitmentID != nil {
+			return commitmentID.Unwrap().Slot()
+		}
+	case *inx.AcceptedTransaction:
+		return iotago.SlotIndex(typedItem.GetSlot())
+	}
+
+	return 0
+}
@@ -0,0 +1,184 @@
+package nodebridge
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// DebugInfo is a snapshot of the internal state of the bridge.
+type DebugInfo struct {
+	// ConnectionState is the connectivity state of the INX connection.
+	ConnectionState string `json:"connectionState"`
+	// Reconnects is the amount of times the INX connection became ready again after it was lost.
+	Reconnects uint64 `json:"reconnects"`
+	// ReadOnly is true if the bridge is in read-only mode.
+	ReadOnly bool `json:"readOnly"`
+	// ManagementEnabled is true if node management operations are enabled.
+	ManagementEnabled bool `json:"managementEnabled"`
+	// LatestCommittedSlot is the slot of the latest commitment.
+	LatestCommittedSlot iotago.SlotIndex `json:"latestCommittedSlot"`
+	// LatestFinalizedSlot is the slot of the latest finalized commitment.
+	LatestFinalizedSlot iotago.SlotIndex `json:"latestFinalizedSlot"`
+	// PruningEpoch is the pruning epoch of the node.
+	PruningEpoch iotago.EpochIndex `json:"pruningEpoch"`
+	// Streams are the currently active streams.
+	Streams []*StreamDebugInfo `json:"streams"`
+	// SupportedRoutesCache are the cached routes of the plugins that are supported by the node.
+	SupportedRoutesCache []string `json:"supportedRoutesCache"`
+	// UnsupportedCapabilities are the capabilities that were found to be unsupported by the node.
+	UnsupportedCapabilities []Capability `json:"unsupportedCapabilities"`
+	// RegisteredAPIRoutes are the API routes that were registered at the node by the bridge.
+	RegisteredAPIRoutes []string `json:"registeredApiRoutes"`
+}
+
+// StreamDebugInfo is a snapshot of the state of an active stream.
+type StreamDebugInfo struct {
+	// Name is the name of the stream, e.g. StreamNameBlocks.
+	Name string `json:"name"`
+	// Started is the time the stream was opened.
+	Started time.Time `json:"started"`
+	// ItemsReceived is the amount of items that were received.
+	ItemsReceived uint64 `json:"itemsReceived"`
+	// LastReceivedSlot is the slot of the last received item.
+	LastReceivedSlot iotago.SlotIndex `json:"lastReceivedSlot"`
+	// LastReceivedTime is the time the last item was received, or zero if no item was received yet.
+	LastReceivedTime time.Time `json:"lastReceivedTime"`
+	// BufferLength is the amount of buffered items in drain mode.
+	BufferLength int `json:"bufferLength"`
+	// BufferCapacity is the capacity of the buffer in drain mode, or zero if the stream is not buffered.
+	BufferCapacity int `json:"bufferCapacity"`
+}
+
+// streamState tracks the state of an active stream for DebugInfo.
+type streamState struct {
+	name    string
+	started time.Time
+
+	itemsReceived    atomic.Uint64
+	lastReceivedSlot atomic.Uint32
+	lastReceivedTime atomic.Int64
+
+	// bufferLength returns the amount of buffered items in drain mode.
+	bufferLength   func() int
+	bufferCapacity int
+}
+
+// received tracks a received item of the stream.
+func (s *streamState) received(item any) {
+	s.itemsReceived.Add(1)
+	if slot := streamItemSlot(item); slot != 0 {
+		// items without a slot (e.g. the outputs of a ledger update) keep the slot of the previous item
+		s.lastReceivedSlot.Store(uint32(slot))
+	}
+	s.lastReceivedTime.Store(time.Now().UnixNano())
+}
+
+func (s *streamState) debugInfo() *StreamDebugInfo {
+	info := &StreamDebugInfo{
+		Name:             s.name,
+		Started:          s.started,
+		ItemsReceived:    s.itemsReceived.Load(),
+		LastReceivedSlot: iotago.SlotIndex(s.lastReceivedSlot.Load()),
+		BufferCapacity:   s.bufferCapacity,
+	}
+	if lastReceivedTime := s.lastReceivedTime.Load(); lastReceivedTime != 0 {
+		info.LastReceivedTime = time.Unix(0, lastReceivedTime)
+	}
+	if s.bufferLength != nil {
+		info.BufferLength = s.bufferLength()
+	}
+
+	return info
+}
+
+func newStreamState(streamName string) *streamState {
+	return &streamState{
+		name:    streamName,
+		started: time.Now(),
+	}
+}
+
+// registerStream adds the stream to the active streams, the buffer of the stream must be set up before.
+func (n *nodeBridge) registerStream(state *streamState) {
+	n.streamsMutex.Lock()
+	defer n.streamsMutex.Unlock()
+
+	n.streams[state] = struct{}{}
+}
+
+func (n *nodeBridge) unregisterStream(state *streamState) {
+	n.streamsMutex.Lock()
+	defer n.streamsMutex.Unlock()
+
+	delete(n.streams, state)
+}
+
+func (n *nodeBridge) trackRegisteredAPIRoute(route string, registered bool) {
+	n.registeredAPIRoutesMutex.Lock()
+	defer n.registeredAPIRoutesMutex.Unlock()
+
+	if registered {
+		n.registeredAPIRoutes[route] = struct{}{}
+	} else {
+		delete(n.registeredAPIRoutes, route)
+	}
+}
+
+// DebugInfo returns a snapshot of the internal state of the bridge.
+func (n *nodeBridge) DebugInfo() *DebugInfo {
+	info := &DebugInfo{
+		ConnectionState:   n.ConnectionState().String(),
+		Reconnects:        n.reconnects.Load(),
+		ReadOnly:          n.readOnly,
+		ManagementEnabled: n.managementEnabled,
+		PruningEpoch:      n.PruningEpoch(),
+	}
+
+	if latestCommitment := n.LatestCommitment(); latestCommitment != nil {
+		info.LatestCommittedSlot = latestCommitment.CommitmentID.Slot()
+	}
+	if latestFinalizedCommitment := n.LatestFinalizedCommitment(); latestFinalizedCommitment != nil {
+		info.LatestFinalizedSlot = latestFinalizedCommitment.CommitmentID.Slot()
+	}
+
+	n.streamsMutex.RLock()
+	info.Streams = make([]*StreamDebugInfo, 0, len(n.streams))
+	for state := range n.streams {
+		info.Streams = append(info.Streams, state.debugInfo())
+	}
+	n.streamsMutex.RUnlock()
+	sort.Slice(info.Streams, func(i, j int) bool {
+		if info.Streams[i].Name != info.Streams[j].Name {
+			return info.Streams[i].Name < info.Streams[j].Name
+		}
+
+		return info.Streams[i].Started.Before(info.Streams[j].Started)
+	})
+
+	n.supportedRoutesMutex.RLock()
+	info.SupportedRoutesCache = append([]string{}, n.supportedRoutes...)
+	n.supportedRoutesMutex.RUnlock()
+
+	n.unsupportedCapabilitiesMutex.RLock()
+	info.UnsupportedCapabilities = make([]Capability, 0, len(n.unsupportedCapabilities))
+	for capability := range n.unsupportedCapabilities {
+		info.UnsupportedCapabilities = append(info.UnsupportedCapabilities, capability)
+	}
+	n.unsupportedCapabilitiesMutex.RUnlock()
+	sort.Slice(info.UnsupportedCapabilities, func(i, j int) bool {
+		return info.UnsupportedCapabilities[i] < info.UnsupportedCapabilities[j]
+	})
+
+	n.registeredAPIRoutesMutex.RLock()
+	info.RegisteredAPIRoutes = make([]string, 0, len(n.registeredAPIRoutes))
+	for route := range n.registeredAPIRoutes {
+		info.RegisteredAPIRoutes = append(info.RegisteredAPIRoutes, route)
+	}
+	n.registeredAPIRoutesMutex.RUnlock()
+	sort.Strings(info.RegisteredAPIRoutes)
+
+	return info
+}
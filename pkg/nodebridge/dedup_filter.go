@@ -0,0 +1,188 @@
+package nodebridge
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultDedupFilterSlotWindow is the default amount of slots the seen IDs are kept for.
+	DefaultDedupFilterSlotWindow = 10
+)
+
+// DedupFilterEvents are the events triggered by the DedupFilter.
+type DedupFilterEvents[K comparable] struct {
+	// DuplicateSuppressed is triggered with the slot and the ID of every suppressed duplicate.
+	DuplicateSuppressed *event.Event2[iotago.SlotIndex, K]
+}
+
+// DedupFilter suppresses items that were already seen, e.g. blocks that are redelivered after a reconnect or replay.
+// The IDs of the seen items are kept per slot for a window of slots behind the latest seen slot.
+// Items older than the window can't be checked and are passed through.
+type DedupFilter[K comparable] struct {
+	events     *DedupFilterEvents[K]
+	slotWindow iotago.SlotIndex
+
+	mutex      sync.Mutex
+	seen       map[iotago.SlotIndex]map[K]struct{}
+	latestSlot iotago.SlotIndex
+
+	passed     atomic.Uint64
+	suppressed atomic.Uint64
+}
+
+// NewDedupFilter creates a new DedupFilter that keeps the seen IDs for the given amount of slots.
+func NewDedupFilter[K comparable](slotWindow iotago.SlotIndex) *DedupFilter[K] {
+	return &DedupFilter[K]{
+		events: &DedupFilterEvents[K]{
+			DuplicateSuppressed: event.New2[iotago.SlotIndex, K](),
+		},
+		slotWindow: slotWindow,
+		seen:       make(map[iotago.SlotIndex]map[K]struct{}),
+	}
+}
+
+// Events returns the events of the DedupFilter.
+func (f *DedupFilter[K]) Events() *DedupFilterEvents[K] {
+	return f.events
+}
+
+// Filter returns true if the item with the given ID wasn't seen before and should be processed.
+func (f *DedupFilter[K]) Filter(slot iotago.SlotIndex, id K) bool {
+	if !f.markSeen(slot, id) {
+		f.suppressed.Add(1)
+		f.events.DuplicateSuppressed.Trigger(slot, id)
+
+		return false
+	}
+	f.passed.Add(1)
+
+	return true
+}
+
+// Passed returns the amount of items that passed the filter.
+func (f *DedupFilter[K]) Passed() uint64 {
+	return f.passed.Load()
+}
+
+// Suppressed returns the amount of suppressed duplicates.
+func (f *DedupFilter[K]) Suppressed() uint64 {
+	return f.suppressed.Load()
+}
+
+// markSeen marks the ID as seen and returns false if it was already seen.
+func (f *DedupFilter[K]) markSeen(slot iotago.SlotIndex, id K) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if slot > f.latestSlot {
+		f.latestSlot = slot
+		f.evict()
+	}
+
+	if f.latestSlot > f.slotWindow && slot < f.latestSlot-f.slotWindow {
+		// the slot is outside of the window, so we can't tell if it is a duplicate
+		return true
+	}
+
+	seenInSlot, exists := f.seen[slot]
+	if !exists {
+		seenInSlot = make(map[K]struct{})
+		f.seen[slot] = seenInSlot
+	}
+
+	if _, alreadySeen := seenInSlot[id]; alreadySeen {
+		return false
+	}
+	seenInSlot[id] = struct{}{}
+
+	return true
+}
+
+func (f *DedupFilter[K]) evict() {
+	if f.latestSlot <= f.slotWindow {
+		return
+	}
+
+	for slot := range f.seen {
+		if slot < f.latestSlot-f.slotWindow {
+			delete(f.seen, slot)
+		}
+	}
+}
+
+// BlockMetadataKey identifies a state change of a block.
+type BlockMetadataKey struct {
+	BlockID iotago.BlockID
+	State   api.BlockState
+}
+
+// DedupBlocks returns a consumer for ListenToBlocks that only passes blocks to the given consumer that weren't seen before.
+func DedupBlocks(filter *DedupFilter[iotago.BlockID], consumer func(block *iotago.Block, rawData []byte) error) func(block *iotago.Block, rawData []byte) error {
+	return func(block *iotago.Block, rawData []byte) error {
+		blockID, err := block.ID()
+		if err != nil {
+			return err
+		}
+
+		if !filter.Filter(blockID.Slot(), blockID) {
+			return nil
+		}
+
+		return consumer(block, rawData)
+	}
+}
+
+// NewBlockMetadataDedupFilter creates a new DedupFilter for DedupBlockMetadata.
+func NewBlockMetadataDedupFilter(slotWindow iotago.SlotIndex) *DedupFilter[BlockMetadataKey] {
+	return NewDedupFilter[BlockMetadataKey](slotWindow)
+}
+
+// DedupBlockMetadata returns a consumer for ListenToBlockMetadata that only passes block state changes
+// to the given consumer that weren't seen before.
+func DedupBlockMetadata(filter *DedupFilter[BlockMetadataKey], consumer func(blockMetadata *api.BlockMetadataResponse) error) func(blockMetadata *api.BlockMetadataResponse) error {
+	return func(blockMetadata *api.BlockMetadataResponse) error {
+		if !filter.Filter(blockMetadata.BlockID.Slot(), BlockMetadataKey{BlockID: blockMetadata.BlockID, State: blockMetadata.BlockState}) {
+			return nil
+		}
+
+		return consumer(blockMetadata)
+	}
+}
+
+// DedupCommitments returns a consumer for ListenToCommitments that only passes commitments to the given consumer that weren't seen before.
+func DedupCommitments(filter *DedupFilter[iotago.CommitmentID], consumer func(commitment *Commitment, rawData []byte) error) func(commitment *Commitment, rawData []byte) error {
+	return func(commitment *Commitment, rawData []byte) error {
+		if !filter.Filter(commitment.CommitmentID.Slot(), commitment.CommitmentID) {
+			return nil
+		}
+
+		return consumer(commitment, rawData)
+	}
+}
+
+// DedupLedgerUpdates returns a consumer for ListenToLedgerUpdates that only passes ledger updates to the given consumer that weren't seen before.
+func DedupLedgerUpdates(filter *DedupFilter[iotago.CommitmentID], consumer func(update *LedgerUpdate) error) func(update *LedgerUpdate) error {
+	return func(update *LedgerUpdate) error {
+		if !filter.Filter(update.CommitmentID.Slot(), update.CommitmentID) {
+			return nil
+		}
+
+		return consumer(update)
+	}
+}
+
+// DedupAcceptedTransactions returns a consumer for ListenToAcceptedTransactions that only passes transactions to the given consumer that weren't seen before.
+func DedupAcceptedTransactions(filter *DedupFilter[iotago.TransactionID], consumer func(tx *AcceptedTransaction) error) func(tx *AcceptedTransaction) error {
+	return func(tx *AcceptedTransaction) error {
+		if !filter.Filter(tx.Slot, tx.TransactionID) {
+			return nil
+		}
+
+		return consumer(tx)
+	}
+}
@@ -0,0 +1,176 @@
+package nodebridge
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// EpochClockEvents are the events triggered by the EpochClock.
+type EpochClockEvents struct {
+	// SlotCommitted is triggered for every slot that got committed by the node (in order, without gaps).
+	SlotCommitted *event.Event1[iotago.SlotIndex]
+	// EpochStarted is triggered when the first slot of a new epoch got committed by the node.
+	EpochStarted *event.Event1[iotago.EpochIndex]
+	// EpochEnded is triggered when the last slot of an epoch got committed by the node.
+	EpochEnded *event.Event1[iotago.EpochIndex]
+}
+
+// EpochClock converts between slots, epochs and time based on the protocol parameters of the node,
+// and triggers events at slot and epoch boundaries driven by the commitments of the node.
+type EpochClock struct {
+	nodeBridge NodeBridge
+	clock      clock.Clock
+	events     *EpochClockEvents
+
+	hook *event.Hook[func(*Commitment)]
+
+	lastCommittedSlotMutex sync.Mutex
+	lastCommittedSlot      iotago.SlotIndex
+	lastCommittedSlotValid bool
+}
+
+// WithEpochClockClock sets the clock that is used to determine the current slot and epoch, e.g. a clock.Mock in tests.
+func WithEpochClockClock(timeSource clock.Clock) options.Option[EpochClock] {
+	return func(c *EpochClock) {
+		c.clock = timeSource
+	}
+}
+
+// NewEpochClock creates a new EpochClock that uses the current APIProvider of the given NodeBridge
+// and follows its LatestCommitmentChanged event.
+func NewEpochClock(nodeBridge NodeBridge, opts ...options.Option[EpochClock]) *EpochClock {
+	return options.Apply(&EpochClock{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &EpochClockEvents{
+			SlotCommitted: event.New1[iotago.SlotIndex](),
+			EpochStarted:  event.New1[iotago.EpochIndex](),
+			EpochEnded:    event.New1[iotago.EpochIndex](),
+		},
+	}, opts, func(c *EpochClock) {
+		if latestCommitment := nodeBridge.LatestCommitment(); latestCommitment != nil {
+			c.lastCommittedSlot = latestCommitment.CommitmentID.Slot()
+			c.lastCommittedSlotValid = true
+		}
+
+		c.hook = nodeBridge.Events().LatestCommitmentChanged.Hook(func(commitment *Commitment) {
+			c.processCommittedSlot(commitment.CommitmentID.Slot())
+		})
+	})
+}
+
+// Events returns the events of the EpochClock.
+func (c *EpochClock) Events() *EpochClockEvents {
+	return c.events
+}
+
+// Shutdown detaches the EpochClock from the NodeBridge events.
+func (c *EpochClock) Shutdown() {
+	c.hook.Unhook()
+}
+
+// apiProvider returns the current APIProvider of the NodeBridge, which is replaced when the bridge connects to the node.
+func (c *EpochClock) apiProvider() iotago.APIProvider {
+	return c.nodeBridge.APIProvider()
+}
+
+// SlotFromTime returns the slot that contains the given time.
+func (c *EpochClock) SlotFromTime(t time.Time) iotago.SlotIndex {
+	return c.apiProvider().APIForTime(t).TimeProvider().SlotFromTime(t)
+}
+
+// SlotStartTime returns the start time of the given slot.
+func (c *EpochClock) SlotStartTime(slot iotago.SlotIndex) time.Time {
+	return c.apiProvider().APIForSlot(slot).TimeProvider().SlotStartTime(slot)
+}
+
+// SlotEndTime returns the end time of the given slot.
+func (c *EpochClock) SlotEndTime(slot iotago.SlotIndex) time.Time {
+	return c.apiProvider().APIForSlot(slot).TimeProvider().SlotEndTime(slot)
+}
+
+// EpochFromSlot returns the epoch that contains the given slot.
+func (c *EpochClock) EpochFromSlot(slot iotago.SlotIndex) iotago.EpochIndex {
+	return c.apiProvider().APIForSlot(slot).TimeProvider().EpochFromSlot(slot)
+}
+
+// EpochFromTime returns the epoch that contains the given time.
+func (c *EpochClock) EpochFromTime(t time.Time) iotago.EpochIndex {
+	return c.EpochFromSlot(c.SlotFromTime(t))
+}
+
+// EpochStartSlot returns the first slot of the given epoch.
+func (c *EpochClock) EpochStartSlot(epoch iotago.EpochIndex) iotago.SlotIndex {
+	return c.apiProvider().APIForEpoch(epoch).TimeProvider().EpochStart(epoch)
+}
+
+// EpochEndSlot returns the last slot of the given epoch.
+func (c *EpochClock) EpochEndSlot(epoch iotago.EpochIndex) iotago.SlotIndex {
+	return c.apiProvider().APIForEpoch(epoch).TimeProvider().EpochEnd(epoch)
+}
+
+// EpochStartTime returns the start time of the given epoch.
+func (c *EpochClock) EpochStartTime(epoch iotago.EpochIndex) time.Time {
+	return c.SlotStartTime(c.EpochStartSlot(epoch))
+}
+
+// EpochEndTime returns the end time of the given epoch.
+func (c *EpochClock) EpochEndTime(epoch iotago.EpochIndex) time.Time {
+	return c.SlotEndTime(c.EpochEndSlot(epoch))
+}
+
+// CurrentSlot returns the slot of the current time of the clock.
+func (c *EpochClock) CurrentSlot() iotago.SlotIndex {
+	return c.SlotFromTime(c.clock.Now())
+}
+
+// CurrentEpoch returns the epoch of the current time of the clock.
+func (c *EpochClock) CurrentEpoch() iotago.EpochIndex {
+	return c.EpochFromTime(c.clock.Now())
+}
+
+// TimeUntilSlotEnd returns the duration until the current slot ends.
+func (c *EpochClock) TimeUntilSlotEnd() time.Duration {
+	now := c.clock.Now()
+
+	return c.SlotEndTime(c.SlotFromTime(now)).Sub(now)
+}
+
+// TimeUntilEpochEnd returns the duration until the current epoch ends.
+func (c *EpochClock) TimeUntilEpochEnd() time.Duration {
+	now := c.clock.Now()
+
+	return c.EpochEndTime(c.EpochFromTime(now)).Sub(now)
+}
+
+func (c *EpochClock) processCommittedSlot(slot iotago.SlotIndex) {
+	c.lastCommittedSlotMutex.Lock()
+	defer c.lastCommittedSlotMutex.Unlock()
+
+	if !c.lastCommittedSlotValid {
+		// we don't know where we came from, so we only trigger the event for the given slot
+		c.lastCommittedSlot = slot - 1
+		c.lastCommittedSlotValid = true
+	}
+
+	for committedSlot := c.lastCommittedSlot + 1; committedSlot <= slot; committedSlot++ {
+		epoch := c.EpochFromSlot(committedSlot)
+
+		if committedSlot == c.EpochStartSlot(epoch) {
+			c.events.EpochStarted.Trigger(epoch)
+		}
+
+		c.events.SlotCommitted.Trigger(committedSlot)
+
+		if committedSlot == c.EpochEndSlot(epoch) {
+			c.events.EpochEnded.Trigger(epoch)
+		}
+
+		c.lastCommittedSlot = committedSlot
+	}
+}
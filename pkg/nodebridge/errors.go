@@ -0,0 +1,69 @@
+package nodebridge
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrNotFound is returned if the requested data was not found on the node.
+	ErrNotFound = ierrors.New("not found")
+	// ErrUnavailable is returned if the node is not reachable or can't serve the request at the moment.
+	ErrUnavailable = ierrors.New("node unavailable")
+	// ErrInvalidArgument is returned if the node rejected the request because of invalid arguments.
+	ErrInvalidArgument = ierrors.New("invalid argument")
+	// ErrNodeNotSynced is returned if the node can't serve the request because it is not synced.
+	ErrNodeNotSynced = ierrors.New("node not synced")
+	// ErrPruned is returned if the requested data was already pruned by the node.
+	ErrPruned = ierrors.New("pruned")
+)
+
+// statusError is an error that keeps the original error message,
+// but additionally matches the exported bridge errors that were derived from the gRPC status.
+type statusError struct {
+	err   error
+	kinds []error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() []error {
+	return append([]error{e.err}, e.kinds...)
+}
+
+// wrapINXError adds the given context to the error returned by an INX call
+// and maps the gRPC status code to the exported bridge errors.
+func (n *nodeBridge) wrapINXError(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	var kinds []error
+	switch status.Code(err) {
+	case codes.NotFound:
+		kinds = append(kinds, ErrNotFound)
+	case codes.InvalidArgument, codes.OutOfRange:
+		kinds = append(kinds, ErrInvalidArgument)
+	case codes.FailedPrecondition:
+		kinds = append(kinds, ErrNodeNotSynced)
+	case codes.Unavailable:
+		kinds = append(kinds, ErrUnavailable)
+		if nodeStatus := n.NodeStatus(); nodeStatus != nil && !nodeStatus.GetIsHealthy() {
+			kinds = append(kinds, ErrNodeNotSynced)
+		}
+	}
+
+	wrappedErr := ierrors.Wrapf(err, format, args...)
+	if len(kinds) == 0 {
+		return wrappedErr
+	}
+
+	return &statusError{
+		err:   wrappedErr,
+		kinds: kinds,
+	}
+}
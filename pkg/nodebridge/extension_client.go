@@ -0,0 +1,145 @@
+package nodebridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// maxExtensionErrorBodyLength is the maximum amount of bytes of an error response that is added to the error.
+	maxExtensionErrorBodyLength = 512
+)
+
+var (
+	// ErrExtensionRouteNotAvailable is returned if the route of the extension is not registered at the node.
+	ErrExtensionRouteNotAvailable = ierrors.New("extension route not available")
+	// ErrExtensionRequestFailed is returned if the extension answered a request with a non-success status code.
+	ErrExtensionRequestFailed = ierrors.New("extension request failed")
+)
+
+type authTokenContextKey struct{}
+
+// ContextWithAuthToken returns a context that passes the given bearer token to the extension
+// for all requests of an ExtensionClient that are issued with the returned context,
+// e.g. to forward the token of the request that is currently handled.
+func ContextWithAuthToken(ctx context.Context, authToken string) context.Context {
+	return context.WithValue(ctx, authTokenContextKey{}, authToken)
+}
+
+// AuthTokenFromRequest returns the bearer token of the given request, or an empty string if there is none.
+func AuthTokenFromRequest(req *http.Request) string {
+	authToken, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		return ""
+	}
+
+	return authToken
+}
+
+// ExtensionClient calls the REST API that another extension registered at the node,
+// through the API gateway of the node.
+type ExtensionClient struct {
+	httpClient *http.Client
+	route      string
+}
+
+// ExtensionClient returns a client for the REST API that another extension registered under the given route (e.g. "indexer/v2").
+// Returns ErrExtensionRouteNotAvailable if the route is not registered at the node.
+func (n *nodeBridge) ExtensionClient(ctx context.Context, route string) (*ExtensionClient, error) {
+	route = strings.Trim(route, "/")
+
+	hasRoute, err := n.waitForPlugin(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+	if !hasRoute {
+		return nil, ierrors.Wrapf(ErrExtensionRouteNotAvailable, "route %s not found in the supported routes of the node", route)
+	}
+
+	return &ExtensionClient{
+		httpClient: inx.NewHTTPClientOverINX(n.client),
+		route:      route,
+	}, nil
+}
+
+// Route returns the route of the extension.
+func (c *ExtensionClient) Route() string {
+	return c.route
+}
+
+// Do sends a request with the given method and body to the given path relative to the route of the extension,
+// e.g. "/outputs/basic". The caller must close the body of the response.
+func (c *ExtensionClient) Do(ctx context.Context, method string, path string, body io.Reader, header http.Header) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s/%s/%s", inx.APIRoundTripperBaseURL, api.APIRoot, c.route, strings.TrimPrefix(path, "/"))
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to create request for %s", url)
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if authToken, ok := ctx.Value(authTokenContextKey{}).(string); ok && authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "request %s %s failed", method, url)
+	}
+
+	return res, nil
+}
+
+// DoJSON sends reqObj as JSON to the given path relative to the route of the extension and decodes the JSON response into resObj.
+// reqObj and resObj can be nil if the request or response has no body.
+// Returns ErrExtensionRequestFailed if the extension answered with a non-success status code.
+func (c *ExtensionClient) DoJSON(ctx context.Context, method string, path string, reqObj any, resObj any) error {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	var body io.Reader
+	if reqObj != nil {
+		data, err := json.Marshal(reqObj)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to marshal request")
+		}
+		body = bytes.NewReader(data)
+		header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.Do(ctx, method, path, body, header)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		errorBody, _ := io.ReadAll(io.LimitReader(res.Body, maxExtensionErrorBodyLength))
+
+		return ierrors.Wrapf(ErrExtensionRequestFailed, "%s %s/%s returned status %d: %s", method, c.route, strings.TrimPrefix(path, "/"), res.StatusCode, strings.TrimSpace(string(errorBody)))
+	}
+
+	if resObj == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(resObj); err != nil {
+		return ierrors.Wrapf(err, "failed to decode response of %s %s/%s", method, c.route, strings.TrimPrefix(path, "/"))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package nodebridge
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultFetchCommitmentsParallelism is the default maximum amount of concurrent commitment reads of FetchCommitments.
+	DefaultFetchCommitmentsParallelism = 8
+)
+
+var (
+	// ErrCommitmentMissing is returned if the node has no commitment for a slot in the requested range.
+	ErrCommitmentMissing = ierrors.New("commitment missing")
+)
+
+// FetchCommitmentsOptions define how FetchCommitments reads the commitments.
+type FetchCommitmentsOptions struct {
+	parallelism int
+}
+
+// WithFetchCommitmentsParallelism sets the maximum amount of concurrent commitment reads.
+func WithFetchCommitmentsParallelism(parallelism int) options.Option[FetchCommitmentsOptions] {
+	return func(o *FetchCommitmentsOptions) {
+		o.parallelism = parallelism
+	}
+}
+
+// FetchCommitments concurrently reads the commitments from fromSlot to toSlot (inclusive)
+// and returns them ordered by slot, e.g. to rebuild the commitment history after a downtime.
+// It fails on the first commitment that could not be read.
+func (n *nodeBridge) FetchCommitments(ctx context.Context, fromSlot iotago.SlotIndex, toSlot iotago.SlotIndex, opts ...options.Option[FetchCommitmentsOptions]) ([]*Commitment, error) {
+	if fromSlot > toSlot {
+		return nil, ierrors.Errorf("invalid slot range, fromSlot: %d, toSlot: %d", fromSlot, toSlot)
+	}
+
+	if err := n.checkSlotPruned(fromSlot); err != nil {
+		return nil, err
+	}
+
+	fetchOptions := options.Apply(&FetchCommitmentsOptions{
+		parallelism: DefaultFetchCommitmentsParallelism,
+	}, opts)
+
+	commitments := make([]*Commitment, int(toSlot-fromSlot)+1)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(fetchOptions.parallelism, 1))
+
+	for i := range commitments {
+		slot := fromSlot + iotago.SlotIndex(i)
+
+		group.Go(func() error {
+			commitment, err := n.Commitment(groupCtx, slot)
+			if err != nil {
+				return ierrors.Wrapf(err, "unable to read commitment for slot %d", slot)
+			}
+			if commitment == nil {
+				return ierrors.Wrapf(ErrCommitmentMissing, "slot %d", slot)
+			}
+
+			// every goroutine writes its own index, so no lock is needed
+			commitments[i] = commitment
+
+			return nil
+		})
+
+		if groupCtx.Err() != nil {
+			break
+		}
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return commitments, nil
+}
@@ -0,0 +1,279 @@
+package nodebridge
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultIssuanceQueueBlocksPerSlot is the default maximum amount of blocks submitted per slot.
+	DefaultIssuanceQueueBlocksPerSlot = 10
+	// DefaultIssuanceQueueMaxSize is the default maximum amount of queued blocks.
+	DefaultIssuanceQueueMaxSize = 1000
+)
+
+var (
+	// ErrIssuanceQueueFull is returned if a block is submitted while the queue is full.
+	ErrIssuanceQueueFull = ierrors.New("issuance queue is full")
+)
+
+// IssuancePriority is the priority class of a block in the IssuanceQueue.
+type IssuancePriority int
+
+const (
+	// IssuancePriorityLow is for blocks that can wait, e.g. spam or housekeeping.
+	IssuancePriorityLow IssuancePriority = iota
+	// IssuancePriorityNormal is for regular blocks.
+	IssuancePriorityNormal
+	// IssuancePriorityHigh is for blocks that should be submitted as soon as possible, e.g. user requests.
+	IssuancePriorityHigh
+
+	issuancePriorityCount = int(IssuancePriorityHigh) + 1
+)
+
+// IssuanceQueueEvents are the events triggered by the IssuanceQueue.
+type IssuanceQueueEvents struct {
+	// Throttled is triggered with the slot and the limit of the slot if the limit is reached and blocks are held back.
+	Throttled *event.Event2[iotago.SlotIndex, int]
+}
+
+type issuanceResult struct {
+	blockID iotago.BlockID
+	err     error
+}
+
+type issuanceRequest struct {
+	ctx    context.Context
+	block  *iotago.Block
+	result chan *issuanceResult
+}
+
+// IssuanceQueue is a queue in front of SubmitBlock that limits the amount of blocks submitted per slot,
+// so extensions that issue many blocks don't get throttled or penalized by the node.
+// Blocks with a higher priority are submitted first, blocks with the same priority in the order they were queued.
+// If congestion throttling is enabled, the limit is lowered by the ratio of the minimum reference mana cost
+// to the reference mana cost of the latest commitment.
+type IssuanceQueue struct {
+	nodeBridge NodeBridge
+	clock      clock.Clock
+	events     *IssuanceQueueEvents
+
+	blocksPerSlot        int
+	maxSize              int
+	congestionThrottling bool
+
+	queueMutex sync.Mutex
+	queues     [issuancePriorityCount][]*issuanceRequest
+	size       int
+	signal     chan struct{}
+
+	// only accessed by Run
+	currentSlot  iotago.SlotIndex
+	issuedInSlot int
+}
+
+// WithIssuanceQueueBlocksPerSlot sets the maximum amount of blocks submitted per slot.
+// If blocksPerSlot is zero, the amount is not limited.
+func WithIssuanceQueueBlocksPerSlot(blocksPerSlot int) options.Option[IssuanceQueue] {
+	return func(q *IssuanceQueue) {
+		q.blocksPerSlot = blocksPerSlot
+	}
+}
+
+// WithIssuanceQueueMaxSize sets the maximum amount of queued blocks.
+func WithIssuanceQueueMaxSize(maxSize int) options.Option[IssuanceQueue] {
+	return func(q *IssuanceQueue) {
+		q.maxSize = maxSize
+	}
+}
+
+// WithIssuanceQueueCongestionThrottling lowers the amount of blocks submitted per slot
+// if the reference mana cost of the latest commitment is above the minimum.
+func WithIssuanceQueueCongestionThrottling(enabled bool) options.Option[IssuanceQueue] {
+	return func(q *IssuanceQueue) {
+		q.congestionThrottling = enabled
+	}
+}
+
+// WithIssuanceQueueClock sets the clock that is used to determine the current slot, e.g. a clock.Mock in tests.
+func WithIssuanceQueueClock(timeSource clock.Clock) options.Option[IssuanceQueue] {
+	return func(q *IssuanceQueue) {
+		q.clock = timeSource
+	}
+}
+
+// NewIssuanceQueue creates a new IssuanceQueue.
+func NewIssuanceQueue(nodeBridge NodeBridge, opts ...options.Option[IssuanceQueue]) *IssuanceQueue {
+	return options.Apply(&IssuanceQueue{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &IssuanceQueueEvents{
+			Throttled: event.New2[iotago.SlotIndex, int](),
+		},
+		blocksPerSlot:        DefaultIssuanceQueueBlocksPerSlot,
+		maxSize:              DefaultIssuanceQueueMaxSize,
+		congestionThrottling: true,
+		signal:               make(chan struct{}, 1),
+	}, opts)
+}
+
+// Events returns the events of the IssuanceQueue.
+func (q *IssuanceQueue) Events() *IssuanceQueueEvents {
+	return q.events
+}
+
+// Len returns the amount of queued blocks.
+func (q *IssuanceQueue) Len() int {
+	q.queueMutex.Lock()
+	defer q.queueMutex.Unlock()
+
+	return q.size
+}
+
+// Submit queues the block with the given priority and waits until it was submitted.
+// If the context is done before the block was submitted, the block is dropped from the queue.
+func (q *IssuanceQueue) Submit(ctx context.Context, block *iotago.Block, priority IssuancePriority) (iotago.BlockID, error) {
+	priority = max(IssuancePriorityLow, min(priority, IssuancePriorityHigh))
+
+	request := &issuanceRequest{
+		ctx:    ctx,
+		block:  block,
+		result: make(chan *issuanceResult, 1),
+	}
+
+	q.queueMutex.Lock()
+	if q.maxSize > 0 && q.size >= q.maxSize {
+		q.queueMutex.Unlock()
+
+		return iotago.EmptyBlockID, ErrIssuanceQueueFull
+	}
+	q.queues[priority] = append(q.queues[priority], request)
+	q.size++
+	q.queueMutex.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-ctx.Done():
+		return iotago.EmptyBlockID, ctx.Err()
+	case result := <-request.result:
+		return result.blockID, result.err
+	}
+}
+
+// Run submits the queued blocks within the limits of the current slot and blocks until the given context is done.
+func (q *IssuanceQueue) Run(ctx context.Context) {
+	for {
+		if !q.waitForSlotBudget(ctx) {
+			return
+		}
+
+		request := q.next()
+		if request == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.signal:
+				continue
+			}
+		}
+
+		if err := request.ctx.Err(); err != nil {
+			// the caller is not waiting anymore
+			continue
+		}
+
+		blockID, err := q.nodeBridge.SubmitBlock(request.ctx, request.block)
+		q.issuedInSlot++
+
+		request.result <- &issuanceResult{blockID: blockID, err: err}
+	}
+}
+
+// next removes the queued block with the highest priority from the queue.
+func (q *IssuanceQueue) next() *issuanceRequest {
+	q.queueMutex.Lock()
+	defer q.queueMutex.Unlock()
+
+	for priority := issuancePriorityCount - 1; priority >= 0; priority-- {
+		if len(q.queues[priority]) == 0 {
+			continue
+		}
+
+		request := q.queues[priority][0]
+		q.queues[priority][0] = nil
+		q.queues[priority] = q.queues[priority][1:]
+		q.size--
+
+		return request
+	}
+
+	return nil
+}
+
+// waitForSlotBudget waits until another block can be submitted in the current slot.
+// Returns false if the context is done.
+func (q *IssuanceQueue) waitForSlotBudget(ctx context.Context) bool {
+	for {
+		now := q.clock.Now()
+		timeProvider := q.nodeBridge.APIProvider().APIForTime(now).TimeProvider()
+
+		slot := timeProvider.SlotFromTime(now)
+		if slot != q.currentSlot {
+			q.currentSlot = slot
+			q.issuedInSlot = 0
+		}
+
+		limit := q.slotLimit()
+		if q.issuedInSlot < limit {
+			return true
+		}
+
+		if q.Len() > 0 {
+			q.events.Throttled.Trigger(slot, limit)
+		}
+
+		timer := q.clock.NewTimer(timeProvider.SlotStartTime(slot + 1).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return false
+		case <-timer.C():
+		}
+	}
+}
+
+// slotLimit returns the maximum amount of blocks that can be submitted in the current slot.
+func (q *IssuanceQueue) slotLimit() int {
+	if q.blocksPerSlot <= 0 {
+		return math.MaxInt
+	}
+
+	if !q.congestionThrottling {
+		return q.blocksPerSlot
+	}
+
+	latestCommitment := q.nodeBridge.LatestCommitment()
+	if latestCommitment == nil {
+		return q.blocksPerSlot
+	}
+
+	minReferenceManaCost := q.nodeBridge.APIProvider().CommittedAPI().ProtocolParameters().CongestionControlParameters().MinReferenceManaCost
+	referenceManaCost := latestCommitment.Commitment.ReferenceManaCost
+	if minReferenceManaCost == 0 || referenceManaCost <= minReferenceManaCost {
+		return q.blocksPerSlot
+	}
+
+	return max(1, int(uint64(q.blocksPerSlot)*uint64(minReferenceManaCost)/uint64(referenceManaCost)))
+}
@@ -0,0 +1,73 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// LazyBlock is a block received from the node that is only deserialized when it is accessed.
+type LazyBlock struct {
+	apiProvider iotago.APIProvider
+	blockID     iotago.BlockID
+	data        []byte
+
+	unwrapOnce sync.Once
+	block      *iotago.Block
+	err        error
+}
+
+// NewLazyBlock creates a new LazyBlock from the raw block data.
+func NewLazyBlock(apiProvider iotago.APIProvider, blockID iotago.BlockID, data []byte) *LazyBlock {
+	return &LazyBlock{
+		apiProvider: apiProvider,
+		blockID:     blockID,
+		data:        data,
+	}
+}
+
+// ID returns the ID of the block.
+func (b *LazyBlock) ID() iotago.BlockID {
+	return b.blockID
+}
+
+// Data returns the raw serialized block.
+func (b *LazyBlock) Data() []byte {
+	return b.data
+}
+
+// Block returns the deserialized block. The block is only deserialized on the first call.
+func (b *LazyBlock) Block() (*iotago.Block, error) {
+	b.unwrapOnce.Do(func() {
+		b.block, _, b.err = iotago.BlockFromBytes(b.apiProvider)(b.data)
+	})
+
+	return b.block, b.err
+}
+
+// ListenToRawBlocks listens to blocks without deserializing them.
+// This is the fastest way to follow the blocks if the consumer only needs the raw bytes.
+func (n *nodeBridge) ListenToRawBlocks(ctx context.Context, consumer func(blockID iotago.BlockID, rawData []byte) error) error {
+	stream, err := n.client.ListenToBlocks(ctx, &inx.NoParams{})
+	if err != nil {
+		return n.wrapINXError(err, "failed to listen to blocks")
+	}
+
+	if err := listenToStream(ctx, n, StreamNameBlocks, stream.Recv, func(block *inx.Block) error {
+		return consumer(block.UnwrapBlockID(), block.GetBlock().GetData())
+	}); err != nil {
+		n.LogErrorf("ListenToRawBlocks failed: %s", err.Error())
+		return n.wrapINXError(err, "ListenToRawBlocks failed")
+	}
+
+	return nil
+}
+
+// ListenToLazyBlocks listens to blocks that are only deserialized if the consumer accesses them.
+func (n *nodeBridge) ListenToLazyBlocks(ctx context.Context, consumer func(block *LazyBlock) error) error {
+	return n.ListenToRawBlocks(ctx, func(blockID iotago.BlockID, rawData []byte) error {
+		return consumer(NewLazyBlock(n.apiProvider, blockID, rawData))
+	})
+}
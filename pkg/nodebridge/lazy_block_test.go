@@ -0,0 +1,185 @@
+package nodebridge
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/log"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+// benchmarkBlocksClient is an INX client that streams the same serialized block message b.N times.
+type benchmarkBlocksClient struct {
+	inx.INXClient
+
+	message []byte
+	count   int
+}
+
+func (c *benchmarkBlocksClient) ListenToBlocks(_ context.Context, _ *inx.NoParams, opts ...grpc.CallOption) (inx.INX_ListenToBlocksClient, error) {
+	stream := &benchmarkBlocksStream{
+		message:   c.message,
+		remaining: c.count,
+	}
+
+	for _, opt := range opts {
+		if codecOption, ok := opt.(grpc.ForceCodecCallOption); ok {
+			stream.codec = codecOption.Codec
+		}
+	}
+
+	return stream, nil
+}
+
+// benchmarkBlocksStream unmarshals the message for every received block, like the gRPC stream of the node.
+// If a codec is forced by the call options, it is used instead of the default proto codec.
+type benchmarkBlocksStream struct {
+	grpc.ClientStream
+
+	codec     encoding.Codec
+	message   []byte
+	remaining int
+}
+
+func (s *benchmarkBlocksStream) Recv() (*inx.Block, error) {
+	block := &inx.Block{}
+	if err := s.RecvMsg(block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (s *benchmarkBlocksStream) RecvMsg(m any) error {
+	if s.remaining == 0 {
+		return io.EOF
+	}
+	s.remaining--
+
+	if s.codec != nil {
+		return s.codec.Unmarshal(s.message, m)
+	}
+
+	//nolint:forcetypeassert // the stream only receives blocks
+	return proto.Unmarshal(s.message, m.(proto.Message))
+}
+
+func newBenchmarkNodeBridge(b *testing.B) *nodeBridge {
+	b.Helper()
+
+	protocolParameters := iotago.NewV3SnapshotProtocolParameters(iotago.WithNetworkOptions("benchmark", iotago.PrefixTestnet))
+	testAPI := iotago.V3API(protocolParameters)
+
+	block, err := builder.NewBasicBlockBuilder(testAPI).
+		StrongParents(iotago.BlockIDs{tpkg.RandBlockID()}).
+		Payload(&iotago.TaggedData{Tag: []byte("benchmark"), Data: tpkg.RandBytes(1024)}).
+		Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	blockID, err := block.ID()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	rawBlock, err := inx.WrapBlock(block)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	message, err := proto.Marshal(&inx.Block{BlockId: inx.NewBlockId(blockID), Block: rawBlock})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	//nolint:forcetypeassert // New always returns a *nodeBridge
+	n := New(log.NewLogger(log.WithName("benchmark"))).(*nodeBridge)
+	n.apiProvider.AddProtocolParametersAtEpoch(protocolParameters, 0)
+	n.client = &benchmarkBlocksClient{
+		message: message,
+		count:   b.N,
+	}
+
+	return n
+}
+
+func BenchmarkListenToBlocks(b *testing.B) {
+	n := newBenchmarkNodeBridge(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := n.ListenToBlocks(context.Background(), func(_ *iotago.Block, _ []byte) error {
+		return nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkListenToRawBlocks(b *testing.B) {
+	n := newBenchmarkNodeBridge(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := n.ListenToRawBlocks(context.Background(), func(_ iotago.BlockID, _ []byte) error {
+		return nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkListenToLazyBlocks(b *testing.B) {
+	n := newBenchmarkNodeBridge(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := n.ListenToLazyBlocks(context.Background(), func(block *LazyBlock) error {
+		_ = block.ID()
+
+		return nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkListenToLazyBlocksDeserialized(b *testing.B) {
+	n := newBenchmarkNodeBridge(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := n.ListenToLazyBlocks(context.Background(), func(block *LazyBlock) error {
+		_, err := block.Block()
+
+		return err
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkListenToBorrowedBlocks(b *testing.B) {
+	n := newBenchmarkNodeBridge(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := n.ListenToBorrowedBlocks(context.Background(), func(block *BorrowedBlock) error {
+		_ = block.ID()
+		block.Release()
+
+		return nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -37,88 +37,10 @@ type LedgerUpdate struct {
 
 // ListenToLedgerUpdates listens to ledger updates.
 func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *LedgerUpdate) error) error {
-	req := &inx.SlotRangeRequest{
-		StartSlot: uint32(startSlot),
-		EndSlot:   uint32(endSlot),
-	}
-
-	stream, err := n.client.ListenToLedgerUpdates(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	var update *LedgerUpdate
-	var latestCommitmentID iotago.CommitmentID
-	if err := ListenToStream(ctx, stream.Recv, func(payload *inx.LedgerUpdate) error {
-		switch op := payload.GetOp().(type) {
-		case *inx.LedgerUpdate_BatchMarker:
-			switch op.BatchMarker.GetMarkerType() {
-			case inx.LedgerUpdate_Marker_BEGIN:
-				commitmentID := op.BatchMarker.GetCommitmentId().Unwrap()
-				n.LogDebugf("BEGIN batch: commitmentID: %s, consumed: %d, created: %d", commitmentID, op.BatchMarker.GetConsumedCount(), op.BatchMarker.GetCreatedCount())
-				if update != nil {
-					return ErrLedgerUpdateTransactionAlreadyInProgress
-				}
-
-				update = &LedgerUpdate{
-					API:          n.apiProvider.APIForSlot(commitmentID.Slot()),
-					CommitmentID: commitmentID,
-					Consumed:     make([]*Output, 0),
-					Created:      make([]*Output, 0),
-				}
-				latestCommitmentID = n.LatestCommitment().CommitmentID
-
-			case inx.LedgerUpdate_Marker_END:
-				commitmentID := op.BatchMarker.GetCommitmentId().Unwrap()
-				n.LogDebugf("END batch: commitmentID: %s, consumed: %d, created: %d", commitmentID, op.BatchMarker.GetConsumedCount(), op.BatchMarker.GetCreatedCount())
-				if update == nil {
-					return ErrLedgerUpdateInvalidOperation
-				}
-
-				if uint32(len(update.Consumed)) != op.BatchMarker.GetConsumedCount() ||
-					uint32(len(update.Created)) != op.BatchMarker.GetCreatedCount() ||
-					update.CommitmentID != commitmentID {
-					return ErrLedgerUpdateEndedAbruptly
-				}
-
-				if err := consumer(update); err != nil {
-					return err
-				}
-				update = nil
-			}
-
-		case *inx.LedgerUpdate_Consumed:
-			if update == nil {
-				return ErrLedgerUpdateInvalidOperation
-			}
-
-			output, err := n.unwrapOutput(op.Consumed.GetOutput(), op.Consumed, latestCommitmentID)
-			if err != nil {
-				return ierrors.Wrap(err, "unable to unwrap consumed output")
-			}
-
-			update.Consumed = append(update.Consumed, output)
-
-		case *inx.LedgerUpdate_Created:
-			if update == nil {
-				return ErrLedgerUpdateInvalidOperation
-			}
-
-			output, err := n.unwrapOutput(op.Created, nil, latestCommitmentID)
-			if err != nil {
-				return ierrors.Wrap(err, "unable to unwrap created output")
-			}
-
-			update.Created = append(update.Created, output)
-		}
-
-		return nil
-	}); err != nil {
-		n.LogErrorf("ListenToLedgerUpdates failed: %s", err.Error())
-		return err
-	}
-
-	return nil
+	return n.ListenToLedgerUpdatesWithConsumer(ctx, startSlot, endSlot, &ledgerUpdateCollector{
+		apiProvider: n.apiProvider,
+		consumer:    consumer,
+	})
 }
 
 type AcceptedTransaction struct {
@@ -133,10 +55,10 @@ type AcceptedTransaction struct {
 func (n *nodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer func(*AcceptedTransaction) error) error {
 	stream, err := n.client.ListenToAcceptedTransactions(ctx, &inx.NoParams{})
 	if err != nil {
-		return err
+		return n.wrapINXError(err, "failed to listen to accepted transactions")
 	}
 
-	if err := ListenToStream(ctx, stream.Recv, func(tx *inx.AcceptedTransaction) error {
+	if err := listenToStream(ctx, n, StreamNameAcceptedTransactions, stream.Recv, func(tx *inx.AcceptedTransaction) error {
 		slot := iotago.SlotIndex(tx.GetSlot())
 
 		latestCommitmentID := n.LatestCommitment().CommitmentID
@@ -172,7 +94,7 @@ func (n *nodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer
 		})
 	}); err != nil {
 		n.LogErrorf("ListenToAcceptedTransactions failed: %s", err.Error())
-		return err
+		return n.wrapINXError(err, "ListenToAcceptedTransactions failed")
 	}
 
 	return nil
@@ -0,0 +1,215 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// LedgerUpdateCounts are the amounts of outputs of a ledger update, which are announced before the outputs are received.
+type LedgerUpdateCounts struct {
+	// Consumed is the amount of consumed outputs.
+	Consumed uint32
+	// Created is the amount of created outputs.
+	Created uint32
+}
+
+// LedgerUpdateConsumer receives the operations of ledger updates in the order they are framed by the INX stream.
+// For every slot, OnBegin is called first, then OnConsumedOutput and OnCreatedOutput for every output
+// in the order they are received, and OnEnd is called last after all announced outputs were received.
+// A ledger update is always completed before the next one begins.
+// If an operation returns an error, the stream ends with that error.
+type LedgerUpdateConsumer interface {
+	// OnBegin is called when the ledger update of the given slot begins.
+	OnBegin(slot iotago.SlotIndex, counts LedgerUpdateCounts) error
+	// OnConsumedOutput is called for every output that was consumed in the slot.
+	OnConsumedOutput(output *Output) error
+	// OnCreatedOutput is called for every output that was created in the slot.
+	OnCreatedOutput(output *Output) error
+	// OnEnd is called when all outputs of the ledger update were received.
+	OnEnd(commitmentID iotago.CommitmentID) error
+}
+
+// ledgerUpdateState is the state of the ledger update that is currently received.
+type ledgerUpdateState struct {
+	commitmentID       iotago.CommitmentID
+	latestCommitmentID iotago.CommitmentID
+	counts             LedgerUpdateCounts
+	received           LedgerUpdateCounts
+	// err is the error of the first operation of the consumer that failed,
+	// if the batch is passed to the dead-letter handler at its end marker.
+	err error
+}
+
+// failed handles the error of an operation of the consumer within the ledger update.
+// Without a dead-letter handler, the error ends the stream. Otherwise the ledger update is marked as failed,
+// so the consumer doesn't receive the remaining operations and the end of the partial ledger update,
+// and the whole batch is passed to the dead-letter handler once at its end marker.
+func (u *ledgerUpdateState) failed(n *nodeBridge, err error) error {
+	if err == nil || n.deadLetterHandler == nil || ierrors.Is(err, context.Canceled) || ierrors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	if u.err == nil {
+		u.err = err
+	}
+
+	return nil
+}
+
+// ListenToLedgerUpdatesWithConsumer listens to ledger updates and passes their operations to the given consumer,
+// without keeping the outputs of a ledger update in memory.
+func (n *nodeBridge) ListenToLedgerUpdatesWithConsumer(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer LedgerUpdateConsumer) error {
+	if startSlot > 0 {
+		if err := n.checkSlotPruned(startSlot); err != nil {
+			return err
+		}
+	}
+
+	req := &inx.SlotRangeRequest{
+		StartSlot: uint32(startSlot),
+		EndSlot:   uint32(endSlot),
+	}
+
+	stream, err := n.client.ListenToLedgerUpdates(ctx, req)
+	if err != nil {
+		return n.wrapINXError(err, "failed to listen to ledger updates")
+	}
+
+	var update *ledgerUpdateState
+	if err := listenToStream(ctx, n, StreamNameLedgerUpdates, stream.Recv, func(payload *inx.LedgerUpdate) error {
+		switch op := payload.GetOp().(type) {
+		case *inx.LedgerUpdate_BatchMarker:
+			switch op.BatchMarker.GetMarkerType() {
+			case inx.LedgerUpdate_Marker_BEGIN:
+				commitmentID := op.BatchMarker.GetCommitmentId().Unwrap()
+				n.LogDebugf("BEGIN batch: commitmentID: %s, consumed: %d, created: %d", commitmentID, op.BatchMarker.GetConsumedCount(), op.BatchMarker.GetCreatedCount())
+				if update != nil {
+					// drop the incomplete update, so the stream can continue with the next batch if the error is handled
+					update = nil
+
+					return ErrLedgerUpdateTransactionAlreadyInProgress
+				}
+
+				update = &ledgerUpdateState{
+					commitmentID:       commitmentID,
+					latestCommitmentID: n.LatestCommitment().CommitmentID,
+					counts: LedgerUpdateCounts{
+						Consumed: op.BatchMarker.GetConsumedCount(),
+						Created:  op.BatchMarker.GetCreatedCount(),
+					},
+				}
+
+				return update.failed(n, consumer.OnBegin(commitmentID.Slot(), update.counts))
+
+			case inx.LedgerUpdate_Marker_END:
+				commitmentID := op.BatchMarker.GetCommitmentId().Unwrap()
+				n.LogDebugf("END batch: commitmentID: %s, consumed: %d, created: %d", commitmentID, op.BatchMarker.GetConsumedCount(), op.BatchMarker.GetCreatedCount())
+				if update == nil {
+					return ErrLedgerUpdateInvalidOperation
+				}
+
+				completedUpdate := update
+
+				// reset the update before calling the consumer, so a recovered panic doesn't leave the batch in progress
+				update = nil
+
+				if completedUpdate.received.Consumed != op.BatchMarker.GetConsumedCount() ||
+					completedUpdate.received.Created != op.BatchMarker.GetCreatedCount() ||
+					completedUpdate.commitmentID != commitmentID {
+					return ErrLedgerUpdateEndedAbruptly
+				}
+
+				if completedUpdate.err != nil {
+					// the end marker is passed to the dead-letter handler for the whole batch
+					return ierrors.Wrapf(completedUpdate.err, "ledger update of commitment %s failed", commitmentID)
+				}
+
+				return consumer.OnEnd(commitmentID)
+			}
+
+		case *inx.LedgerUpdate_Consumed:
+			if update == nil {
+				return ErrLedgerUpdateInvalidOperation
+			}
+
+			update.received.Consumed++
+			if update.err != nil {
+				// the batch already failed, the remaining outputs are skipped
+				return nil
+			}
+
+			output, err := n.unwrapOutput(op.Consumed.GetOutput(), op.Consumed, update.latestCommitmentID)
+			if err != nil {
+				return update.failed(n, ierrors.Wrap(err, "unable to unwrap consumed output"))
+			}
+
+			return update.failed(n, consumer.OnConsumedOutput(output))
+
+		case *inx.LedgerUpdate_Created:
+			if update == nil {
+				return ErrLedgerUpdateInvalidOperation
+			}
+
+			update.received.Created++
+			if update.err != nil {
+				// the batch already failed, the remaining outputs are skipped
+				return nil
+			}
+
+			output, err := n.unwrapOutput(op.Created, nil, update.latestCommitmentID)
+			if err != nil {
+				return update.failed(n, ierrors.Wrap(err, "unable to unwrap created output"))
+			}
+
+			return update.failed(n, consumer.OnCreatedOutput(output))
+		}
+
+		return nil
+	}); err != nil {
+		n.LogErrorf("ListenToLedgerUpdates failed: %s", err.Error())
+		return n.wrapINXError(err, "ListenToLedgerUpdates failed")
+	}
+
+	return nil
+}
+
+// ledgerUpdateCollector is a LedgerUpdateConsumer that collects the operations of a ledger update into a LedgerUpdate.
+type ledgerUpdateCollector struct {
+	apiProvider iotago.APIProvider
+	consumer    func(update *LedgerUpdate) error
+
+	update *LedgerUpdate
+}
+
+func (c *ledgerUpdateCollector) OnBegin(slot iotago.SlotIndex, counts LedgerUpdateCounts) error {
+	c.update = &LedgerUpdate{
+		API:      c.apiProvider.APIForSlot(slot),
+		Consumed: make([]*Output, 0, counts.Consumed),
+		Created:  make([]*Output, 0, counts.Created),
+	}
+
+	return nil
+}
+
+func (c *ledgerUpdateCollector) OnConsumedOutput(output *Output) error {
+	c.update.Consumed = append(c.update.Consumed, output)
+
+	return nil
+}
+
+func (c *ledgerUpdateCollector) OnCreatedOutput(output *Output) error {
+	c.update.Created = append(c.update.Created, output)
+
+	return nil
+}
+
+func (c *ledgerUpdateCollector) OnEnd(commitmentID iotago.CommitmentID) error {
+	completedUpdate := c.update
+	completedUpdate.CommitmentID = commitmentID
+	c.update = nil
+
+	return c.consumer(completedUpdate)
+}
@@ -0,0 +1,206 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultStreamingLedgerUpdatesBufferSize is the default amount of outputs of a streaming ledger update
+	// that are buffered until the consumer reads them.
+	DefaultStreamingLedgerUpdatesBufferSize = 100
+)
+
+// LedgerUpdateOutput is an output of a streaming ledger update.
+type LedgerUpdateOutput struct {
+	// Output is the consumed or created output.
+	Output *Output
+	// Consumed is true if the output was consumed and false if it was created.
+	Consumed bool
+}
+
+// StreamingLedgerUpdate is a ledger update whose outputs are delivered incrementally while they are received,
+// so only a bounded amount of outputs of a slot is kept in memory.
+type StreamingLedgerUpdate struct {
+	// API is the API of the slot of the ledger update.
+	API iotago.API
+	// Slot is the slot of the ledger update.
+	Slot iotago.SlotIndex
+	// Counts are the amounts of outputs of the ledger update, e.g. to report the progress.
+	Counts LedgerUpdateCounts
+
+	outputs      chan *LedgerUpdateOutput
+	commitmentID iotago.CommitmentID
+	err          error
+}
+
+// Outputs returns the channel that delivers the outputs of the ledger update in the order they are received.
+// The channel is closed after the last output or if the ledger update ended abruptly.
+func (u *StreamingLedgerUpdate) Outputs() <-chan *LedgerUpdateOutput {
+	return u.outputs
+}
+
+// CommitmentID returns the ID of the commitment of the ledger update.
+// It is only set after the Outputs channel was closed and Err returns nil.
+func (u *StreamingLedgerUpdate) CommitmentID() iotago.CommitmentID {
+	return u.commitmentID
+}
+
+// Err returns ErrLedgerUpdateEndedAbruptly if the ledger update ended before all outputs were received.
+// It is only set after the Outputs channel was closed.
+func (u *StreamingLedgerUpdate) Err() error {
+	return u.err
+}
+
+// StreamingLedgerUpdatesOptions define how ListenToStreamingLedgerUpdates delivers the ledger updates.
+type StreamingLedgerUpdatesOptions struct {
+	bufferSize int
+}
+
+// WithStreamingLedgerUpdatesBufferSize sets the amount of outputs that are buffered until the consumer reads them.
+// The stream of the node is paused while the buffer is full.
+func WithStreamingLedgerUpdatesBufferSize(bufferSize int) options.Option[StreamingLedgerUpdatesOptions] {
+	return func(o *StreamingLedgerUpdatesOptions) {
+		o.bufferSize = bufferSize
+	}
+}
+
+// ListenToStreamingLedgerUpdates listens to ledger updates and passes every ledger update to the given consumer
+// when it begins, before its outputs were received. The consumer reads the outputs from StreamingLedgerUpdate.Outputs
+// while they are received, and is called for the next ledger update after it returned.
+// If the consumer returns before all outputs were read, the remaining outputs of the ledger update are skipped.
+func (n *nodeBridge) ListenToStreamingLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *StreamingLedgerUpdate) error, opts ...options.Option[StreamingLedgerUpdatesOptions]) error {
+	streamOptions := options.Apply(&StreamingLedgerUpdatesOptions{
+		bufferSize: DefaultStreamingLedgerUpdatesBufferSize,
+	}, opts)
+
+	streamingConsumer := &streamingLedgerUpdateConsumer{
+		ctx:         ctx,
+		apiProvider: n.apiProvider,
+		bufferSize:  max(streamOptions.bufferSize, 0),
+		consumer:    consumer,
+	}
+	// stop the consumer of an incomplete ledger update if the stream ended
+	defer streamingConsumer.abort()
+
+	return n.ListenToLedgerUpdatesWithConsumer(ctx, startSlot, endSlot, streamingConsumer)
+}
+
+// streamingLedgerUpdateConsumer is a LedgerUpdateConsumer that runs the consumer of a streaming ledger update
+// in its own goroutine and passes the outputs to it through a bounded channel.
+type streamingLedgerUpdateConsumer struct {
+	ctx         context.Context
+	apiProvider iotago.APIProvider
+	bufferSize  int
+	consumer    func(update *StreamingLedgerUpdate) error
+
+	update           *StreamingLedgerUpdate
+	consumerDone     chan struct{}
+	consumerErr      error
+	consumerPanic    any
+	consumerPanicked bool
+}
+
+func (c *streamingLedgerUpdateConsumer) OnBegin(slot iotago.SlotIndex, counts LedgerUpdateCounts) error {
+	if c.update != nil {
+		// the previous ledger update ended abruptly and the error was handled by the stream
+		if err := c.finish(iotago.EmptyCommitmentID, ErrLedgerUpdateEndedAbruptly); err != nil {
+			return err
+		}
+	}
+
+	update := &StreamingLedgerUpdate{
+		API:     c.apiProvider.APIForSlot(slot),
+		Slot:    slot,
+		Counts:  counts,
+		outputs: make(chan *LedgerUpdateOutput, c.bufferSize),
+	}
+
+	consumerDone := make(chan struct{})
+	c.update = update
+	c.consumerDone = consumerDone
+	c.consumerErr = nil
+	c.consumerPanic = nil
+	c.consumerPanicked = false
+
+	go func() {
+		defer close(consumerDone)
+		defer func() {
+			// the panic is raised again in the stream, so it is handled by the panic policy of the stream
+			if r := recover(); r != nil {
+				c.consumerPanic = r
+				c.consumerPanicked = true
+			}
+		}()
+
+		c.consumerErr = c.consumer(update)
+	}()
+
+	return nil
+}
+
+func (c *streamingLedgerUpdateConsumer) OnConsumedOutput(output *Output) error {
+	return c.send(&LedgerUpdateOutput{Output: output, Consumed: true})
+}
+
+func (c *streamingLedgerUpdateConsumer) OnCreatedOutput(output *Output) error {
+	return c.send(&LedgerUpdateOutput{Output: output, Consumed: false})
+}
+
+func (c *streamingLedgerUpdateConsumer) OnEnd(commitmentID iotago.CommitmentID) error {
+	return c.finish(commitmentID, nil)
+}
+
+// send passes the output to the consumer, or skips it if the consumer already returned.
+func (c *streamingLedgerUpdateConsumer) send(output *LedgerUpdateOutput) error {
+	select {
+	case c.update.outputs <- output:
+		return nil
+	case <-c.consumerDone:
+		return c.consumerResult()
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+// finish closes the outputs of the current ledger update and waits until the consumer returned.
+func (c *streamingLedgerUpdateConsumer) finish(commitmentID iotago.CommitmentID, err error) error {
+	c.update.commitmentID = commitmentID
+	c.update.err = err
+	close(c.update.outputs)
+
+	<-c.consumerDone
+	c.update = nil
+
+	return c.consumerResult()
+}
+
+// consumerResult returns the error of the consumer or raises its panic again, but only once per ledger update,
+// so the remaining outputs are skipped if the error is handled by the stream.
+// It must only be called after the consumer returned.
+func (c *streamingLedgerUpdateConsumer) consumerResult() error {
+	if c.consumerPanicked {
+		c.consumerPanicked = false
+		panic(c.consumerPanic)
+	}
+
+	err := c.consumerErr
+	c.consumerErr = nil
+
+	return err
+}
+
+// abort ends the current ledger update, if any, and waits until its consumer returned.
+func (c *streamingLedgerUpdateConsumer) abort() {
+	if c.update == nil {
+		return
+	}
+
+	c.update.err = ErrLedgerUpdateEndedAbruptly
+	close(c.update.outputs)
+
+	<-c.consumerDone
+	c.update = nil
+}
@@ -0,0 +1,235 @@
+package nodebridge
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// managedEventAPIQoS is the quality of service of the subscriptions of the ManagedEventAPI.
+	managedEventAPIQoS = 2
+	// managedEventAPIConnectTimeout is the timeout for the initial connection to the broker of the node.
+	managedEventAPIConnectTimeout = 10 * time.Second
+)
+
+// ManagedEventAPIEvents are the events triggered by the ManagedEventAPI.
+type ManagedEventAPIEvents struct {
+	// ConnectionLost is triggered with the error if the connection to the broker was lost.
+	ConnectionLost *event.Event1[error]
+	// Reconnected is triggered after the connection to the broker was re-established and the topics were resubscribed.
+	Reconnected *event.Event
+	// Error is triggered if a payload could not be decoded or a topic could not be resubscribed.
+	Error *event.Event1[error]
+}
+
+// ManagedEventAPI is an EventAPI client whose MQTT connection is managed by the bridge.
+// It reconnects automatically, resubscribes all topics after a reconnect
+// and disconnects once the context it was created with is done.
+type ManagedEventAPI struct {
+	nodeBridge *nodeBridge
+	client     mqtt.Client
+	events     *ManagedEventAPIEvents
+
+	subscriptionsMutex sync.Mutex
+	subscriptions      map[string]mqtt.MessageHandler
+	connectedOnce      bool
+}
+
+// ManagedEventAPISubscription is a subscription to a topic of a ManagedEventAPI.
+type ManagedEventAPISubscription struct {
+	eventAPI *ManagedEventAPI
+	topic    string
+}
+
+// ManagedEventAPI returns an EventAPI client whose connection is managed by the bridge until the given context is done.
+// Returns ErrMQTTPluginNotAvailable if the current node does not support the plugin.
+func (n *nodeBridge) ManagedEventAPI(ctx context.Context) (*ManagedEventAPI, error) {
+	eventAPIClient, err := n.EventAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ManagedEventAPI{
+		nodeBridge: n,
+		events: &ManagedEventAPIEvents{
+			ConnectionLost: event.New1[error](),
+			Reconnected:    event.New(),
+			Error:          event.New1[error](),
+		},
+		subscriptions: make(map[string]mqtt.MessageHandler),
+	}
+
+	clientOpts := mqtt.NewClientOptions()
+	clientOpts.Order = false
+	clientOpts.ClientID = "inx-app-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	optionsReader := eventAPIClient.MQTTClient.OptionsReader()
+	for _, server := range optionsReader.Servers() {
+		clientOpts.AddBroker(server.String())
+	}
+	clientOpts.AutoReconnect = true
+	clientOpts.ConnectRetry = true
+	clientOpts.OnConnectionLost = func(_ mqtt.Client, err error) {
+		n.LogWarnf("EventAPI connection lost: %s", err)
+		m.events.ConnectionLost.Trigger(err)
+	}
+	clientOpts.OnConnect = m.onConnect
+	m.client = mqtt.NewClient(clientOpts)
+
+	token := m.client.Connect()
+	if !token.WaitTimeout(managedEventAPIConnectTimeout) {
+		m.client.Disconnect(0)
+
+		return nil, ierrors.New("timeout while connecting to the EventAPI of the node")
+	}
+	if err := token.Error(); err != nil {
+		return nil, ierrors.Wrap(err, "failed to connect to the EventAPI of the node")
+	}
+
+	go func() {
+		<-ctx.Done()
+		m.client.Disconnect(250)
+	}()
+
+	return m, nil
+}
+
+// Events returns the events of the ManagedEventAPI.
+func (m *ManagedEventAPI) Events() *ManagedEventAPIEvents {
+	return m.events
+}
+
+// IsConnected returns true if the client is connected to the broker of the node.
+func (m *ManagedEventAPI) IsConnected() bool {
+	return m.client.IsConnectionOpen()
+}
+
+// onConnect resubscribes all topics after a reconnect.
+func (m *ManagedEventAPI) onConnect(client mqtt.Client) {
+	m.subscriptionsMutex.Lock()
+	reconnected := m.connectedOnce
+	m.connectedOnce = true
+	subscriptions := make(map[string]mqtt.MessageHandler, len(m.subscriptions))
+	for topic, handler := range m.subscriptions {
+		subscriptions[topic] = handler
+	}
+	m.subscriptionsMutex.Unlock()
+
+	if !reconnected {
+		return
+	}
+
+	for topic, handler := range subscriptions {
+		if token := client.Subscribe(topic, managedEventAPIQoS, handler); token.Wait() && token.Error() != nil {
+			m.events.Error.Trigger(ierrors.Wrapf(token.Error(), "failed to resubscribe topic %s", topic))
+		}
+	}
+
+	m.nodeBridge.LogInfof("EventAPI reconnected, resubscribed %d topics", len(subscriptions))
+	m.events.Reconnected.Trigger()
+}
+
+// SubscribeEventAPITopic subscribes to the given topic of the ManagedEventAPI and passes the decoded payloads to the consumer.
+// Payloads that can't be decoded trigger the Error event.
+func SubscribeEventAPITopic[T any](m *ManagedEventAPI, topic string, decode func(payload []byte) (T, error), consumer func(T)) (*ManagedEventAPISubscription, error) {
+	handler := func(_ mqtt.Client, message mqtt.Message) {
+		obj, err := decode(message.Payload())
+		if err != nil {
+			m.events.Error.Trigger(ierrors.Wrapf(err, "failed to decode payload of topic %s", message.Topic()))
+
+			return
+		}
+
+		consumer(obj)
+	}
+
+	m.subscriptionsMutex.Lock()
+	defer m.subscriptionsMutex.Unlock()
+
+	if token := m.client.Subscribe(topic, managedEventAPIQoS, handler); token.Wait() && token.Error() != nil {
+		return nil, ierrors.Wrapf(token.Error(), "failed to subscribe topic %s", topic)
+	}
+	m.subscriptions[topic] = handler
+
+	return &ManagedEventAPISubscription{
+		eventAPI: m,
+		topic:    topic,
+	}, nil
+}
+
+// Topic returns the topic of the subscription.
+func (s *ManagedEventAPISubscription) Topic() string {
+	return s.topic
+}
+
+// Close unsubscribes from the topic.
+func (s *ManagedEventAPISubscription) Close() error {
+	s.eventAPI.subscriptionsMutex.Lock()
+	defer s.eventAPI.subscriptionsMutex.Unlock()
+
+	delete(s.eventAPI.subscriptions, s.topic)
+
+	if token := s.eventAPI.client.Unsubscribe(s.topic); token.Wait() && token.Error() != nil {
+		return ierrors.Wrapf(token.Error(), "failed to unsubscribe topic %s", s.topic)
+	}
+
+	return nil
+}
+
+// SubscribeBlocks passes the blocks of the given blocks topic (e.g. api.EventAPITopicBlocksBasic) to the consumer.
+func (m *ManagedEventAPI) SubscribeBlocks(topic string, consumer func(block *iotago.Block)) (*ManagedEventAPISubscription, error) {
+	return SubscribeEventAPITopic(m, topic+api.EventAPITopicSuffixRaw, func(payload []byte) (*iotago.Block, error) {
+		block, _, err := iotago.BlockFromBytes(m.nodeBridge.apiProvider)(payload)
+
+		return block, err
+	}, consumer)
+}
+
+// SubscribeLatestCommitments passes the latest commitments of the node to the consumer.
+func (m *ManagedEventAPI) SubscribeLatestCommitments(consumer func(commitment *iotago.Commitment)) (*ManagedEventAPISubscription, error) {
+	return SubscribeEventAPITopic(m, api.EventAPITopicCommitmentsLatest+api.EventAPITopicSuffixRaw, decodeWithCommittedAPI[iotago.Commitment](m), consumer)
+}
+
+// SubscribeFinalizedCommitments passes the finalized commitments of the node to the consumer.
+func (m *ManagedEventAPI) SubscribeFinalizedCommitments(consumer func(commitment *iotago.Commitment)) (*ManagedEventAPISubscription, error) {
+	return SubscribeEventAPITopic(m, api.EventAPITopicCommitmentsFinalized+api.EventAPITopicSuffixRaw, decodeWithCommittedAPI[iotago.Commitment](m), consumer)
+}
+
+// SubscribeBlockMetadata passes the block metadata of the given block metadata topic (e.g. api.EventAPITopicBlockMetadataAccepted) to the consumer.
+func (m *ManagedEventAPI) SubscribeBlockMetadata(topic string, consumer func(blockMetadata *api.BlockMetadataResponse)) (*ManagedEventAPISubscription, error) {
+	return SubscribeEventAPITopic(m, topic+api.EventAPITopicSuffixRaw, decodeWithCommittedAPI[api.BlockMetadataResponse](m), consumer)
+}
+
+// SubscribeTransactionMetadata passes the metadata changes of the given transaction to the consumer.
+func (m *ManagedEventAPI) SubscribeTransactionMetadata(transactionID iotago.TransactionID, consumer func(transactionMetadata *api.TransactionMetadataResponse)) (*ManagedEventAPISubscription, error) {
+	topic := api.EndpointWithNamedParameterValue(api.EventAPITopicTransactionMetadata, api.ParameterTransactionID, transactionID.ToHex())
+
+	return SubscribeEventAPITopic(m, topic+api.EventAPITopicSuffixRaw, decodeWithCommittedAPI[api.TransactionMetadataResponse](m), consumer)
+}
+
+// SubscribeOutputsByUnlockConditionAndAddress passes the changes of the outputs that match the given unlock condition and address to the consumer.
+func (m *ManagedEventAPI) SubscribeOutputsByUnlockConditionAndAddress(condition api.EventAPIUnlockCondition, address iotago.Address, consumer func(output *api.OutputWithMetadataResponse)) (*ManagedEventAPISubscription, error) {
+	topic := api.EndpointWithNamedParameterValue(api.EventAPITopicOutputsByUnlockConditionAndAddress, api.ParameterCondition, string(condition))
+	topic = api.EndpointWithNamedParameterValue(topic, api.ParameterAddress, address.Bech32(m.nodeBridge.Bech32HRP()))
+
+	return SubscribeEventAPITopic(m, topic+api.EventAPITopicSuffixRaw, decodeWithCommittedAPI[api.OutputWithMetadataResponse](m), consumer)
+}
+
+func decodeWithCommittedAPI[T any](m *ManagedEventAPI) func(payload []byte) (*T, error) {
+	return func(payload []byte) (*T, error) {
+		obj := new(T)
+		if _, err := m.nodeBridge.apiProvider.CommittedAPI().Decode(payload, obj); err != nil {
+			return nil, err
+		}
+
+		return obj, nil
+	}
+}
@@ -0,0 +1,344 @@
+package nodebridge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+const (
+	// DefaultManagedIndexerCacheTTL is the default duration for which indexer responses are cached.
+	DefaultManagedIndexerCacheTTL = 2 * time.Second
+	// DefaultManagedIndexerCacheSize is the default maximum amount of cached indexer responses.
+	DefaultManagedIndexerCacheSize = 1000
+	// DefaultManagedIndexerMaxRetries is the default amount of retries of a failed indexer request.
+	DefaultManagedIndexerMaxRetries = 3
+	// DefaultManagedIndexerRetryBackoff is the default duration between two retries of a failed indexer request.
+	DefaultManagedIndexerRetryBackoff = 200 * time.Millisecond
+)
+
+// IndexerQueryResult is the result of a drained indexer query.
+// Results may be shared between callers via the cache, so they must not be modified.
+type IndexerQueryResult struct {
+	// OutputIDs are the output IDs of all pages of the query.
+	OutputIDs iotago.OutputIDs
+	// CommittedSlot is the slot of the ledger state the first page of the query was answered from.
+	CommittedSlot iotago.SlotIndex
+}
+
+// ManagedIndexerStats are the statistics of a ManagedIndexer.
+type ManagedIndexerStats struct {
+	// Requests is the amount of requests sent to the indexer, including retries and pages.
+	Requests uint64 `json:"requests"`
+	// CacheHits is the amount of queries that were answered from the cache.
+	CacheHits uint64 `json:"cacheHits"`
+	// Retries is the amount of retried requests.
+	Retries uint64 `json:"retries"`
+	// Errors is the amount of queries that failed after all retries.
+	Errors uint64 `json:"errors"`
+}
+
+type managedIndexerCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// ManagedIndexer wraps the IndexerClient of the node with response caching for hot queries,
+// draining of paginated queries, retries with backoff and request statistics.
+type ManagedIndexer struct {
+	nodeBridge NodeBridge
+
+	cacheTTL     time.Duration
+	cacheSize    int
+	maxRetries   int
+	retryBackoff time.Duration
+
+	cacheMutex sync.Mutex
+	cache      map[string]*managedIndexerCacheEntry
+
+	requests  atomic.Uint64
+	cacheHits atomic.Uint64
+	retries   atomic.Uint64
+	errors    atomic.Uint64
+}
+
+// WithManagedIndexerCache sets the duration for which indexer responses are cached and the maximum amount of cached responses.
+// If ttl is zero, the responses are not cached.
+func WithManagedIndexerCache(ttl time.Duration, size int) options.Option[ManagedIndexer] {
+	return func(i *ManagedIndexer) {
+		i.cacheTTL = ttl
+		i.cacheSize = size
+	}
+}
+
+// WithManagedIndexerRetries sets the amount of retries of a failed indexer request and the duration between them.
+// The backoff is doubled after every retry.
+func WithManagedIndexerRetries(maxRetries int, backoff time.Duration) options.Option[ManagedIndexer] {
+	return func(i *ManagedIndexer) {
+		i.maxRetries = maxRetries
+		i.retryBackoff = backoff
+	}
+}
+
+// NewManagedIndexer creates a new ManagedIndexer.
+func NewManagedIndexer(nodeBridge NodeBridge, opts ...options.Option[ManagedIndexer]) *ManagedIndexer {
+	return options.Apply(&ManagedIndexer{
+		nodeBridge:   nodeBridge,
+		cacheTTL:     DefaultManagedIndexerCacheTTL,
+		cacheSize:    DefaultManagedIndexerCacheSize,
+		maxRetries:   DefaultManagedIndexerMaxRetries,
+		retryBackoff: DefaultManagedIndexerRetryBackoff,
+		cache:        make(map[string]*managedIndexerCacheEntry),
+	}, opts)
+}
+
+// Stats returns the statistics of the ManagedIndexer.
+func (i *ManagedIndexer) Stats() *ManagedIndexerStats {
+	return &ManagedIndexerStats{
+		Requests:  i.requests.Load(),
+		CacheHits: i.cacheHits.Load(),
+		Retries:   i.retries.Load(),
+		Errors:    i.errors.Load(),
+	}
+}
+
+// ClearCache removes all cached responses.
+func (i *ManagedIndexer) ClearCache() {
+	i.cacheMutex.Lock()
+	defer i.cacheMutex.Unlock()
+
+	i.cache = make(map[string]*managedIndexerCacheEntry)
+}
+
+// QueryAll runs the given query and drains all pages of the result.
+// Returns ErrIndexerPluginNotAvailable if the current node does not support the plugin.
+func (i *ManagedIndexer) QueryAll(ctx context.Context, query nodeclient.IndexerQuery) (*IndexerQueryResult, error) {
+	// the offset of the query is modified while draining, so always start from the first page
+	query.SetOffset(nil)
+
+	urlParams, err := query.URLParams()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "invalid indexer query")
+	}
+
+	return managedIndexerQuery(ctx, i, fmt.Sprintf("%T?%s", query, urlParams), func(ctx context.Context, indexer nodeclient.IndexerClient) (*IndexerQueryResult, error) {
+		query.SetOffset(nil)
+
+		resultSet, err := indexer.Outputs(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &IndexerQueryResult{}
+		for firstPage := true; resultSet.Next(); firstPage = false {
+			i.requests.Add(1)
+
+			if firstPage {
+				result.CommittedSlot = resultSet.Response.CommittedSlot
+			}
+
+			outputIDs, err := resultSet.Response.Items.OutputIDs()
+			if err != nil {
+				return nil, ierrors.Wrap(err, "invalid output IDs in indexer response")
+			}
+			result.OutputIDs = append(result.OutputIDs, outputIDs...)
+		}
+		if resultSet.Error != nil {
+			return nil, resultSet.Error
+		}
+
+		return result, nil
+	})
+}
+
+// Outputs runs the given query, drains all pages of the result and returns the outputs with metadata.
+// Returns ErrIndexerPluginNotAvailable if the current node does not support the plugin.
+func (i *ManagedIndexer) Outputs(ctx context.Context, query nodeclient.IndexerQuery) ([]*Output, error) {
+	result, err := i.QueryAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]*Output, 0, len(result.OutputIDs))
+	for _, outputID := range result.OutputIDs {
+		output, err := i.nodeBridge.Output(ctx, outputID)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// Account returns the ID of the current output of the account with the given address.
+// Returns ErrIndexerPluginNotAvailable if the current node does not support the plugin.
+func (i *ManagedIndexer) Account(ctx context.Context, accountAddress *iotago.AccountAddress) (iotago.OutputID, error) {
+	return managedIndexerQuery(ctx, i, "account:"+accountAddress.String(), func(ctx context.Context, indexer nodeclient.IndexerClient) (iotago.OutputID, error) {
+		i.requests.Add(1)
+		outputID, _, _, err := indexer.Account(ctx, accountAddress)
+		if err != nil {
+			return iotago.EmptyOutputID, err
+		}
+
+		return *outputID, nil
+	})
+}
+
+// NFT returns the ID of the current output of the NFT with the given address.
+// Returns ErrIndexerPluginNotAvailable if the current node does not support the plugin.
+func (i *ManagedIndexer) NFT(ctx context.Context, nftAddress *iotago.NFTAddress) (iotago.OutputID, error) {
+	return managedIndexerQuery(ctx, i, "nft:"+nftAddress.String(), func(ctx context.Context, indexer nodeclient.IndexerClient) (iotago.OutputID, error) {
+		i.requests.Add(1)
+		outputID, _, _, err := indexer.NFT(ctx, nftAddress)
+		if err != nil {
+			return iotago.EmptyOutputID, err
+		}
+
+		return *outputID, nil
+	})
+}
+
+// Foundry returns the ID of the current output of the foundry with the given ID.
+// Returns ErrIndexerPluginNotAvailable if the current node does not support the plugin.
+func (i *ManagedIndexer) Foundry(ctx context.Context, foundryID iotago.FoundryID) (iotago.OutputID, error) {
+	return managedIndexerQuery(ctx, i, "foundry:"+foundryID.ToHex(), func(ctx context.Context, indexer nodeclient.IndexerClient) (iotago.OutputID, error) {
+		i.requests.Add(1)
+		outputID, _, _, err := indexer.Foundry(ctx, foundryID)
+		if err != nil {
+			return iotago.EmptyOutputID, err
+		}
+
+		return *outputID, nil
+	})
+}
+
+// Delegation returns the ID of the current output of the delegation with the given ID.
+// Returns ErrIndexerPluginNotAvailable if the current node does not support the plugin.
+func (i *ManagedIndexer) Delegation(ctx context.Context, delegationID iotago.DelegationID) (iotago.OutputID, error) {
+	return managedIndexerQuery(ctx, i, "delegation:"+delegationID.ToHex(), func(ctx context.Context, indexer nodeclient.IndexerClient) (iotago.OutputID, error) {
+		i.requests.Add(1)
+		outputID, _, _, err := indexer.Delegation(ctx, delegationID)
+		if err != nil {
+			return iotago.EmptyOutputID, err
+		}
+
+		return *outputID, nil
+	})
+}
+
+// managedIndexerQuery answers the query from the cache or runs it with retries and caches the result.
+func managedIndexerQuery[T any](ctx context.Context, i *ManagedIndexer, key string, query func(ctx context.Context, indexer nodeclient.IndexerClient) (T, error)) (T, error) {
+	if cached, ok := i.cached(key); ok {
+		//nolint:forcetypeassert // the key determines the type of the cached value
+		return cached.(T), nil
+	}
+
+	var result T
+
+	indexer, err := i.nodeBridge.Indexer(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	backoff := i.retryBackoff
+	for attempt := 0; ; attempt++ {
+		result, err = query(ctx, indexer)
+		if err == nil {
+			i.store(key, result)
+
+			return result, nil
+		}
+
+		if attempt >= i.maxRetries || !isRetriableIndexerError(err) {
+			i.errors.Add(1)
+
+			return result, err
+		}
+
+		i.retries.Add(1)
+		select {
+		case <-ctx.Done():
+			i.errors.Add(1)
+
+			return result, ierrors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isRetriableIndexerError returns true if the indexer request failed because of a temporary error.
+func isRetriableIndexerError(err error) bool {
+	if ierrors.Is(err, nodeclient.ErrHTTPInternalServerError) || ierrors.Is(err, nodeclient.ErrHTTPServiceUnavailable) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (i *ManagedIndexer) cached(key string) (any, bool) {
+	if i.cacheTTL <= 0 {
+		return nil, false
+	}
+
+	i.cacheMutex.Lock()
+	defer i.cacheMutex.Unlock()
+
+	entry, exists := i.cache[key]
+	if !exists {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(i.cache, key)
+
+		return nil, false
+	}
+
+	i.cacheHits.Add(1)
+
+	return entry.value, true
+}
+
+func (i *ManagedIndexer) store(key string, value any) {
+	if i.cacheTTL <= 0 {
+		return
+	}
+
+	i.cacheMutex.Lock()
+	defer i.cacheMutex.Unlock()
+
+	now := time.Now()
+	if len(i.cache) >= i.cacheSize {
+		// remove the expired entries first, and drop the whole cache if it is still full
+		for cachedKey, entry := range i.cache {
+			if now.After(entry.expires) {
+				delete(i.cache, cachedKey)
+			}
+		}
+		if len(i.cache) >= i.cacheSize {
+			i.cache = make(map[string]*managedIndexerCacheEntry)
+		}
+	}
+
+	i.cache[key] = &managedIndexerCacheEntry{
+		value:   value,
+		expires: now.Add(i.cacheTTL),
+	}
+}
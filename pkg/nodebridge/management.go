@@ -0,0 +1,96 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+// WithManagementEnabled enables the node management operations (pruning, snapshot creation).
+// They are disabled by default, because they irreversibly change the database of the node.
+func WithManagementEnabled(enabled bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.managementEnabled = enabled
+	}
+}
+
+// managementClient returns the ManagementClient if node management operations are allowed.
+func (n *nodeBridge) managementClient(ctx context.Context) (nodeclient.ManagementClient, error) {
+	if err := n.checkCapability(CapabilityManagement); err != nil {
+		return nil, err
+	}
+
+	return n.Management(ctx)
+}
+
+// PruneDatabaseByEpoch triggers the node to prune its database until the given epoch.
+// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+func (n *nodeBridge) PruneDatabaseByEpoch(ctx context.Context, epoch iotago.EpochIndex) (*api.PruneDatabaseResponse, error) {
+	management, err := n.managementClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n.LogInfof("Pruning database of the node until epoch %d ...", epoch)
+	response, err := management.PruneDatabaseByEpoch(ctx, epoch)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to prune database until epoch %d", epoch)
+	}
+
+	return response, nil
+}
+
+// PruneDatabaseByDepth triggers the node to prune its database and only keep the given amount of epochs.
+// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+func (n *nodeBridge) PruneDatabaseByDepth(ctx context.Context, depth iotago.EpochIndex) (*api.PruneDatabaseResponse, error) {
+	management, err := n.managementClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n.LogInfof("Pruning database of the node to a depth of %d epochs ...", depth)
+	response, err := management.PruneDatabaseByDepth(ctx, depth)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to prune database to a depth of %d epochs", depth)
+	}
+
+	return response, nil
+}
+
+// PruneDatabaseBySize triggers the node to prune its database until it is smaller than the given size (e.g. "50GB").
+// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+func (n *nodeBridge) PruneDatabaseBySize(ctx context.Context, targetDatabaseSize string) (*api.PruneDatabaseResponse, error) {
+	management, err := n.managementClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n.LogInfof("Pruning database of the node to a size of %s ...", targetDatabaseSize)
+	response, err := management.PruneDatabaseBySize(ctx, targetDatabaseSize)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to prune database to a size of %s", targetDatabaseSize)
+	}
+
+	return response, nil
+}
+
+// CreateSnapshot triggers the node to create a snapshot.
+// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+func (n *nodeBridge) CreateSnapshot(ctx context.Context) (*api.CreateSnapshotResponse, error) {
+	management, err := n.managementClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n.LogInfo("Creating snapshot of the node ...")
+	response, err := management.CreateSnapshot(ctx)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "unable to create snapshot")
+	}
+
+	return response, nil
+}
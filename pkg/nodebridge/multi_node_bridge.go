@@ -0,0 +1,383 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultMultiNodeBridgeReconnectInterval is the default interval in which a dropped node is reconnected.
+	DefaultMultiNodeBridgeReconnectInterval = 5 * time.Second
+	// DefaultMultiNodeBridgeHealthCheckInterval is the default interval in which the primary node is re-evaluated.
+	DefaultMultiNodeBridgeHealthCheckInterval = 1 * time.Second
+)
+
+var (
+	ErrNoNodeAvailable = ierrors.New("no node available")
+)
+
+// MultiNodeBridgeEvents are the events triggered by the MultiNodeBridge.
+type MultiNodeBridgeEvents struct {
+	// PrimaryChanged is triggered with the new primary NodeBridge if the primary node changed.
+	PrimaryChanged *event.Event1[NodeBridge]
+}
+
+// MultiNodeBridge maintains connections to several nodes via INX, routes reads to the healthiest node
+// and fails over streams if the primary node becomes unhealthy.
+type MultiNodeBridge struct {
+	// the logger used to log events.
+	log.Logger
+
+	addresses []string
+	events    *MultiNodeBridgeEvents
+
+	bridgeOptions         []options.Option[nodeBridge]
+	maxConnectionAttempts uint
+	reconnectInterval     time.Duration
+	healthCheckInterval   time.Duration
+
+	bridgesMutex sync.RWMutex
+	bridges      []NodeBridge
+	primary      NodeBridge
+}
+
+// WithMultiNodeBridgeOptions sets the options that are used for every NodeBridge of the MultiNodeBridge.
+func WithMultiNodeBridgeOptions(bridgeOptions ...options.Option[nodeBridge]) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.bridgeOptions = bridgeOptions
+	}
+}
+
+// WithMultiNodeBridgeReconnectInterval sets the interval in which a dropped node is reconnected.
+func WithMultiNodeBridgeReconnectInterval(reconnectInterval time.Duration) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.reconnectInterval = reconnectInterval
+	}
+}
+
+// WithMultiNodeBridgeHealthCheckInterval sets the interval in which the primary node is re-evaluated.
+func WithMultiNodeBridgeHealthCheckInterval(healthCheckInterval time.Duration) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.healthCheckInterval = healthCheckInterval
+	}
+}
+
+// NewMultiNodeBridge creates a new MultiNodeBridge for the given INX addresses.
+// The order of the addresses defines the priority if several nodes are equally healthy.
+func NewMultiNodeBridge(log log.Logger, addresses []string, opts ...options.Option[MultiNodeBridge]) *MultiNodeBridge {
+	return options.Apply(&MultiNodeBridge{
+		Logger:    log,
+		addresses: addresses,
+		events: &MultiNodeBridgeEvents{
+			PrimaryChanged: event.New1[NodeBridge](),
+		},
+		reconnectInterval:   DefaultMultiNodeBridgeReconnectInterval,
+		healthCheckInterval: DefaultMultiNodeBridgeHealthCheckInterval,
+		bridges:             make([]NodeBridge, len(addresses)),
+	}, opts)
+}
+
+// Events returns the events of the MultiNodeBridge.
+func (m *MultiNodeBridge) Events() *MultiNodeBridgeEvents {
+	return m.events
+}
+
+// Connect connects to all nodes and reads their configuration.
+// It only fails if no node could be connected.
+func (m *MultiNodeBridge) Connect(ctx context.Context, maxConnectionAttempts uint) error {
+	m.maxConnectionAttempts = maxConnectionAttempts
+
+	var wg sync.WaitGroup
+	for i := range m.addresses {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			bridge, err := m.connect(ctx, index)
+			if err != nil {
+				m.LogWarnf("Connecting to node %s failed: %s", m.addresses[index], err)
+				return
+			}
+
+			m.setBridge(index, bridge)
+		}(i)
+	}
+	wg.Wait()
+
+	if m.selectPrimary() == nil {
+		return ErrNoNodeAvailable
+	}
+
+	return nil
+}
+
+// Run runs all node bridges, reconnects dropped nodes and re-evaluates the primary node.
+// It blocks until the given context is done.
+func (m *MultiNodeBridge) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := range m.addresses {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			m.runNode(ctx, index)
+		}(i)
+	}
+
+	ticker := time.NewTicker(m.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+			m.selectPrimary()
+		}
+	}
+}
+
+// Primary returns the NodeBridge of the current primary node, or nil if no node is available.
+func (m *MultiNodeBridge) Primary() NodeBridge {
+	m.bridgesMutex.RLock()
+	defer m.bridgesMutex.RUnlock()
+
+	return m.primary
+}
+
+// Bridges returns the NodeBridges of all currently connected nodes.
+func (m *MultiNodeBridge) Bridges() []NodeBridge {
+	m.bridgesMutex.RLock()
+	defer m.bridgesMutex.RUnlock()
+
+	bridges := make([]NodeBridge, 0, len(m.bridges))
+	for _, bridge := range m.bridges {
+		if bridge != nil {
+			bridges = append(bridges, bridge)
+		}
+	}
+
+	return bridges
+}
+
+// Read executes the given read function on the primary node.
+// If the primary node is unavailable, the read is retried on the other connected nodes.
+func (m *MultiNodeBridge) Read(ctx context.Context, read func(ctx context.Context, bridge NodeBridge) error) error {
+	primary := m.Primary()
+	if primary == nil {
+		return ErrNoNodeAvailable
+	}
+
+	err := read(ctx, primary)
+	if err == nil || !ierrors.Is(err, ErrUnavailable) {
+		return err
+	}
+
+	for _, bridge := range m.Bridges() {
+		if bridge == primary || !bridge.IsNodeHealthy() {
+			continue
+		}
+
+		if err = read(ctx, bridge); err == nil || !ierrors.Is(err, ErrUnavailable) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// ListenWithFailover runs the given listen function on the primary node and restarts it on the new primary node
+// if the primary node changes or the stream fails because the node became unavailable.
+// The listen function is responsible for resuming the stream at the correct position, e.g. by tracking the last processed slot.
+// It returns if the listen function returned without an error, failed while the node was still healthy, or the context is done.
+func (m *MultiNodeBridge) ListenWithFailover(ctx context.Context, listen func(ctx context.Context, bridge NodeBridge) error) error {
+	for ctx.Err() == nil {
+		bridge := m.Primary()
+		if bridge == nil {
+			if err := m.waitForPrimary(ctx); err != nil {
+				return nil
+			}
+
+			continue
+		}
+
+		streamCtx, streamCancel := context.WithCancel(ctx)
+		hook := m.events.PrimaryChanged.Hook(func(_ NodeBridge) { streamCancel() })
+
+		err := listen(streamCtx, bridge)
+
+		hook.Unhook()
+		primaryChanged := streamCtx.Err() != nil
+		streamCancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case primaryChanged:
+			m.LogInfo("Primary node changed, restarting stream ...")
+		case err == nil:
+			return nil
+		case bridge.ConnectionState() == connectivity.Ready && bridge.IsNodeHealthy():
+			// the node is fine, so the error was caused by the listen function itself
+			return err
+		default:
+			m.LogWarnf("Stream failed, failing over to the next node: %s", err)
+			m.selectPrimary()
+
+			// give the bridges some time to detect the failure
+			select {
+			case <-ctx.Done():
+			case <-time.After(m.healthCheckInterval):
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *MultiNodeBridge) connect(ctx context.Context, index int) (NodeBridge, error) {
+	bridge := New(m.NewChildLogger(m.addresses[index]), m.bridgeOptions...)
+	if err := bridge.Connect(ctx, m.addresses[index], m.maxConnectionAttempts); err != nil {
+		return nil, err
+	}
+
+	return bridge, nil
+}
+
+func (m *MultiNodeBridge) runNode(ctx context.Context, index int) {
+	for ctx.Err() == nil {
+		bridge := m.bridge(index)
+		if bridge == nil {
+			var err error
+			if bridge, err = m.connect(ctx, index); err != nil {
+				if ctx.Err() == nil {
+					m.LogWarnf("Connecting to node %s failed: %s", m.addresses[index], err)
+				}
+			} else {
+				m.setBridge(index, bridge)
+				m.selectPrimary()
+			}
+		}
+
+		if bridge != nil {
+			bridge.Run(ctx)
+
+			m.setBridge(index, nil)
+			m.selectPrimary()
+
+			if ctx.Err() != nil {
+				return
+			}
+			m.LogWarnf("Connection to node %s dropped", m.addresses[index])
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(m.reconnectInterval):
+		}
+	}
+}
+
+func (m *MultiNodeBridge) bridge(index int) NodeBridge {
+	m.bridgesMutex.RLock()
+	defer m.bridgesMutex.RUnlock()
+
+	return m.bridges[index]
+}
+
+func (m *MultiNodeBridge) setBridge(index int, bridge NodeBridge) {
+	m.bridgesMutex.Lock()
+	defer m.bridgesMutex.Unlock()
+
+	m.bridges[index] = bridge
+}
+
+// selectPrimary keeps the current primary node as long as it is healthy,
+// otherwise the healthy node with the most recent commitment becomes the new primary.
+// If no node is healthy, the connected node with the most recent commitment is used.
+func (m *MultiNodeBridge) selectPrimary() NodeBridge {
+	m.bridgesMutex.Lock()
+
+	currentPrimaryConnected := false
+	for _, bridge := range m.bridges {
+		if bridge != nil && bridge == m.primary {
+			currentPrimaryConnected = true
+			break
+		}
+	}
+
+	if currentPrimaryConnected && m.primary.IsNodeHealthy() {
+		defer m.bridgesMutex.Unlock()
+		return m.primary
+	}
+
+	var best NodeBridge
+	for _, bridge := range m.bridges {
+		if bridge == nil {
+			continue
+		}
+
+		if best == nil || isBetterPrimary(bridge, best) {
+			best = bridge
+		}
+	}
+
+	changed := best != m.primary
+	m.primary = best
+	m.bridgesMutex.Unlock()
+
+	if changed && best != nil {
+		m.events.PrimaryChanged.Trigger(best)
+	}
+
+	return best
+}
+
+// isBetterPrimary returns true if the candidate is healthier than the current best node.
+func isBetterPrimary(candidate NodeBridge, best NodeBridge) bool {
+	if candidate.IsNodeHealthy() != best.IsNodeHealthy() {
+		return candidate.IsNodeHealthy()
+	}
+
+	candidateCommitment := candidate.LatestCommitment()
+	bestCommitment := best.LatestCommitment()
+
+	switch {
+	case candidateCommitment == nil:
+		return false
+	case bestCommitment == nil:
+		return true
+	default:
+		return candidateCommitment.CommitmentID.Slot() > bestCommitment.CommitmentID.Slot()
+	}
+}
+
+func (m *MultiNodeBridge) waitForPrimary(ctx context.Context) error {
+	signalChan := make(chan struct{}, 1)
+	hook := m.events.PrimaryChanged.Hook(func(_ NodeBridge) {
+		select {
+		case signalChan <- struct{}{}:
+		default:
+		}
+	})
+	defer hook.Unhook()
+
+	if m.Primary() != nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-signalChan:
+		return nil
+	}
+}
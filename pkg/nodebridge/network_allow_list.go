@@ -0,0 +1,71 @@
+package nodebridge
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+var (
+	// ErrNetworkMismatch is returned if the network name of the node is not one of the target network names.
+	// The returned error is a *NetworkMismatchError, which contains both names.
+	ErrNetworkMismatch = ierrors.New("network name mismatch")
+)
+
+// NetworkMismatchError is the error returned by Connect if the network name of the node is not allowed.
+// It matches ErrNetworkMismatch.
+type NetworkMismatchError struct {
+	// NetworkName is the network name of the node.
+	NetworkName string
+	// TargetNetworkNames are the allowed network names and patterns.
+	TargetNetworkNames []string
+}
+
+func (e *NetworkMismatchError) Error() string {
+	quotedNames := make([]string, 0, len(e.TargetNetworkNames))
+	for _, targetNetworkName := range e.TargetNetworkNames {
+		quotedNames = append(quotedNames, fmt.Sprintf("%q", targetNetworkName))
+	}
+
+	return fmt.Sprintf("%s, networkName: %q, targetNetworkNames: [%s]", ErrNetworkMismatch, e.NetworkName, strings.Join(quotedNames, ", "))
+}
+
+// Is returns true for ErrNetworkMismatch.
+func (e *NetworkMismatchError) Is(target error) bool {
+	return target == ErrNetworkMismatch
+}
+
+// WithTargetNetworkNames checks if the network name of the node matches one of the given names.
+// The names can be glob patterns as supported by path.Match, e.g. "testnet-*".
+// If no names are given, the check is disabled.
+func WithTargetNetworkNames(targetNetworkNames ...string) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.targetNetworkNames = targetNetworkNames
+	}
+}
+
+// checkNetworkName returns a *NetworkMismatchError if the given network name doesn't match any target network name.
+func (n *nodeBridge) checkNetworkName(networkName string) error {
+	if len(n.targetNetworkNames) == 0 {
+		return nil
+	}
+
+	for _, targetNetworkName := range n.targetNetworkNames {
+		matched, err := path.Match(targetNetworkName, networkName)
+		if err != nil {
+			return ierrors.Wrapf(err, "invalid target network name %q", targetNetworkName)
+		}
+
+		if matched {
+			return nil
+		}
+	}
+
+	return &NetworkMismatchError{
+		NetworkName:        networkName,
+		TargetNetworkNames: n.targetNetworkNames,
+	}
+}
@@ -0,0 +1,35 @@
+package nodebridge
+
+import (
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// BaseToken returns the metadata of the base token of the network.
+// The metadata is kept up to date if the node configuration changes.
+func (n *nodeBridge) BaseToken() *api.InfoResBaseToken {
+	baseToken := n.NodeConfig().GetBaseToken()
+
+	return &api.InfoResBaseToken{
+		Name:         baseToken.GetName(),
+		TickerSymbol: baseToken.GetTickerSymbol(),
+		Unit:         baseToken.GetUnit(),
+		Subunit:      baseToken.GetSubunit(),
+		Decimals:     baseToken.GetDecimals(),
+	}
+}
+
+// NetworkName returns the name of the network of the currently committed protocol parameters.
+func (n *nodeBridge) NetworkName() string {
+	return n.apiProvider.CommittedAPI().ProtocolParameters().NetworkName()
+}
+
+// NetworkID returns the ID of the network of the currently committed protocol parameters.
+func (n *nodeBridge) NetworkID() iotago.NetworkID {
+	return n.apiProvider.CommittedAPI().ProtocolParameters().NetworkID()
+}
+
+// Bech32HRP returns the human-readable part of bech32 addresses of the currently committed protocol parameters.
+func (n *nodeBridge) Bech32HRP() iotago.NetworkPrefix {
+	return n.apiProvider.CommittedAPI().ProtocolParameters().Bech32HRP()
+}
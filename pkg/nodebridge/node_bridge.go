@@ -3,14 +3,17 @@ package nodebridge
 import (
 	"context"
 	"io"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
-	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 
 	"github.com/iotaledger/hive.go/ierrors"
@@ -30,12 +33,39 @@ type NodeBridge interface {
 	Connect(ctx context.Context, address string, maxConnectionAttempts uint) error
 	// Run starts the node bridge.
 	Run(ctx context.Context)
+	// ConnectionState returns the current connectivity state of the INX connection.
+	ConnectionState() connectivity.State
 	// Client returns the INXClient.
 	Client() inx.INXClient
 	// NodeConfig returns the NodeConfiguration.
 	NodeConfig() *inx.NodeConfiguration
+	// RefreshNodeConfig reads the current node configuration from the node and triggers NodeConfigChanged if it changed.
+	RefreshNodeConfig(ctx context.Context) (*inx.NodeConfiguration, error)
 	// APIProvider returns the APIProvider.
 	APIProvider() iotago.APIProvider
+	// BaseToken returns the metadata of the base token of the network.
+	BaseToken() *api.InfoResBaseToken
+	// NetworkName returns the name of the network of the currently committed protocol parameters.
+	NetworkName() string
+	// NetworkID returns the ID of the network of the currently committed protocol parameters.
+	NetworkID() iotago.NetworkID
+	// Bech32HRP returns the human-readable part of bech32 addresses of the currently committed protocol parameters.
+	Bech32HRP() iotago.NetworkPrefix
+	// Bech32Address encodes the given address as bech32 string with the HRP of the network of the node.
+	Bech32Address(address iotago.Address) string
+	// ParseBech32Address parses the given bech32 address.
+	// Returns ErrBech32HRPMismatch if the HRP of the address is not the one of the network of the node.
+	ParseBech32Address(bech32Address string) (iotago.Address, error)
+	// IsReadOnly returns true if the bridge is in read-only mode.
+	IsReadOnly() bool
+	// Capabilities returns the capabilities that are currently supported.
+	Capabilities() []Capability
+	// HasCapability returns true if the given capability is currently supported.
+	HasCapability(capability Capability) bool
+	// DebugInfo returns a snapshot of the internal state of the bridge.
+	DebugInfo() *DebugInfo
+	// Stats returns the call counts, error counts and latency percentiles per INX method and stream consumer.
+	Stats() *Stats
 
 	// INXNodeClient returns the NodeClient.
 	INXNodeClient() (*nodeclient.Client, error)
@@ -48,9 +78,28 @@ type NodeBridge interface {
 	// EventAPI returns the EventAPIClient if supported by the node.
 	// Returns ErrMQTTPluginNotAvailable if the current node does not support the plugin.
 	EventAPI(ctx context.Context) (*nodeclient.EventAPIClient, error)
+	// ManagedEventAPI returns an EventAPI client whose connection is managed by the bridge until the given context is done.
+	// Returns ErrMQTTPluginNotAvailable if the current node does not support the plugin.
+	ManagedEventAPI(ctx context.Context) (*ManagedEventAPI, error)
 	// BlockIssuer returns the BlockIssuerClient.
 	// Returns ErrBlockIssuerPluginNotAvailable if the current node does not support the plugin.
 	BlockIssuer(ctx context.Context) (nodeclient.BlockIssuerClient, error)
+	// ExtensionClient returns a client for the REST API that another extension registered under the given route.
+	// Returns ErrExtensionRouteNotAvailable if the route is not registered at the node.
+	ExtensionClient(ctx context.Context, route string) (*ExtensionClient, error)
+	// SupportedRoutes returns the routes of the plugins that are supported by the node.
+	// The routes are queried once and cached afterwards, the plugin client getters refresh the cache if a plugin is missing.
+	SupportedRoutes(ctx context.Context) ([]string, error)
+	// RefreshSupportedRoutes queries the routes of the plugins that are supported by the node and updates the cache.
+	RefreshSupportedRoutes(ctx context.Context) ([]string, error)
+	// HasPlugin returns true if the node supports the plugin with the given name (e.g. "indexer/v2").
+	HasPlugin(ctx context.Context, pluginName string) (bool, error)
+	// Peers returns the peers of the node together with their gossip metrics.
+	// Returns ErrManagementPluginNotAvailable if the current node does not support the plugin.
+	Peers(ctx context.Context) ([]*api.PeerInfo, error)
+	// Peer returns the peer with the given libp2p identifier together with its gossip metrics.
+	// Returns ErrManagementPluginNotAvailable if the current node does not support the plugin.
+	Peer(ctx context.Context, peerID string) (*api.PeerInfo, error)
 
 	// ReadIsCandidate returns true if the given account is a candidate.
 	ReadIsCandidate(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error)
@@ -59,57 +108,157 @@ type NodeBridge interface {
 	// ReadIsValidatorAccount returns true if the given account is a validator account.
 	ReadIsValidatorAccount(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error)
 
+	// DelegationOutput returns the current delegation output with metadata for the given delegation ID.
+	DelegationOutput(ctx context.Context, delegationID iotago.DelegationID) (*Output, error)
+	// ReadRewards returns the mana rewards of the given staking account or delegation output.
+	ReadRewards(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (*api.ManaRewardsResponse, error)
+	// ReadValidatorRewards returns the mana rewards of the staking account with the given account ID.
+	ReadValidatorRewards(ctx context.Context, accountID iotago.AccountID, slot iotago.SlotIndex) (*api.ManaRewardsResponse, error)
+	// ReadDelegatorRewards returns the mana rewards of the delegation output with the given delegation ID.
+	ReadDelegatorRewards(ctx context.Context, delegationID iotago.DelegationID, slot iotago.SlotIndex) (*api.ManaRewardsResponse, error)
+
+	// PruneDatabaseByEpoch triggers the node to prune its database until the given epoch.
+	// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+	PruneDatabaseByEpoch(ctx context.Context, epoch iotago.EpochIndex) (*api.PruneDatabaseResponse, error)
+	// PruneDatabaseByDepth triggers the node to prune its database and only keep the given amount of epochs.
+	// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+	PruneDatabaseByDepth(ctx context.Context, depth iotago.EpochIndex) (*api.PruneDatabaseResponse, error)
+	// PruneDatabaseBySize triggers the node to prune its database until it is smaller than the given size (e.g. "50GB").
+	// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+	PruneDatabaseBySize(ctx context.Context, targetDatabaseSize string) (*api.PruneDatabaseResponse, error)
+	// CreateSnapshot triggers the node to create a snapshot.
+	// Returns ErrManagementDisabled if the bridge was created without WithManagementEnabled.
+	CreateSnapshot(ctx context.Context) (*api.CreateSnapshotResponse, error)
+
 	// RegisterAPIRoute registers the given API route.
+	// Returns ErrReadOnly if the bridge is in read-only mode.
 	RegisterAPIRoute(ctx context.Context, route string, bindAddress string, path string) error
 	// UnregisterAPIRoute unregisters the given API route.
+	// Returns ErrReadOnly if the bridge is in read-only mode.
 	UnregisterAPIRoute(ctx context.Context, route string) error
 
 	// ActiveRootBlocks returns the active root blocks.
 	ActiveRootBlocks(ctx context.Context) (map[iotago.BlockID]iotago.CommitmentID, error)
 	// SubmitBlock submits the given block.
+	// Returns ErrReadOnly if the bridge is in read-only mode.
+	// Returns a BlockValidationError if the bridge was created with WithLocalBlockValidation and the block is invalid.
 	SubmitBlock(ctx context.Context, block *iotago.Block) (iotago.BlockID, error)
+	// SubmitBlocks submits the given blocks concurrently and returns the result of every block in the order of the given blocks.
+	SubmitBlocks(ctx context.Context, blocks []*iotago.Block, opts ...options.Option[SubmitBlocksOptions]) []*SubmitBlockResult
 	// Block returns the block for the given block ID.
+	// Returns ErrSlotPruned if the slot was already pruned by the node.
 	Block(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, error)
 	// BlockMetadata returns the block metadata for the given block ID.
+	// Returns ErrSlotPruned if the slot was already pruned by the node.
 	BlockMetadata(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error)
+	// RawBlockMetadata returns the block metadata for the given block ID as received from the node, together with its protobuf encoding.
+	RawBlockMetadata(ctx context.Context, blockID iotago.BlockID) (*inx.BlockMetadata, []byte, error)
+	// ValidatePayload lets the node simulate the acceptance of the given payload without issuing it.
+	// Returns ErrPayloadInvalid if the payload would be rejected by the node.
+	ValidatePayload(ctx context.Context, payload iotago.ApplicationPayload) error
+	// ValidateBlock checks locally and against the node whether the given block would be accepted, without submitting it.
+	// Returns ErrBlockInvalid wrapped with the reason if the block would be rejected.
+	ValidateBlock(ctx context.Context, block *iotago.Block) error
+	// ValidateTransaction runs the semantic validation of the given signed transaction with inputs resolved from the node, without issuing it.
+	// It returns a TransactionValidationError that names the failed rule if the transaction would be rejected.
+	ValidateTransaction(ctx context.Context, signedTransaction *iotago.SignedTransaction) error
 	// ListenToBlocks listens to blocks.
 	ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error
+	// ListenToFilteredBlocks listens to blocks and only passes the blocks to the consumer that pass the given filter.
+	ListenToFilteredBlocks(ctx context.Context, filter BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error
+	// ListenToValidationBlocks listens to blocks and passes the validation blocks to the consumer.
+	ListenToValidationBlocks(ctx context.Context, consumer func(validationBlock *ValidationBlock) error) error
+	// SubscribeTaggedData listens to blocks and passes the tagged data with the given tag to the consumer.
+	SubscribeTaggedData(ctx context.Context, tag []byte, consumer func(taggedData *TaggedDataBlock) error) error
+	// ListenToRawBlocks listens to blocks without deserializing them.
+	ListenToRawBlocks(ctx context.Context, consumer func(blockID iotago.BlockID, rawData []byte) error) error
+	// ListenToLazyBlocks listens to blocks that are only deserialized if the consumer accesses them.
+	ListenToLazyBlocks(ctx context.Context, consumer func(block *LazyBlock) error) error
+	// ListenToBorrowedBlocks listens to pooled blocks, the consumer must release every block once it is done with it.
+	ListenToBorrowedBlocks(ctx context.Context, consumer func(block *BorrowedBlock) error) error
 	// ListenToBlockMetadata listens to block metadata changes (pending, accepted, confirmed, dropped).
 	ListenToBlockMetadata(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error
+	// ListenToFilteredBlockMetadata listens to block metadata changes and only passes the changes to one of the given states.
+	ListenToFilteredBlockMetadata(ctx context.Context, states []api.BlockState, consumer func(blockMetadata *api.BlockMetadataResponse) error) error
+	// ListenToRawBlockMetadata listens to block metadata changes and passes the block metadata as received from the node, together with its protobuf encoding.
+	ListenToRawBlockMetadata(ctx context.Context, consumer func(blockMetadata *inx.BlockMetadata, rawData []byte) error) error
 
 	// TransactionMetadata returns the transaction metadata for the given transaction ID.
+	// Returns ErrSlotPruned if the slot was already pruned by the node.
 	TransactionMetadata(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error)
 
 	// Output returns the output with metadata for the given output ID.
 	Output(ctx context.Context, outputID iotago.OutputID) (*Output, error)
+	// OutputIDProof returns the verified proof that the output with the given output ID is part of its transaction.
+	OutputIDProof(ctx context.Context, outputID iotago.OutputID) (*iotago.OutputIDProof, error)
+	// UnspentOutputs passes all unspent outputs of the ledger of the node to the given consumer.
+	UnspentOutputs(ctx context.Context, consumer func(output *Output) error) error
 
 	// ForceCommitUntil forces the node to commit until the given slot.
+	// Returns ErrReadOnly if the bridge is in read-only mode.
 	ForceCommitUntil(ctx context.Context, slot iotago.SlotIndex) error
 	// Commitment returns the commitment for the given slot.
+	// Returns ErrSlotPruned if the slot was already pruned by the node.
 	Commitment(ctx context.Context, slot iotago.SlotIndex) (*Commitment, error)
 	// CommitmentByID returns the commitment for the given commitment ID.
+	// Returns ErrSlotPruned if the slot was already pruned by the node.
 	CommitmentByID(ctx context.Context, id iotago.CommitmentID) (*Commitment, error)
+	// VerifyCommitmentChain verifies the linkage and consistency of the commitments in the given slot range.
+	VerifyCommitmentChain(ctx context.Context, fromSlot iotago.SlotIndex, toSlot iotago.SlotIndex) error
+	// FetchCommitments concurrently reads the commitments in the given slot range and returns them ordered by slot.
+	FetchCommitments(ctx context.Context, fromSlot iotago.SlotIndex, toSlot iotago.SlotIndex, opts ...options.Option[FetchCommitmentsOptions]) ([]*Commitment, error)
+	// Attestations returns the attestations derived from the accepted validation blocks of the given slot.
+	Attestations(ctx context.Context, slot iotago.SlotIndex) (iotago.Attestations, error)
+	// AttestationsByCommitmentID returns the attestations of the slot of the given commitment.
+	AttestationsByCommitmentID(ctx context.Context, commitmentID iotago.CommitmentID) (iotago.Attestations, error)
 	// ListenToCommitments listens to commitments.
 	ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error
 
 	// ListenToLedgerUpdates listens to ledger updates.
 	ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *LedgerUpdate) error) error
+	// ListenToLedgerUpdatesWithConsumer listens to ledger updates and passes their operations to the given consumer
+	// in the order they are received, without keeping the outputs of a ledger update in memory.
+	ListenToLedgerUpdatesWithConsumer(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer LedgerUpdateConsumer) error
+	// ListenToStreamingLedgerUpdates listens to ledger updates and passes every ledger update to the given consumer when it begins,
+	// which reads the outputs incrementally while they are received, so huge ledger updates are not kept in memory.
+	ListenToStreamingLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *StreamingLedgerUpdate) error, opts ...options.Option[StreamingLedgerUpdatesOptions]) error
 	// ListenToAcceptedTransactions listens to accepted transactions.
 	ListenToAcceptedTransactions(ctx context.Context, consumer func(tx *AcceptedTransaction) error) error
 
 	// NodeStatus returns the current node status.
 	NodeStatus() *inx.NodeStatus
+	// RefreshNodeStatus reads the current node status from the node immediately.
+	RefreshNodeStatus(ctx context.Context) (*inx.NodeStatus, error)
 	// IsNodeHealthy returns true if the node is healthy.
 	IsNodeHealthy() bool
+	// IsNodeSynced returns true if the node is bootstrapped and healthy.
+	IsNodeSynced() bool
+	// WaitUntilNodeSynced blocks until the node is bootstrapped and healthy, or the context is done.
+	WaitUntilNodeSynced(ctx context.Context) error
+	// WaitForSlotCommitted blocks until the given slot was committed by the node, or the context is done.
+	WaitForSlotCommitted(ctx context.Context, slot iotago.SlotIndex) error
+	// WaitForSlotFinalized blocks until the given slot was finalized by the node, or the context is done.
+	WaitForSlotFinalized(ctx context.Context, slot iotago.SlotIndex) error
 	// LatestCommitment returns the latest commitment.
 	LatestCommitment() *Commitment
 	// LatestFinalizedCommitment returns the latest finalized commitment.
 	LatestFinalizedCommitment() *Commitment
 	// PruningEpoch returns the pruning epoch.
 	PruningEpoch() iotago.EpochIndex
-
-	// BlockIssuance requests the necessary data to issue a block.
-	BlockIssuance(ctx context.Context, maxParentCount uint32) (*api.IssuanceBlockHeaderResponse, error)
+	// IsSlotPruned returns true if the data of the given slot was already pruned by the node.
+	IsSlotPruned(slot iotago.SlotIndex) bool
+	// IsEpochPruned returns true if the data of the given epoch was already pruned by the node.
+	IsEpochPruned(epoch iotago.EpochIndex) bool
+
+	// BlockIssuance requests the necessary data to issue a block with the given maximum amount of parents per parent type.
+	// Returns ErrInvalidParentCount if the parent counts exceed the limits of the protocol.
+	BlockIssuance(ctx context.Context, parents BlockIssuanceParents) (*api.IssuanceBlockHeaderResponse, error)
+	// InvalidateBlockIssuanceCache removes all cached block issuance responses.
+	// The cache is only used if the bridge was created with WithBlockIssuanceCache.
+	InvalidateBlockIssuanceCache()
+	// RequestTips requests tips from the node that can be used as parents for a new block.
+	// Returns ErrInvalidParentCount if the parent counts exceed the limits of the protocol.
+	RequestTips(ctx context.Context, opts ...options.Option[RequestTipsOptions]) (*api.IssuanceBlockHeaderResponse, error)
 }
 
 var _ NodeBridge = &nodeBridge{}
@@ -118,14 +267,54 @@ type nodeBridge struct {
 	// the logger used to log events.
 	log.Logger
 
-	targetNetworkName string
-	events            *Events
+	targetNetworkNames        []string
+	supportedProtocolVersions []iotago.Version
+	defaultCallTimeout        time.Duration
+	keepaliveParams           *keepalive.ClientParameters
+	contextDialer             func(ctx context.Context, address string) (net.Conn, error)
+	blockIssuanceCache        *blockIssuanceCache
+	compressor                string
+	maxRecvMsgSize            int
+	maxSendMsgSize            int
+	nodeStatusCooldown        time.Duration
+	readOnly                  bool
+	managementEnabled         bool
+	readCoalescing            bool
+	localBlockValidation      bool
+	consumerPanicPolicy       PanicPolicy
+	deadLetterHandler         DeadLetterHandler
+	pluginRetry               pluginRetryPolicy
+	retry                     retryPolicy
+	callStats                 *callStatsRegistry
+	callStatsLogInterval      time.Duration
+	events                    *Events
+
+	streamDrainBufferSize   int
+	streamDrainGraceTimeout time.Duration
+
+	supportedRoutesMutex sync.RWMutex
+	supportedRoutes      []string
+
+	unsupportedCapabilitiesMutex sync.RWMutex
+	unsupportedCapabilities      map[Capability]struct{}
+
+	readGroup singleflight.Group
+
+	streamsMutex sync.RWMutex
+	streams      map[*streamState]struct{}
+
+	registeredAPIRoutesMutex sync.RWMutex
+	registeredAPIRoutes      map[string]struct{}
+
+	reconnects atomic.Uint64
 
 	conn        *grpc.ClientConn
 	client      inx.INXClient
-	nodeConfig  *inx.NodeConfiguration
 	apiProvider *iotago.EpochBasedProvider
 
+	nodeConfigMutex sync.RWMutex
+	nodeConfig      *inx.NodeConfiguration
+
 	nodeStatusMutex           sync.RWMutex
 	nodeStatus                *inx.NodeStatus
 	latestCommitment          *Commitment
@@ -135,25 +324,97 @@ type nodeBridge struct {
 type Events struct {
 	LatestCommitmentChanged          *event.Event1[*Commitment]
 	LatestFinalizedCommitmentChanged *event.Event1[*Commitment]
+	// ProtocolParametersActivated is triggered with the old and the new committed API
+	// if the protocol version or the protocol parameters of the committed API changed.
+	ProtocolParametersActivated *event.Event2[iotago.API, iotago.API]
+	// UnsupportedProtocolVersionActivated is triggered with the new protocol version before the bridge stops,
+	// if the node activated a protocol version that is not supported according to WithSupportedProtocolVersions.
+	// The event is triggered synchronously, so hooks can block to halt the extension.
+	UnsupportedProtocolVersionActivated *event.Event1[iotago.Version]
+	// ConnectionStateChanged is triggered if the connectivity state of the INX connection changed (e.g. Ready, TransientFailure).
+	ConnectionStateChanged *event.Event1[connectivity.State]
+	// NodeHealthChanged is triggered with the new health status if the health of the node changed.
+	NodeHealthChanged *event.Event1[bool]
+	// SyncStatusChanged is triggered with the new sync status if the node got synced or lost its sync.
+	// The node is synced if it is bootstrapped and healthy, like reported by IsNodeSynced.
+	SyncStatusChanged *event.Event1[bool]
+	// PruningEpochChanged is triggered with the new pruning epoch if the node pruned its database.
+	PruningEpochChanged *event.Event1[iotago.EpochIndex]
+	// PluginAvailable is triggered with the route of the plugin if a plugin became available on the node.
+	PluginAvailable *event.Event1[string]
+	// StreamDrained is triggered with the name of the stream after a stream was drained in drain mode,
+	// so consumers can flush their checkpoints.
+	StreamDrained *event.Event1[string]
+	// ConsumerPanicked is triggered with the name of the stream and the recovered value if a stream consumer panicked.
+	ConsumerPanicked *event.Event2[string, any]
+	// NodeConfigChanged is triggered with the old and the new node configuration
+	// if the configuration of the node changed, e.g. after the node was restarted.
+	NodeConfigChanged *event.Event2[*inx.NodeConfiguration, *inx.NodeConfiguration]
 }
 
 // WithTargetNetworkName checks if the network name of the node is equal to the given targetNetworkName.
-// If targetNetworkName is empty, the check is disabled.
+// If targetNetworkName is empty, the check is disabled. Use WithTargetNetworkNames to allow several networks.
 func WithTargetNetworkName(targetNetworkName string) options.Option[nodeBridge] {
 	return func(n *nodeBridge) {
-		n.targetNetworkName = targetNetworkName
+		n.targetNetworkNames = nil
+		if targetNetworkName != "" {
+			n.targetNetworkNames = []string{targetNetworkName}
+		}
+	}
+}
+
+// WithDefaultCallTimeout sets the timeout that is applied to all unary calls to the node
+// if the context of the caller has no deadline. The timeout can be overridden per call with ContextWithCallTimeout.
+// If defaultCallTimeout is zero, no default timeout is applied.
+func WithDefaultCallTimeout(defaultCallTimeout time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.defaultCallTimeout = defaultCallTimeout
+	}
+}
+
+// WithNodeStatusCooldown sets the minimum interval in which the node sends node status updates.
+// A lower value results in more up-to-date information, a higher value reduces the traffic between node and bridge.
+func WithNodeStatusCooldown(cooldown time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.nodeStatusCooldown = cooldown
 	}
 }
 
 func New(log log.Logger, opts ...options.Option[nodeBridge]) NodeBridge {
 	return options.Apply(&nodeBridge{
-		Logger:            log,
-		targetNetworkName: "",
+		Logger:             log,
+		targetNetworkNames: nil,
+		defaultCallTimeout: 0,
+		nodeStatusCooldown: ListenToNodeStatusCooldownInMilliseconds * time.Millisecond,
 		events: &Events{
-			LatestCommitmentChanged:          event.New1[*Commitment](),
-			LatestFinalizedCommitmentChanged: event.New1[*Commitment](),
+			LatestCommitmentChanged:             event.New1[*Commitment](),
+			LatestFinalizedCommitmentChanged:    event.New1[*Commitment](),
+			ProtocolParametersActivated:         event.New2[iotago.API, iotago.API](),
+			UnsupportedProtocolVersionActivated: event.New1[iotago.Version](),
+			ConnectionStateChanged:              event.New1[connectivity.State](),
+			NodeHealthChanged:                   event.New1[bool](),
+			SyncStatusChanged:                   event.New1[bool](),
+			PruningEpochChanged:                 event.New1[iotago.EpochIndex](),
+			PluginAvailable:                     event.New1[string](),
+			StreamDrained:                       event.New1[string](),
+			ConsumerPanicked:                    event.New2[string, any](),
+			NodeConfigChanged:                   event.New2[*inx.NodeConfiguration, *inx.NodeConfiguration](),
+		},
+		pluginRetry: pluginRetryPolicy{
+			interval: DefaultPluginRetryInterval,
+			maxWait:  DefaultPluginRetryMaxWait,
 		},
-		apiProvider: iotago.NewEpochBasedProvider(),
+		retry: retryPolicy{
+			backoff: DefaultRetryBackoff,
+			jitter:  DefaultRetryJitter,
+			codes:   grpcretry.DefaultRetriableCodes,
+		},
+		callStats:               newCallStatsRegistry(),
+		streamDrainBufferSize:   DefaultStreamDrainBufferSize,
+		unsupportedCapabilities: make(map[Capability]struct{}),
+		streams:                 make(map[*streamState]struct{}),
+		registeredAPIRoutes:     make(map[string]struct{}),
+		apiProvider:             iotago.NewEpochBasedProvider(),
 	}, opts)
 }
 
@@ -164,11 +425,11 @@ func (n *nodeBridge) Events() *Events {
 
 // Connect connects to the given address and reads the node configuration.
 func (n *nodeBridge) Connect(ctx context.Context, address string, maxConnectionAttempts uint) error {
-	conn, err := grpc.Dial(address,
-		grpc.WithChainUnaryInterceptor(grpcretry.UnaryClientInterceptor(), grpcprometheus.UnaryClientInterceptor),
-		grpc.WithStreamInterceptor(grpcprometheus.StreamClientInterceptor),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	if err := n.checkCompressor(); err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(address, n.dialOptions()...)
 	if err != nil {
 		return err
 	}
@@ -181,34 +442,43 @@ func (n *nodeBridge) Connect(ctx context.Context, address string, maxConnectionA
 	}
 
 	n.LogInfo("Connecting to node and reading node configuration ...")
-	nodeConfig, err := n.client.ReadNodeConfiguration(ctx, &inx.NoParams{}, grpcretry.WithMax(maxConnectionAttempts), grpcretry.WithBackoff(retryBackoff))
+	// the connection attempts are limited by maxConnectionAttempts, so the default call timeout is disabled
+	nodeConfig, err := n.client.ReadNodeConfiguration(ContextWithCallTimeout(ctx, 0), &inx.NoParams{}, grpcretry.WithMax(maxConnectionAttempts), grpcretry.WithBackoff(retryBackoff))
 	if err != nil {
-		return err
+		return n.wrapINXError(err, "failed to read node configuration")
 	}
+	n.nodeConfigMutex.Lock()
 	n.nodeConfig = nodeConfig
-
 	n.apiProvider = nodeConfig.APIProvider()
+	n.nodeConfigMutex.Unlock()
 
-	if n.targetNetworkName != "" {
-		// we need to check for the correct target network name
-		if n.targetNetworkName != n.APIProvider().CommittedAPI().ProtocolParameters().NetworkName() {
-			return ierrors.Errorf("network name mismatch, networkName: \"%s\", targetNetworkName: \"%s\"", n.APIProvider().CommittedAPI().ProtocolParameters().NetworkName(), n.targetNetworkName)
-		}
+	// we need to check for the correct target network name
+	if err := n.checkNetworkName(n.NetworkName()); err != nil {
+		return err
 	}
 
 	n.LogInfo("Reading node status ...")
 	nodeStatus, err := n.client.ReadNodeStatus(ctx, &inx.NoParams{})
 	if err != nil {
+		return n.wrapINXError(err, "failed to read node status")
+	}
+
+	if err := n.processNodeStatus(nodeStatus); err != nil {
 		return err
 	}
 
-	return n.processNodeStatus(nodeStatus)
+	n.warnAboutScheduledProtocolVersions()
+
+	return nil
 }
 
 // Run starts the node bridge.
 func (n *nodeBridge) Run(ctx context.Context) {
 	c, cancel := context.WithCancel(ctx)
 
+	go n.watchConnectionState(c)
+	go n.logCallStats(c)
+
 	go func() {
 		if err := n.listenToNodeStatus(c); err != nil {
 			n.LogErrorf("Error listening to node status: %s", err)
@@ -225,11 +495,6 @@ func (n *nodeBridge) Client() inx.INXClient {
 	return n.client
 }
 
-// NodeConfig returns the NodeConfiguration.
-func (n *nodeBridge) NodeConfig() *inx.NodeConfiguration {
-	return n.nodeConfig
-}
-
 // APIProvider returns the APIProvider.
 func (n *nodeBridge) APIProvider() iotago.APIProvider {
 	return n.apiProvider
@@ -240,34 +505,21 @@ func (n *nodeBridge) INXNodeClient() (*nodeclient.Client, error) {
 	return inx.NewNodeclientOverINX(n.client)
 }
 
-func (n *nodeBridge) getPluginClient(ctx context.Context, clientInitHook func(ctx context.Context, nodeClient *nodeclient.Client) error, notAvailableError error) error {
-	nodeClient, err := n.INXNodeClient()
+func (n *nodeBridge) getPluginClient(ctx context.Context, pluginName string, clientInitHook func(ctx context.Context, nodeClient *nodeclient.Client) error, notAvailableError error) error {
+	hasPlugin, err := n.waitForPlugin(ctx, pluginName)
 	if err != nil {
 		return err
 	}
-
-	initClient := func(ctx context.Context, nodeClient *nodeclient.Client) error {
-		ctxTimeout, cancelTimeout := context.WithTimeout(ctx, 1*time.Second)
-		defer cancelTimeout()
-
-		return clientInitHook(ctxTimeout, nodeClient)
+	if !hasPlugin {
+		return ierrors.Wrapf(notAvailableError, "plugin %s not found in the supported routes of the node", pluginName)
 	}
 
-	// wait until the plugin is available
-	for ctx.Err() == nil {
-		if err := initClient(ctx, nodeClient); err != nil {
-			if !ierrors.Is(err, notAvailableError) {
-				return err
-			}
-			time.Sleep(1 * time.Second)
-
-			continue
-		}
-
-		return nil
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return err
 	}
 
-	return notAvailableError
+	return clientInitHook(ctx, nodeClient)
 }
 
 // Management returns the ManagementClient.
@@ -275,7 +527,7 @@ func (n *nodeBridge) getPluginClient(ctx context.Context, clientInitHook func(ct
 func (n *nodeBridge) Management(ctx context.Context) (nodeclient.ManagementClient, error) {
 	var client nodeclient.ManagementClient
 
-	if err := n.getPluginClient(ctx, func(ctx context.Context, nodeClient *nodeclient.Client) error {
+	if err := n.getPluginClient(ctx, api.ManagementPluginName, func(ctx context.Context, nodeClient *nodeclient.Client) error {
 		managementClient, err := nodeClient.Management(ctx)
 		if err != nil {
 			return err
@@ -295,7 +547,7 @@ func (n *nodeBridge) Management(ctx context.Context) (nodeclient.ManagementClien
 func (n *nodeBridge) Indexer(ctx context.Context) (nodeclient.IndexerClient, error) {
 	var client nodeclient.IndexerClient
 
-	if err := n.getPluginClient(ctx, func(ctx context.Context, nodeClient *nodeclient.Client) error {
+	if err := n.getPluginClient(ctx, api.IndexerPluginName, func(ctx context.Context, nodeClient *nodeclient.Client) error {
 		indexerClient, err := nodeClient.Indexer(ctx)
 		if err != nil {
 			return err
@@ -315,7 +567,7 @@ func (n *nodeBridge) Indexer(ctx context.Context) (nodeclient.IndexerClient, err
 func (n *nodeBridge) EventAPI(ctx context.Context) (*nodeclient.EventAPIClient, error) {
 	var client *nodeclient.EventAPIClient
 
-	if err := n.getPluginClient(ctx, func(ctx context.Context, nodeClient *nodeclient.Client) error {
+	if err := n.getPluginClient(ctx, api.MQTTPluginName, func(ctx context.Context, nodeClient *nodeclient.Client) error {
 		eventAPIClient, err := nodeClient.EventAPI(ctx)
 		if err != nil {
 			return err
@@ -335,7 +587,7 @@ func (n *nodeBridge) EventAPI(ctx context.Context) (*nodeclient.EventAPIClient,
 func (n *nodeBridge) BlockIssuer(ctx context.Context) (nodeclient.BlockIssuerClient, error) {
 	var client nodeclient.BlockIssuerClient
 
-	if err := n.getPluginClient(ctx, func(ctx context.Context, nodeClient *nodeclient.Client) error {
+	if err := n.getPluginClient(ctx, api.BlockIssuerPluginName, func(ctx context.Context, nodeClient *nodeclient.Client) error {
 		blockIssuerClient, err := nodeClient.BlockIssuer(ctx)
 		if err != nil {
 			return err
@@ -0,0 +1,82 @@
+package nodebridge
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+)
+
+// NodeConfig returns the NodeConfiguration.
+func (n *nodeBridge) NodeConfig() *inx.NodeConfiguration {
+	n.nodeConfigMutex.RLock()
+	defer n.nodeConfigMutex.RUnlock()
+
+	return n.nodeConfig
+}
+
+// RefreshNodeConfig reads the current node configuration from the node and triggers
+// the NodeConfigChanged event if it differs from the known configuration.
+// New protocol parameters of the configuration are added to the APIProvider.
+// The node configuration is refreshed automatically after the INX connection was re-established.
+func (n *nodeBridge) RefreshNodeConfig(ctx context.Context) (*inx.NodeConfiguration, error) {
+	nodeConfig, err := n.client.ReadNodeConfiguration(ctx, &inx.NoParams{})
+	if err != nil {
+		return nil, n.wrapINXError(err, "failed to read node configuration")
+	}
+
+	if err := n.processNodeConfig(nodeConfig); err != nil {
+		return nil, err
+	}
+
+	return n.NodeConfig(), nil
+}
+
+// processNodeConfig updates the known node configuration and the protocol parameters of the APIProvider.
+func (n *nodeBridge) processNodeConfig(nodeConfig *inx.NodeConfiguration) error {
+	n.nodeConfigMutex.Lock()
+	oldNodeConfig := n.nodeConfig
+	if proto.Equal(oldNodeConfig, nodeConfig) {
+		n.nodeConfigMutex.Unlock()
+
+		return nil
+	}
+
+	for _, rawParams := range nodeConfig.GetProtocolParameters() {
+		startEpoch, protocolParameters, err := rawParams.Unwrap()
+		if err != nil {
+			n.nodeConfigMutex.Unlock()
+
+			return ierrors.Wrap(err, "failed to unwrap protocol parameters of the node configuration")
+		}
+
+		protocolParametersHash, err := protocolParameters.Hash()
+		if err != nil {
+			n.nodeConfigMutex.Unlock()
+
+			return ierrors.Wrap(err, "failed to hash protocol parameters of the node configuration")
+		}
+
+		if n.apiProvider.ProtocolParametersHash(protocolParameters.Version()) != protocolParametersHash {
+			n.apiProvider.AddProtocolParametersAtEpoch(protocolParameters, startEpoch)
+		}
+	}
+	n.nodeConfig = nodeConfig
+	n.nodeConfigMutex.Unlock()
+
+	n.LogInfo("Node configuration changed")
+	n.events.NodeConfigChanged.Trigger(oldNodeConfig, nodeConfig)
+	n.warnAboutScheduledProtocolVersions()
+
+	return nil
+}
+
+// refreshNodeConfigAfterReconnect refreshes the node configuration,
+// because the node might have been restarted with a different configuration.
+func (n *nodeBridge) refreshNodeConfigAfterReconnect(ctx context.Context) {
+	if _, err := n.RefreshNodeConfig(ctx); err != nil && ctx.Err() == nil {
+		n.LogWarnf("Failed to refresh node configuration after reconnect: %s", err)
+	}
+}
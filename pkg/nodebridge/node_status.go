@@ -11,6 +11,21 @@ const (
 	ListenToNodeStatusCooldownInMilliseconds = 1_000
 )
 
+// RefreshNodeStatus reads the current node status from the node immediately,
+// independent of the cooldown of the node status listener, and triggers the corresponding events.
+func (n *nodeBridge) RefreshNodeStatus(ctx context.Context) (*inx.NodeStatus, error) {
+	nodeStatus, err := n.client.ReadNodeStatus(ctx, &inx.NoParams{})
+	if err != nil {
+		return nil, n.wrapINXError(err, "failed to read node status")
+	}
+
+	if err := n.processNodeStatus(nodeStatus); err != nil {
+		return nil, err
+	}
+
+	return n.NodeStatus(), nil
+}
+
 // NodeStatus returns the current node status.
 func (n *nodeBridge) NodeStatus() *inx.NodeStatus {
 	n.nodeStatusMutex.RLock()
@@ -46,14 +61,14 @@ func (n *nodeBridge) PruningEpoch() iotago.EpochIndex {
 }
 
 func (n *nodeBridge) listenToNodeStatus(ctx context.Context) error {
-	stream, err := n.client.ListenToNodeStatus(ctx, &inx.NodeStatusRequest{CooldownInMilliseconds: ListenToNodeStatusCooldownInMilliseconds})
+	stream, err := n.client.ListenToNodeStatus(ctx, &inx.NodeStatusRequest{CooldownInMilliseconds: uint32(n.nodeStatusCooldown.Milliseconds())})
 	if err != nil {
-		return err
+		return n.wrapINXError(err, "failed to listen to node status")
 	}
 
 	if err := ListenToStream(ctx, stream.Recv, n.processNodeStatus); err != nil {
 		n.LogErrorf("listenToNodeStatus failed: %s", err.Error())
-		return err
+		return n.wrapINXError(err, "listenToNodeStatus failed")
 	}
 
 	return nil
@@ -66,6 +81,10 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 	var latestFinalizedCommitment *Commitment
 	var latestFinalizedCommitmentChanged bool
 
+	var nodeHealthChanged bool
+	var syncStatusChanged bool
+	var pruningEpochChanged bool
+
 	updateStatus := func() error {
 		n.nodeStatusMutex.Lock()
 		defer n.nodeStatusMutex.Unlock()
@@ -83,6 +102,12 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 				latestFinalizedCommitmentChanged = true
 			}
 		}
+
+		if n.nodeStatus != nil {
+			nodeHealthChanged = nodeStatus.GetIsHealthy() != n.nodeStatus.GetIsHealthy()
+			syncStatusChanged = isNodeStatusSynced(nodeStatus) != isNodeStatusSynced(n.nodeStatus)
+			pruningEpochChanged = nodeStatus.GetPruningEpoch() != n.nodeStatus.GetPruningEpoch() || nodeStatus.GetHasPruned() != n.nodeStatus.GetHasPruned()
+		}
 		n.nodeStatus = nodeStatus
 
 		return nil
@@ -94,14 +119,70 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 
 	if latestCommitmentChanged {
 		slot := latestCommitment.CommitmentID.Slot()
+
+		oldAPI := n.apiProvider.CommittedAPI()
 		n.apiProvider.SetCommittedSlot(slot)
+		newAPI := n.apiProvider.CommittedAPI()
+
+		// the consumers must not process commitments of a protocol version the extension was not built for
+		if err := n.checkProtocolVersion(newAPI.Version()); err != nil {
+			n.LogErrorf("The node activated protocol version %d at slot %d, which is not supported by this extension", newAPI.Version(), slot)
+			n.events.UnsupportedProtocolVersionActivated.Trigger(newAPI.Version())
+
+			return err
+		}
+
+		// the block issuance contains the latest commitment, so cached responses are outdated
+		n.InvalidateBlockIssuanceCache()
 
 		n.events.LatestCommitmentChanged.Trigger(latestCommitment)
+
+		if protocolParametersChanged(oldAPI, newAPI) {
+			n.LogInfof("Protocol parameters activated at slot %d, version: %d -> %d", slot, oldAPI.Version(), newAPI.Version())
+			n.events.ProtocolParametersActivated.Trigger(oldAPI, newAPI)
+		}
 	}
 
 	if latestFinalizedCommitmentChanged {
 		n.events.LatestFinalizedCommitmentChanged.Trigger(latestFinalizedCommitment)
 	}
 
+	if nodeHealthChanged {
+		n.LogInfof("Node health changed, healthy: %t", nodeStatus.GetIsHealthy())
+		n.events.NodeHealthChanged.Trigger(nodeStatus.GetIsHealthy())
+	}
+
+	if syncStatusChanged {
+		n.LogInfof("Node sync status changed, synced: %t", isNodeStatusSynced(nodeStatus))
+		n.events.SyncStatusChanged.Trigger(isNodeStatusSynced(nodeStatus))
+	}
+
+	if pruningEpochChanged {
+		n.events.PruningEpochChanged.Trigger(iotago.EpochIndex(nodeStatus.GetPruningEpoch()))
+	}
+
 	return nil
 }
+
+func protocolParametersChanged(oldAPI iotago.API, newAPI iotago.API) bool {
+	if oldAPI == nil || newAPI == nil {
+		// there is nothing to compare against
+		return false
+	}
+
+	if oldAPI.Version() != newAPI.Version() {
+		return true
+	}
+
+	oldHash, err := oldAPI.ProtocolParameters().Hash()
+	if err != nil {
+		return false
+	}
+
+	newHash, err := newAPI.ProtocolParameters().Hash()
+	if err != nil {
+		return false
+	}
+
+	return oldHash != newHash
+}
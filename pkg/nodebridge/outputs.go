@@ -52,15 +52,10 @@ func (n *nodeBridge) unwrapOutput(inxOutput *inx.LedgerOutput, inxSpent *inx.Led
 		return nil, err
 	}
 
-	derivedOutputID, err := outputIDProof.OutputID(output)
-	if err != nil {
+	if err := VerifyOutputIDProof(outputIDProof, output, outputID); err != nil {
 		return nil, err
 	}
 
-	if derivedOutputID != outputID {
-		return nil, ierrors.Errorf("output ID mismatch. Expected %s, got %s", outputID.ToHex(), derivedOutputID.ToHex())
-	}
-
 	return &Output{
 		OutputID:      outputID,
 		Output:        output,
@@ -72,17 +67,42 @@ func (n *nodeBridge) unwrapOutput(inxOutput *inx.LedgerOutput, inxSpent *inx.Led
 
 // Output returns the output with metadata for the given output ID.
 func (n *nodeBridge) Output(ctx context.Context, outputID iotago.OutputID) (*Output, error) {
-	inxOutputReponse, err := n.client.ReadOutput(ctx, inx.NewOutputId(outputID))
+	return coalesceRead(ctx, n, "output:"+outputID.ToHex(), func(ctx context.Context) (*Output, error) {
+		inxOutputReponse, err := n.client.ReadOutput(ctx, inx.NewOutputId(outputID))
+		if err != nil {
+			return nil, n.wrapINXError(err, "failed to read output %s", outputID.ToHex())
+		}
+
+		inxOutput := inxOutputReponse.GetOutput()
+		inxSpent := inxOutputReponse.GetSpent()
+		if inxSpent != nil {
+			// if spent is not nil, the output is included in spent
+			inxOutput = inxSpent.GetOutput()
+		}
+
+		return n.unwrapOutput(inxOutput, inxSpent, inxOutputReponse.GetLatestCommitmentId().Unwrap())
+	})
+}
+
+// UnspentOutputs passes all unspent outputs of the ledger of the node to the given consumer.
+// The node keeps the ledger locked while the outputs are read, so all outputs belong to
+// the ledger state of the latest commitment contained in their metadata.
+func (n *nodeBridge) UnspentOutputs(ctx context.Context, consumer func(output *Output) error) error {
+	stream, err := n.client.ReadUnspentOutputs(ctx, &inx.NoParams{})
 	if err != nil {
-		return nil, err
+		return n.wrapINXError(err, "failed to read unspent outputs")
 	}
 
-	inxOutput := inxOutputReponse.GetOutput()
-	inxSpent := inxOutputReponse.GetSpent()
-	if inxSpent != nil {
-		// if spent is not nil, the output is included in spent
-		inxOutput = inxSpent.GetOutput()
+	if err := ListenToStream(ctx, stream.Recv, func(unspentOutput *inx.UnspentOutput) error {
+		output, err := n.unwrapOutput(unspentOutput.GetOutput(), nil, unspentOutput.GetLatestCommitmentId().Unwrap())
+		if err != nil {
+			return ierrors.Wrap(err, "unable to unwrap unspent output")
+		}
+
+		return consumer(output)
+	}); err != nil {
+		return n.wrapINXError(err, "failed to read unspent outputs")
 	}
 
-	return n.unwrapOutput(inxOutput, inxSpent, inxOutputReponse.GetLatestCommitmentId().Unwrap())
+	return nil
 }
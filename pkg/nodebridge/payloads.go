@@ -0,0 +1,33 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	ErrPayloadInvalid = ierrors.New("payload is invalid")
+)
+
+// ValidatePayload lets the node simulate the acceptance of the given payload without issuing it.
+// It returns ErrPayloadInvalid wrapped with the reason given by the node if the payload would be rejected.
+func (n *nodeBridge) ValidatePayload(ctx context.Context, payload iotago.ApplicationPayload) error {
+	rawPayload, err := inx.WrapPayload(payload, n.apiProvider.CommittedAPI())
+	if err != nil {
+		return ierrors.Wrap(err, "unable to wrap payload")
+	}
+
+	response, err := n.client.ValidatePayload(ctx, rawPayload)
+	if err != nil {
+		return n.wrapINXError(err, "failed to validate payload")
+	}
+
+	if !response.GetIsValid() {
+		return ierrors.Wrap(ErrPayloadInvalid, response.GetError())
+	}
+
+	return nil
+}
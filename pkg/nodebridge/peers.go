@@ -0,0 +1,207 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultPeerMonitorInterval is the default interval in which the peers of the node are polled.
+	DefaultPeerMonitorInterval = 5 * time.Second
+)
+
+// Peers returns the peers of the node together with their gossip metrics.
+// Returns ErrManagementPluginNotAvailable if the current node does not support the plugin.
+func (n *nodeBridge) Peers(ctx context.Context) ([]*api.PeerInfo, error) {
+	management, err := n.Management(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peers, err := management.Peers(ctx)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "unable to query peers")
+	}
+
+	return peers.Peers, nil
+}
+
+// Peer returns the peer with the given libp2p identifier together with its gossip metrics.
+// Returns ErrManagementPluginNotAvailable if the current node does not support the plugin.
+func (n *nodeBridge) Peer(ctx context.Context, peerID string) (*api.PeerInfo, error) {
+	management, err := n.Management(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := management.PeerByID(ctx, peerID)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to query peer %s", peerID)
+	}
+
+	return peer, nil
+}
+
+// PeersSample is a single sample of the peers of the node.
+type PeersSample struct {
+	// Time is the time the sample was taken.
+	Time time.Time
+	// Peers are the peers returned by the node.
+	Peers []*api.PeerInfo
+	// ConnectedCount is the amount of connected peers.
+	ConnectedCount int
+	// PacketsReceived is the total amount of gossip packets received from all peers.
+	PacketsReceived uint64
+	// PacketsSent is the total amount of gossip packets sent to all peers.
+	PacketsSent uint64
+}
+
+// PeerMonitorEvents are the events triggered by the PeerMonitor.
+type PeerMonitorEvents struct {
+	// Sampled is triggered for every successful sample of the peers.
+	Sampled *event.Event1[*PeersSample]
+	// SampleFailed is triggered if the peers could not be queried from the node.
+	SampleFailed *event.Event1[error]
+	// PeerConnected is triggered if a peer is connected that was unknown or disconnected in the previous sample.
+	PeerConnected *event.Event1[*api.PeerInfo]
+	// PeerDisconnected is triggered if a peer that was connected in the previous sample is disconnected or gone.
+	PeerDisconnected *event.Event1[*api.PeerInfo]
+}
+
+// PeerMonitor periodically queries the peers of the node and reports gossip metrics and peer connects and disconnects.
+// The first sample only reports the currently connected peers as connected.
+type PeerMonitor struct {
+	nodeBridge NodeBridge
+	clock      clock.Clock
+	events     *PeerMonitorEvents
+
+	interval time.Duration
+
+	latestSampleMutex sync.RWMutex
+	latestSample      *PeersSample
+	connectedPeers    map[string]*api.PeerInfo
+}
+
+// WithPeerMonitorInterval sets the interval in which the peers of the node are polled.
+func WithPeerMonitorInterval(interval time.Duration) options.Option[PeerMonitor] {
+	return func(m *PeerMonitor) {
+		m.interval = interval
+	}
+}
+
+// WithPeerMonitorClock sets the clock that is used for the sample interval, e.g. a clock.Mock in tests.
+func WithPeerMonitorClock(timeSource clock.Clock) options.Option[PeerMonitor] {
+	return func(m *PeerMonitor) {
+		m.clock = timeSource
+	}
+}
+
+// NewPeerMonitor creates a new PeerMonitor.
+func NewPeerMonitor(nodeBridge NodeBridge, opts ...options.Option[PeerMonitor]) *PeerMonitor {
+	return options.Apply(&PeerMonitor{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &PeerMonitorEvents{
+			Sampled:          event.New1[*PeersSample](),
+			SampleFailed:     event.New1[error](),
+			PeerConnected:    event.New1[*api.PeerInfo](),
+			PeerDisconnected: event.New1[*api.PeerInfo](),
+		},
+		interval:       DefaultPeerMonitorInterval,
+		connectedPeers: make(map[string]*api.PeerInfo),
+	}, opts)
+}
+
+// Events returns the events of the PeerMonitor.
+func (m *PeerMonitor) Events() *PeerMonitorEvents {
+	return m.events
+}
+
+// LatestSample returns the latest successful sample of the peers, or nil if there is none yet.
+func (m *PeerMonitor) LatestSample() *PeersSample {
+	m.latestSampleMutex.RLock()
+	defer m.latestSampleMutex.RUnlock()
+
+	return m.latestSample
+}
+
+// Run samples the peers in the configured interval and blocks until the given context is done.
+func (m *PeerMonitor) Run(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.sample(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+func (m *PeerMonitor) sample(ctx context.Context) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, m.interval)
+	defer cancel()
+
+	peers, err := m.nodeBridge.Peers(ctxTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.events.SampleFailed.Trigger(err)
+		}
+
+		return
+	}
+
+	sample := &PeersSample{
+		Time:  m.clock.Now(),
+		Peers: peers,
+	}
+
+	connectedPeers := make(map[string]*api.PeerInfo)
+	for _, peer := range peers {
+		if peer.Connected {
+			sample.ConnectedCount++
+			connectedPeers[peer.ID] = peer
+		}
+
+		if peer.GossipMetrics != nil {
+			sample.PacketsReceived += uint64(peer.GossipMetrics.PacketsReceived)
+			sample.PacketsSent += uint64(peer.GossipMetrics.PacketsSent)
+		}
+	}
+
+	var connected, disconnected []*api.PeerInfo
+
+	m.latestSampleMutex.Lock()
+	for id, peer := range connectedPeers {
+		if _, wasConnected := m.connectedPeers[id]; !wasConnected {
+			connected = append(connected, peer)
+		}
+	}
+	for id, peer := range m.connectedPeers {
+		if _, isConnected := connectedPeers[id]; !isConnected {
+			disconnected = append(disconnected, peer)
+		}
+	}
+	m.latestSample = sample
+	m.connectedPeers = connectedPeers
+	m.latestSampleMutex.Unlock()
+
+	for _, peer := range disconnected {
+		m.events.PeerDisconnected.Trigger(peer)
+	}
+	for _, peer := range connected {
+		m.events.PeerConnected.Trigger(peer)
+	}
+
+	m.events.Sampled.Trigger(sample)
+}
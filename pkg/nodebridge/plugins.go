@@ -0,0 +1,160 @@
+package nodebridge
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultPluginRetryInterval is the default interval in which the supported routes are queried while waiting for a plugin.
+	DefaultPluginRetryInterval = 1 * time.Second
+	// DefaultPluginRetryMaxWait is the default maximum duration the plugin client getters wait for a plugin.
+	// By default, the getters fail with the not available error of the plugin (e.g. nodeclient.ErrIndexerPluginNotAvailable)
+	// if the plugin is missing after refreshing the routes once.
+	DefaultPluginRetryMaxWait time.Duration = 0
+	// PluginRetryWaitUntilDone lets the plugin client getters wait for a plugin until their context is done.
+	// Waiting is opt-in, because a getter called with a context without deadline blocks its goroutine
+	// for an unbounded time if the plugin never becomes available. Every negative maximum wait has the same effect.
+	PluginRetryWaitUntilDone time.Duration = -1
+)
+
+// pluginRetryPolicy defines how long the plugin client getters wait for a plugin to become available.
+type pluginRetryPolicy struct {
+	interval time.Duration
+	maxWait  time.Duration
+	jitter   time.Duration
+}
+
+// WithPluginRetryPolicy configures how long Management, Indexer, EventAPI and BlockIssuer
+// wait for the plugin to become available on the node.
+// If the plugin is missing in the cached routes, the routes are refreshed once, and then queried every interval
+// plus a random jitter in the range [0, jitter) until the plugin is available or maxWait elapsed.
+// If maxWait is negative, e.g. PluginRetryWaitUntilDone, the getters wait until their context is done,
+// so they should only be called with a context that has a deadline or is canceled on shutdown.
+// If maxWait is zero (the default), the getters fail after the single refresh if the plugin is not available.
+func WithPluginRetryPolicy(interval time.Duration, maxWait time.Duration, jitter time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.pluginRetry = pluginRetryPolicy{
+			interval: interval,
+			maxWait:  maxWait,
+			jitter:   jitter,
+		}
+	}
+}
+
+// SupportedRoutes returns the routes of the plugins that are supported by the node.
+// The routes are queried once and cached afterwards, the plugin client getters refresh the cache if a plugin is missing.
+func (n *nodeBridge) SupportedRoutes(ctx context.Context) ([]string, error) {
+	n.supportedRoutesMutex.RLock()
+	supportedRoutes := n.supportedRoutes
+	n.supportedRoutesMutex.RUnlock()
+
+	if supportedRoutes != nil {
+		return slices.Clone(supportedRoutes), nil
+	}
+
+	return n.RefreshSupportedRoutes(ctx)
+}
+
+// RefreshSupportedRoutes queries the routes of the plugins that are supported by the node and updates the cache.
+func (n *nodeBridge) RefreshSupportedRoutes(ctx context.Context) ([]string, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	routesResponse, err := nodeClient.Routes(ctx)
+	if err != nil {
+		return nil, n.wrapINXError(err, "failed to read supported routes")
+	}
+
+	supportedRoutes := lo.Map(routesResponse.Routes, func(route iotago.PrefixedStringUint8) string { return string(route) })
+
+	n.supportedRoutesMutex.Lock()
+	previousRoutes := n.supportedRoutes
+	n.supportedRoutes = supportedRoutes
+	n.supportedRoutesMutex.Unlock()
+
+	if previousRoutes != nil {
+		for _, route := range supportedRoutes {
+			if !slices.Contains(previousRoutes, route) {
+				n.events.PluginAvailable.Trigger(route)
+			}
+		}
+	}
+
+	return slices.Clone(supportedRoutes), nil
+}
+
+// HasPlugin returns true if the node supports the plugin with the given name (e.g. "indexer/v2").
+func (n *nodeBridge) HasPlugin(ctx context.Context, pluginName string) (bool, error) {
+	supportedRoutes, err := n.SupportedRoutes(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(supportedRoutes, pluginName), nil
+}
+
+// waitForPlugin returns true if the node supports the plugin with the given name.
+// If the plugin is not in the cached routes, the cache is refreshed, because extensions like the indexer
+// register their routes after the node started. If the plugin is still not available, the supported routes are
+// queried again according to the configured retry policy until the plugin is available or the policy gives up.
+func (n *nodeBridge) waitForPlugin(ctx context.Context, pluginName string) (bool, error) {
+	hasPlugin, err := n.HasPlugin(ctx, pluginName)
+	if err != nil || hasPlugin {
+		return hasPlugin, err
+	}
+
+	supportedRoutes, err := n.RefreshSupportedRoutes(ctx)
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(supportedRoutes, pluginName) || n.pluginRetry.maxWait == 0 {
+		return slices.Contains(supportedRoutes, pluginName), nil
+	}
+
+	ctxWait, cancelWait := context.WithCancel(ctx)
+	if n.pluginRetry.maxWait > 0 {
+		n.LogInfof("Waiting for plugin %s to become available on the node (max %s) ...", pluginName, n.pluginRetry.maxWait)
+		ctxWait, cancelWait = context.WithTimeout(ctx, n.pluginRetry.maxWait)
+	} else {
+		n.LogInfof("Waiting for plugin %s to become available on the node ...", pluginName)
+	}
+	defer cancelWait()
+
+	for {
+		retryInterval := n.pluginRetry.interval
+		if n.pluginRetry.jitter > 0 {
+			//nolint:gosec // the jitter doesn't need to be cryptographically secure
+			retryInterval += time.Duration(rand.Int64N(int64(n.pluginRetry.jitter)))
+		}
+
+		select {
+		case <-ctxWait.Done():
+			// the plugin is not available, the caller returns the not available error
+			return false, ctx.Err()
+		case <-time.After(retryInterval):
+		}
+
+		supportedRoutes, err := n.RefreshSupportedRoutes(ctxWait)
+		if err != nil {
+			if ctxWait.Err() != nil {
+				return false, ctx.Err()
+			}
+			n.LogDebugf("Failed to read supported routes: %s", err)
+
+			continue
+		}
+
+		if slices.Contains(supportedRoutes, pluginName) {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrOutputIDProofMismatch is returned if an output ID proof does not prove the expected output ID.
+	ErrOutputIDProofMismatch = ierrors.New("output ID proof mismatch")
+)
+
+// VerifyOutputIDProof verifies that the given proof proves that the output belongs to the given output ID,
+// i.e. that the output is part of the transaction with the transaction ID contained in the output ID.
+func VerifyOutputIDProof(proof *iotago.OutputIDProof, output iotago.Output, outputID iotago.OutputID) error {
+	if proof == nil {
+		return ierrors.Wrapf(ErrOutputIDProofMismatch, "missing proof for output %s", outputID.ToHex())
+	}
+
+	derivedOutputID, err := proof.OutputID(output)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to derive output ID from proof for output %s", outputID.ToHex())
+	}
+
+	if derivedOutputID != outputID {
+		return ierrors.Wrapf(ErrOutputIDProofMismatch, "expected %s, got %s", outputID.ToHex(), derivedOutputID.ToHex())
+	}
+
+	return nil
+}
+
+// OutputIDProof returns the verified proof that the output with the given output ID is part of its transaction.
+// INX does not expose inclusion proofs of blocks or transactions against the roots of a slot commitment,
+// so the output ID proof is the only proof that can be served by extensions.
+func (n *nodeBridge) OutputIDProof(ctx context.Context, outputID iotago.OutputID) (*iotago.OutputIDProof, error) {
+	output, err := n.Output(ctx, outputID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the proof was already verified while unwrapping the output
+	return output.OutputIDProof, nil
+}
@@ -0,0 +1,51 @@
+package nodebridge
+
+import (
+	"slices"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrUnsupportedProtocolVersion is returned if the node activated a protocol version the extension was not built for.
+	ErrUnsupportedProtocolVersion = ierrors.New("unsupported protocol version")
+)
+
+// WithSupportedProtocolVersions sets the protocol versions the extension was built for.
+// Connect fails with ErrUnsupportedProtocolVersion if the committed protocol version of the node is not supported,
+// and if the node activates an unsupported protocol version while the bridge is running,
+// the UnsupportedProtocolVersionActivated event is triggered and the bridge stops,
+// instead of mis-parsing blocks and outputs of the new protocol version.
+// If no versions are given, all protocol versions are supported.
+func WithSupportedProtocolVersions(versions []iotago.Version) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.supportedProtocolVersions = versions
+	}
+}
+
+// checkProtocolVersion returns ErrUnsupportedProtocolVersion if the given protocol version is not supported.
+func (n *nodeBridge) checkProtocolVersion(version iotago.Version) error {
+	if len(n.supportedProtocolVersions) == 0 || slices.Contains(n.supportedProtocolVersions, version) {
+		return nil
+	}
+
+	return ierrors.Wrapf(ErrUnsupportedProtocolVersion, "protocol version %d is not supported, supported versions: %v", version, n.supportedProtocolVersions)
+}
+
+// warnAboutScheduledProtocolVersions logs a warning for every unsupported protocol version the node will activate in the future.
+func (n *nodeBridge) warnAboutScheduledProtocolVersions() {
+	committedVersion := n.apiProvider.CommittedAPI().Version()
+
+	for _, rawParams := range n.NodeConfig().GetProtocolParameters() {
+		startEpoch, protocolParameters, err := rawParams.Unwrap()
+		if err != nil || protocolParameters.Version() <= committedVersion {
+			continue
+		}
+
+		if err := n.checkProtocolVersion(protocolParameters.Version()); err != nil {
+			n.LogWarnf("The node activates protocol version %d at epoch %d, which is not supported by this extension, the bridge will stop at the activation", protocolParameters.Version(), startEpoch)
+		}
+	}
+}
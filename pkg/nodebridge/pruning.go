@@ -0,0 +1,68 @@
+package nodebridge
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrSlotPruned is returned if the requested slot was already pruned by the node.
+	// It also matches ErrPruned.
+	ErrSlotPruned = ierrors.New("slot was pruned by the node")
+	// ErrEpochPruned is returned if the requested epoch was already pruned by the node.
+	// It also matches ErrPruned.
+	ErrEpochPruned = ierrors.New("epoch was pruned by the node")
+)
+
+// IsEpochPruned returns true if the data of the given epoch was already pruned by the node.
+func (n *nodeBridge) IsEpochPruned(epoch iotago.EpochIndex) bool {
+	nodeStatus := n.NodeStatus()
+	if !nodeStatus.GetHasPruned() {
+		return false
+	}
+
+	return epoch <= iotago.EpochIndex(nodeStatus.GetPruningEpoch())
+}
+
+// IsSlotPruned returns true if the data of the given slot was already pruned by the node.
+func (n *nodeBridge) IsSlotPruned(slot iotago.SlotIndex) bool {
+	return n.IsEpochPruned(n.apiProvider.APIForSlot(slot).TimeProvider().EpochFromSlot(slot))
+}
+
+// checkSlotPruned returns ErrSlotPruned if the data of the given slot was already pruned by the node.
+func (n *nodeBridge) checkSlotPruned(slot iotago.SlotIndex) error {
+	if n.IsSlotPruned(slot) {
+		return &statusError{
+			err:   ierrors.Wrapf(ErrSlotPruned, "slot %d, pruning epoch %d", slot, n.PruningEpoch()),
+			kinds: []error{ErrPruned},
+		}
+	}
+
+	return nil
+}
+
+// checkEpochPruned returns ErrEpochPruned if the data of the given epoch was already pruned by the node.
+func (n *nodeBridge) checkEpochPruned(epoch iotago.EpochIndex) error {
+	if n.IsEpochPruned(epoch) {
+		return &statusError{
+			err:   ierrors.Wrapf(ErrEpochPruned, "epoch %d, pruning epoch %d", epoch, n.PruningEpoch()),
+			kinds: []error{ErrPruned},
+		}
+	}
+
+	return nil
+}
+
+// wrapSlotPrunedError checks if the request for the given slot failed because the slot
+// was pruned in the meantime and returns ErrSlotPruned in that case, otherwise the original error.
+func (n *nodeBridge) wrapSlotPrunedError(slot iotago.SlotIndex, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if prunedErr := n.checkSlotPruned(slot); prunedErr != nil {
+		return ierrors.Join(prunedErr, err)
+	}
+
+	return err
+}
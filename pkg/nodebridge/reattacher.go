@@ -0,0 +1,176 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultReattacherAcceptanceWindow is the default duration a block has to be accepted before it is reattached.
+	DefaultReattacherAcceptanceWindow = 30 * time.Second
+	// DefaultReattacherPollInterval is the default interval in which the state of a submitted block is checked.
+	DefaultReattacherPollInterval = 1 * time.Second
+	// DefaultReattacherMaxAttempts is the default maximum amount of submitted blocks per payload.
+	DefaultReattacherMaxAttempts = 5
+)
+
+var (
+	// ErrReattachmentAttemptsExceeded is returned if none of the submitted blocks was accepted.
+	ErrReattachmentAttemptsExceeded = ierrors.New("reattachment attempts exceeded")
+)
+
+// BuildBlockFunc builds a block on the parents of the given block issuance response.
+type BuildBlockFunc func(ctx context.Context, blockIssuance *api.IssuanceBlockHeaderResponse) (*iotago.Block, error)
+
+// ReattacherEvents are the events triggered by the Reattacher.
+type ReattacherEvents struct {
+	// Reattached is triggered with the ID of the stuck block and the ID of the block that replaces it.
+	Reattached *event.Event2[iotago.BlockID, iotago.BlockID]
+}
+
+// Reattacher submits blocks and rebuilds them on fresh parents if they are not accepted within the acceptance window.
+// The state of the submitted blocks is polled from the node.
+type Reattacher struct {
+	nodeBridge NodeBridge
+	clock      clock.Clock
+	events     *ReattacherEvents
+
+	acceptanceWindow time.Duration
+	pollInterval     time.Duration
+	maxAttempts      int
+	maxParentCount   uint32
+}
+
+// WithReattacherAcceptanceWindow sets the duration a block has to be accepted before it is reattached.
+func WithReattacherAcceptanceWindow(acceptanceWindow time.Duration) options.Option[Reattacher] {
+	return func(r *Reattacher) {
+		r.acceptanceWindow = acceptanceWindow
+	}
+}
+
+// WithReattacherPollInterval sets the interval in which the state of a submitted block is checked.
+func WithReattacherPollInterval(pollInterval time.Duration) options.Option[Reattacher] {
+	return func(r *Reattacher) {
+		r.pollInterval = pollInterval
+	}
+}
+
+// WithReattacherMaxAttempts sets the maximum amount of submitted blocks per payload.
+func WithReattacherMaxAttempts(maxAttempts int) options.Option[Reattacher] {
+	return func(r *Reattacher) {
+		r.maxAttempts = maxAttempts
+	}
+}
+
+// WithReattacherMaxParentCount sets the amount of parents that is requested per parent type.
+func WithReattacherMaxParentCount(maxParentCount uint32) options.Option[Reattacher] {
+	return func(r *Reattacher) {
+		r.maxParentCount = maxParentCount
+	}
+}
+
+// WithReattacherClock sets the clock that is used for the acceptance window and the poll interval, e.g. a clock.Mock in tests.
+func WithReattacherClock(timeSource clock.Clock) options.Option[Reattacher] {
+	return func(r *Reattacher) {
+		r.clock = timeSource
+	}
+}
+
+// NewReattacher creates a new Reattacher.
+func NewReattacher(nodeBridge NodeBridge, opts ...options.Option[Reattacher]) *Reattacher {
+	return options.Apply(&Reattacher{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &ReattacherEvents{
+			Reattached: event.New2[iotago.BlockID, iotago.BlockID](),
+		},
+		acceptanceWindow: DefaultReattacherAcceptanceWindow,
+		pollInterval:     DefaultReattacherPollInterval,
+		maxAttempts:      DefaultReattacherMaxAttempts,
+		maxParentCount:   iotago.BasicBlockMaxParents,
+	}, opts)
+}
+
+// Events returns the events of the Reattacher.
+func (r *Reattacher) Events() *ReattacherEvents {
+	return r.events
+}
+
+// SubmitBlock builds a block on fresh parents, submits it and rebuilds it on fresh parents
+// if it is not accepted within the acceptance window or was dropped or orphaned.
+// It returns the ID of the block that was accepted.
+func (r *Reattacher) SubmitBlock(ctx context.Context, buildBlock BuildBlockFunc) (iotago.BlockID, error) {
+	var previousBlockID iotago.BlockID
+
+	for attempt := 0; attempt < r.maxAttempts; attempt++ {
+		blockIssuance, err := r.nodeBridge.BlockIssuance(ctx, UniformBlockIssuanceParents(r.maxParentCount))
+		if err != nil {
+			return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to request block issuance")
+		}
+
+		block, err := buildBlock(ctx, blockIssuance)
+		if err != nil {
+			return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to build block")
+		}
+
+		blockID, err := r.nodeBridge.SubmitBlock(ctx, block)
+		if err != nil {
+			return iotago.EmptyBlockID, err
+		}
+
+		if attempt > 0 {
+			r.events.Reattached.Trigger(previousBlockID, blockID)
+		}
+		previousBlockID = blockID
+
+		accepted, err := r.waitForAcceptance(ctx, blockID)
+		if err != nil {
+			return iotago.EmptyBlockID, err
+		}
+		if accepted {
+			return blockID, nil
+		}
+	}
+
+	return iotago.EmptyBlockID, ierrors.Wrapf(ErrReattachmentAttemptsExceeded, "no block was accepted after %d attempts, last block: %s", r.maxAttempts, previousBlockID.ToHex())
+}
+
+// waitForAcceptance returns true if the given block was accepted within the acceptance window,
+// or false if the window passed or the block was dropped or orphaned.
+func (r *Reattacher) waitForAcceptance(ctx context.Context, blockID iotago.BlockID) (bool, error) {
+	deadline := r.clock.NewTimer(r.acceptanceWindow)
+	defer deadline.Stop()
+
+	ticker := r.clock.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-deadline.C():
+			return false, nil
+		case <-ticker.C():
+		}
+
+		metadata, err := r.nodeBridge.BlockMetadata(ctx, blockID)
+		if err != nil {
+			// the block might not be known to the node yet
+			continue
+		}
+
+		switch metadata.BlockState {
+		case api.BlockStateAccepted, api.BlockStateConfirmed, api.BlockStateFinalized:
+			return true, nil
+		case api.BlockStateDropped, api.BlockStateOrphaned:
+			return false, nil
+		}
+	}
+}
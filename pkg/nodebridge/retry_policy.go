@@ -0,0 +1,76 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultRetryBackoff is the default duration between two retries of a unary call.
+	DefaultRetryBackoff = 50 * time.Millisecond
+	// DefaultRetryJitter is the default fraction of the backoff that is randomly added or subtracted.
+	DefaultRetryJitter = 0.1
+)
+
+// retryPolicy defines how failed unary calls to the node are retried.
+type retryPolicy struct {
+	maxRetries uint
+	backoff    time.Duration
+	jitter     float64
+	codes      []codes.Code
+}
+
+// WithRetryPolicy configures the retries of all unary calls to the node.
+// A failed call is retried up to maxRetries times if the status code of the error is one of the given codes,
+// with a backoff in the range [backoff*(1-jitter), backoff*(1+jitter)] between the attempts.
+// If no codes are given, calls failing with ResourceExhausted or Unavailable are retried.
+// If maxRetries is zero, unary calls are not retried, which is the default.
+// Retries can be disabled for single calls with ContextWithoutRetries.
+func WithRetryPolicy(maxRetries uint, backoff time.Duration, jitter float64, retryCodes ...codes.Code) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		if len(retryCodes) == 0 {
+			retryCodes = grpcretry.DefaultRetriableCodes
+		}
+
+		n.retry = retryPolicy{
+			maxRetries: maxRetries,
+			backoff:    backoff,
+			jitter:     jitter,
+			codes:      retryCodes,
+		}
+	}
+}
+
+type noRetriesContextKey struct{}
+
+// ContextWithoutRetries returns a context that disables the retries of the bridge for all unary calls
+// that are issued with the returned context, e.g. for latency-sensitive calls like SubmitBlock.
+func ContextWithoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesContextKey{}, true)
+}
+
+// retryUnaryClientInterceptors returns the interceptors that retry failed unary calls according to the retry policy.
+func (n *nodeBridge) retryUnaryClientInterceptors() []grpc.UnaryClientInterceptor {
+	noRetriesInterceptor := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if noRetries, _ := ctx.Value(noRetriesContextKey{}).(bool); noRetries {
+			opts = append(opts, grpcretry.Disable())
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	return []grpc.UnaryClientInterceptor{
+		noRetriesInterceptor,
+		grpcretry.UnaryClientInterceptor(
+			grpcretry.WithMax(n.retry.maxRetries),
+			grpcretry.WithCodes(n.retry.codes...),
+			grpcretry.WithBackoff(grpcretry.BackoffLinearWithJitter(n.retry.backoff, n.retry.jitter)),
+		),
+	}
+}
@@ -0,0 +1,101 @@
+package nodebridge
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+var (
+	ErrOutputNotDelegation = ierrors.New("output is not a delegation output")
+)
+
+// DelegationOutput returns the current delegation output with metadata for the given delegation ID.
+// The lookup is done via the indexer, so ErrIndexerPluginNotAvailable is returned if the node does not support the plugin.
+func (n *nodeBridge) DelegationOutput(ctx context.Context, delegationID iotago.DelegationID) (*Output, error) {
+	indexer, err := n.Indexer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputID, _, _, err := indexer.Delegation(ctx, delegationID)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to query delegation output %s", delegationID.ToHex())
+	}
+
+	output, err := n.Output(ctx, *outputID)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Output.Type() != iotago.OutputDelegation {
+		return nil, ierrors.Wrapf(ErrOutputNotDelegation, "output %s", outputID.ToHex())
+	}
+
+	return output, nil
+}
+
+// ReadRewards returns the mana rewards of the given staking account or delegation output.
+// The returned StartEpoch and EndEpoch define the epoch range for which the rewards can be claimed.
+// If slot is not zero, the rewards are calculated as if they were claimed in the given slot,
+// otherwise the latest committed slot of the node is used.
+func (n *nodeBridge) ReadRewards(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (*api.ManaRewardsResponse, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	query := api.EndpointWithNamedParameterValue(api.CoreRouteRewards, api.ParameterOutputID, outputID.ToHex())
+	if slot != 0 {
+		queryParams := url.Values{}
+		queryParams.Add(api.ParameterSlot, strconv.FormatUint(uint64(slot), 10))
+		query += "?" + queryParams.Encode()
+	}
+
+	res := new(api.ManaRewardsResponse)
+	//nolint:bodyclose // the body is closed by the nodeclient
+	if _, err := nodeClient.DoWithRequestHeaderHook(ctx, http.MethodGet, query, nodeclient.RequestHeaderHookAcceptJSON, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReadValidatorRewards returns the mana rewards of the staking account with the given account ID.
+// The lookup of the account output is done via the indexer.
+func (n *nodeBridge) ReadValidatorRewards(ctx context.Context, accountID iotago.AccountID, slot iotago.SlotIndex) (*api.ManaRewardsResponse, error) {
+	indexer, err := n.Indexer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accountAddress := iotago.AccountAddress(accountID)
+	outputID, _, _, err := indexer.Account(ctx, &accountAddress)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to query account output %s", accountID.ToHex())
+	}
+
+	return n.ReadRewards(ctx, *outputID, slot)
+}
+
+// ReadDelegatorRewards returns the mana rewards of the delegation output with the given delegation ID.
+// The lookup of the delegation output is done via the indexer.
+func (n *nodeBridge) ReadDelegatorRewards(ctx context.Context, delegationID iotago.DelegationID, slot iotago.SlotIndex) (*api.ManaRewardsResponse, error) {
+	indexer, err := n.Indexer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputID, _, _, err := indexer.Delegation(ctx, delegationID)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to query delegation output %s", delegationID.ToHex())
+	}
+
+	return n.ReadRewards(ctx, *outputID, slot)
+}
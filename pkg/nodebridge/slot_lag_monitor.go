@@ -0,0 +1,223 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultSlotLagMonitorInterval is the default interval in which the slot lag is checked.
+	DefaultSlotLagMonitorInterval = 1 * time.Second
+)
+
+// SlotLag is the lag of the commitments of the node behind the current wall-clock slot.
+type SlotLag struct {
+	// Time is the time the lag was checked.
+	Time time.Time
+	// CurrentSlot is the wall-clock slot derived from the protocol parameters.
+	CurrentSlot iotago.SlotIndex
+	// LatestCommittedSlot is the slot of the latest commitment of the node.
+	LatestCommittedSlot iotago.SlotIndex
+	// LatestFinalizedSlot is the slot of the latest finalized commitment of the node.
+	LatestFinalizedSlot iotago.SlotIndex
+	// CommitmentLag is the amount of slots the latest commitment lags behind the current slot.
+	CommitmentLag iotago.SlotIndex
+	// FinalizationLag is the amount of slots the latest finalized commitment lags behind the current slot.
+	FinalizationLag iotago.SlotIndex
+}
+
+// SlotLagMonitorEvents are the events triggered by the SlotLagMonitor.
+type SlotLagMonitorEvents struct {
+	// Checked is triggered for every check of the slot lag.
+	Checked *event.Event1[*SlotLag]
+	// CommitmentLagExceeded is triggered if the commitment lag exceeded the threshold.
+	CommitmentLagExceeded *event.Event1[*SlotLag]
+	// CommitmentLagRecovered is triggered if the commitment lag is within the threshold again.
+	CommitmentLagRecovered *event.Event1[*SlotLag]
+	// FinalizationStalled is triggered if the finalization lag exceeded the threshold.
+	FinalizationStalled *event.Event1[*SlotLag]
+	// FinalizationRecovered is triggered if the finalization lag is within the threshold again.
+	FinalizationRecovered *event.Event1[*SlotLag]
+}
+
+// SlotLagMonitor periodically compares the wall-clock slot with the latest commitment and
+// the latest finalized commitment of the node and warns if the lag exceeds the thresholds,
+// which is a strong indicator for a node that is out of sync or stuck.
+type SlotLagMonitor struct {
+	nodeBridge NodeBridge
+	clock      clock.Clock
+	events     *SlotLagMonitorEvents
+
+	interval                      time.Duration
+	commitmentLagThreshold        iotago.SlotIndex
+	finalizationLagThreshold      iotago.SlotIndex
+	commitmentLagThresholdFixed   bool
+	finalizationLagThresholdFixed bool
+
+	latestLagMutex        sync.RWMutex
+	latestLag             *SlotLag
+	commitmentLagExceeded bool
+	finalizationStalled   bool
+}
+
+// WithSlotLagMonitorInterval sets the interval in which the slot lag is checked.
+func WithSlotLagMonitorInterval(interval time.Duration) options.Option[SlotLagMonitor] {
+	return func(m *SlotLagMonitor) {
+		m.interval = interval
+	}
+}
+
+// WithSlotLagMonitorCommitmentLagThreshold sets the maximum amount of slots the latest commitment may lag behind the current slot.
+// By default, the max committable age of the protocol parameters is used.
+func WithSlotLagMonitorCommitmentLagThreshold(threshold iotago.SlotIndex) options.Option[SlotLagMonitor] {
+	return func(m *SlotLagMonitor) {
+		m.commitmentLagThreshold = threshold
+		m.commitmentLagThresholdFixed = true
+	}
+}
+
+// WithSlotLagMonitorFinalizationLagThreshold sets the maximum amount of slots the latest finalized commitment may lag behind the current slot.
+// By default, twice the max committable age of the protocol parameters is used.
+func WithSlotLagMonitorFinalizationLagThreshold(threshold iotago.SlotIndex) options.Option[SlotLagMonitor] {
+	return func(m *SlotLagMonitor) {
+		m.finalizationLagThreshold = threshold
+		m.finalizationLagThresholdFixed = true
+	}
+}
+
+// WithSlotLagMonitorClock sets the clock that is used for the wall-clock slot and the check interval, e.g. a clock.Mock in tests.
+func WithSlotLagMonitorClock(timeSource clock.Clock) options.Option[SlotLagMonitor] {
+	return func(m *SlotLagMonitor) {
+		m.clock = timeSource
+	}
+}
+
+// NewSlotLagMonitor creates a new SlotLagMonitor.
+func NewSlotLagMonitor(nodeBridge NodeBridge, opts ...options.Option[SlotLagMonitor]) *SlotLagMonitor {
+	return options.Apply(&SlotLagMonitor{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &SlotLagMonitorEvents{
+			Checked:                event.New1[*SlotLag](),
+			CommitmentLagExceeded:  event.New1[*SlotLag](),
+			CommitmentLagRecovered: event.New1[*SlotLag](),
+			FinalizationStalled:    event.New1[*SlotLag](),
+			FinalizationRecovered:  event.New1[*SlotLag](),
+		},
+		interval: DefaultSlotLagMonitorInterval,
+	}, opts)
+}
+
+// Events returns the events of the SlotLagMonitor.
+func (m *SlotLagMonitor) Events() *SlotLagMonitorEvents {
+	return m.events
+}
+
+// LatestLag returns the latest checked slot lag, or nil if there is none yet.
+func (m *SlotLagMonitor) LatestLag() *SlotLag {
+	m.latestLagMutex.RLock()
+	defer m.latestLagMutex.RUnlock()
+
+	return m.latestLag
+}
+
+// Run checks the slot lag in the configured interval and blocks until the given context is done.
+func (m *SlotLagMonitor) Run(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Check checks the slot lag and triggers the events if a threshold was crossed.
+// It returns nil if the node did not report any commitment yet.
+func (m *SlotLagMonitor) Check() *SlotLag {
+	latestCommitment := m.nodeBridge.LatestCommitment()
+	latestFinalizedCommitment := m.nodeBridge.LatestFinalizedCommitment()
+	if latestCommitment == nil || latestFinalizedCommitment == nil {
+		return nil
+	}
+
+	now := m.clock.Now()
+	apiForTime := m.nodeBridge.APIProvider().APIForTime(now)
+
+	lag := &SlotLag{
+		Time:                now,
+		CurrentSlot:         apiForTime.TimeProvider().SlotFromTime(now),
+		LatestCommittedSlot: latestCommitment.CommitmentID.Slot(),
+		LatestFinalizedSlot: latestFinalizedCommitment.CommitmentID.Slot(),
+	}
+	lag.CommitmentLag = slotDistance(lag.CurrentSlot, lag.LatestCommittedSlot)
+	lag.FinalizationLag = slotDistance(lag.CurrentSlot, lag.LatestFinalizedSlot)
+
+	commitmentLagThreshold, finalizationLagThreshold := m.thresholds(apiForTime)
+	commitmentLagExceeded := lag.CommitmentLag > commitmentLagThreshold
+	finalizationStalled := lag.FinalizationLag > finalizationLagThreshold
+
+	m.latestLagMutex.Lock()
+	m.latestLag = lag
+	commitmentLagChanged := m.commitmentLagExceeded != commitmentLagExceeded
+	finalizationStalledChanged := m.finalizationStalled != finalizationStalled
+	m.commitmentLagExceeded = commitmentLagExceeded
+	m.finalizationStalled = finalizationStalled
+	m.latestLagMutex.Unlock()
+
+	m.events.Checked.Trigger(lag)
+
+	if commitmentLagChanged {
+		if commitmentLagExceeded {
+			m.events.CommitmentLagExceeded.Trigger(lag)
+		} else {
+			m.events.CommitmentLagRecovered.Trigger(lag)
+		}
+	}
+
+	if finalizationStalledChanged {
+		if finalizationStalled {
+			m.events.FinalizationStalled.Trigger(lag)
+		} else {
+			m.events.FinalizationRecovered.Trigger(lag)
+		}
+	}
+
+	return lag
+}
+
+// thresholds returns the configured thresholds or the ones derived from the protocol parameters.
+func (m *SlotLagMonitor) thresholds(apiForTime iotago.API) (iotago.SlotIndex, iotago.SlotIndex) {
+	maxCommittableAge := apiForTime.ProtocolParameters().MaxCommittableAge()
+
+	commitmentLagThreshold := maxCommittableAge
+	if m.commitmentLagThresholdFixed {
+		commitmentLagThreshold = m.commitmentLagThreshold
+	}
+
+	finalizationLagThreshold := 2 * maxCommittableAge
+	if m.finalizationLagThresholdFixed {
+		finalizationLagThreshold = m.finalizationLagThreshold
+	}
+
+	return commitmentLagThreshold, finalizationLagThreshold
+}
+
+func slotDistance(currentSlot iotago.SlotIndex, slot iotago.SlotIndex) iotago.SlotIndex {
+	if slot >= currentSlot {
+		return 0
+	}
+
+	return currentSlot - slot
+}
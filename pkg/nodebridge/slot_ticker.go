@@ -0,0 +1,122 @@
+package nodebridge
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// SlotTicker fires a callback at the start of every slot.
+// The slot boundaries are derived from the genesis time and the slot duration of the protocol parameters.
+type SlotTicker struct {
+	nodeBridge NodeBridge
+	callback   func(slot iotago.SlotIndex)
+	clock      clock.Clock
+
+	driftCorrectionEnabled bool
+	// driftInSlots is the difference between the slot derived from the latest commitment of the node and the local wall clock slot.
+	// It is updated by Run and may be read concurrently by Drift.
+	driftInSlots atomic.Int64
+
+	lastTickedSlot iotago.SlotIndex
+	ticked         bool
+}
+
+// WithSlotTickerDriftCorrection enables or disables the correction of the local wall clock slot against the latest commitment of the node.
+// If enabled and the node is healthy, the ticked slot is corrected if the latest commitment is outside of the
+// committable age window (MinCommittableAge/MaxCommittableAge) relative to the local wall clock slot.
+func WithSlotTickerDriftCorrection(enabled bool) options.Option[SlotTicker] {
+	return func(t *SlotTicker) {
+		t.driftCorrectionEnabled = enabled
+	}
+}
+
+// WithSlotTickerClock sets the clock that is used to determine the current slot, e.g. a clock.Mock in tests.
+func WithSlotTickerClock(timeSource clock.Clock) options.Option[SlotTicker] {
+	return func(t *SlotTicker) {
+		t.clock = timeSource
+	}
+}
+
+// NewSlotTicker creates a new SlotTicker that calls the given callback at the start of every slot.
+func NewSlotTicker(nodeBridge NodeBridge, callback func(slot iotago.SlotIndex), opts ...options.Option[SlotTicker]) *SlotTicker {
+	return options.Apply(&SlotTicker{
+		nodeBridge:             nodeBridge,
+		callback:               callback,
+		clock:                  clock.System,
+		driftCorrectionEnabled: true,
+	}, opts)
+}
+
+// Run starts the SlotTicker and blocks until the given context is done.
+func (t *SlotTicker) Run(ctx context.Context) {
+	for {
+		now := t.clock.Now()
+		localSlot := t.nodeBridge.APIProvider().APIForTime(now).TimeProvider().SlotFromTime(now)
+
+		if slot := t.correctedSlot(localSlot); !t.ticked || slot > t.lastTickedSlot {
+			t.lastTickedSlot = slot
+			t.ticked = true
+
+			t.callback(slot)
+		}
+
+		nextSlotStartTime := t.nodeBridge.APIProvider().APIForSlot(localSlot + 1).TimeProvider().SlotStartTime(localSlot + 1)
+
+		timer := t.clock.NewTimer(nextSlotStartTime.Sub(t.clock.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C():
+		}
+	}
+}
+
+// Drift returns the current correction of the local wall clock in slots.
+func (t *SlotTicker) Drift() int64 {
+	return t.driftInSlots.Load()
+}
+
+func (t *SlotTicker) correctedSlot(localSlot iotago.SlotIndex) iotago.SlotIndex {
+	if t.driftCorrectionEnabled {
+		t.updateDrift(localSlot)
+	}
+
+	correctedSlot := int64(localSlot) + t.driftInSlots.Load()
+	if correctedSlot < 0 {
+		return 0
+	}
+
+	return iotago.SlotIndex(correctedSlot)
+}
+
+func (t *SlotTicker) updateDrift(localSlot iotago.SlotIndex) {
+	latestCommitment := t.nodeBridge.LatestCommitment()
+	if latestCommitment == nil || !t.nodeBridge.IsNodeHealthy() {
+		// we can't correct the drift without a healthy node
+		return
+	}
+
+	latestCommitmentSlot := int64(latestCommitment.CommitmentID.Slot())
+	protocolParams := t.nodeBridge.APIProvider().APIForSlot(latestCommitment.CommitmentID.Slot()).ProtocolParameters()
+
+	// the latest commitment of a healthy node is expected to be within the committable age window
+	earliestExpectedSlot := latestCommitmentSlot + int64(protocolParams.MinCommittableAge())
+	latestExpectedSlot := latestCommitmentSlot + int64(protocolParams.MaxCommittableAge())
+
+	switch slot := int64(localSlot); {
+	case slot < earliestExpectedSlot:
+		// the local clock is behind
+		t.driftInSlots.Store(earliestExpectedSlot - slot)
+	case slot > latestExpectedSlot:
+		// the local clock is ahead
+		t.driftInSlots.Store(latestExpectedSlot - slot)
+	default:
+		t.driftInSlots.Store(0)
+	}
+}
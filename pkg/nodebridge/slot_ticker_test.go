@@ -0,0 +1,127 @@
+package nodebridge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+const testSlotDurationInSeconds = 10
+
+var testGenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newSlotTickerTestNodeBridge(t *testing.T) (*nodeBridge, iotago.API) {
+	t.Helper()
+
+	protocolParameters := iotago.NewV3SnapshotProtocolParameters(
+		iotago.WithNetworkOptions("slot-ticker", iotago.PrefixTestnet),
+		iotago.WithTimeProviderOptions(0, testGenesisTime.Unix(), testSlotDurationInSeconds, 13),
+	)
+
+	//nolint:forcetypeassert // New always returns a *nodeBridge
+	n := New(log.NewLogger(log.WithName("slot-ticker"))).(*nodeBridge)
+	n.apiProvider.AddProtocolParametersAtEpoch(protocolParameters, 0)
+
+	return n, n.apiProvider.APIForEpoch(0)
+}
+
+// runSlotTicker runs a SlotTicker driven by the given mock clock and returns the channel of the ticked slots.
+func runSlotTicker(ctx context.Context, t *testing.T, n *nodeBridge, mock *clock.Mock) (*SlotTicker, <-chan iotago.SlotIndex) {
+	t.Helper()
+
+	ticks := make(chan iotago.SlotIndex, 10)
+	ticker := NewSlotTicker(n, func(slot iotago.SlotIndex) {
+		ticks <- slot
+	}, WithSlotTickerClock(mock))
+
+	go ticker.Run(ctx)
+
+	return ticker, ticks
+}
+
+// expectTick waits for the next ticked slot and checks it.
+func expectTick(ctx context.Context, t *testing.T, ticks <-chan iotago.SlotIndex, expected iotago.SlotIndex) {
+	t.Helper()
+
+	select {
+	case slot := <-ticks:
+		if slot != expected {
+			t.Fatalf("ticked slot %d, expected %d", slot, expected)
+		}
+	case <-ctx.Done():
+		t.Fatalf("slot %d was not ticked", expected)
+	}
+}
+
+func TestSlotTicker(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, testAPI := newSlotTickerTestNodeBridge(t)
+	timeProvider := testAPI.TimeProvider()
+
+	startSlot := iotago.SlotIndex(5)
+	mock := clock.NewMock(timeProvider.SlotStartTime(startSlot).Add(time.Second))
+
+	_, ticks := runSlotTicker(ctx, t, n, mock)
+
+	// the current slot is ticked right away
+	expectTick(ctx, t, ticks, startSlot)
+
+	for slot := startSlot + 1; slot < startSlot+5; slot++ {
+		if err := mock.WaitForWaiters(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		// nothing is ticked before the start of the next slot
+		mock.Set(timeProvider.SlotStartTime(slot).Add(-time.Nanosecond))
+		select {
+		case tickedSlot := <-ticks:
+			t.Fatalf("slot %d was ticked before its start", tickedSlot)
+		default:
+		}
+
+		mock.Set(timeProvider.SlotStartTime(slot))
+		expectTick(ctx, t, ticks, slot)
+	}
+
+	// skipped slots are not ticked, the ticker continues with the current slot
+	if err := mock.WaitForWaiters(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	mock.Set(timeProvider.SlotStartTime(startSlot + 10))
+	expectTick(ctx, t, ticks, startSlot+10)
+}
+
+func TestSlotTickerDriftCorrection(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, testAPI := newSlotTickerTestNodeBridge(t)
+	timeProvider := testAPI.TimeProvider()
+	minCommittableAge := testAPI.ProtocolParameters().MinCommittableAge()
+
+	// the node already committed the local wall clock slot, so the local clock is behind by the min committable age
+	localSlot := iotago.SlotIndex(20)
+	n.nodeStatus = &inx.NodeStatus{IsHealthy: true}
+	n.latestCommitment = &Commitment{CommitmentID: iotago.NewCommitmentID(localSlot, tpkg.Rand32ByteArray())}
+
+	mock := clock.NewMock(timeProvider.SlotStartTime(localSlot))
+
+	ticker, ticks := runSlotTicker(ctx, t, n, mock)
+
+	expectTick(ctx, t, ticks, localSlot+minCommittableAge)
+	if drift := ticker.Drift(); drift != int64(minCommittableAge) {
+		t.Fatalf("expected drift of %d slots, got %d", minCommittableAge, drift)
+	}
+}
@@ -0,0 +1,206 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultStreamDispatcherBufferSize is the default amount of items that are buffered per consumer.
+	DefaultStreamDispatcherBufferSize = 1024
+)
+
+var (
+	// ErrConsumerTooSlow is returned to a consumer of a StreamDispatcher that was dropped because its buffer was full.
+	ErrConsumerTooSlow = ierrors.New("consumer too slow")
+	// ErrStreamDispatcherStopped is returned if a consumer subscribes to a StreamDispatcher that is not running anymore.
+	ErrStreamDispatcherStopped = ierrors.New("stream dispatcher stopped")
+)
+
+// StreamDispatcher maintains a single upstream stream of the node and fans it out to all subscribed consumers.
+// Every consumer has its own buffer and consumes the items at its own pace.
+type StreamDispatcher[T any] struct {
+	listen func(ctx context.Context, consumer func(item T) error) error
+
+	bufferSize        int
+	blockOnFullBuffer bool
+
+	subscribersMutex sync.RWMutex
+	subscribers      map[*streamSubscriber[T]]struct{}
+	stopped          bool
+}
+
+// WithStreamDispatcherBufferSize sets the amount of items that are buffered per consumer.
+func WithStreamDispatcherBufferSize[T any](bufferSize int) options.Option[StreamDispatcher[T]] {
+	return func(d *StreamDispatcher[T]) {
+		d.bufferSize = bufferSize
+	}
+}
+
+// WithStreamDispatcherBlockOnFullBuffer sets whether the upstream stream is paused if the buffer of a consumer is full.
+// If disabled, consumers with a full buffer are dropped with ErrConsumerTooSlow.
+func WithStreamDispatcherBlockOnFullBuffer[T any](block bool) options.Option[StreamDispatcher[T]] {
+	return func(d *StreamDispatcher[T]) {
+		d.blockOnFullBuffer = block
+	}
+}
+
+// NewStreamDispatcher creates a new StreamDispatcher for the stream opened by the given listen function.
+func NewStreamDispatcher[T any](listen func(ctx context.Context, consumer func(item T) error) error, opts ...options.Option[StreamDispatcher[T]]) *StreamDispatcher[T] {
+	return options.Apply(&StreamDispatcher[T]{
+		listen:      listen,
+		bufferSize:  DefaultStreamDispatcherBufferSize,
+		subscribers: make(map[*streamSubscriber[T]]struct{}),
+	}, opts)
+}
+
+// NewBlockDispatcher creates a new StreamDispatcher for the blocks of the node.
+// The blocks are only deserialized once, on the first access of any consumer.
+func NewBlockDispatcher(nodeBridge NodeBridge, opts ...options.Option[StreamDispatcher[*LazyBlock]]) *StreamDispatcher[*LazyBlock] {
+	return NewStreamDispatcher(nodeBridge.ListenToLazyBlocks, opts...)
+}
+
+// NewLedgerUpdateDispatcher creates a new StreamDispatcher for the ledger updates of the node in the given slot range.
+func NewLedgerUpdateDispatcher(nodeBridge NodeBridge, startSlot iotago.SlotIndex, endSlot iotago.SlotIndex, opts ...options.Option[StreamDispatcher[*LedgerUpdate]]) *StreamDispatcher[*LedgerUpdate] {
+	return NewStreamDispatcher(func(ctx context.Context, consumer func(update *LedgerUpdate) error) error {
+		return nodeBridge.ListenToLedgerUpdates(ctx, startSlot, endSlot, consumer)
+	}, opts...)
+}
+
+// Run opens the upstream stream and dispatches the items to the consumers until the given context is done or the stream ended.
+// All consumers are stopped afterwards, after they consumed their buffered items.
+func (d *StreamDispatcher[T]) Run(ctx context.Context) error {
+	err := d.listen(ctx, func(item T) error {
+		d.dispatch(ctx, item)
+
+		return nil
+	})
+
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+
+	d.stopped = true
+	for subscriber := range d.subscribers {
+		subscriber.stop(nil)
+	}
+
+	return err
+}
+
+// Subscribe passes all items of the stream to the given consumer and blocks until the given context is done,
+// the stream ended, the consumer returned an error or the consumer was dropped because it was too slow.
+func (d *StreamDispatcher[T]) Subscribe(ctx context.Context, consumer func(item T) error) error {
+	subscriber, err := d.subscribe()
+	if err != nil {
+		return err
+	}
+	defer d.unsubscribe(subscriber)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case item := <-subscriber.items:
+			if err := consumer(item); err != nil {
+				return err
+			}
+
+		case <-subscriber.done:
+			if subscriber.err != nil {
+				return subscriber.err
+			}
+
+			// the stream ended, consume the remaining items
+			for {
+				select {
+				case item := <-subscriber.items:
+					if err := consumer(item); err != nil {
+						return err
+					}
+				default:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// SubscribersCount returns the amount of subscribed consumers.
+func (d *StreamDispatcher[T]) SubscribersCount() int {
+	d.subscribersMutex.RLock()
+	defer d.subscribersMutex.RUnlock()
+
+	return len(d.subscribers)
+}
+
+func (d *StreamDispatcher[T]) subscribe() (*streamSubscriber[T], error) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+
+	if d.stopped {
+		return nil, ErrStreamDispatcherStopped
+	}
+
+	subscriber := &streamSubscriber[T]{
+		items: make(chan T, d.bufferSize),
+		done:  make(chan struct{}),
+	}
+	d.subscribers[subscriber] = struct{}{}
+
+	return subscriber, nil
+}
+
+func (d *StreamDispatcher[T]) unsubscribe(subscriber *streamSubscriber[T]) {
+	// stop the subscriber before acquiring the lock, so a dispatch that is blocked on its full buffer is released
+	subscriber.stop(nil)
+
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+
+	delete(d.subscribers, subscriber)
+}
+
+func (d *StreamDispatcher[T]) dispatch(ctx context.Context, item T) {
+	d.subscribersMutex.RLock()
+	defer d.subscribersMutex.RUnlock()
+
+	for subscriber := range d.subscribers {
+		if d.blockOnFullBuffer {
+			select {
+			case subscriber.items <- item:
+			case <-subscriber.done:
+			case <-ctx.Done():
+			}
+
+			continue
+		}
+
+		select {
+		case subscriber.items <- item:
+		default:
+			subscriber.stop(ErrConsumerTooSlow)
+		}
+	}
+}
+
+// streamSubscriber is a consumer of a StreamDispatcher with its own buffer.
+type streamSubscriber[T any] struct {
+	items chan T
+
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// stop stops the subscriber with the given error, the error is only set by the first call.
+func (s *streamSubscriber[T]) stop(err error) {
+	s.stopOnce.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
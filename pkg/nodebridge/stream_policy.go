@@ -0,0 +1,190 @@
+package nodebridge
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultStreamDrainBufferSize is the default amount of items that are buffered per stream in drain mode.
+	DefaultStreamDrainBufferSize = 100
+)
+
+const (
+	StreamNameBlocks               = "blocks"
+	StreamNameBlockMetadata        = "blockMetadata"
+	StreamNameCommitments          = "commitments"
+	StreamNameLedgerUpdates        = "ledgerUpdates"
+	StreamNameAcceptedTransactions = "acceptedTransactions"
+)
+
+// WithStreamDraining enables the drain mode of the streams.
+// In drain mode, the items of a stream are received into a buffer of the given size.
+// If the context of the stream is canceled, no new items are received, but the buffered items
+// are still delivered to the consumer until the graceTimeout elapsed. Afterwards the StreamDrained event is triggered,
+// so consumers can flush their checkpoints. If graceTimeout is zero, the drain mode is disabled.
+func WithStreamDraining(bufferSize int, graceTimeout time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.streamDrainBufferSize = bufferSize
+		n.streamDrainGraceTimeout = graceTimeout
+	}
+}
+
+// listenToStream listens to the stream with the given name and applies the configured stream policies.
+func listenToStream[K any](ctx context.Context, n *nodeBridge, streamName string, receiverFunc func() (K, error), consumerFunc func(K) error) error {
+	consumerFunc = deadLetterConsumer(n, streamName, callStatsConsumer(n, streamName, recoverConsumer(n, streamName, consumerFunc)))
+
+	state := newStreamState(streamName)
+	trackedReceiverFunc := func() (K, error) {
+		item, err := receiverFunc()
+		if err == nil {
+			state.received(item)
+		}
+
+		return item, err
+	}
+
+	if n.streamDrainGraceTimeout <= 0 {
+		n.registerStream(state)
+		defer n.unregisterStream(state)
+
+		return ListenToStream(ctx, trackedReceiverFunc, consumerFunc)
+	}
+
+	return listenToStreamDraining(ctx, n, state, trackedReceiverFunc, consumerFunc)
+}
+
+// listenToStreamDraining receives the items of the stream into a buffer and delivers
+// the buffered items to the consumer after the context was canceled, bounded by the grace timeout.
+func listenToStreamDraining[K any](ctx context.Context, n *nodeBridge, state *streamState, receiverFunc func() (K, error), consumerFunc func(K) error) error {
+	streamName := state.name
+
+	items := make(chan K, max(n.streamDrainBufferSize, 1))
+	state.bufferCapacity = cap(items)
+	state.bufferLength = func() int { return len(items) }
+
+	n.registerStream(state)
+	defer n.unregisterStream(state)
+	receiverErr := make(chan error, 1)
+	consumerDone := make(chan struct{})
+	defer close(consumerDone)
+
+	go func() {
+		defer close(items)
+
+		receiverErr <- ListenToStream(ctx, receiverFunc, func(item K) error {
+			select {
+			case items <- item:
+				return nil
+			case <-consumerDone:
+				return context.Canceled
+			}
+		})
+	}()
+
+	ctxDone := ctx.Done()
+	var graceTimeout <-chan time.Time
+
+	for {
+		select {
+		case item, ok := <-items:
+			if !ok {
+				// the receiver stopped and all buffered items were delivered
+				if ctx.Err() != nil {
+					n.LogDebugf("Stream %s drained", streamName)
+					n.events.StreamDrained.Trigger(streamName)
+				}
+
+				return <-receiverErr
+			}
+
+			if err := consumerFunc(item); err != nil {
+				return err
+			}
+
+		case <-ctxDone:
+			// stop waiting for the context, the receiver stops and closes the buffer
+			ctxDone = nil
+
+			timer := time.NewTimer(n.streamDrainGraceTimeout)
+			defer timer.Stop()
+			graceTimeout = timer.C
+
+		case <-graceTimeout:
+			n.LogWarnf("Stream %s not drained within %s, dropping %d buffered items", streamName, n.streamDrainGraceTimeout, len(items))
+			n.events.StreamDrained.Trigger(streamName)
+
+			return nil
+		}
+	}
+}
+
+// PanicPolicy defines how a panic in a stream consumer is handled.
+type PanicPolicy int
+
+const (
+	// PanicPolicyPropagate doesn't recover panics in stream consumers.
+	PanicPolicyPropagate PanicPolicy = iota
+	// PanicPolicySkipItem recovers a panic in a stream consumer and continues with the next item.
+	PanicPolicySkipItem
+	// PanicPolicyTerminateStream recovers a panic in a stream consumer and terminates the stream with ErrConsumerPanicked.
+	PanicPolicyTerminateStream
+)
+
+var (
+	// ErrConsumerPanicked is returned if a stream was terminated because its consumer panicked.
+	ErrConsumerPanicked = ierrors.New("consumer panicked")
+	// ErrUnknownPanicPolicy is returned if a panic policy can't be parsed.
+	ErrUnknownPanicPolicy = ierrors.New("unknown panic policy")
+)
+
+// ParsePanicPolicy parses the given panic policy ("propagate", "skip" or "terminate").
+func ParsePanicPolicy(policy string) (PanicPolicy, error) {
+	switch policy {
+	case "propagate", "":
+		return PanicPolicyPropagate, nil
+	case "skip":
+		return PanicPolicySkipItem, nil
+	case "terminate":
+		return PanicPolicyTerminateStream, nil
+	default:
+		return PanicPolicyPropagate, ierrors.Wrapf(ErrUnknownPanicPolicy, "policy %q", policy)
+	}
+}
+
+// WithConsumerPanicPolicy sets how panics in the consumers of the streams are handled.
+// Recovered panics are logged and trigger the ConsumerPanicked event.
+func WithConsumerPanicPolicy(policy PanicPolicy) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.consumerPanicPolicy = policy
+	}
+}
+
+// recoverConsumer wraps the given consumer with the configured panic policy.
+func recoverConsumer[K any](n *nodeBridge, streamName string, consumerFunc func(K) error) func(K) error {
+	if n.consumerPanicPolicy == PanicPolicyPropagate {
+		return consumerFunc
+	}
+
+	return func(item K) (err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			n.LogErrorf("Consumer of stream %s panicked: %v\n%s", streamName, recovered, debug.Stack())
+			n.events.ConsumerPanicked.Trigger(streamName, recovered)
+
+			if n.consumerPanicPolicy == PanicPolicyTerminateStream {
+				err = ierrors.Wrapf(ErrConsumerPanicked, "stream %s: %v", streamName, recovered)
+			}
+		}()
+
+		return consumerFunc(item)
+	}
+}
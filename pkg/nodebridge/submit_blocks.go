@@ -0,0 +1,147 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultSubmitBlocksParallelism is the default maximum amount of concurrent submissions of SubmitBlocks.
+	DefaultSubmitBlocksParallelism = 8
+)
+
+var (
+	// ErrBlockChainingFailed is returned for a block of a chained batch if the parents of the block could not be chained.
+	ErrBlockChainingFailed = ierrors.New("failed to chain block to the previous block of the batch")
+)
+
+// SignBlockFunc signs the given block after its parents were changed.
+type SignBlockFunc func(block *iotago.Block) error
+
+// SubmitBlockResult is the result of the submission of a single block of a batch.
+type SubmitBlockResult struct {
+	// BlockID is the ID of the submitted block, if it was submitted successfully.
+	BlockID iotago.BlockID
+	// Err is the error of the submission, or nil if it was successful.
+	Err error
+}
+
+// SubmitBlocksOptions define how SubmitBlocks submits a batch of blocks.
+type SubmitBlocksOptions struct {
+	parallelism int
+	signBlock   SignBlockFunc
+}
+
+// WithSubmitBlocksParallelism sets the maximum amount of concurrent submissions.
+func WithSubmitBlocksParallelism(parallelism int) options.Option[SubmitBlocksOptions] {
+	return func(o *SubmitBlocksOptions) {
+		o.parallelism = parallelism
+	}
+}
+
+// WithSubmitBlocksChaining adds the ID of the previous block of the batch to the strong parents of every block,
+// so the blocks of the batch reference each other. The blocks are re-signed with the given function after their parents were changed
+// and are submitted one after the other, because the node has to know the parent before the block is submitted.
+// If a block of the chain fails, the following blocks are not submitted.
+func WithSubmitBlocksChaining(signBlock SignBlockFunc) options.Option[SubmitBlocksOptions] {
+	return func(o *SubmitBlocksOptions) {
+		o.signBlock = signBlock
+	}
+}
+
+// SubmitBlocks submits the given blocks concurrently and returns the result of every block in the order of the given blocks.
+func (n *nodeBridge) SubmitBlocks(ctx context.Context, blocks []*iotago.Block, opts ...options.Option[SubmitBlocksOptions]) []*SubmitBlockResult {
+	submitOptions := options.Apply(&SubmitBlocksOptions{
+		parallelism: DefaultSubmitBlocksParallelism,
+	}, opts)
+
+	results := make([]*SubmitBlockResult, len(blocks))
+
+	if submitOptions.signBlock != nil {
+		n.submitChainedBlocks(ctx, blocks, submitOptions.signBlock, results)
+
+		return results
+	}
+
+	semaphore := make(chan struct{}, max(1, submitOptions.parallelism))
+
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		select {
+		case <-ctx.Done():
+			results[i] = &SubmitBlockResult{Err: ctx.Err()}
+
+			continue
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, block *iotago.Block) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			blockID, err := n.SubmitBlock(ctx, block)
+			results[i] = &SubmitBlockResult{BlockID: blockID, Err: err}
+		}(i, block)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// submitChainedBlocks chains the given blocks and submits them one after the other.
+func (n *nodeBridge) submitChainedBlocks(ctx context.Context, blocks []*iotago.Block, signBlock SignBlockFunc, results []*SubmitBlockResult) {
+	var previousBlockID iotago.BlockID
+	for i, block := range blocks {
+		if i > 0 {
+			if results[i-1].Err != nil {
+				results[i] = &SubmitBlockResult{Err: ierrors.Wrap(ErrBlockChainingFailed, "previous block of the batch was not submitted")}
+
+				continue
+			}
+
+			if err := chainBlock(block, previousBlockID, signBlock); err != nil {
+				results[i] = &SubmitBlockResult{Err: err}
+
+				continue
+			}
+		}
+
+		blockID, err := n.SubmitBlock(ctx, block)
+		results[i] = &SubmitBlockResult{BlockID: blockID, Err: err}
+		previousBlockID = blockID
+	}
+}
+
+// chainBlock adds the given parent to the strong parents of the block and re-signs it.
+// If the block already has the maximum amount of strong parents, the last one is replaced.
+func chainBlock(block *iotago.Block, parentID iotago.BlockID, signBlock SignBlockFunc) error {
+	chainParents := func(strongParents iotago.BlockIDs, maxParents int) iotago.BlockIDs {
+		if len(strongParents) >= maxParents {
+			strongParents = strongParents[:maxParents-1]
+		}
+
+		return append(strongParents, parentID).RemoveDupsAndSort()
+	}
+
+	switch body := block.Body.(type) {
+	case *iotago.BasicBlockBody:
+		body.StrongParents = chainParents(body.StrongParents, iotago.BasicBlockMaxParents)
+	case *iotago.ValidationBlockBody:
+		body.StrongParents = chainParents(body.StrongParents, iotago.ValidationBlockMaxParents)
+	default:
+		return ierrors.Wrapf(ErrBlockChainingFailed, "unsupported block body type %T", block.Body)
+	}
+
+	if err := signBlock(block); err != nil {
+		return ierrors.Join(ErrBlockChainingFailed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package nodebridge
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Subscription delivers the values of an event on a channel, for applications that prefer channels over event hooks.
+// Values are delivered without blocking the event, if the buffer of the channel is full, the value is dropped.
+type Subscription[T any] struct {
+	values chan T
+	unhook func()
+
+	closedMutex sync.Mutex
+	closed      bool
+
+	dropped atomic.Uint64
+}
+
+// newSubscription creates a new Subscription, hook is called with the function that delivers the values
+// and returns the function that unhooks it.
+func newSubscription[T any](bufferSize int, hook func(deliver func(value T)) (unhook func())) *Subscription[T] {
+	s := &Subscription[T]{
+		values: make(chan T, bufferSize),
+	}
+	s.unhook = hook(s.deliver)
+
+	return s
+}
+
+// Values returns the channel on which the values are delivered.
+// The channel is closed after Unsubscribe was called.
+func (s *Subscription[T]) Values() <-chan T {
+	return s.values
+}
+
+// Dropped returns the amount of values that were dropped because the buffer was full.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe unhooks the subscription from the event and closes the channel.
+func (s *Subscription[T]) Unsubscribe() {
+	s.unhook()
+
+	s.closedMutex.Lock()
+	defer s.closedMutex.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.values)
+}
+
+func (s *Subscription[T]) deliver(value T) {
+	s.closedMutex.Lock()
+	defer s.closedMutex.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.values <- value:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// SubscribeEvent1 subscribes to the given event with a channel of the given buffer size.
+func SubscribeEvent1[T any](e *event.Event1[T], bufferSize int) *Subscription[T] {
+	return newSubscription(bufferSize, func(deliver func(value T)) func() {
+		return e.Hook(deliver).Unhook
+	})
+}
+
+// ProtocolParametersChange contains the old and the new committed API of a ProtocolParametersActivated event.
+type ProtocolParametersChange struct {
+	OldAPI iotago.API
+	NewAPI iotago.API
+}
+
+// ConsumerPanic contains the name of the stream and the recovered value of a ConsumerPanicked event.
+type ConsumerPanic struct {
+	Stream    string
+	Recovered any
+}
+
+// SubscribeLatestCommitment subscribes to LatestCommitmentChanged.
+func (e *Events) SubscribeLatestCommitment(bufferSize int) *Subscription[*Commitment] {
+	return SubscribeEvent1(e.LatestCommitmentChanged, bufferSize)
+}
+
+// SubscribeLatestFinalizedCommitment subscribes to LatestFinalizedCommitmentChanged.
+func (e *Events) SubscribeLatestFinalizedCommitment(bufferSize int) *Subscription[*Commitment] {
+	return SubscribeEvent1(e.LatestFinalizedCommitmentChanged, bufferSize)
+}
+
+// SubscribeProtocolParametersActivated subscribes to ProtocolParametersActivated.
+func (e *Events) SubscribeProtocolParametersActivated(bufferSize int) *Subscription[*ProtocolParametersChange] {
+	return newSubscription(bufferSize, func(deliver func(value *ProtocolParametersChange)) func() {
+		return e.ProtocolParametersActivated.Hook(func(oldAPI iotago.API, newAPI iotago.API) {
+			deliver(&ProtocolParametersChange{OldAPI: oldAPI, NewAPI: newAPI})
+		}).Unhook
+	})
+}
+
+// SubscribeConnectionState subscribes to ConnectionStateChanged.
+func (e *Events) SubscribeConnectionState(bufferSize int) *Subscription[connectivity.State] {
+	return SubscribeEvent1(e.ConnectionStateChanged, bufferSize)
+}
+
+// SubscribeNodeHealth subscribes to NodeHealthChanged.
+func (e *Events) SubscribeNodeHealth(bufferSize int) *Subscription[bool] {
+	return SubscribeEvent1(e.NodeHealthChanged, bufferSize)
+}
+
+// SubscribeSyncStatus subscribes to SyncStatusChanged.
+func (e *Events) SubscribeSyncStatus(bufferSize int) *Subscription[bool] {
+	return SubscribeEvent1(e.SyncStatusChanged, bufferSize)
+}
+
+// SubscribePruningEpoch subscribes to PruningEpochChanged.
+func (e *Events) SubscribePruningEpoch(bufferSize int) *Subscription[iotago.EpochIndex] {
+	return SubscribeEvent1(e.PruningEpochChanged, bufferSize)
+}
+
+// SubscribePluginAvailable subscribes to PluginAvailable.
+func (e *Events) SubscribePluginAvailable(bufferSize int) *Subscription[string] {
+	return SubscribeEvent1(e.PluginAvailable, bufferSize)
+}
+
+// SubscribeStreamDrained subscribes to StreamDrained.
+func (e *Events) SubscribeStreamDrained(bufferSize int) *Subscription[string] {
+	return SubscribeEvent1(e.StreamDrained, bufferSize)
+}
+
+// SubscribeConsumerPanicked subscribes to ConsumerPanicked.
+func (e *Events) SubscribeConsumerPanicked(bufferSize int) *Subscription[*ConsumerPanic] {
+	return newSubscription(bufferSize, func(deliver func(value *ConsumerPanic)) func() {
+		return e.ConsumerPanicked.Hook(func(stream string, recovered any) {
+			deliver(&ConsumerPanic{Stream: stream, Recovered: recovered})
+		}).Unhook
+	})
+}
@@ -0,0 +1,51 @@
+package nodebridge
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// TaggedDataBlock is the tagged data of a block together with the metadata of the block.
+type TaggedDataBlock struct {
+	// BlockID is the ID of the block.
+	BlockID iotago.BlockID
+	// IssuerID is the ID of the account that issued the block.
+	IssuerID iotago.AccountID
+	// IssuingTime is the time the block was issued.
+	IssuingTime time.Time
+	// Block is the block that contains the tagged data.
+	Block *iotago.Block
+	// Tag is the tag of the tagged data.
+	Tag []byte
+	// Data is the data of the tagged data.
+	Data []byte
+}
+
+// SubscribeTaggedData listens to blocks and passes the tagged data with the given tag to the consumer,
+// either from the payload of the block or from the payload of its signed transaction.
+func (n *nodeBridge) SubscribeTaggedData(ctx context.Context, tag []byte, consumer func(taggedData *TaggedDataBlock) error) error {
+	return n.ListenToFilteredBlocks(ctx, func(block *iotago.Block) bool {
+		taggedData := BlockTaggedData(block)
+
+		return taggedData != nil && bytes.Equal(taggedData.Tag, tag)
+	}, func(block *iotago.Block, _ []byte) error {
+		blockID, err := block.ID()
+		if err != nil {
+			return err
+		}
+
+		taggedData := BlockTaggedData(block)
+
+		return consumer(&TaggedDataBlock{
+			BlockID:     blockID,
+			IssuerID:    block.Header.IssuerID,
+			IssuingTime: block.Header.IssuingTime,
+			Block:       block,
+			Tag:         taggedData.Tag,
+			Data:        taggedData.Data,
+		})
+	})
+}
@@ -0,0 +1,154 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultTipPoolMonitorInterval is the default interval in which the tip pool is sampled.
+	DefaultTipPoolMonitorInterval = 1 * time.Second
+	// DefaultTipPoolMonitorMaxParentCount is the default amount of parents that is requested per parent type.
+	DefaultTipPoolMonitorMaxParentCount = iotago.BasicBlockMaxParents
+)
+
+// TipPoolSample is a single sample of the tips returned by the node.
+type TipPoolSample struct {
+	// Time is the time the sample was taken.
+	Time time.Time
+	// StrongParentsCount is the amount of strong parents returned by the node.
+	StrongParentsCount int
+	// WeakParentsCount is the amount of weak parents returned by the node.
+	WeakParentsCount int
+	// ShallowLikeParentsCount is the amount of shallow like parents returned by the node.
+	ShallowLikeParentsCount int
+	// LatestParentBlockIssuingTime is the latest issuing time of the returned parents.
+	LatestParentBlockIssuingTime time.Time
+	// Staleness is the duration between the sample time and the latest issuing time of the returned parents.
+	Staleness time.Duration
+}
+
+// TipPoolMonitorEvents are the events triggered by the TipPoolMonitor.
+type TipPoolMonitorEvents struct {
+	// Sampled is triggered for every successful sample of the tip pool.
+	Sampled *event.Event1[*TipPoolSample]
+	// SampleFailed is triggered if the tips could not be requested from the node.
+	SampleFailed *event.Event1[error]
+}
+
+// TipPoolMonitor periodically requests tips from the node and reports tip counts and their staleness.
+type TipPoolMonitor struct {
+	nodeBridge NodeBridge
+	clock      clock.Clock
+	events     *TipPoolMonitorEvents
+
+	interval       time.Duration
+	maxParentCount uint32
+
+	latestSampleMutex sync.RWMutex
+	latestSample      *TipPoolSample
+}
+
+// WithTipPoolMonitorInterval sets the interval in which the tip pool is sampled.
+func WithTipPoolMonitorInterval(interval time.Duration) options.Option[TipPoolMonitor] {
+	return func(m *TipPoolMonitor) {
+		m.interval = interval
+	}
+}
+
+// WithTipPoolMonitorMaxParentCount sets the amount of parents that is requested per parent type.
+func WithTipPoolMonitorMaxParentCount(maxParentCount uint32) options.Option[TipPoolMonitor] {
+	return func(m *TipPoolMonitor) {
+		m.maxParentCount = maxParentCount
+	}
+}
+
+// WithTipPoolMonitorClock sets the clock that is used for the sample interval and the staleness, e.g. a clock.Mock in tests.
+func WithTipPoolMonitorClock(timeSource clock.Clock) options.Option[TipPoolMonitor] {
+	return func(m *TipPoolMonitor) {
+		m.clock = timeSource
+	}
+}
+
+// NewTipPoolMonitor creates a new TipPoolMonitor.
+func NewTipPoolMonitor(nodeBridge NodeBridge, opts ...options.Option[TipPoolMonitor]) *TipPoolMonitor {
+	return options.Apply(&TipPoolMonitor{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &TipPoolMonitorEvents{
+			Sampled:      event.New1[*TipPoolSample](),
+			SampleFailed: event.New1[error](),
+		},
+		interval:       DefaultTipPoolMonitorInterval,
+		maxParentCount: DefaultTipPoolMonitorMaxParentCount,
+	}, opts)
+}
+
+// Events returns the events of the TipPoolMonitor.
+func (m *TipPoolMonitor) Events() *TipPoolMonitorEvents {
+	return m.events
+}
+
+// LatestSample returns the latest successful sample of the tip pool, or nil if there is none yet.
+func (m *TipPoolMonitor) LatestSample() *TipPoolSample {
+	m.latestSampleMutex.RLock()
+	defer m.latestSampleMutex.RUnlock()
+
+	return m.latestSample
+}
+
+// Run samples the tip pool in the configured interval and blocks until the given context is done.
+func (m *TipPoolMonitor) Run(ctx context.Context) {
+	ticker := m.clock.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.sample(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+func (m *TipPoolMonitor) sample(ctx context.Context) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, m.interval)
+	defer cancel()
+
+	// the tips are only sampled and never used for a block, so the higher limits of validation blocks are allowed
+	parents := UniformBlockIssuanceParents(m.maxParentCount)
+	parents.ForValidationBlock = true
+
+	blockIssuance, err := m.nodeBridge.BlockIssuance(ctxTimeout, parents)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.events.SampleFailed.Trigger(err)
+		}
+
+		return
+	}
+
+	now := m.clock.Now()
+	sample := &TipPoolSample{
+		Time:                         now,
+		StrongParentsCount:           len(blockIssuance.StrongParents),
+		WeakParentsCount:             len(blockIssuance.WeakParents),
+		ShallowLikeParentsCount:      len(blockIssuance.ShallowLikeParents),
+		LatestParentBlockIssuingTime: blockIssuance.LatestParentBlockIssuingTime,
+		Staleness:                    now.Sub(blockIssuance.LatestParentBlockIssuingTime),
+	}
+
+	m.latestSampleMutex.Lock()
+	m.latestSample = sample
+	m.latestSampleMutex.Unlock()
+
+	m.events.Sampled.Trigger(sample)
+}
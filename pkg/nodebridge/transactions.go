@@ -10,9 +10,13 @@ import (
 
 // TransactionMetadata returns the transaction metadata for the given transaction ID.
 func (n *nodeBridge) TransactionMetadata(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error) {
+	if err := n.checkSlotPruned(transactionID.Slot()); err != nil {
+		return nil, err
+	}
+
 	inxTransactionMetadata, err := n.client.ReadTransactionMetadata(ctx, inx.NewTransactionId(transactionID))
 	if err != nil {
-		return nil, err
+		return nil, n.wrapSlotPrunedError(transactionID.Slot(), n.wrapINXError(err, "failed to read transaction metadata %s", transactionID.ToHex()))
 	}
 
 	return inxTransactionMetadata.Unwrap(), nil
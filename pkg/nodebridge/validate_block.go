@@ -0,0 +1,145 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+var (
+	// ErrBlockInvalid is returned if a block would be rejected by the node.
+	// It is wrapped together with the reason, which is an error of iota.go (e.g. iotago.ErrCommitmentTooOld) if available.
+	ErrBlockInvalid = ierrors.New("block is invalid")
+)
+
+// ValidateBlock checks whether the given block would be accepted by the node, without submitting it.
+// The block is checked locally (size, protocol version, network ID, commitment age, signature and mana cost)
+// and against the node (known slot commitment, valid parents and the validity of the payload).
+// It returns ErrBlockInvalid wrapped with the reason if the block would be rejected.
+// A valid block can still be rejected later, e.g. because of congestion or because its parents got orphaned.
+func (n *nodeBridge) ValidateBlock(ctx context.Context, block *iotago.Block) error {
+	if err := n.validateBlockSyntax(block); err != nil {
+		return err
+	}
+
+	commitment, err := n.CommitmentByID(ctx, block.Header.SlotCommitmentID)
+	if err != nil {
+		if ierrors.Is(err, ErrNotFound) {
+			return ierrors.Wrapf(ErrBlockInvalid, "slot commitment %s is unknown to the node", block.Header.SlotCommitmentID)
+		}
+
+		return err
+	}
+
+	if err := validateBlockManaCost(block, commitment.Commitment.ReferenceManaCost); err != nil {
+		return err
+	}
+
+	if err := n.validateBlockParents(ctx, block); err != nil {
+		return err
+	}
+
+	if basicBlockBody, isBasicBlock := block.Body.(*iotago.BasicBlockBody); isBasicBlock && basicBlockBody.Payload != nil {
+		if err := n.ValidatePayload(ctx, basicBlockBody.Payload); err != nil {
+			if ierrors.Is(err, ErrPayloadInvalid) {
+				return ierrors.Join(ErrBlockInvalid, err)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateBlockSyntax runs the syntactic validation of iota.go, which is also run by the node when it parses the block,
+// and verifies the signature of the block.
+// It returns a BlockValidationError that names the invalid field of the block.
+func (n *nodeBridge) validateBlockSyntax(block *iotago.Block) error {
+	if block.API == nil {
+		return newBlockValidationError(BlockFieldBlock, ierrors.New("block has no API"))
+	}
+
+	if _, err := n.apiProvider.APIForVersion(block.Header.ProtocolVersion); err != nil {
+		return newBlockValidationError(BlockFieldProtocolVersion, ierrors.Wrapf(err, "protocol version %d is not supported by the node", block.Header.ProtocolVersion))
+	}
+
+	if err := validateBlockParentIDs(block); err != nil {
+		return err
+	}
+
+	// the payload is validated on its own first, so errors in the payload are not reported for the whole block
+	if basicBlockBody, isBasicBlock := block.Body.(*iotago.BasicBlockBody); isBasicBlock && basicBlockBody.Payload != nil {
+		if _, err := block.API.Encode(basicBlockBody.Payload, serix.WithValidation()); err != nil {
+			return newBlockValidationError(BlockFieldPayload, err)
+		}
+	}
+
+	if _, err := block.API.Encode(block, serix.WithValidation()); err != nil {
+		return newBlockValidationError(blockFieldForError(err), err)
+	}
+
+	valid, err := block.VerifySignature()
+	if err != nil {
+		return newBlockValidationError(BlockFieldSignature, ierrors.Wrap(err, "failed to verify signature"))
+	}
+	if !valid {
+		return newBlockValidationError(BlockFieldSignature, ierrors.New("invalid signature"))
+	}
+
+	return nil
+}
+
+// validateBlockManaCost checks that a basic block burns enough mana for its work score
+// at the reference mana cost of its slot commitment.
+func validateBlockManaCost(block *iotago.Block, referenceManaCost iotago.Mana) error {
+	basicBlockBody, isBasicBlock := block.Body.(*iotago.BasicBlockBody)
+	if !isBasicBlock {
+		return nil
+	}
+
+	manaCost, err := block.ManaCost(referenceManaCost)
+	if err != nil {
+		return ierrors.Join(ErrBlockInvalid, ierrors.Wrap(err, "failed to calculate mana cost"))
+	}
+
+	if basicBlockBody.MaxBurnedMana < manaCost {
+		return ierrors.Wrapf(ErrBlockInvalid, "max burned mana %d is lower than the mana cost %d of the block (reference mana cost: %d)", basicBlockBody.MaxBurnedMana, manaCost, referenceManaCost)
+	}
+
+	return nil
+}
+
+// validateBlockParents checks that all parents are known to the node, are not from a later slot than the block
+// and were neither orphaned nor dropped.
+func (n *nodeBridge) validateBlockParents(ctx context.Context, block *iotago.Block) error {
+	for _, parent := range block.ParentsWithType() {
+		if parent.ID.Slot() > block.Slot() {
+			return ierrors.Wrapf(ErrBlockInvalid, "parent %s is from a later slot than the block", parent.ID)
+		}
+
+		blockMetadata, err := n.BlockMetadata(ctx, parent.ID)
+		if err != nil {
+			if ierrors.Is(err, ErrNotFound) {
+				return ierrors.Wrapf(ErrBlockInvalid, "parent %s is unknown to the node", parent.ID)
+			}
+
+			// parents from pruned slots are root blocks, which are checked by the node
+			if ierrors.Is(err, ErrSlotPruned) {
+				continue
+			}
+
+			return err
+		}
+
+		switch blockMetadata.BlockState {
+		case api.BlockStateOrphaned, api.BlockStateDropped:
+			return ierrors.Wrapf(ErrBlockInvalid, "parent %s is %s", parent.ID, blockMetadata.BlockState)
+		}
+	}
+
+	return nil
+}
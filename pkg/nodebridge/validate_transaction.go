@@ -0,0 +1,222 @@
+package nodebridge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/vm"
+	"github.com/iotaledger/iota.go/v4/vm/nova"
+)
+
+var (
+	// ErrTransactionInvalid is returned if a transaction would be rejected by the node.
+	ErrTransactionInvalid = ierrors.New("transaction is invalid")
+)
+
+// The rules of the transaction validation that are reported by a TransactionValidationError.
+const (
+	TransactionRuleSyntax                   = "syntax"
+	TransactionRuleInputs                   = "inputs"
+	TransactionRuleCommitmentInput          = "commitment input"
+	TransactionRuleBlockIssuanceCredits     = "block issuance credit inputs"
+	TransactionRuleRewards                  = "reward inputs"
+	TransactionRuleUnlocks                  = "unlocks"
+	TransactionRuleTimelocks                = "timelocks"
+	TransactionRuleSenderUnlocked           = "sender unlocked"
+	TransactionRuleBaseTokenBalance         = "base token balance"
+	TransactionRuleNativeTokenBalance       = "native token balance"
+	TransactionRuleChainTransitions         = "chain transitions"
+	TransactionRuleManaBalance              = "mana balance"
+	TransactionRuleImplicitAccountCreations = "implicit account creations"
+)
+
+// TransactionValidationError is returned if a transaction failed the validation.
+// It names the rule that failed and matches ErrTransactionInvalid and the reason of the failure,
+// which is an error of iota.go (e.g. iotago.ErrInputOutputBaseTokenMismatch) if available.
+type TransactionValidationError struct {
+	// Rule is the rule of the validation that failed, e.g. TransactionRuleManaBalance.
+	Rule string
+	// Err is the reason of the failure.
+	Err error
+}
+
+func newTransactionValidationError(rule string, err error) *TransactionValidationError {
+	return &TransactionValidationError{
+		Rule: rule,
+		Err:  err,
+	}
+}
+
+func (e *TransactionValidationError) Error() string {
+	return fmt.Sprintf("%s: rule %s failed: %s", ErrTransactionInvalid, e.Rule, e.Err)
+}
+
+func (e *TransactionValidationError) Unwrap() []error {
+	return []error{ErrTransactionInvalid, e.Err}
+}
+
+// transactionExecRules are the execution functions of the Nova virtual machine in the order they are run by the node.
+var transactionExecRules = []struct {
+	rule     string
+	execFunc vm.ExecFunc
+}{
+	{TransactionRuleTimelocks, vm.ExecFuncTimelocks()},
+	{TransactionRuleSenderUnlocked, vm.ExecFuncSenderUnlocked()},
+	{TransactionRuleBaseTokenBalance, vm.ExecFuncBalancedBaseTokens()},
+	{TransactionRuleNativeTokenBalance, vm.ExecFuncBalancedNativeTokens()},
+	{TransactionRuleChainTransitions, vm.ExecFuncChainTransitions()},
+	{TransactionRuleManaBalance, vm.ExecFuncBalancedMana()},
+	{TransactionRuleImplicitAccountCreations, vm.ExecFuncAtMostOneImplicitAccountCreationAddress()},
+}
+
+// ValidateTransaction runs the semantic validation of the node for the given signed transaction before it is issued.
+// The inputs, the commitment input, the block issuance credits and the rewards are resolved from the node,
+// then the unlocks, timelocks, balances of base tokens, native tokens and mana and the chain transitions are checked locally.
+// It returns a TransactionValidationError that names the failed rule if the transaction would be rejected.
+func (n *nodeBridge) ValidateTransaction(ctx context.Context, signedTransaction *iotago.SignedTransaction) error {
+	if signedTransaction.API == nil {
+		return newTransactionValidationError(TransactionRuleSyntax, ierrors.New("transaction has no API"))
+	}
+
+	if _, err := signedTransaction.API.Encode(signedTransaction, serix.WithValidation()); err != nil {
+		return newTransactionValidationError(TransactionRuleSyntax, err)
+	}
+
+	resolvedInputs, err := n.resolveTransactionInputs(ctx, signedTransaction.Transaction)
+	if err != nil {
+		return err
+	}
+
+	virtualMachine := nova.NewVirtualMachine()
+
+	unlockedAddresses, err := virtualMachine.ValidateUnlocks(signedTransaction, resolvedInputs)
+	if err != nil {
+		return newTransactionValidationError(TransactionRuleUnlocks, err)
+	}
+
+	workingSet, err := nova.NewVMParamsWorkingSet(signedTransaction.API, signedTransaction.Transaction, resolvedInputs)
+	if err != nil {
+		return newTransactionValidationError(TransactionRuleInputs, err)
+	}
+	workingSet.UnlockedAddrs = unlockedAddresses
+
+	vmParams := &vm.Params{
+		API:        signedTransaction.API,
+		WorkingSet: workingSet,
+	}
+
+	for _, execRule := range transactionExecRules {
+		if err := execRule.execFunc(virtualMachine, vmParams); err != nil {
+			return newTransactionValidationError(execRule.rule, err)
+		}
+	}
+
+	return nil
+}
+
+// resolveTransactionInputs resolves the inputs and the context inputs of the given transaction from the node.
+func (n *nodeBridge) resolveTransactionInputs(ctx context.Context, transaction *iotago.Transaction) (vm.ResolvedInputs, error) {
+	resolvedInputs := vm.ResolvedInputs{
+		InputSet:                    make(vm.InputSet),
+		BlockIssuanceCreditInputSet: make(vm.BlockIssuanceCreditInputSet),
+		RewardsInputSet:             make(vm.RewardsInputSet),
+	}
+
+	inputIDs := make([]iotago.OutputID, 0, len(transaction.Inputs()))
+	for _, input := range transaction.Inputs() {
+		outputID := input.OutputID()
+
+		output, err := n.Output(ctx, outputID)
+		if err != nil {
+			if ierrors.Is(err, ErrNotFound) {
+				return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleInputs, ierrors.Wrapf(err, "input %s is unknown to the node", outputID))
+			}
+
+			return vm.ResolvedInputs{}, err
+		}
+
+		if output.Metadata != nil && output.Metadata.Spent != nil {
+			return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleInputs, ierrors.Wrapf(iotago.ErrInputAlreadySpent, "input %s was spent by transaction %s", outputID, output.Metadata.Spent.TransactionID))
+		}
+
+		resolvedInputs.InputSet[outputID] = output.Output
+		inputIDs = append(inputIDs, outputID)
+	}
+
+	var commitmentID iotago.CommitmentID
+	if commitmentInput := transaction.CommitmentInput(); commitmentInput != nil {
+		commitment, err := n.CommitmentByID(ctx, commitmentInput.CommitmentID)
+		if err != nil {
+			if ierrors.Is(err, ErrNotFound) {
+				return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleCommitmentInput, ierrors.Wrapf(err, "commitment %s is unknown to the node", commitmentInput.CommitmentID))
+			}
+
+			return vm.ResolvedInputs{}, err
+		}
+
+		commitmentID = commitment.CommitmentID
+		resolvedInputs.CommitmentInput = commitment.Commitment
+	}
+
+	bicInputs := transaction.BICInputs()
+	rewardInputs := transaction.RewardInputs()
+	if (len(bicInputs) > 0 || len(rewardInputs) > 0) && resolvedInputs.CommitmentInput == nil {
+		return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleCommitmentInput, iotago.ErrCommitmentInputMissing)
+	}
+
+	if len(bicInputs) > 0 {
+		nodeClient, err := n.INXNodeClient()
+		if err != nil {
+			return vm.ResolvedInputs{}, err
+		}
+
+		for _, bicInput := range bicInputs {
+			accountAddress, isAccountAddress := bicInput.AccountID.ToAddress().(*iotago.AccountAddress)
+			if !isAccountAddress {
+				return vm.ResolvedInputs{}, ierrors.Errorf("unexpected address type for account %s", bicInput.AccountID)
+			}
+
+			congestion, err := nodeClient.Congestion(ctx, accountAddress, 0, commitmentID)
+			if err != nil {
+				return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleBlockIssuanceCredits, ierrors.Wrapf(err, "failed to read block issuance credits of account %s", bicInput.AccountID))
+			}
+
+			resolvedInputs.BlockIssuanceCreditInputSet[bicInput.AccountID] = congestion.BlockIssuanceCredits
+		}
+	}
+
+	for _, rewardInput := range rewardInputs {
+		if int(rewardInput.Index) >= len(inputIDs) {
+			return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleRewards, ierrors.Wrapf(iotago.ErrRewardInputReferenceInvalid, "reward input references input %d, but the transaction has %d inputs", rewardInput.Index, len(inputIDs)))
+		}
+		outputID := inputIDs[rewardInput.Index]
+
+		var chainID iotago.ChainID
+		switch output := resolvedInputs.InputSet[outputID].(type) {
+		case *iotago.AccountOutput:
+			chainID = output.AccountID
+			if output.AccountID.Empty() {
+				chainID = iotago.AccountIDFromOutputID(outputID)
+			}
+		case *iotago.DelegationOutput:
+			chainID = output.DelegationID
+			if output.DelegationID.Empty() {
+				chainID = iotago.DelegationIDFromOutputID(outputID)
+			}
+		default:
+			return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleRewards, ierrors.Wrapf(iotago.ErrRewardInputReferenceInvalid, "reward input references input %s, which is neither an account nor a delegation", outputID))
+		}
+
+		rewards, err := n.ReadRewards(ctx, outputID, commitmentID.Slot())
+		if err != nil {
+			return vm.ResolvedInputs{}, newTransactionValidationError(TransactionRuleRewards, ierrors.Wrapf(err, "failed to read rewards of input %s", outputID))
+		}
+
+		resolvedInputs.RewardsInputSet[chainID] = rewards.Rewards
+	}
+
+	return resolvedInputs, nil
+}
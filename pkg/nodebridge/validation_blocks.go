@@ -0,0 +1,56 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// ValidationBlock is a validation block of a validator together with the metadata of the block.
+type ValidationBlock struct {
+	// BlockID is the ID of the block.
+	BlockID iotago.BlockID
+	// IssuerID is the ID of the validator account that issued the block.
+	IssuerID iotago.AccountID
+	// IssuingTime is the time the block was issued.
+	IssuingTime time.Time
+	// SlotCommitmentID is the commitment the validator voted for.
+	SlotCommitmentID iotago.CommitmentID
+	// LatestFinalizedSlot is the latest finalized slot known to the validator.
+	LatestFinalizedSlot iotago.SlotIndex
+	// HighestSupportedVersion is the highest protocol version supported by the validator.
+	HighestSupportedVersion iotago.Version
+	// ProtocolParametersHash is the hash of the protocol parameters of the highest supported version.
+	ProtocolParametersHash iotago.Identifier
+	// Block is the validation block.
+	Block *iotago.Block
+	// Body is the body of the validation block.
+	Body *iotago.ValidationBlockBody
+}
+
+// ListenToValidationBlocks listens to blocks and passes the validation blocks to the consumer.
+// INX has no dedicated stream for validation blocks, so all blocks are received and filtered by the bridge.
+func (n *nodeBridge) ListenToValidationBlocks(ctx context.Context, consumer func(validationBlock *ValidationBlock) error) error {
+	return n.ListenToFilteredBlocks(ctx, BlockFilterValidationBlocks(), func(block *iotago.Block, _ []byte) error {
+		blockID, err := block.ID()
+		if err != nil {
+			return err
+		}
+
+		//nolint:forcetypeassert // the filter only passes validation blocks
+		body := block.Body.(*iotago.ValidationBlockBody)
+
+		return consumer(&ValidationBlock{
+			BlockID:                 blockID,
+			IssuerID:                block.Header.IssuerID,
+			IssuingTime:             block.Header.IssuingTime,
+			SlotCommitmentID:        block.Header.SlotCommitmentID,
+			LatestFinalizedSlot:     block.Header.LatestFinalizedSlot,
+			HighestSupportedVersion: body.HighestSupportedVersion,
+			ProtocolParametersHash:  body.ProtocolParametersHash,
+			Block:                   block,
+			Body:                    body,
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package nodebridge
+
+import (
+	"context"
+
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// isNodeStatusSynced returns true if the node is bootstrapped and healthy according to the given node status.
+// It defines the sync status of IsNodeSynced and the SyncStatusChanged event.
+func isNodeStatusSynced(nodeStatus *inx.NodeStatus) bool {
+	return nodeStatus.GetIsBootstrapped() && nodeStatus.GetIsHealthy()
+}
+
+// IsNodeSynced returns true if the node is bootstrapped and healthy.
+func (n *nodeBridge) IsNodeSynced() bool {
+	return isNodeStatusSynced(n.NodeStatus())
+}
+
+// WaitUntilNodeSynced blocks until the node is bootstrapped and healthy, or the context is done.
+func (n *nodeBridge) WaitUntilNodeSynced(ctx context.Context) error {
+	return n.waitForCondition(ctx, n.IsNodeSynced, func(signal func()) func() {
+		return n.events.SyncStatusChanged.Hook(func(_ bool) { signal() }).Unhook
+	})
+}
+
+// WaitForSlotCommitted blocks until the given slot was committed by the node, or the context is done.
+func (n *nodeBridge) WaitForSlotCommitted(ctx context.Context, slot iotago.SlotIndex) error {
+	return n.waitForCondition(ctx, func() bool {
+		latestCommitment := n.LatestCommitment()
+
+		return latestCommitment != nil && latestCommitment.CommitmentID.Slot() >= slot
+	}, func(signal func()) func() {
+		return n.events.LatestCommitmentChanged.Hook(func(_ *Commitment) { signal() }).Unhook
+	})
+}
+
+// WaitForSlotFinalized blocks until the given slot was finalized by the node, or the context is done.
+func (n *nodeBridge) WaitForSlotFinalized(ctx context.Context, slot iotago.SlotIndex) error {
+	return n.waitForCondition(ctx, func() bool {
+		latestFinalizedCommitment := n.LatestFinalizedCommitment()
+
+		return latestFinalizedCommitment != nil && latestFinalizedCommitment.CommitmentID.Slot() >= slot
+	}, func(signal func()) func() {
+		return n.events.LatestFinalizedCommitmentChanged.Hook(func(_ *Commitment) { signal() }).Unhook
+	})
+}
+
+// waitForCondition blocks until the condition is met or the context is done.
+// The condition is checked initially and every time the signal function passed to hookEvents is called.
+// hookEvents returns a function that unhooks the events.
+func (n *nodeBridge) waitForCondition(ctx context.Context, condition func() bool, hookEvents func(signal func()) (unhook func())) error {
+	if condition() {
+		return nil
+	}
+
+	signalChan := make(chan struct{}, 1)
+	unhook := hookEvents(func() {
+		select {
+		case signalChan <- struct{}{}:
+		default:
+		}
+	})
+	defer unhook()
+
+	for {
+		// check again, the condition could have been met before the events were hooked
+		if condition() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-signalChan:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// RecordKind is the kind of a record in a recording.
+type RecordKind byte
+
+const (
+	// RecordKindSlot marks that all following records belong to the slot in the payload.
+	RecordKindSlot RecordKind = iota + 1
+	// RecordKindBlock contains the raw bytes of a block.
+	RecordKindBlock
+	// RecordKindLedgerUpdateBegin marks the begin of a ledger update, the payload is the commitment ID.
+	RecordKindLedgerUpdateBegin
+	// RecordKindConsumedOutput contains a consumed output of the current ledger update.
+	RecordKindConsumedOutput
+	// RecordKindCreatedOutput contains a created output of the current ledger update.
+	RecordKindCreatedOutput
+	// RecordKindLedgerUpdateEnd marks the end of a ledger update, the payload is the commitment ID.
+	RecordKindLedgerUpdateEnd
+	// RecordKindNodeConfiguration contains the protobuf encoded configuration of the node.
+	RecordKindNodeConfiguration
+	// RecordKindNodeStatus contains the protobuf encoded status of the node.
+	RecordKindNodeStatus
+)
+
+const (
+	// recordHeaderLength is the length of the header of a record, the kind and the length of the payload.
+	recordHeaderLength = 1 + 4
+	// maxRecordPayloadLength is the maximum length of the payload of a record.
+	maxRecordPayloadLength = 64 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidRecord is returned if a recording contains an invalid record.
+	ErrInvalidRecord = ierrors.New("invalid record")
+)
+
+// Record is a single length-prefixed entry of a recording.
+type Record struct {
+	Kind    RecordKind
+	Payload []byte
+}
+
+// RecordWriter writes length-prefixed records.
+type RecordWriter struct {
+	writer *bufio.Writer
+}
+
+// NewRecordWriter creates a new RecordWriter that writes to the given writer.
+func NewRecordWriter(writer io.Writer) *RecordWriter {
+	return &RecordWriter{
+		writer: bufio.NewWriter(writer),
+	}
+}
+
+// Write writes a record.
+func (w *RecordWriter) Write(kind RecordKind, payload []byte) error {
+	if len(payload) > maxRecordPayloadLength {
+		return ierrors.Wrapf(ErrInvalidRecord, "payload too large: %d bytes", len(payload))
+	}
+
+	var header [recordHeaderLength]byte
+	header[0] = byte(kind)
+	binary.LittleEndian.PutUint32(header[1:], uint32(len(payload)))
+
+	if _, err := w.writer.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.writer.Write(payload)
+
+	return err
+}
+
+// Flush writes all buffered records to the underlying writer.
+func (w *RecordWriter) Flush() error {
+	return w.writer.Flush()
+}
+
+// RecordReader reads length-prefixed records.
+type RecordReader struct {
+	reader *bufio.Reader
+}
+
+// NewRecordReader creates a new RecordReader that reads from the given reader.
+func NewRecordReader(reader io.Reader) *RecordReader {
+	return &RecordReader{
+		reader: bufio.NewReader(reader),
+	}
+}
+
+// Read reads the next record.
+// Returns io.EOF if there are no more records.
+func (r *RecordReader) Read() (*Record, error) {
+	var header [recordHeaderLength]byte
+	if _, err := io.ReadFull(r.reader, header[:]); err != nil {
+		if ierrors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ierrors.Wrap(ErrInvalidRecord, "truncated record header")
+		}
+
+		return nil, err
+	}
+
+	length := binary.LittleEndian.Uint32(header[1:])
+	if length > maxRecordPayloadLength {
+		return nil, ierrors.Wrapf(ErrInvalidRecord, "payload too large: %d bytes", length)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.reader, payload); err != nil {
+		return nil, ierrors.Wrap(ErrInvalidRecord, "truncated record payload")
+	}
+
+	return &Record{
+		Kind:    RecordKind(header[0]),
+		Payload: payload,
+	}, nil
+}
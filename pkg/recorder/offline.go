@@ -0,0 +1,294 @@
+package recorder
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// offlineBufferSize is the size of the in-memory connection between the offline bridge and the OfflineServer.
+	offlineBufferSize = 1024 * 1024
+	// offlineAddress is the address the offline bridge connects to, it is only used for logging.
+	offlineAddress = "offline"
+)
+
+var (
+	// ErrIncompleteNodeSnapshot is returned if a node snapshot doesn't contain the configuration and the status of the node.
+	ErrIncompleteNodeSnapshot = ierrors.New("incomplete node snapshot")
+)
+
+// OfflineServer is an INX server that serves a node snapshot and recorded streams instead of a live node,
+// so the business logic of an extension can run against historical data.
+// It serves the node configuration and status, the blocks and the ledger updates.
+// All other calls return codes.Unimplemented, which the NodeBridge reports like a node that does not support them.
+type OfflineServer struct {
+	inx.UnimplementedINXServer
+
+	nodeConfig  *inx.NodeConfiguration
+	nodeStatus  *inx.NodeStatus
+	apiProvider iotago.APIProvider
+
+	blocksFile        string
+	ledgerUpdatesFile string
+}
+
+// WithOfflineBlocksFile sets the file with the recorded blocks that is replayed by ListenToBlocks.
+func WithOfflineBlocksFile(path string) options.Option[OfflineServer] {
+	return func(s *OfflineServer) {
+		s.blocksFile = path
+	}
+}
+
+// WithOfflineLedgerUpdatesFile sets the file with the recorded ledger updates that is replayed by ListenToLedgerUpdates.
+func WithOfflineLedgerUpdatesFile(path string) options.Option[OfflineServer] {
+	return func(s *OfflineServer) {
+		s.ledgerUpdatesFile = path
+	}
+}
+
+// NewOfflineServer creates a new OfflineServer from the node snapshot written by Recorder.RecordNodeSnapshot.
+func NewOfflineServer(snapshot *RecordReader, opts ...options.Option[OfflineServer]) (*OfflineServer, error) {
+	s := options.Apply(&OfflineServer{}, opts)
+
+	for s.nodeConfig == nil || s.nodeStatus == nil {
+		record, err := snapshot.Read()
+		if err != nil {
+			if ierrors.Is(err, io.EOF) {
+				return nil, ErrIncompleteNodeSnapshot
+			}
+
+			return nil, err
+		}
+
+		switch record.Kind {
+		case RecordKindNodeConfiguration:
+			nodeConfig := &inx.NodeConfiguration{}
+			if err := proto.Unmarshal(record.Payload, nodeConfig); err != nil {
+				return nil, ierrors.Join(ErrInvalidRecord, err)
+			}
+			s.nodeConfig = nodeConfig
+
+		case RecordKindNodeStatus:
+			nodeStatus := &inx.NodeStatus{}
+			if err := proto.Unmarshal(record.Payload, nodeStatus); err != nil {
+				return nil, ierrors.Join(ErrInvalidRecord, err)
+			}
+			s.nodeStatus = nodeStatus
+		}
+	}
+
+	s.apiProvider = s.nodeConfig.APIProvider()
+
+	return s, nil
+}
+
+// NewOfflineNodeBridge creates a NodeBridge that is connected to the given OfflineServer over an in-memory connection,
+// so no node is needed. The server is stopped once the given context is done.
+// The bridge is already connected, but Run needs to be called like for a live node.
+func NewOfflineNodeBridge(ctx context.Context, logger log.Logger, server *OfflineServer) (nodebridge.NodeBridge, error) {
+	listener := bufconn.Listen(offlineBufferSize)
+
+	grpcServer := grpc.NewServer()
+	inx.RegisterINXServer(grpcServer, server)
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.LogErrorf("offline INX server stopped: %s", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.Stop()
+	}()
+
+	nodeBridge := nodebridge.New(logger, nodebridge.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+
+	if err := nodeBridge.Connect(ctx, offlineAddress, 1); err != nil {
+		grpcServer.Stop()
+
+		return nil, err
+	}
+
+	return nodeBridge, nil
+}
+
+// ReadNodeConfiguration returns the configuration of the node snapshot.
+func (s *OfflineServer) ReadNodeConfiguration(_ context.Context, _ *inx.NoParams) (*inx.NodeConfiguration, error) {
+	return s.nodeConfig, nil
+}
+
+// ReadNodeStatus returns the status of the node snapshot.
+func (s *OfflineServer) ReadNodeStatus(_ context.Context, _ *inx.NoParams) (*inx.NodeStatus, error) {
+	return s.nodeStatus, nil
+}
+
+// ListenToNodeStatus sends the status of the node snapshot once and keeps the stream open until it is closed by the client.
+func (s *OfflineServer) ListenToNodeStatus(_ *inx.NodeStatusRequest, srv inx.INX_ListenToNodeStatusServer) error {
+	if err := srv.Send(s.nodeStatus); err != nil {
+		return err
+	}
+
+	<-srv.Context().Done()
+
+	return nil
+}
+
+// ListenToBlocks replays the recorded blocks and ends the stream after the last one.
+func (s *OfflineServer) ListenToBlocks(_ *inx.NoParams, srv inx.INX_ListenToBlocksServer) error {
+	return s.replay(srv.Context(), s.blocksFile, "blocks", nil, func(replayer *Replayer) error {
+		return replayer.ReplayBlocks(srv.Context(), func(block *iotago.Block, rawData []byte) error {
+			blockID, err := block.ID()
+			if err != nil {
+				return err
+			}
+
+			return srv.Send(inx.NewBlockWithBytes(blockID, rawData))
+		})
+	})
+}
+
+// ListenToLedgerUpdates replays the recorded ledger updates in the requested slot range and ends the stream after the last one.
+func (s *OfflineServer) ListenToLedgerUpdates(req *inx.SlotRangeRequest, srv inx.INX_ListenToLedgerUpdatesServer) error {
+	replayOpts := []options.Option[Replayer]{
+		WithReplayStartSlot(iotago.SlotIndex(req.GetStartSlot())),
+		WithReplayEndSlot(iotago.SlotIndex(req.GetEndSlot())),
+	}
+
+	return s.replay(srv.Context(), s.ledgerUpdatesFile, "ledger updates", replayOpts, func(replayer *Replayer) error {
+		return replayer.ReplayLedgerUpdates(srv.Context(), func(update *nodebridge.LedgerUpdate) error {
+			return sendLedgerUpdate(srv, update)
+		})
+	})
+}
+
+// replay opens the given recording and passes a Replayer for it to the given function.
+func (s *OfflineServer) replay(ctx context.Context, path string, name string, replayOpts []options.Option[Replayer], replayFunc func(replayer *Replayer) error) error {
+	if path == "" {
+		return status.Errorf(codes.Unimplemented, "no recorded %s available", name)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to open recorded %s: %s", name, err)
+	}
+	defer file.Close()
+
+	if err := replayFunc(NewReplayer(s.apiProvider, NewRecordReader(file), replayOpts...)); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return status.Errorf(codes.Internal, "failed to replay recorded %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func sendLedgerUpdate(srv inx.INX_ListenToLedgerUpdatesServer, update *nodebridge.LedgerUpdate) error {
+	sendMarker := func(markerType inx.LedgerUpdate_Marker_MarkerType) error {
+		return srv.Send(&inx.LedgerUpdate{
+			Op: &inx.LedgerUpdate_BatchMarker{
+				BatchMarker: &inx.LedgerUpdate_Marker{
+					CommitmentId:  inx.NewCommitmentId(update.CommitmentID),
+					MarkerType:    markerType,
+					ConsumedCount: uint32(len(update.Consumed)),
+					CreatedCount:  uint32(len(update.Created)),
+				},
+			},
+		})
+	}
+
+	if err := sendMarker(inx.LedgerUpdate_Marker_BEGIN); err != nil {
+		return err
+	}
+
+	for _, output := range update.Consumed {
+		ledgerSpent, err := wrapLedgerSpent(output)
+		if err != nil {
+			return err
+		}
+
+		if err := srv.Send(&inx.LedgerUpdate{Op: &inx.LedgerUpdate_Consumed{Consumed: ledgerSpent}}); err != nil {
+			return err
+		}
+	}
+
+	for _, output := range update.Created {
+		ledgerOutput, err := wrapLedgerOutput(output)
+		if err != nil {
+			return err
+		}
+
+		if err := srv.Send(&inx.LedgerUpdate{Op: &inx.LedgerUpdate_Created{Created: ledgerOutput}}); err != nil {
+			return err
+		}
+	}
+
+	return sendMarker(inx.LedgerUpdate_Marker_END)
+}
+
+// wrapLedgerOutput converts a recorded output back to the message the node sends for it.
+func wrapLedgerOutput(output *nodebridge.Output) (*inx.LedgerOutput, error) {
+	ledgerOutput := &inx.LedgerOutput{
+		OutputId: inx.NewOutputId(output.OutputID),
+		BlockId:  inx.NewBlockId(output.Metadata.BlockID),
+		Output:   &inx.RawOutput{Data: output.RawOutputData},
+	}
+
+	if included := output.Metadata.Included; included != nil {
+		ledgerOutput.SlotBooked = uint32(included.Slot)
+		if included.CommitmentID != iotago.EmptyCommitmentID {
+			ledgerOutput.CommitmentIdIncluded = inx.NewCommitmentId(included.CommitmentID)
+		}
+	}
+
+	if output.OutputIDProof != nil {
+		proofBytes, err := output.OutputIDProof.Bytes()
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "failed to encode proof of output %s", output.OutputID.ToHex())
+		}
+		ledgerOutput.OutputIdProof = &inx.RawOutputIDProof{Data: proofBytes}
+	}
+
+	return ledgerOutput, nil
+}
+
+// wrapLedgerSpent converts a recorded consumed output back to the message the node sends for it.
+func wrapLedgerSpent(output *nodebridge.Output) (*inx.LedgerSpent, error) {
+	ledgerOutput, err := wrapLedgerOutput(output)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerSpent := &inx.LedgerSpent{
+		Output: ledgerOutput,
+	}
+
+	if spent := output.Metadata.Spent; spent != nil {
+		ledgerSpent.SlotSpent = uint32(spent.Slot)
+		ledgerSpent.TransactionIdSpent = inx.NewTransactionId(spent.TransactionID)
+		if spent.CommitmentID != iotago.EmptyCommitmentID {
+			ledgerSpent.CommitmentIdSpent = inx.NewCommitmentId(spent.CommitmentID)
+		}
+	}
+
+	return ledgerSpent, nil
+}
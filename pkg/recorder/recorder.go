@@ -0,0 +1,254 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/sink"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Recorder records the streams of the node to length-prefixed files with slot markers.
+type Recorder struct {
+	nodeBridge nodebridge.NodeBridge
+
+	writerMutex sync.Mutex
+	writer      *RecordWriter
+	lastSlot    iotago.SlotIndex
+	slotWritten bool
+
+	// the recording is rotated by slot range if it is written to an object storage
+	objectWriter    sink.ObjectWriter
+	objectName      string
+	slotsPerObject  iotago.SlotIndex
+	objectBuffer    *bytes.Buffer
+	objectStartSlot iotago.SlotIndex
+}
+
+// New creates a new Recorder that writes to the given RecordWriter.
+// Blocks and ledger updates should be recorded to different writers.
+func New(nodeBridge nodebridge.NodeBridge, writer *RecordWriter) *Recorder {
+	return &Recorder{
+		nodeBridge: nodeBridge,
+		writer:     writer,
+	}
+}
+
+// NewObjectStorageRecorder creates a new Recorder that writes the records to objects of the given ObjectWriter,
+// e.g. a sink.S3ObjectWriter wrapped in a sink.GzipObjectWriter.
+// Every object contains the records of a range of slotsPerObject slots and is named "<name>-<first slot>-<last slot>.rec".
+// An object is written once the first record of a later slot range arrives or the Recorder is flushed.
+// Blocks and ledger updates should be recorded with different names.
+func NewObjectStorageRecorder(nodeBridge nodebridge.NodeBridge, objectWriter sink.ObjectWriter, name string, slotsPerObject iotago.SlotIndex) *Recorder {
+	objectBuffer := &bytes.Buffer{}
+
+	return &Recorder{
+		nodeBridge:     nodeBridge,
+		writer:         NewRecordWriter(objectBuffer),
+		objectWriter:   objectWriter,
+		objectName:     name,
+		slotsPerObject: max(1, slotsPerObject),
+		objectBuffer:   objectBuffer,
+	}
+}
+
+// RecordBlocks records all blocks until the given context is done.
+func (r *Recorder) RecordBlocks(ctx context.Context) error {
+	return r.nodeBridge.ListenToRawBlocks(ctx, func(blockID iotago.BlockID, rawData []byte) error {
+		r.writerMutex.Lock()
+		defer r.writerMutex.Unlock()
+
+		// the records are flushed whenever a new slot begins
+		if err := r.writeSlotMarker(ctx, blockID.Slot()); err != nil {
+			return err
+		}
+
+		return r.writer.Write(RecordKindBlock, rawData)
+	})
+}
+
+// RecordLedgerUpdates records the ledger updates in the given slot range.
+// If endSlot is 0, it records until the given context is done.
+func (r *Recorder) RecordLedgerUpdates(ctx context.Context, startSlot iotago.SlotIndex, endSlot iotago.SlotIndex) error {
+	return r.nodeBridge.ListenToLedgerUpdates(ctx, startSlot, endSlot, func(update *nodebridge.LedgerUpdate) error {
+		r.writerMutex.Lock()
+		defer r.writerMutex.Unlock()
+
+		if err := r.writeSlotMarker(ctx, update.CommitmentID.Slot()); err != nil {
+			return err
+		}
+
+		if err := r.writeLedgerUpdate(update); err != nil {
+			return err
+		}
+
+		return r.writer.Flush()
+	})
+}
+
+// RecordNodeSnapshot records the current configuration and status of the node,
+// which is needed to replay the recorded streams with an OfflineServer.
+// The snapshot should be recorded to a different writer than the streams.
+// If the Recorder writes to an object storage, the snapshot is written once the Recorder is flushed.
+func (r *Recorder) RecordNodeSnapshot() error {
+	nodeConfig, err := proto.Marshal(r.nodeBridge.NodeConfig())
+	if err != nil {
+		return ierrors.Wrap(err, "failed to encode node configuration")
+	}
+
+	nodeStatus, err := proto.Marshal(r.nodeBridge.NodeStatus())
+	if err != nil {
+		return ierrors.Wrap(err, "failed to encode node status")
+	}
+
+	r.writerMutex.Lock()
+	defer r.writerMutex.Unlock()
+
+	if err := r.writer.Write(RecordKindNodeConfiguration, nodeConfig); err != nil {
+		return err
+	}
+
+	if err := r.writer.Write(RecordKindNodeStatus, nodeStatus); err != nil {
+		return err
+	}
+
+	return r.writer.Flush()
+}
+
+func (r *Recorder) writeSlotMarker(ctx context.Context, slot iotago.SlotIndex) error {
+	if r.slotWritten && r.lastSlot == slot {
+		return nil
+	}
+
+	if r.objectWriter != nil {
+		startSlot := slot - slot%r.slotsPerObject
+
+		switch {
+		case !r.slotWritten:
+			r.objectStartSlot = startSlot
+
+		case startSlot > r.objectStartSlot:
+			// records of earlier slots that arrive late are written to the current object
+			if err := r.writeObject(ctx); err != nil {
+				return err
+			}
+			r.objectBuffer.Reset()
+			r.objectStartSlot = startSlot
+		}
+	}
+
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+
+	if err := r.writer.Write(RecordKindSlot, binary.LittleEndian.AppendUint32(nil, uint32(slot))); err != nil {
+		return err
+	}
+	r.lastSlot = slot
+	r.slotWritten = true
+
+	return nil
+}
+
+func (r *Recorder) writeLedgerUpdate(update *nodebridge.LedgerUpdate) error {
+	commitmentID, err := update.CommitmentID.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err := r.writer.Write(RecordKindLedgerUpdateBegin, commitmentID); err != nil {
+		return err
+	}
+
+	for _, output := range update.Consumed {
+		if err := r.writeOutput(update.API, RecordKindConsumedOutput, output); err != nil {
+			return err
+		}
+	}
+
+	for _, output := range update.Created {
+		if err := r.writeOutput(update.API, RecordKindCreatedOutput, output); err != nil {
+			return err
+		}
+	}
+
+	return r.writer.Write(RecordKindLedgerUpdateEnd, commitmentID)
+}
+
+// writeOutput writes the output ID followed by the length-prefixed proof, output and metadata.
+func (r *Recorder) writeOutput(apiForSlot iotago.API, kind RecordKind, output *nodebridge.Output) error {
+	payload, err := output.OutputID.Bytes()
+	if err != nil {
+		return err
+	}
+
+	var proofBytes []byte
+	if output.OutputIDProof != nil {
+		if proofBytes, err = output.OutputIDProof.Bytes(); err != nil {
+			return ierrors.Wrapf(err, "failed to encode proof of output %s", output.OutputID.ToHex())
+		}
+	}
+
+	rawOutputData := output.RawOutputData
+	if len(rawOutputData) == 0 {
+		if rawOutputData, err = apiForSlot.Encode(output.Output); err != nil {
+			return ierrors.Wrapf(err, "failed to encode output %s", output.OutputID.ToHex())
+		}
+	}
+
+	metadataBytes, err := apiForSlot.JSONEncode(output.Metadata)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to encode metadata of output %s", output.OutputID.ToHex())
+	}
+
+	for _, segment := range [][]byte{proofBytes, rawOutputData, metadataBytes} {
+		payload = binary.LittleEndian.AppendUint32(payload, uint32(len(segment)))
+		payload = append(payload, segment...)
+	}
+
+	return r.writer.Write(kind, payload)
+}
+
+// writeObject writes the records of the current slot range to the object storage.
+func (r *Recorder) writeObject(ctx context.Context) error {
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+
+	if r.objectBuffer.Len() == 0 {
+		return nil
+	}
+
+	name := r.objectName + ".rec"
+	if r.slotWritten {
+		name = fmt.Sprintf("%s-%010d-%010d.rec", r.objectName, r.objectStartSlot, r.objectStartSlot+r.slotsPerObject-1)
+	}
+
+	if err := r.objectWriter.WriteObject(ctx, name, r.objectBuffer.Bytes()); err != nil {
+		return ierrors.Wrapf(err, "failed to write recording %s", name)
+	}
+
+	return nil
+}
+
+// Flush writes all buffered records.
+// If the Recorder writes to an object storage, the object of the current slot range is written,
+// and it is overwritten once more records of the slot range arrive.
+func (r *Recorder) Flush() error {
+	r.writerMutex.Lock()
+	defer r.writerMutex.Unlock()
+
+	if r.objectWriter != nil {
+		// the recording is usually flushed on shutdown, when the context of the recording is already done
+		return r.writeObject(context.Background())
+	}
+
+	return r.writer.Flush()
+}
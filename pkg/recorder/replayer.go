@@ -0,0 +1,204 @@
+package recorder
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// Replayer feeds recorded streams back to the consumers of the NodeBridge.
+type Replayer struct {
+	apiProvider iotago.APIProvider
+	reader      *RecordReader
+
+	startSlot iotago.SlotIndex
+	endSlot   iotago.SlotIndex
+}
+
+// WithReplayStartSlot skips all records before the given slot.
+func WithReplayStartSlot(startSlot iotago.SlotIndex) options.Option[Replayer] {
+	return func(r *Replayer) {
+		r.startSlot = startSlot
+	}
+}
+
+// WithReplayEndSlot stops the replay after the given slot. 0 replays until the end of the recording.
+func WithReplayEndSlot(endSlot iotago.SlotIndex) options.Option[Replayer] {
+	return func(r *Replayer) {
+		r.endSlot = endSlot
+	}
+}
+
+// NewReplayer creates a new Replayer that reads from the given RecordReader.
+func NewReplayer(apiProvider iotago.APIProvider, reader *RecordReader, opts ...options.Option[Replayer]) *Replayer {
+	return options.Apply(&Replayer{
+		apiProvider: apiProvider,
+		reader:      reader,
+	}, opts)
+}
+
+// ReplayBlocks passes all recorded blocks to the given consumer, like NodeBridge.ListenToBlocks.
+func (r *Replayer) ReplayBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return r.replay(ctx, func(record *Record) error {
+		if record.Kind != RecordKindBlock {
+			return nil
+		}
+
+		block, _, err := iotago.BlockFromBytes(r.apiProvider)(record.Payload)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to parse recorded block")
+		}
+
+		return consumer(block, record.Payload)
+	})
+}
+
+// ReplayLedgerUpdates passes all recorded ledger updates to the given consumer, like NodeBridge.ListenToLedgerUpdates.
+func (r *Replayer) ReplayLedgerUpdates(ctx context.Context, consumer func(update *nodebridge.LedgerUpdate) error) error {
+	var update *nodebridge.LedgerUpdate
+
+	return r.replay(ctx, func(record *Record) error {
+		switch record.Kind {
+		case RecordKindLedgerUpdateBegin:
+			if update != nil {
+				return nodebridge.ErrLedgerUpdateTransactionAlreadyInProgress
+			}
+
+			commitmentID, _, err := iotago.CommitmentIDFromBytes(record.Payload)
+			if err != nil {
+				return ierrors.Join(ErrInvalidRecord, err)
+			}
+
+			update = &nodebridge.LedgerUpdate{
+				API:          r.apiProvider.APIForSlot(commitmentID.Slot()),
+				CommitmentID: commitmentID,
+				Consumed:     make([]*nodebridge.Output, 0),
+				Created:      make([]*nodebridge.Output, 0),
+			}
+
+		case RecordKindConsumedOutput, RecordKindCreatedOutput:
+			if update == nil {
+				return nodebridge.ErrLedgerUpdateInvalidOperation
+			}
+
+			output, err := decodeOutput(update.API, record.Payload)
+			if err != nil {
+				return err
+			}
+
+			if record.Kind == RecordKindConsumedOutput {
+				update.Consumed = append(update.Consumed, output)
+			} else {
+				update.Created = append(update.Created, output)
+			}
+
+		case RecordKindLedgerUpdateEnd:
+			if update == nil {
+				return nodebridge.ErrLedgerUpdateInvalidOperation
+			}
+
+			if err := consumer(update); err != nil {
+				return err
+			}
+			update = nil
+		}
+
+		return nil
+	})
+}
+
+// replay reads all records and passes the ones in the configured slot range to the given handler.
+func (r *Replayer) replay(ctx context.Context, handler func(record *Record) error) error {
+	inRange := r.startSlot == 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record, err := r.reader.Read()
+		if err != nil {
+			if ierrors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if record.Kind == RecordKindSlot {
+			if len(record.Payload) != iotago.SlotIndexLength {
+				return ierrors.Wrap(ErrInvalidRecord, "invalid slot marker")
+			}
+
+			slot := iotago.SlotIndex(binary.LittleEndian.Uint32(record.Payload))
+			if r.endSlot != 0 && slot > r.endSlot {
+				return nil
+			}
+			inRange = slot >= r.startSlot
+
+			continue
+		}
+
+		if !inRange {
+			continue
+		}
+
+		if err := handler(record); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeOutput(apiForSlot iotago.API, payload []byte) (*nodebridge.Output, error) {
+	outputID, offset, err := iotago.OutputIDFromBytes(payload)
+	if err != nil {
+		return nil, ierrors.Join(ErrInvalidRecord, err)
+	}
+
+	segments := make([][]byte, 3)
+	for i := range segments {
+		if len(payload) < offset+4 {
+			return nil, ierrors.Wrapf(ErrInvalidRecord, "truncated output %s", outputID.ToHex())
+		}
+		length := int(binary.LittleEndian.Uint32(payload[offset:]))
+		offset += 4
+
+		if len(payload) < offset+length {
+			return nil, ierrors.Wrapf(ErrInvalidRecord, "truncated output %s", outputID.ToHex())
+		}
+		segments[i] = payload[offset : offset+length]
+		offset += length
+	}
+	proofBytes, rawOutputData, metadataBytes := segments[0], segments[1], segments[2]
+
+	var outputIDProof *iotago.OutputIDProof
+	if len(proofBytes) > 0 {
+		if outputIDProof, _, err = iotago.OutputIDProofFromBytes(apiForSlot)(proofBytes); err != nil {
+			return nil, ierrors.Wrapf(err, "failed to decode proof of recorded output %s", outputID.ToHex())
+		}
+	}
+
+	var output iotago.TxEssenceOutput
+	if _, err := apiForSlot.Decode(rawOutputData, &output); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to decode recorded output %s", outputID.ToHex())
+	}
+
+	metadata := &api.OutputMetadata{}
+	if err := apiForSlot.JSONDecode(metadataBytes, metadata); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to decode metadata of recorded output %s", outputID.ToHex())
+	}
+
+	return &nodebridge.Output{
+		OutputID:      outputID,
+		Output:        output,
+		OutputIDProof: outputIDProof,
+		Metadata:      metadata,
+		RawOutputData: rawOutputData,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package restproxy
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// RouteNodeStatus is the route of the node status, which has the shape of the status in the info response of the core API.
+	RouteNodeStatus = "/api/inx/v1/node-status"
+)
+
+var (
+	// ErrServiceUnavailable is returned if the node is not available or not synced.
+	ErrServiceUnavailable = echo.NewHTTPError(http.StatusServiceUnavailable, "service unavailable")
+	// ErrNotFound is returned if the requested data was not found on the node.
+	ErrNotFound = echo.NewHTTPError(http.StatusNotFound, "not found")
+	// ErrForbidden is returned if the operation is not allowed by the bridge or the node.
+	ErrForbidden = echo.NewHTTPError(http.StatusForbidden, "forbidden")
+)
+
+// Proxy exposes a subset of the NodeBridge methods over a local REST API
+// that uses the routes and response shapes of the core API of the node.
+type Proxy struct {
+	nodeBridge nodebridge.NodeBridge
+}
+
+// New creates a new Proxy.
+func New(nodeBridge nodebridge.NodeBridge) *Proxy {
+	return &Proxy{
+		nodeBridge: nodeBridge,
+	}
+}
+
+// RegisterRoutes registers the routes of the proxy at the given echo instance.
+// If submitBlocks is false, the route to submit blocks is not registered.
+func (p *Proxy) RegisterRoutes(e *echo.Echo, submitBlocks bool) {
+	e.GET(RouteNodeStatus, p.nodeStatus)
+
+	e.GET(api.EndpointWithEchoParameters(api.CoreRouteBlock), p.block)
+	e.GET(api.EndpointWithEchoParameters(api.CoreRouteBlockMetadata), p.blockMetadata)
+	e.GET(api.EndpointWithEchoParameters(api.CoreRouteOutput), p.output)
+	e.GET(api.EndpointWithEchoParameters(api.CoreRouteOutputWithMetadata), p.outputWithMetadata)
+	e.GET(api.EndpointWithEchoParameters(api.CoreRouteCommitmentByID), p.commitmentByID)
+	e.GET(api.EndpointWithEchoParameters(api.CoreRouteCommitmentBySlot), p.commitmentBySlot)
+
+	if submitBlocks {
+		e.POST(api.CoreRouteBlocks, p.submitBlock)
+	}
+}
+
+func (p *Proxy) nodeStatus(c echo.Context) error {
+	nodeStatus := p.nodeBridge.NodeStatus()
+	if nodeStatus == nil {
+		return ErrServiceUnavailable
+	}
+
+	status := &api.InfoResNodeStatus{
+		IsHealthy:                nodeStatus.GetIsHealthy(),
+		LatestAcceptedBlockSlot:  iotago.SlotIndex(nodeStatus.GetLastAcceptedBlockSlot()),
+		LatestConfirmedBlockSlot: iotago.SlotIndex(nodeStatus.GetLastConfirmedBlockSlot()),
+		PruningEpoch:             p.nodeBridge.PruningEpoch(),
+	}
+	if latestCommitment := p.nodeBridge.LatestCommitment(); latestCommitment != nil {
+		status.LatestCommitmentID = latestCommitment.CommitmentID
+	}
+	if latestFinalizedCommitment := p.nodeBridge.LatestFinalizedCommitment(); latestFinalizedCommitment != nil {
+		status.LatestFinalizedSlot = latestFinalizedCommitment.CommitmentID.Slot()
+	}
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().CommittedAPI(), status)
+}
+
+func (p *Proxy) block(c echo.Context) error {
+	blockID, err := httpserver.ParseBlockIDParam(c, api.ParameterBlockID)
+	if err != nil {
+		return err
+	}
+
+	block, err := p.nodeBridge.Block(c.Request().Context(), blockID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	return httpserver.SendResponseByHeader(c, block.API, block)
+}
+
+func (p *Proxy) blockMetadata(c echo.Context) error {
+	blockID, err := httpserver.ParseBlockIDParam(c, api.ParameterBlockID)
+	if err != nil {
+		return err
+	}
+
+	blockMetadata, err := p.nodeBridge.BlockMetadata(c.Request().Context(), blockID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().APIForSlot(blockID.Slot()), blockMetadata)
+}
+
+func (p *Proxy) output(c echo.Context) error {
+	output, err := p.readOutput(c)
+	if err != nil {
+		return err
+	}
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().APIForSlot(output.OutputID.Slot()), &api.OutputResponse{
+		Output:        output.Output,
+		OutputIDProof: output.OutputIDProof,
+	})
+}
+
+func (p *Proxy) outputWithMetadata(c echo.Context) error {
+	output, err := p.readOutput(c)
+	if err != nil {
+		return err
+	}
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().APIForSlot(output.OutputID.Slot()), &api.OutputWithMetadataResponse{
+		Output:        output.Output,
+		OutputIDProof: output.OutputIDProof,
+		Metadata:      output.Metadata,
+	})
+}
+
+func (p *Proxy) readOutput(c echo.Context) (*nodebridge.Output, error) {
+	outputID, err := httpserver.ParseOutputIDParam(c, api.ParameterOutputID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := p.nodeBridge.Output(c.Request().Context(), outputID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return output, nil
+}
+
+func (p *Proxy) commitmentByID(c echo.Context) error {
+	commitmentID, err := httpserver.ParseCommitmentIDParam(c, api.ParameterCommitmentID)
+	if err != nil {
+		return err
+	}
+
+	commitment, err := p.nodeBridge.CommitmentByID(c.Request().Context(), commitmentID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().APIForSlot(commitmentID.Slot()), commitment.Commitment)
+}
+
+func (p *Proxy) commitmentBySlot(c echo.Context) error {
+	slot, err := httpserver.ParseSlotParam(c, api.ParameterSlot)
+	if err != nil {
+		return err
+	}
+
+	commitment, err := p.nodeBridge.Commitment(c.Request().Context(), slot)
+	if err != nil {
+		return mapError(err)
+	}
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().APIForSlot(slot), commitment.Commitment)
+}
+
+func (p *Proxy) submitBlock(c echo.Context) error {
+	block, err := httpserver.ParseRequestByHeader(c, p.nodeBridge.APIProvider().CommittedAPI(), iotago.BlockFromBytes(p.nodeBridge.APIProvider()))
+	if err != nil {
+		return err
+	}
+
+	blockID, err := p.nodeBridge.SubmitBlock(c.Request().Context(), block)
+	if err != nil {
+		return mapError(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, blockID.ToHex())
+
+	return httpserver.SendResponseByHeader(c, p.nodeBridge.APIProvider().CommittedAPI(), &api.BlockCreatedResponse{
+		BlockID: blockID,
+	}, http.StatusCreated)
+}
+
+// mapError maps the errors of the node bridge to HTTP errors.
+func mapError(err error) error {
+	switch {
+	case ierrors.Is(err, nodebridge.ErrNotFound), ierrors.Is(err, nodebridge.ErrPruned):
+		return ierrors.Join(ErrNotFound, err)
+	case ierrors.Is(err, nodebridge.ErrInvalidArgument):
+		return ierrors.Join(httpserver.ErrInvalidParameter, err)
+	case ierrors.Is(err, nodebridge.ErrReadOnly), ierrors.Is(err, nodebridge.ErrCapabilityNotSupported):
+		return ierrors.Join(ErrForbidden, err)
+	case ierrors.Is(err, nodebridge.ErrUnavailable), ierrors.Is(err, nodebridge.ErrNodeNotSynced):
+		return ierrors.Join(ErrServiceUnavailable, err)
+	default:
+		return err
+	}
+}
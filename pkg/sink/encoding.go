@@ -0,0 +1,179 @@
+package sink
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// LedgerUpdateMessage is the JSON representation of a ledger update.
+type LedgerUpdateMessage struct {
+	CommitmentID string            `json:"commitmentId"`
+	Slot         iotago.SlotIndex  `json:"slot"`
+	Consumed     []json.RawMessage `json:"consumed"`
+	Created      []json.RawMessage `json:"created"`
+}
+
+// AcceptedTransactionMessage is the JSON representation of an accepted transaction.
+type AcceptedTransactionMessage struct {
+	TransactionID string            `json:"transactionId"`
+	Slot          iotago.SlotIndex  `json:"slot"`
+	Consumed      []json.RawMessage `json:"consumed"`
+	Created       []json.RawMessage `json:"created"`
+}
+
+// EncodeOutputJSON encodes the output with its metadata like the output endpoint of the core API.
+func EncodeOutputJSON(apiForSlot iotago.API, output *nodebridge.Output) ([]byte, error) {
+	return apiForSlot.JSONEncode(&api.OutputWithMetadataResponse{
+		Output:        output.Output,
+		OutputIDProof: output.OutputIDProof,
+		Metadata:      output.Metadata,
+	})
+}
+
+// EncodeLedgerUpdateJSON encodes the given ledger update as LedgerUpdateMessage.
+func EncodeLedgerUpdateJSON(update *nodebridge.LedgerUpdate) ([]byte, error) {
+	consumed, err := encodeOutputsJSON(update.API, update.Consumed)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := encodeOutputsJSON(update.API, update.Created)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&LedgerUpdateMessage{
+		CommitmentID: update.CommitmentID.ToHex(),
+		Slot:         update.CommitmentID.Slot(),
+		Consumed:     consumed,
+		Created:      created,
+	})
+}
+
+// EncodeAcceptedTransactionJSON encodes the given accepted transaction as AcceptedTransactionMessage.
+func EncodeAcceptedTransactionJSON(tx *nodebridge.AcceptedTransaction) ([]byte, error) {
+	consumed, err := encodeOutputsJSON(tx.API, tx.Consumed)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := encodeOutputsJSON(tx.API, tx.Created)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&AcceptedTransactionMessage{
+		TransactionID: tx.TransactionID.ToHex(),
+		Slot:          tx.Slot,
+		Consumed:      consumed,
+		Created:       created,
+	})
+}
+
+func encodeOutputsJSON(apiForSlot iotago.API, outputs []*nodebridge.Output) ([]json.RawMessage, error) {
+	encoded := make([]json.RawMessage, 0, len(outputs))
+	for _, output := range outputs {
+		data, err := EncodeOutputJSON(apiForSlot, output)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, data)
+	}
+
+	return encoded, nil
+}
+
+// WrapLedgerOutput wraps the given output into its INX protobuf representation.
+func WrapLedgerOutput(output *nodebridge.Output) (*inx.LedgerOutput, error) {
+	outputIDProof, err := inx.WrapOutputIDProof(output.OutputIDProof)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerOutput := &inx.LedgerOutput{
+		OutputId:      inx.NewOutputId(output.OutputID),
+		BlockId:       inx.NewBlockId(output.Metadata.BlockID),
+		Output:        &inx.RawOutput{Data: output.RawOutputData},
+		OutputIdProof: outputIDProof,
+	}
+
+	if output.Metadata.Included != nil {
+		ledgerOutput.SlotBooked = uint32(output.Metadata.Included.Slot)
+		ledgerOutput.CommitmentIdIncluded = inx.NewCommitmentId(output.Metadata.Included.CommitmentID)
+	}
+
+	return ledgerOutput, nil
+}
+
+// WrapLedgerSpent wraps the given spent output into its INX protobuf representation.
+func WrapLedgerSpent(output *nodebridge.Output) (*inx.LedgerSpent, error) {
+	ledgerOutput, err := WrapLedgerOutput(output)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerSpent := &inx.LedgerSpent{
+		Output: ledgerOutput,
+	}
+
+	if output.Metadata.Spent != nil {
+		ledgerSpent.CommitmentIdSpent = inx.NewCommitmentId(output.Metadata.Spent.CommitmentID)
+		ledgerSpent.TransactionIdSpent = inx.NewTransactionId(output.Metadata.Spent.TransactionID)
+		ledgerSpent.SlotSpent = uint32(output.Metadata.Spent.Slot)
+	}
+
+	return ledgerSpent, nil
+}
+
+// EncodeOutputProtobuf encodes the given output as INX LedgerOutput, or as INX LedgerSpent if the output is spent.
+func EncodeOutputProtobuf(output *nodebridge.Output) ([]byte, error) {
+	if output.Metadata.Spent != nil {
+		ledgerSpent, err := WrapLedgerSpent(output)
+		if err != nil {
+			return nil, err
+		}
+
+		return proto.Marshal(ledgerSpent)
+	}
+
+	ledgerOutput, err := WrapLedgerOutput(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(ledgerOutput)
+}
+
+// EncodeAcceptedTransactionProtobuf encodes the given accepted transaction as INX AcceptedTransaction.
+func EncodeAcceptedTransactionProtobuf(tx *nodebridge.AcceptedTransaction) ([]byte, error) {
+	acceptedTransaction := &inx.AcceptedTransaction{
+		TransactionId: inx.NewTransactionId(tx.TransactionID),
+		Slot:          uint32(tx.Slot),
+		Consumed:      make([]*inx.LedgerSpent, 0, len(tx.Consumed)),
+		Created:       make([]*inx.LedgerOutput, 0, len(tx.Created)),
+	}
+
+	for _, output := range tx.Consumed {
+		ledgerSpent, err := WrapLedgerSpent(output)
+		if err != nil {
+			return nil, err
+		}
+		acceptedTransaction.Consumed = append(acceptedTransaction.Consumed, ledgerSpent)
+	}
+
+	for _, output := range tx.Created {
+		ledgerOutput, err := WrapLedgerOutput(output)
+		if err != nil {
+			return nil, err
+		}
+		acceptedTransaction.Created = append(acceptedTransaction.Created, ledgerOutput)
+	}
+
+	return proto.Marshal(acceptedTransaction)
+}
@@ -0,0 +1,276 @@
+package sink
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultKafkaTopicPrefix is the default prefix of the Kafka topics.
+	DefaultKafkaTopicPrefix = "iota."
+
+	// KafkaTopicOutputsCreated is the topic of the outputs created by a ledger update.
+	KafkaTopicOutputsCreated = "outputs.created"
+	// KafkaTopicOutputsConsumed is the topic of the outputs consumed by a ledger update.
+	KafkaTopicOutputsConsumed = "outputs.consumed"
+	// KafkaTopicTransactionsAccepted is the topic of the accepted transactions.
+	KafkaTopicTransactionsAccepted = "transactions.accepted"
+)
+
+// Encoding is the encoding of the messages produced by a sink.
+type Encoding int
+
+const (
+	// EncodingJSON encodes the messages as JSON like the core API.
+	EncodingJSON Encoding = iota
+	// EncodingProtobuf encodes the messages as INX protobuf messages.
+	EncodingProtobuf
+)
+
+// KafkaPartitioning defines how the key of the Kafka messages is derived, which determines the partition.
+type KafkaPartitioning int
+
+const (
+	// KafkaPartitioningBySlot uses the slot as key, so all messages of a slot end up in the same partition.
+	KafkaPartitioningBySlot KafkaPartitioning = iota
+	// KafkaPartitioningByAddress uses the address of the output as key, so all outputs of an address are ordered.
+	// Messages without an address (e.g. accepted transactions) are keyed by slot.
+	KafkaPartitioningByAddress
+)
+
+// KafkaMessage is a single message that is produced to Kafka.
+type KafkaMessage struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// KafkaProducer produces a batch of messages to Kafka and blocks until all messages were acknowledged by the brokers.
+// It is implemented by a thin adapter around the Kafka client of the application.
+type KafkaProducer interface {
+	Produce(ctx context.Context, messages []*KafkaMessage) error
+}
+
+// KafkaSinkEvents are the events triggered by the KafkaSink.
+type KafkaSinkEvents struct {
+	// CheckpointReached is triggered with the slot of a ledger update after all its messages were acknowledged.
+	// Applications persist the slot to resume with WithKafkaStartSlot after a restart.
+	CheckpointReached *event.Event1[iotago.SlotIndex]
+}
+
+// KafkaSink produces the outputs of the ledger updates and the accepted transactions to Kafka topics.
+type KafkaSink struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	producer   KafkaProducer
+	events     *KafkaSinkEvents
+
+	topicPrefix                 string
+	encoding                    Encoding
+	partitioning                KafkaPartitioning
+	startSlot                   iotago.SlotIndex
+	produceLedgerUpdates        bool
+	produceAcceptedTransactions bool
+}
+
+// WithKafkaTopicPrefix sets the prefix of the Kafka topics.
+func WithKafkaTopicPrefix(topicPrefix string) options.Option[KafkaSink] {
+	return func(s *KafkaSink) {
+		s.topicPrefix = topicPrefix
+	}
+}
+
+// WithKafkaEncoding sets the encoding of the messages.
+func WithKafkaEncoding(encoding Encoding) options.Option[KafkaSink] {
+	return func(s *KafkaSink) {
+		s.encoding = encoding
+	}
+}
+
+// WithKafkaPartitioning sets how the key of the messages is derived.
+func WithKafkaPartitioning(partitioning KafkaPartitioning) options.Option[KafkaSink] {
+	return func(s *KafkaSink) {
+		s.partitioning = partitioning
+	}
+}
+
+// WithKafkaStartSlot sets the slot from which the ledger updates are produced.
+// If the start slot is zero, the ledger updates are produced from the latest commitment on.
+func WithKafkaStartSlot(startSlot iotago.SlotIndex) options.Option[KafkaSink] {
+	return func(s *KafkaSink) {
+		s.startSlot = startSlot
+	}
+}
+
+// WithKafkaLedgerUpdates sets whether the outputs of the ledger updates are produced.
+func WithKafkaLedgerUpdates(enabled bool) options.Option[KafkaSink] {
+	return func(s *KafkaSink) {
+		s.produceLedgerUpdates = enabled
+	}
+}
+
+// WithKafkaAcceptedTransactions sets whether the accepted transactions are produced.
+func WithKafkaAcceptedTransactions(enabled bool) options.Option[KafkaSink] {
+	return func(s *KafkaSink) {
+		s.produceAcceptedTransactions = enabled
+	}
+}
+
+// NewKafkaSink creates a new KafkaSink.
+func NewKafkaSink(logger log.Logger, nodeBridge nodebridge.NodeBridge, producer KafkaProducer, opts ...options.Option[KafkaSink]) *KafkaSink {
+	return options.Apply(&KafkaSink{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		producer:   producer,
+		events: &KafkaSinkEvents{
+			CheckpointReached: event.New1[iotago.SlotIndex](),
+		},
+		topicPrefix:                 DefaultKafkaTopicPrefix,
+		encoding:                    EncodingJSON,
+		partitioning:                KafkaPartitioningBySlot,
+		produceLedgerUpdates:        true,
+		produceAcceptedTransactions: true,
+	}, opts)
+}
+
+// Events returns the events of the KafkaSink.
+func (s *KafkaSink) Events() *KafkaSinkEvents {
+	return s.events
+}
+
+// Run produces the selected streams until the given context is done or producing fails.
+func (s *KafkaSink) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errChan := make(chan error, 2)
+
+	runStream := func(listen func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := listen(ctx); err != nil && ctx.Err() == nil {
+				errChan <- err
+				cancel()
+			}
+		}()
+	}
+
+	if s.produceLedgerUpdates {
+		runStream(s.produceLedgerUpdatesStream)
+	}
+	if s.produceAcceptedTransactions {
+		runStream(s.produceAcceptedTransactionsStream)
+	}
+
+	wg.Wait()
+
+	select {
+	case err := <-errChan:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (s *KafkaSink) produceLedgerUpdatesStream(ctx context.Context) error {
+	return s.nodeBridge.ListenToLedgerUpdates(ctx, s.startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+		slot := update.CommitmentID.Slot()
+
+		messages := make([]*KafkaMessage, 0, len(update.Consumed)+len(update.Created))
+		for _, output := range update.Consumed {
+			message, err := s.outputMessage(update.API, KafkaTopicOutputsConsumed, slot, output)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, message)
+		}
+		for _, output := range update.Created {
+			message, err := s.outputMessage(update.API, KafkaTopicOutputsCreated, slot, output)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, message)
+		}
+
+		if len(messages) > 0 {
+			if err := s.producer.Produce(ctx, messages); err != nil {
+				return ierrors.Wrapf(err, "failed to produce ledger update %s", update.CommitmentID)
+			}
+		}
+
+		// all messages of the slot were acknowledged, so the slot doesn't need to be produced again
+		s.events.CheckpointReached.Trigger(slot)
+
+		return nil
+	})
+}
+
+func (s *KafkaSink) produceAcceptedTransactionsStream(ctx context.Context) error {
+	return s.nodeBridge.ListenToAcceptedTransactions(ctx, func(tx *nodebridge.AcceptedTransaction) error {
+		var value []byte
+		var err error
+		switch s.encoding {
+		case EncodingProtobuf:
+			value, err = EncodeAcceptedTransactionProtobuf(tx)
+		default:
+			value, err = EncodeAcceptedTransactionJSON(tx)
+		}
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to encode accepted transaction %s", tx.TransactionID)
+		}
+
+		if err := s.producer.Produce(ctx, []*KafkaMessage{{
+			Topic: s.topicPrefix + KafkaTopicTransactionsAccepted,
+			Key:   slotKey(tx.Slot),
+			Value: value,
+		}}); err != nil {
+			return ierrors.Wrapf(err, "failed to produce accepted transaction %s", tx.TransactionID)
+		}
+
+		return nil
+	})
+}
+
+func (s *KafkaSink) outputMessage(apiForSlot iotago.API, topic string, slot iotago.SlotIndex, output *nodebridge.Output) (*KafkaMessage, error) {
+	var value []byte
+	var err error
+	switch s.encoding {
+	case EncodingProtobuf:
+		value, err = EncodeOutputProtobuf(output)
+	default:
+		value, err = EncodeOutputJSON(apiForSlot, output)
+	}
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to encode output %s", output.OutputID)
+	}
+
+	key := slotKey(slot)
+	if s.partitioning == KafkaPartitioningByAddress {
+		if addressUnlock := output.Output.UnlockConditionSet().Address(); addressUnlock != nil {
+			key = []byte(addressUnlock.Address.Bech32(apiForSlot.ProtocolParameters().Bech32HRP()))
+		}
+	}
+
+	return &KafkaMessage{
+		Topic: s.topicPrefix + topic,
+		Key:   key,
+		Value: value,
+	}, nil
+}
+
+func slotKey(slot iotago.SlotIndex) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(slot))
+}
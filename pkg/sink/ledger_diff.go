@@ -0,0 +1,310 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// LedgerDiffKindCreated marks an output that was created in the slot.
+	LedgerDiffKindCreated = "created"
+	// LedgerDiffKindConsumed marks an output that was consumed in the slot.
+	LedgerDiffKindConsumed = "consumed"
+)
+
+// DiffFormat is the format of the exported ledger diffs.
+type DiffFormat int
+
+const (
+	// DiffFormatJSON exports a ledger diff as a JSON object.
+	DiffFormatJSON DiffFormat = iota
+	// DiffFormatCSV exports a ledger diff as CSV with a header and one row per output.
+	DiffFormatCSV
+)
+
+// ledgerDiffCSVHeader is the header of the ledger diffs in the CSV format.
+var ledgerDiffCSVHeader = []string{"slot", "commitment_id", "kind", "output_id", "output_type", "owner", "amount", "mana"}
+
+// LedgerDiffEntry is a created or consumed output of a ledger diff.
+type LedgerDiffEntry struct {
+	Kind       string           `json:"kind"`
+	OutputID   string           `json:"outputId"`
+	OutputType string           `json:"outputType"`
+	Owner      string           `json:"owner,omitempty"`
+	Amount     iotago.BaseToken `json:"amount,string"`
+	Mana       iotago.Mana      `json:"mana,string"`
+}
+
+// LedgerDiff are the outputs that were created and consumed in a committed slot.
+type LedgerDiff struct {
+	Slot         iotago.SlotIndex   `json:"slot"`
+	CommitmentID string             `json:"commitmentId"`
+	Entries      []*LedgerDiffEntry `json:"entries"`
+}
+
+// ObjectWriter writes named objects, e.g. files in a directory or objects in a bucket.
+type ObjectWriter interface {
+	WriteObject(ctx context.Context, name string, data []byte) error
+}
+
+// FileObjectWriter writes the objects as files to a directory.
+type FileObjectWriter struct {
+	directory string
+}
+
+// NewFileObjectWriter creates a new FileObjectWriter that writes to the given directory.
+func NewFileObjectWriter(directory string) (*FileObjectWriter, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to create directory %s", directory)
+	}
+
+	return &FileObjectWriter{directory: directory}, nil
+}
+
+// WriteObject writes the object atomically, so readers never see a partially written file.
+func (w *FileObjectWriter) WriteObject(_ context.Context, name string, data []byte) error {
+	filePath := filepath.Join(w.directory, name)
+
+	tmpFilePath := filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, data, 0o600); err != nil {
+		return ierrors.Wrapf(err, "failed to write %s", tmpFilePath)
+	}
+
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return ierrors.Wrapf(err, "failed to rename %s", tmpFilePath)
+	}
+
+	return nil
+}
+
+// StreamObjectWriter writes the content of the objects one after the other to a stream, e.g. os.Stdout.
+type StreamObjectWriter struct {
+	writerMutex sync.Mutex
+	writer      io.Writer
+}
+
+// NewStreamObjectWriter creates a new StreamObjectWriter that writes to the given writer.
+func NewStreamObjectWriter(writer io.Writer) *StreamObjectWriter {
+	return &StreamObjectWriter{writer: writer}
+}
+
+// WriteObject writes the content of the object, the name is ignored.
+func (w *StreamObjectWriter) WriteObject(_ context.Context, _ string, data []byte) error {
+	w.writerMutex.Lock()
+	defer w.writerMutex.Unlock()
+
+	_, err := w.writer.Write(data)
+
+	return err
+}
+
+// LedgerDiffExporterEvents are the events triggered by the LedgerDiffExporter.
+type LedgerDiffExporterEvents struct {
+	// CheckpointReached is triggered with the slot of a ledger diff after it was written by all writers.
+	// Applications persist the slot to resume with WithLedgerDiffStartSlot after a restart.
+	CheckpointReached *event.Event1[iotago.SlotIndex]
+}
+
+// LedgerDiffExporter writes the ledger diff of every committed slot to the given writers,
+// e.g. for accounting or compliance pipelines.
+type LedgerDiffExporter struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	writers    []ObjectWriter
+	events     *LedgerDiffExporterEvents
+
+	format    DiffFormat
+	startSlot iotago.SlotIndex
+	skipEmpty bool
+}
+
+// WithLedgerDiffFormat sets the format of the exported ledger diffs.
+func WithLedgerDiffFormat(format DiffFormat) options.Option[LedgerDiffExporter] {
+	return func(e *LedgerDiffExporter) {
+		e.format = format
+	}
+}
+
+// WithLedgerDiffStartSlot sets the slot from which the ledger diffs are exported.
+// If the start slot is zero, the ledger diffs are exported from the latest commitment on.
+func WithLedgerDiffStartSlot(startSlot iotago.SlotIndex) options.Option[LedgerDiffExporter] {
+	return func(e *LedgerDiffExporter) {
+		e.startSlot = startSlot
+	}
+}
+
+// WithLedgerDiffSkipEmpty sets whether slots without created or consumed outputs are skipped.
+func WithLedgerDiffSkipEmpty(skipEmpty bool) options.Option[LedgerDiffExporter] {
+	return func(e *LedgerDiffExporter) {
+		e.skipEmpty = skipEmpty
+	}
+}
+
+// NewLedgerDiffExporter creates a new LedgerDiffExporter.
+func NewLedgerDiffExporter(logger log.Logger, nodeBridge nodebridge.NodeBridge, writers []ObjectWriter, opts ...options.Option[LedgerDiffExporter]) *LedgerDiffExporter {
+	return options.Apply(&LedgerDiffExporter{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		writers:    writers,
+		events: &LedgerDiffExporterEvents{
+			CheckpointReached: event.New1[iotago.SlotIndex](),
+		},
+		format:    DiffFormatJSON,
+		skipEmpty: true,
+	}, opts)
+}
+
+// Events returns the events of the LedgerDiffExporter.
+func (e *LedgerDiffExporter) Events() *LedgerDiffExporterEvents {
+	return e.events
+}
+
+// Run exports the ledger diffs until the given context is done or writing fails.
+func (e *LedgerDiffExporter) Run(ctx context.Context) error {
+	return e.nodeBridge.ListenToLedgerUpdates(ctx, e.startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+		slot := update.CommitmentID.Slot()
+
+		if !e.skipEmpty || len(update.Consumed) > 0 || len(update.Created) > 0 {
+			data, err := e.encode(NewLedgerDiff(update))
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to encode ledger diff of slot %d", slot)
+			}
+
+			name := e.objectName(slot)
+			for _, writer := range e.writers {
+				if err := writer.WriteObject(ctx, name, data); err != nil {
+					return ierrors.Wrapf(err, "failed to write ledger diff of slot %d", slot)
+				}
+			}
+			e.LogDebugf("Exported ledger diff of slot %d (consumed: %d, created: %d)", slot, len(update.Consumed), len(update.Created))
+		}
+
+		// the diff was written by all writers, so the slot doesn't need to be exported again
+		e.events.CheckpointReached.Trigger(slot)
+
+		return nil
+	})
+}
+
+func (e *LedgerDiffExporter) objectName(slot iotago.SlotIndex) string {
+	extension := "json"
+	if e.format == DiffFormatCSV {
+		extension = "csv"
+	}
+
+	return fmt.Sprintf("ledger-diff-%010d.%s", slot, extension)
+}
+
+func (e *LedgerDiffExporter) encode(diff *LedgerDiff) ([]byte, error) {
+	switch e.format {
+	case DiffFormatCSV:
+		return EncodeLedgerDiffCSV(diff)
+	default:
+		data, err := json.Marshal(diff)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(data, '\n'), nil
+	}
+}
+
+// NewLedgerDiff creates the ledger diff of the given ledger update.
+func NewLedgerDiff(update *nodebridge.LedgerUpdate) *LedgerDiff {
+	hrp := update.API.ProtocolParameters().Bech32HRP()
+
+	entries := make([]*LedgerDiffEntry, 0, len(update.Consumed)+len(update.Created))
+	for _, output := range update.Consumed {
+		entries = append(entries, newLedgerDiffEntry(LedgerDiffKindConsumed, output, hrp))
+	}
+	for _, output := range update.Created {
+		entries = append(entries, newLedgerDiffEntry(LedgerDiffKindCreated, output, hrp))
+	}
+
+	return &LedgerDiff{
+		Slot:         update.CommitmentID.Slot(),
+		CommitmentID: update.CommitmentID.ToHex(),
+		Entries:      entries,
+	}
+}
+
+func newLedgerDiffEntry(kind string, output *nodebridge.Output, hrp iotago.NetworkPrefix) *LedgerDiffEntry {
+	return &LedgerDiffEntry{
+		Kind:       kind,
+		OutputID:   output.OutputID.ToHex(),
+		OutputType: output.Output.Type().String(),
+		Owner:      outputOwner(output.Output, hrp),
+		Amount:     output.Output.BaseTokenAmount(),
+		Mana:       output.Output.StoredMana(),
+	}
+}
+
+// outputOwner returns the bech32 address that unlocks the output, or an empty string if the output has none.
+// Anchor outputs are owned by their state controller.
+func outputOwner(output iotago.TxEssenceOutput, hrp iotago.NetworkPrefix) string {
+	unlockConditions := output.UnlockConditionSet()
+
+	if addressUnlock := unlockConditions.Address(); addressUnlock != nil {
+		return addressUnlock.Address.Bech32(hrp)
+	}
+
+	if stateControllerUnlock := unlockConditions.StateControllerAddress(); stateControllerUnlock != nil {
+		return stateControllerUnlock.Address.Bech32(hrp)
+	}
+
+	if immutableAccountUnlock := unlockConditions.ImmutableAccount(); immutableAccountUnlock != nil {
+		return immutableAccountUnlock.Address.Bech32(hrp)
+	}
+
+	return ""
+}
+
+// EncodeLedgerDiffCSV encodes the given ledger diff as CSV with a header and one row per output.
+func EncodeLedgerDiffCSV(diff *LedgerDiff) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	csvWriter := csv.NewWriter(&buffer)
+	if err := csvWriter.Write(ledgerDiffCSVHeader); err != nil {
+		return nil, err
+	}
+
+	slot := strconv.FormatUint(uint64(diff.Slot), 10)
+	for _, entry := range diff.Entries {
+		if err := csvWriter.Write([]string{
+			slot,
+			diff.CommitmentID,
+			entry.Kind,
+			entry.OutputID,
+			entry.OutputType,
+			entry.Owner,
+			strconv.FormatUint(uint64(entry.Amount), 10),
+			strconv.FormatUint(uint64(entry.Mana), 10),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	csvWriter.Flush()
+
+	if err := csvWriter.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
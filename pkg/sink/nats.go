@@ -0,0 +1,171 @@
+package sink
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultNATSSubjectPrefix is the default prefix of the NATS subjects.
+	DefaultNATSSubjectPrefix = "iota"
+
+	natsSubjectLedgerUpdates        = ".ledger.updates"
+	natsSubjectAcceptedTransactions = ".transactions.accepted"
+)
+
+// JetStreamPublisher publishes a message to NATS JetStream and blocks until the message was acknowledged by the stream.
+// The msgID has to be passed as "Nats-Msg-Id" header, so JetStream discards duplicates within its deduplication window.
+// It is implemented by a thin adapter around the JetStream client of the application,
+// e.g. js.Publish(ctx, subject, data, jetstream.WithMsgID(msgID)).
+type JetStreamPublisher interface {
+	Publish(ctx context.Context, subject string, data []byte, msgID string) error
+}
+
+// NATSSinkEvents are the events triggered by the NATSSink.
+type NATSSinkEvents struct {
+	// LedgerUpdatePublished is triggered with the slot of a ledger update after it was acknowledged by JetStream.
+	// Applications persist the slot to resume with WithNATSStartSlot after a restart.
+	LedgerUpdatePublished *event.Event1[iotago.SlotIndex]
+}
+
+// NATSSink forwards ledger updates and accepted transactions to NATS JetStream subjects with at-least-once delivery.
+// Every message carries a deduplication key derived from the slot or transaction,
+// so messages that are published again after a restart are discarded by JetStream.
+type NATSSink struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	publisher  JetStreamPublisher
+	events     *NATSSinkEvents
+
+	subjectPrefix               string
+	startSlot                   iotago.SlotIndex
+	forwardLedgerUpdates        bool
+	forwardAcceptedTransactions bool
+}
+
+// WithNATSSubjectPrefix sets the prefix of the NATS subjects.
+func WithNATSSubjectPrefix(subjectPrefix string) options.Option[NATSSink] {
+	return func(s *NATSSink) {
+		s.subjectPrefix = subjectPrefix
+	}
+}
+
+// WithNATSStartSlot sets the slot from which the ledger updates are forwarded.
+// If the start slot is zero, the ledger updates are forwarded from the latest commitment on.
+func WithNATSStartSlot(startSlot iotago.SlotIndex) options.Option[NATSSink] {
+	return func(s *NATSSink) {
+		s.startSlot = startSlot
+	}
+}
+
+// WithNATSLedgerUpdates sets whether the ledger updates are forwarded.
+func WithNATSLedgerUpdates(enabled bool) options.Option[NATSSink] {
+	return func(s *NATSSink) {
+		s.forwardLedgerUpdates = enabled
+	}
+}
+
+// WithNATSAcceptedTransactions sets whether the accepted transactions are forwarded.
+func WithNATSAcceptedTransactions(enabled bool) options.Option[NATSSink] {
+	return func(s *NATSSink) {
+		s.forwardAcceptedTransactions = enabled
+	}
+}
+
+// NewNATSSink creates a new NATSSink.
+func NewNATSSink(logger log.Logger, nodeBridge nodebridge.NodeBridge, publisher JetStreamPublisher, opts ...options.Option[NATSSink]) *NATSSink {
+	return options.Apply(&NATSSink{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		publisher:  publisher,
+		events: &NATSSinkEvents{
+			LedgerUpdatePublished: event.New1[iotago.SlotIndex](),
+		},
+		subjectPrefix:               DefaultNATSSubjectPrefix,
+		forwardLedgerUpdates:        true,
+		forwardAcceptedTransactions: true,
+	}, opts)
+}
+
+// Events returns the events of the NATSSink.
+func (s *NATSSink) Events() *NATSSinkEvents {
+	return s.events
+}
+
+// Run forwards the selected streams until the given context is done or forwarding fails.
+func (s *NATSSink) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errChan := make(chan error, 2)
+
+	runStream := func(listen func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := listen(ctx); err != nil && ctx.Err() == nil {
+				errChan <- err
+				cancel()
+			}
+		}()
+	}
+
+	if s.forwardLedgerUpdates {
+		runStream(s.forwardLedgerUpdatesStream)
+	}
+	if s.forwardAcceptedTransactions {
+		runStream(s.forwardAcceptedTransactionsStream)
+	}
+
+	wg.Wait()
+
+	select {
+	case err := <-errChan:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (s *NATSSink) forwardLedgerUpdatesStream(ctx context.Context) error {
+	return s.nodeBridge.ListenToLedgerUpdates(ctx, s.startSlot, 0, func(update *nodebridge.LedgerUpdate) error {
+		data, err := EncodeLedgerUpdateJSON(update)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to encode ledger update %s", update.CommitmentID)
+		}
+
+		if err := s.publisher.Publish(ctx, s.subjectPrefix+natsSubjectLedgerUpdates, data, "ledger-update-"+update.CommitmentID.ToHex()); err != nil {
+			return ierrors.Wrapf(err, "failed to publish ledger update %s", update.CommitmentID)
+		}
+
+		s.events.LedgerUpdatePublished.Trigger(update.CommitmentID.Slot())
+
+		return nil
+	})
+}
+
+func (s *NATSSink) forwardAcceptedTransactionsStream(ctx context.Context) error {
+	return s.nodeBridge.ListenToAcceptedTransactions(ctx, func(tx *nodebridge.AcceptedTransaction) error {
+		data, err := EncodeAcceptedTransactionJSON(tx)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to encode accepted transaction %s", tx.TransactionID)
+		}
+
+		if err := s.publisher.Publish(ctx, s.subjectPrefix+natsSubjectAcceptedTransactions, data, "accepted-transaction-"+tx.TransactionID.ToHex()); err != nil {
+			return ierrors.Wrapf(err, "failed to publish accepted transaction %s", tx.TransactionID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,177 @@
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// GCSEndpoint is the endpoint of the S3 compatible XML API of Google Cloud Storage.
+	// It requires HMAC keys of a service account as access key ID and secret access key.
+	GCSEndpoint = "https://storage.googleapis.com"
+	// GCSRegion is the region that is used to sign requests to Google Cloud Storage.
+	GCSRegion = "auto"
+
+	// DefaultS3Timeout is the default timeout of an upload to the object storage.
+	DefaultS3Timeout = time.Minute
+)
+
+var (
+	// ErrObjectUploadFailed is returned if the object storage rejected an upload.
+	ErrObjectUploadFailed = ierrors.New("object upload failed")
+)
+
+// GzipObjectWriter compresses the objects with gzip before they are written by the wrapped ObjectWriter.
+// The names of the objects get the suffix ".gz".
+type GzipObjectWriter struct {
+	writer ObjectWriter
+	level  int
+}
+
+// NewGzipObjectWriter creates a new GzipObjectWriter that compresses with the given level (e.g. gzip.DefaultCompression).
+func NewGzipObjectWriter(writer ObjectWriter, level int) *GzipObjectWriter {
+	return &GzipObjectWriter{
+		writer: writer,
+		level:  level,
+	}
+}
+
+// WriteObject compresses the data and writes it with the wrapped ObjectWriter.
+func (w *GzipObjectWriter) WriteObject(ctx context.Context, name string, data []byte) error {
+	var buffer bytes.Buffer
+
+	gzipWriter, err := gzip.NewWriterLevel(&buffer, w.level)
+	if err != nil {
+		return err
+	}
+	gzipWriter.Name = name
+
+	if _, err := gzipWriter.Write(data); err != nil {
+		return ierrors.Wrapf(err, "failed to compress %s", name)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return ierrors.Wrapf(err, "failed to compress %s", name)
+	}
+
+	return w.writer.WriteObject(ctx, name+".gz", buffer.Bytes())
+}
+
+// S3ObjectWriter uploads the objects to a bucket of an S3 compatible object storage,
+// e.g. AWS S3, MinIO or Google Cloud Storage (with GCSEndpoint and GCSRegion).
+// The uploads are done by the MinIO client, which signs the requests with AWS Signature Version 4 and uses path-style URLs.
+type S3ObjectWriter struct {
+	client *minio.Client
+	bucket string
+
+	sessionToken string
+	prefix       string
+	httpClient   *http.Client
+	timeout      time.Duration
+}
+
+// WithS3SessionToken sets the session token of temporary credentials, e.g. issued by AWS STS.
+func WithS3SessionToken(sessionToken string) options.Option[S3ObjectWriter] {
+	return func(w *S3ObjectWriter) {
+		w.sessionToken = sessionToken
+	}
+}
+
+// WithS3Prefix sets the prefix of the keys of the uploaded objects, e.g. "recordings/".
+func WithS3Prefix(prefix string) options.Option[S3ObjectWriter] {
+	return func(w *S3ObjectWriter) {
+		w.prefix = prefix
+	}
+}
+
+// WithS3HTTPClient sets the HTTP client whose transport is used for the uploads.
+func WithS3HTTPClient(httpClient *http.Client) options.Option[S3ObjectWriter] {
+	return func(w *S3ObjectWriter) {
+		w.httpClient = httpClient
+	}
+}
+
+// WithS3Timeout sets the timeout of an upload.
+func WithS3Timeout(timeout time.Duration) options.Option[S3ObjectWriter] {
+	return func(w *S3ObjectWriter) {
+		w.timeout = timeout
+	}
+}
+
+// NewS3ObjectWriter creates a new S3ObjectWriter that uploads to the given bucket.
+// If no access key ID is given, the credentials are taken from the environment (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY
+// and AWS_SESSION_TOKEN) or from the IAM role of the instance.
+func NewS3ObjectWriter(endpoint string, region string, bucket string, accessKeyID string, secretAccessKey string, opts ...options.Option[S3ObjectWriter]) (*S3ObjectWriter, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "invalid object storage endpoint %s", endpoint)
+	}
+	if endpointURL.Scheme == "" || endpointURL.Host == "" {
+		return nil, ierrors.Errorf("invalid object storage endpoint %s, scheme and host are required", endpoint)
+	}
+	if endpointURL.Path != "" && endpointURL.Path != "/" {
+		return nil, ierrors.Errorf("invalid object storage endpoint %s, paths are not supported", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, ierrors.New("object storage bucket is required")
+	}
+
+	w := options.Apply(&S3ObjectWriter{
+		bucket:     bucket,
+		httpClient: http.DefaultClient,
+		timeout:    DefaultS3Timeout,
+	}, opts)
+
+	var creds *credentials.Credentials
+	if accessKeyID != "" {
+		creds = credentials.NewStaticV4(accessKeyID, secretAccessKey, w.sessionToken)
+	} else {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		})
+	}
+
+	w.client, err = minio.New(endpointURL.Host, &minio.Options{
+		Creds:        creds,
+		Secure:       endpointURL.Scheme == "https",
+		Transport:    w.httpClient.Transport,
+		Region:       region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to create object storage client for %s", endpoint)
+	}
+
+	return w, nil
+}
+
+// WriteObject uploads the object with a single PUT request.
+func (w *S3ObjectWriter) WriteObject(ctx context.Context, name string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	key := w.prefix + name
+
+	if _, err := w.client.PutObject(ctx, w.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	}); err != nil {
+		if statusCode := minio.ToErrorResponse(err).StatusCode; statusCode != 0 {
+			return ierrors.Wrapf(ErrObjectUploadFailed, "upload of %s failed with status %d: %s", key, statusCode, err)
+		}
+
+		return ierrors.Wrapf(err, "failed to upload %s", key)
+	}
+
+	return nil
+}
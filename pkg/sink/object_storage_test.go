@@ -0,0 +1,92 @@
+package sink_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/inx-app/pkg/sink"
+)
+
+func TestS3ObjectWriterWriteObject(t *testing.T) {
+	t.Parallel()
+
+	type upload struct {
+		method        string
+		escapedPath   string
+		authorization string
+		securityToken string
+		body          string
+	}
+
+	uploads := make(chan *upload, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploads <- &upload{
+			method:        r.Method,
+			escapedPath:   r.URL.EscapedPath(),
+			authorization: r.Header.Get("Authorization"),
+			securityToken: r.Header.Get("X-Amz-Security-Token"),
+			body:          string(body),
+		}
+
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	writer, err := sink.NewS3ObjectWriter(server.URL, "us-east-1", "bucket", "access-key", "secret-key",
+		sink.WithS3Prefix("recordings/"),
+		sink.WithS3SessionToken("session-token"),
+		sink.WithS3HTTPClient(server.Client()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.WriteObject(context.Background(), "blocks:1@2+3=4.bin", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	received := <-uploads
+	if received.method != http.MethodPut {
+		t.Errorf("unexpected method %s", received.method)
+	}
+	// the reserved characters of the key must be URI-encoded like AWS expects it, except the slashes
+	if expected := "/bucket/recordings/blocks%3A1%402%2B3%3D4.bin"; received.escapedPath != expected {
+		t.Errorf("unexpected path %s, expected %s", received.escapedPath, expected)
+	}
+	if !strings.HasPrefix(received.authorization, "AWS4-HMAC-SHA256 Credential=access-key/") {
+		t.Errorf("unexpected authorization %s", received.authorization)
+	}
+	if received.securityToken != "session-token" {
+		t.Errorf("unexpected security token %q", received.securityToken)
+	}
+	if received.body != "data" {
+		t.Errorf("unexpected body %q", received.body)
+	}
+}
+
+func TestS3ObjectWriterUploadFailed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+	}))
+	defer server.Close()
+
+	writer, err := sink.NewS3ObjectWriter(server.URL, "us-east-1", "bucket", "access-key", "secret-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writer.WriteObject(context.Background(), "object", []byte("data"))
+	if err == nil || !strings.Contains(err.Error(), sink.ErrObjectUploadFailed.Error()) {
+		t.Fatalf("expected %s, got %v", sink.ErrObjectUploadFailed, err)
+	}
+}
@@ -0,0 +1,437 @@
+package spammer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+// SpamType is the kind of payload that is issued by the Spammer.
+type SpamType string
+
+const (
+	// SpamTypeEmpty issues basic blocks without payload.
+	SpamTypeEmpty SpamType = "empty"
+	// SpamTypeTaggedData issues basic blocks with a tagged data payload.
+	SpamTypeTaggedData SpamType = "taggedData"
+	// SpamTypeValue issues basic blocks with a transaction that sends the funds of an output back to the own address.
+	SpamTypeValue SpamType = "value"
+)
+
+const (
+	// DefaultBlocksPerSecond is the default rate in which blocks are issued.
+	DefaultBlocksPerSecond = 1.0
+	// DefaultTag is the default tag of the tagged data payloads.
+	DefaultTag = "inx-spammer"
+	// DefaultValueSpamMaxPending is the default amount of value spam transactions that are chained
+	// onto each other before the spammer waits for their acceptance.
+	DefaultValueSpamMaxPending = 10
+	// DefaultValueSpamAcceptanceTimeout is the default time after which the spammer falls back to
+	// the last accepted output if the pending value spam transactions were not accepted.
+	DefaultValueSpamAcceptanceTimeout = time.Minute
+)
+
+var (
+	// ErrUnknownSpamType is returned if the configured spam type is unknown.
+	ErrUnknownSpamType = ierrors.New("unknown spam type")
+	// ErrValueSpamOutputMissing is returned if value spam is configured without an output to spend.
+	ErrValueSpamOutputMissing = ierrors.New("value spam output missing")
+	// ErrValueSpamOutputSpent is returned if the value spam output was spent by a transaction that wasn't issued by the spammer.
+	ErrValueSpamOutputSpent = ierrors.New("value spam output spent by a foreign transaction")
+	// ErrValueSpamTransactionsPending is returned if the spammer waits for the acceptance of the pending value spam transactions.
+	ErrValueSpamTransactionsPending = ierrors.New("value spam transactions pending")
+)
+
+// Events are the events of the Spammer.
+type Events struct {
+	// BlockIssued is triggered with the ID of every issued block.
+	BlockIssued *event.Event1[iotago.BlockID]
+	// IssuanceFailed is triggered if a block could not be issued.
+	IssuanceFailed *event.Event1[error]
+}
+
+// Spammer issues blocks in a configurable rate via BlockIssuance and SubmitBlock of the node bridge,
+// e.g. for load tests of test networks.
+type Spammer struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	clock      clock.Clock
+	events     *Events
+
+	// the account that issues the blocks.
+	accountID iotago.AccountID
+	// the key that signs the blocks and unlocks the value spam outputs.
+	privateKey ed25519.PrivateKey
+	address    *iotago.Ed25519Address
+
+	blocksPerSecond            float64
+	spamType                   SpamType
+	tag                        []byte
+	valueSpamOutputID          iotago.OutputID
+	valueSpamMaxPending        int
+	valueSpamAcceptanceTimeout time.Duration
+
+	// the last output of the value spam chain that is known to be accepted.
+	valueSpamAcceptedOutput *nodebridge.Output
+	// the outputs created by the issued value spam transactions that are not known to be accepted yet, in issuance order.
+	// The next transaction spends the newest of them without waiting for its acceptance.
+	valueSpamPendingOutputs []*pendingOutput
+	// whether the pending outputs are discarded before the next value spam transaction, because an issuance failed.
+	valueSpamFallback bool
+
+	issued atomic.Uint64
+	failed atomic.Uint64
+}
+
+// WithBlocksPerSecond sets the rate in which blocks are issued.
+func WithBlocksPerSecond(blocksPerSecond float64) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.blocksPerSecond = blocksPerSecond
+	}
+}
+
+// WithSpamType sets the kind of payload that is issued.
+func WithSpamType(spamType SpamType) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.spamType = spamType
+	}
+}
+
+// WithTag sets the tag of the tagged data payloads.
+func WithTag(tag []byte) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.tag = tag
+	}
+}
+
+// WithValueSpamOutputID sets the output that is spent by the first value spam transaction.
+// The output must be a basic output owned by the Ed25519 address of the private key of the spammer.
+// Every following transaction spends the output created by the previous one without waiting for its acceptance.
+func WithValueSpamOutputID(outputID iotago.OutputID) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.valueSpamOutputID = outputID
+	}
+}
+
+// WithValueSpamMaxPending sets the amount of value spam transactions that are chained onto each other
+// before the spammer waits for their acceptance.
+func WithValueSpamMaxPending(maxPending int) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.valueSpamMaxPending = maxPending
+	}
+}
+
+// WithValueSpamAcceptanceTimeout sets the time after which the spammer falls back to the last accepted output
+// if the pending value spam transactions were not accepted, e.g. because one of them was dropped or rejected.
+func WithValueSpamAcceptanceTimeout(timeout time.Duration) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.valueSpamAcceptanceTimeout = timeout
+	}
+}
+
+// WithClock sets the clock that is used for the issuance rate and the issuing time, e.g. a clock.Mock in tests.
+func WithClock(timeSource clock.Clock) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.clock = timeSource
+	}
+}
+
+// New creates a new Spammer that issues blocks for the given account, signed with the given private key.
+func New(logger log.Logger, nodeBridge nodebridge.NodeBridge, accountID iotago.AccountID, privateKey ed25519.PrivateKey, opts ...options.Option[Spammer]) *Spammer {
+	return options.Apply(&Spammer{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		events: &Events{
+			BlockIssued:    event.New1[iotago.BlockID](),
+			IssuanceFailed: event.New1[error](),
+		},
+		accountID:       accountID,
+		privateKey:      privateKey,
+		address:         iotago.Ed25519AddressFromPubKey(privateKey.Public().(ed25519.PublicKey)),
+		blocksPerSecond: DefaultBlocksPerSecond,
+		spamType:        SpamTypeTaggedData,
+		tag:             []byte(DefaultTag),
+
+		valueSpamMaxPending:        DefaultValueSpamMaxPending,
+		valueSpamAcceptanceTimeout: DefaultValueSpamAcceptanceTimeout,
+	}, opts)
+}
+
+// Events returns the events of the Spammer.
+func (s *Spammer) Events() *Events {
+	return s.events
+}
+
+// Issued returns the amount of issued blocks.
+func (s *Spammer) Issued() uint64 {
+	return s.issued.Load()
+}
+
+// Failed returns the amount of blocks that could not be issued.
+func (s *Spammer) Failed() uint64 {
+	return s.failed.Load()
+}
+
+// Run issues blocks in the configured rate until the given context is done.
+func (s *Spammer) Run(ctx context.Context) error {
+	if s.blocksPerSecond <= 0 {
+		return ierrors.Errorf("invalid blocks per second: %f", s.blocksPerSecond)
+	}
+
+	switch s.spamType {
+	case SpamTypeEmpty, SpamTypeTaggedData:
+	case SpamTypeValue:
+		if s.valueSpamOutputID.Empty() {
+			return ErrValueSpamOutputMissing
+		}
+		if s.valueSpamMaxPending <= 0 {
+			return ierrors.Errorf("invalid value spam max pending: %d", s.valueSpamMaxPending)
+		}
+
+		output, err := s.nodeBridge.Output(ctx, s.valueSpamOutputID)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to read value spam output %s", s.valueSpamOutputID.ToHex())
+		}
+		s.valueSpamAcceptedOutput = output
+	default:
+		return ierrors.Wrapf(ErrUnknownSpamType, "spam type: %s", s.spamType)
+	}
+
+	ticker := s.clock.NewTicker(time.Duration(float64(time.Second) / s.blocksPerSecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+		}
+
+		blockID, err := s.issueBlock(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			if s.spamType == SpamTypeValue && !ierrors.Is(err, ErrValueSpamTransactionsPending) {
+				// the chain of pending transactions might be broken, so the next transaction spends the last accepted output
+				s.valueSpamFallback = true
+			}
+
+			s.failed.Add(1)
+			s.LogDebugf("failed to issue block: %s", err)
+			s.events.IssuanceFailed.Trigger(err)
+
+			continue
+		}
+
+		s.issued.Add(1)
+		s.events.BlockIssued.Trigger(blockID)
+	}
+}
+
+func (s *Spammer) issueBlock(ctx context.Context) (iotago.BlockID, error) {
+	blockIssuance, err := s.nodeBridge.BlockIssuance(ctx, nodebridge.UniformBlockIssuanceParents(iotago.BasicBlockMaxParents))
+	if err != nil {
+		return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to request block issuance")
+	}
+
+	commitmentID, err := blockIssuance.LatestCommitment.ID()
+	if err != nil {
+		return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to compute commitment ID")
+	}
+
+	issuingTime := s.clock.Now()
+	apiForTime := s.nodeBridge.APIProvider().APIForTime(issuingTime)
+	referenceManaCost := blockIssuance.LatestCommitment.ReferenceManaCost
+
+	blockBuilder := builder.NewBasicBlockBuilder(apiForTime).
+		IssuingTime(issuingTime).
+		SlotCommitmentID(commitmentID).
+		LatestFinalizedSlot(blockIssuance.LatestFinalizedSlot).
+		StrongParents(blockIssuance.StrongParents).
+		WeakParents(blockIssuance.WeakParents).
+		ShallowLikeParents(blockIssuance.ShallowLikeParents)
+
+	var createdOutput *nodebridge.Output
+	switch s.spamType {
+	case SpamTypeTaggedData:
+		blockBuilder.Payload(&iotago.TaggedData{
+			Tag:  s.tag,
+			Data: binary.BigEndian.AppendUint64(nil, s.issued.Load()),
+		})
+	case SpamTypeValue:
+		input, err := s.valueSpamInput(ctx)
+		if err != nil {
+			return iotago.EmptyBlockID, err
+		}
+
+		signedTransaction, output, err := s.valueSpamTransaction(apiForTime, input, issuingTime, commitmentID, referenceManaCost)
+		if err != nil {
+			return iotago.EmptyBlockID, err
+		}
+		blockBuilder.Payload(signedTransaction)
+		createdOutput = output
+	}
+
+	block, err := blockBuilder.
+		CalculateAndSetMaxBurnedMana(referenceManaCost).
+		Sign(s.accountID, s.privateKey).
+		Build()
+	if err != nil {
+		return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to build block")
+	}
+
+	blockID, err := s.nodeBridge.SubmitBlock(ctx, block)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	if createdOutput != nil {
+		// the next transaction spends the output of this transaction without waiting for its acceptance
+		s.valueSpamPendingOutputs = append(s.valueSpamPendingOutputs, &pendingOutput{
+			output:   createdOutput,
+			issuedAt: issuingTime,
+		})
+	}
+
+	return blockID, nil
+}
+
+// pendingOutput is an output created by a value spam transaction that is not known to be accepted yet.
+type pendingOutput struct {
+	output   *nodebridge.Output
+	issuedAt time.Time
+}
+
+// valueSpamInput returns the output that is spent by the next value spam transaction.
+// If too many transactions are pending, the chain is synced with the ledger of the node first.
+// The pending outputs are discarded if an issuance failed or if they were not accepted in time,
+// so the spammer falls back to the last accepted output instead of spending outputs that might never exist.
+func (s *Spammer) valueSpamInput(ctx context.Context) (*nodebridge.Output, error) {
+	if s.valueSpamFallback || len(s.valueSpamPendingOutputs) >= s.valueSpamMaxPending {
+		if err := s.syncValueSpamOutputs(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(s.valueSpamPendingOutputs) >= s.valueSpamMaxPending && s.clock.Now().Sub(s.valueSpamPendingOutputs[0].issuedAt) < s.valueSpamAcceptanceTimeout {
+		return nil, ierrors.Wrapf(ErrValueSpamTransactionsPending, "waiting for the acceptance of %d transactions", len(s.valueSpamPendingOutputs))
+	}
+
+	if s.valueSpamFallback || len(s.valueSpamPendingOutputs) >= s.valueSpamMaxPending {
+		if len(s.valueSpamPendingOutputs) > 0 {
+			s.LogDebugf("discarding %d pending value spam transactions, falling back to output %s", len(s.valueSpamPendingOutputs), s.valueSpamAcceptedOutput.OutputID.ToHex())
+		}
+
+		s.valueSpamPendingOutputs = nil
+		s.valueSpamFallback = false
+	}
+
+	if len(s.valueSpamPendingOutputs) == 0 {
+		return s.valueSpamAcceptedOutput, nil
+	}
+
+	return s.valueSpamPendingOutputs[len(s.valueSpamPendingOutputs)-1].output, nil
+}
+
+// syncValueSpamOutputs follows the value spam chain in the ledger of the node, starting at the last accepted output,
+// via the transactions that spent the outputs. The newest unspent output of the chain becomes the last accepted output,
+// and the pending outputs up to it are removed.
+func (s *Spammer) syncValueSpamOutputs(ctx context.Context) error {
+	output, err := s.nodeBridge.Output(ctx, s.valueSpamAcceptedOutput.OutputID)
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to read value spam output %s", s.valueSpamAcceptedOutput.OutputID.ToHex())
+	}
+
+	for output.Metadata.Spent != nil {
+		// every value spam transaction creates a single output that is owned by the spammer
+		nextOutputID := iotago.OutputIDFromTransactionIDAndIndex(output.Metadata.Spent.TransactionID, 0)
+
+		nextOutput, err := s.nodeBridge.Output(ctx, nextOutputID)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to read value spam output %s", nextOutputID.ToHex())
+		}
+
+		if !s.ownsOutput(nextOutput.Output) {
+			return ierrors.Wrapf(ErrValueSpamOutputSpent, "output %s was spent by transaction %s", output.OutputID.ToHex(), output.Metadata.Spent.TransactionID.ToHex())
+		}
+
+		output = nextOutput
+	}
+
+	for i, pending := range s.valueSpamPendingOutputs {
+		if pending.output.OutputID == output.OutputID {
+			s.valueSpamPendingOutputs = s.valueSpamPendingOutputs[i+1:]
+
+			break
+		}
+	}
+	s.valueSpamAcceptedOutput = output
+
+	return nil
+}
+
+// ownsOutput returns whether the given output is a basic output that is owned by the address of the spammer.
+func (s *Spammer) ownsOutput(output iotago.Output) bool {
+	basicOutput, ok := output.(*iotago.BasicOutput)
+	if !ok {
+		return false
+	}
+
+	addressUnlockCondition := basicOutput.UnlockConditionSet().Address()
+
+	return addressUnlockCondition != nil && addressUnlockCondition.Address.Equal(s.address)
+}
+
+// valueSpamTransaction builds a transaction that sends the given output back to the own address,
+// the remaining mana is allotted to the issuing account.
+func (s *Spammer) valueSpamTransaction(apiForTime iotago.API, input *nodebridge.Output, issuingTime time.Time, commitmentID iotago.CommitmentID, referenceManaCost iotago.Mana) (*iotago.SignedTransaction, *nodebridge.Output, error) {
+	creationSlot := apiForTime.TimeProvider().SlotFromTime(issuingTime)
+
+	output := &iotago.BasicOutput{
+		Amount: input.Output.BaseTokenAmount(),
+		UnlockConditions: iotago.BasicOutputUnlockConditions{
+			&iotago.AddressUnlockCondition{Address: s.address},
+		},
+	}
+
+	signedTransaction, err := builder.NewTransactionBuilder(apiForTime, iotago.NewInMemoryAddressSignerFromEd25519PrivateKeys(s.privateKey)).
+		AddInput(&builder.TxInput{
+			UnlockTarget: s.address,
+			InputID:      input.OutputID,
+			Input:        input.Output,
+		}).
+		AddOutput(output).
+		AddCommitmentInput(&iotago.CommitmentInput{CommitmentID: commitmentID}).
+		SetCreationSlot(creationSlot).
+		AllotMinRequiredManaAndStoreRemainingManaInOutput(creationSlot, referenceManaCost, s.accountID, 0).
+		Build()
+	if err != nil {
+		return nil, nil, ierrors.Wrap(err, "failed to build value spam transaction")
+	}
+
+	transactionID, err := signedTransaction.Transaction.ID()
+	if err != nil {
+		return nil, nil, ierrors.Wrap(err, "failed to compute value spam transaction ID")
+	}
+
+	return signedTransaction, &nodebridge.Output{
+		OutputID: iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0),
+		Output:   signedTransaction.Transaction.Outputs[0],
+	}, nil
+}
@@ -0,0 +1,279 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultHeartbeatInterval is the default interval in which a comment is sent to keep the connection alive.
+	DefaultHeartbeatInterval = 15 * time.Second
+
+	// EventCommitment is the name of the server-sent event for commitments.
+	EventCommitment = "commitment"
+	// EventBlockConfirmed is the name of the server-sent event for confirmed blocks.
+	EventBlockConfirmed = "block-confirmed"
+
+	// HeaderLastEventID is the header that is sent by the browser on reconnect.
+	HeaderLastEventID = "Last-Event-ID"
+)
+
+// Handler is a http.Handler that streams events of the node bridge as text/event-stream.
+// The ID of every event is the slot it belongs to, so reconnecting clients resume
+// after the slot in the Last-Event-ID header, as far as the stream supports it.
+//
+// All clients share a single stream of the node, which is maintained by Run.
+// Clients that can't keep up with the shared stream are disconnected and resume on reconnect.
+type Handler struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge        nodebridge.NodeBridge
+	heartbeatInterval time.Duration
+	bufferSize        int
+	run               func(ctx context.Context) error
+	listen            func(ctx context.Context, resumeSlot iotago.SlotIndex, send func(event *Event) error) error
+}
+
+// Event is a single server-sent event.
+type Event struct {
+	// ID is the slot the event belongs to.
+	ID iotago.SlotIndex
+	// Name is the name of the event.
+	Name string
+	// Data is the JSON encoded payload of the event.
+	Data []byte
+}
+
+// WithHeartbeatInterval sets the interval in which a comment is sent to keep the connection alive.
+// If the interval is zero, no heartbeats are sent.
+func WithHeartbeatInterval(interval time.Duration) options.Option[Handler] {
+	return func(h *Handler) {
+		h.heartbeatInterval = interval
+	}
+}
+
+// WithBufferSize sets the amount of events that are buffered per client before the client is disconnected.
+func WithBufferSize(bufferSize int) options.Option[Handler] {
+	return func(h *Handler) {
+		h.bufferSize = bufferSize
+	}
+}
+
+// NewCommitmentsHandler returns a Handler that streams the commitments of the node.
+// Reconnecting clients receive all commitments after the slot in the Last-Event-ID header.
+func NewCommitmentsHandler(logger log.Logger, nodeBridge nodebridge.NodeBridge, opts ...options.Option[Handler]) *Handler {
+	h := newHandler(logger, nodeBridge, opts...)
+
+	dispatcher := nodebridge.NewStreamDispatcher(func(ctx context.Context, consumer func(commitment *nodebridge.Commitment) error) error {
+		return nodeBridge.ListenToCommitments(ctx, 0, 0, func(commitment *nodebridge.Commitment, _ []byte) error {
+			return consumer(commitment)
+		})
+	}, nodebridge.WithStreamDispatcherBufferSize[*nodebridge.Commitment](h.bufferSize))
+
+	h.run = dispatcher.Run
+	h.listen = func(ctx context.Context, resumeSlot iotago.SlotIndex, send func(event *Event) error) error {
+		return streamCommitments(ctx, nodeBridge, dispatcher, resumeSlot, func(commitment *nodebridge.Commitment) error {
+			apiForSlot := nodeBridge.APIProvider().APIForSlot(commitment.CommitmentID.Slot())
+
+			data, err := apiForSlot.JSONEncode(commitment.Commitment)
+			if err != nil {
+				return err
+			}
+
+			return send(&Event{ID: commitment.CommitmentID.Slot(), Name: EventCommitment, Data: data})
+		})
+	}
+
+	return h
+}
+
+// NewConfirmedBlocksHandler returns a Handler that streams the metadata of confirmed blocks.
+// The node does not replay block metadata, so reconnecting clients only receive new confirmations.
+func NewConfirmedBlocksHandler(logger log.Logger, nodeBridge nodebridge.NodeBridge, opts ...options.Option[Handler]) *Handler {
+	h := newHandler(logger, nodeBridge, opts...)
+
+	// only the confirmed blocks are dispatched to the clients
+	dispatcher := nodebridge.NewStreamDispatcher(func(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+		return nodeBridge.ListenToBlockMetadata(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			if blockMetadata.BlockState != api.BlockStateConfirmed {
+				return nil
+			}
+
+			return consumer(blockMetadata)
+		})
+	}, nodebridge.WithStreamDispatcherBufferSize[*api.BlockMetadataResponse](h.bufferSize))
+
+	h.run = dispatcher.Run
+	h.listen = func(ctx context.Context, _ iotago.SlotIndex, send func(event *Event) error) error {
+		return dispatcher.Subscribe(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			apiForSlot := nodeBridge.APIProvider().APIForSlot(blockMetadata.BlockID.Slot())
+
+			data, err := apiForSlot.JSONEncode(blockMetadata)
+			if err != nil {
+				return err
+			}
+
+			return send(&Event{ID: blockMetadata.BlockID.Slot(), Name: EventBlockConfirmed, Data: data})
+		})
+	}
+
+	return h
+}
+
+func newHandler(logger log.Logger, nodeBridge nodebridge.NodeBridge, opts ...options.Option[Handler]) *Handler {
+	return options.Apply(&Handler{
+		Logger:            logger,
+		nodeBridge:        nodeBridge,
+		heartbeatInterval: DefaultHeartbeatInterval,
+		bufferSize:        nodebridge.DefaultStreamDispatcherBufferSize,
+	}, opts)
+}
+
+// streamCommitments passes the commitments from the resume slot on to the given consumer, followed by the commitments
+// of the shared stream. The commitments that the shared stream delivered before the client subscribed are read
+// with a one-off range request. If the resume slot is zero, only the commitments of the shared stream are passed.
+func streamCommitments(ctx context.Context, nodeBridge nodebridge.NodeBridge, dispatcher *nodebridge.StreamDispatcher[*nodebridge.Commitment], resumeSlot iotago.SlotIndex, consumer func(commitment *nodebridge.Commitment) error) error {
+	// the slot of the next commitment the client expects, or zero if the client only expects new commitments
+	nextSlot := resumeSlot
+
+	replay := func(endSlot iotago.SlotIndex) error {
+		if nextSlot == 0 || nextSlot > endSlot {
+			return nil
+		}
+
+		return nodeBridge.ListenToCommitments(ctx, nextSlot, endSlot, func(commitment *nodebridge.Commitment, _ []byte) error {
+			nextSlot = commitment.CommitmentID.Slot() + 1
+
+			return consumer(commitment)
+		})
+	}
+
+	if latestCommitment := nodeBridge.LatestCommitment(); latestCommitment != nil {
+		if err := replay(latestCommitment.CommitmentID.Slot()); err != nil {
+			return err
+		}
+	}
+
+	return dispatcher.Subscribe(ctx, func(commitment *nodebridge.Commitment) error {
+		slot := commitment.CommitmentID.Slot()
+
+		if nextSlot != 0 {
+			if slot < nextSlot {
+				// the commitment was already replayed
+				return nil
+			}
+
+			// fill the gap between the replayed commitments and the shared stream
+			if err := replay(slot - 1); err != nil {
+				return err
+			}
+		}
+		nextSlot = slot + 1
+
+		return consumer(commitment)
+	})
+}
+
+// Run maintains the stream of the node that is shared by all clients until the given context is done.
+// Clients are only served while the Handler is running.
+func (h *Handler) Run(ctx context.Context) error {
+	return h.run(ctx)
+}
+
+// ServeHTTP streams the events until the client disconnects.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	resumeSlot, err := parseLastEventID(r.Header.Get(HeaderLastEventID))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s header: %s", HeaderLastEventID, err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx, cancel := context.WithCancel(r.Context())
+
+	// the heartbeat must not write to the response after ServeHTTP returned
+	var heartbeatWaitGroup sync.WaitGroup
+	defer func() {
+		cancel()
+		heartbeatWaitGroup.Wait()
+	}()
+
+	// the stream and the heartbeat write concurrently to the response
+	var writeMutex sync.Mutex
+	write := func(format string, args ...any) error {
+		writeMutex.Lock()
+		defer writeMutex.Unlock()
+
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		flusher.Flush()
+
+		return nil
+	}
+
+	if h.heartbeatInterval > 0 {
+		heartbeatWaitGroup.Add(1)
+		go func() {
+			defer heartbeatWaitGroup.Done()
+
+			ticker := time.NewTicker(h.heartbeatInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := write(": heartbeat\n\n"); err != nil {
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	if err := h.listen(ctx, resumeSlot, func(event *Event) error {
+		return write("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Name, event.Data)
+	}); err != nil && ctx.Err() == nil {
+		h.LogWarnf("server-sent events stream failed: %s", err)
+	}
+}
+
+// parseLastEventID returns the slot after the slot in the Last-Event-ID header, or zero if the header is empty.
+func parseLastEventID(lastEventID string) (iotago.SlotIndex, error) {
+	if lastEventID == "" {
+		return 0, nil
+	}
+
+	slot, err := strconv.ParseUint(lastEventID, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return iotago.SlotIndex(slot) + 1, nil
+}
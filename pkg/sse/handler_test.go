@@ -0,0 +1,140 @@
+package sse
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+// commitmentsNodeBridge serves the commitments of all slots up to the latest slot, the other methods are not implemented.
+type commitmentsNodeBridge struct {
+	nodebridge.NodeBridge
+
+	latestSlot iotago.SlotIndex
+	// the slot ranges that were requested by ListenToCommitments.
+	requestedRanges [][2]iotago.SlotIndex
+}
+
+func testCommitment(slot iotago.SlotIndex) *nodebridge.Commitment {
+	return &nodebridge.Commitment{CommitmentID: iotago.NewCommitmentID(slot, tpkg.Rand32ByteArray())}
+}
+
+func (n *commitmentsNodeBridge) LatestCommitment() *nodebridge.Commitment {
+	return testCommitment(n.latestSlot)
+}
+
+func (n *commitmentsNodeBridge) ListenToCommitments(_ context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *nodebridge.Commitment, rawData []byte) error) error {
+	n.requestedRanges = append(n.requestedRanges, [2]iotago.SlotIndex{startSlot, endSlot})
+
+	for slot := startSlot; slot <= endSlot; slot++ {
+		if err := consumer(testCommitment(slot), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestStreamCommitments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		resumeSlot iotago.SlotIndex
+		latestSlot iotago.SlotIndex
+		// the slots of the shared stream after the client subscribed.
+		sharedSlots    []iotago.SlotIndex
+		expectedSlots  []iotago.SlotIndex
+		expectedRanges [][2]iotago.SlotIndex
+	}{
+		{
+			name:          "no resume",
+			latestSlot:    7,
+			sharedSlots:   []iotago.SlotIndex{8, 9, 10},
+			expectedSlots: []iotago.SlotIndex{8, 9, 10},
+		},
+		{
+			name:           "resume up to the shared stream",
+			resumeSlot:     3,
+			latestSlot:     7,
+			sharedSlots:    []iotago.SlotIndex{8, 9, 10},
+			expectedSlots:  []iotago.SlotIndex{3, 4, 5, 6, 7, 8, 9, 10},
+			expectedRanges: [][2]iotago.SlotIndex{{3, 7}},
+		},
+		{
+			name:           "resume with a gap to the shared stream",
+			resumeSlot:     3,
+			latestSlot:     5,
+			sharedSlots:    []iotago.SlotIndex{8, 9, 10},
+			expectedSlots:  []iotago.SlotIndex{3, 4, 5, 6, 7, 8, 9, 10},
+			expectedRanges: [][2]iotago.SlotIndex{{3, 5}, {6, 7}},
+		},
+		{
+			name:           "resume overlapping the shared stream",
+			resumeSlot:     3,
+			latestSlot:     9,
+			sharedSlots:    []iotago.SlotIndex{8, 9, 10},
+			expectedSlots:  []iotago.SlotIndex{3, 4, 5, 6, 7, 8, 9, 10},
+			expectedRanges: [][2]iotago.SlotIndex{{3, 9}},
+		},
+		{
+			name:          "resume at the shared stream",
+			resumeSlot:    8,
+			latestSlot:    7,
+			sharedSlots:   []iotago.SlotIndex{8, 9, 10},
+			expectedSlots: []iotago.SlotIndex{8, 9, 10},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			nodeBridge := &commitmentsNodeBridge{latestSlot: test.latestSlot}
+
+			var dispatcher *nodebridge.StreamDispatcher[*nodebridge.Commitment]
+			dispatcher = nodebridge.NewStreamDispatcher(func(ctx context.Context, consumer func(commitment *nodebridge.Commitment) error) error {
+				// the shared stream starts once the client subscribed
+				for dispatcher.SubscribersCount() == 0 {
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					time.Sleep(time.Millisecond)
+				}
+
+				for _, slot := range test.sharedSlots {
+					if err := consumer(testCommitment(slot)); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+			go func() { _ = dispatcher.Run(ctx) }()
+
+			var slots []iotago.SlotIndex
+			if err := streamCommitments(ctx, nodeBridge, dispatcher, test.resumeSlot, func(commitment *nodebridge.Commitment) error {
+				slots = append(slots, commitment.CommitmentID.Slot())
+
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(slots, test.expectedSlots) {
+				t.Errorf("expected slots %v, got %v", test.expectedSlots, slots)
+			}
+			if !slices.Equal(nodeBridge.requestedRanges, test.expectedRanges) {
+				t.Errorf("expected range requests %v, got %v", test.expectedRanges, nodeBridge.requestedRanges)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package storagedeposit
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Calculator computes the minimum storage deposits of outputs with the storage score structure of the protocol parameters.
+// The protocol parameters are taken from the APIProvider on every call, so the results follow protocol parameter changes.
+type Calculator struct {
+	apiProvider iotago.APIProvider
+}
+
+// New creates a new Calculator, e.g. with the APIProvider of the node bridge.
+func New(apiProvider iotago.APIProvider) *Calculator {
+	return &Calculator{
+		apiProvider: apiProvider,
+	}
+}
+
+// MinDeposit returns the minimum storage deposit of the given output under the committed API.
+func (c *Calculator) MinDeposit(output iotago.Output) (iotago.BaseToken, error) {
+	return minDeposit(c.apiProvider.CommittedAPI(), output)
+}
+
+// MinDepositForSlot returns the minimum storage deposit of the given output under the API of the given slot.
+func (c *Calculator) MinDepositForSlot(output iotago.Output, slot iotago.SlotIndex) (iotago.BaseToken, error) {
+	return minDeposit(c.apiProvider.APIForSlot(slot), output)
+}
+
+// StorageScore returns the storage score of the given output under the committed API.
+func (c *Calculator) StorageScore(output iotago.Output) iotago.StorageScore {
+	return output.StorageScore(c.apiProvider.CommittedAPI().StorageScoreStructure(), nil)
+}
+
+// MinBasicOutputDeposit returns the minimum storage deposit of a basic output that only has an address unlock condition.
+func (c *Calculator) MinBasicOutputDeposit(address iotago.Address) (iotago.BaseToken, error) {
+	return c.MinDeposit(&iotago.BasicOutput{
+		UnlockConditions: iotago.BasicOutputUnlockConditions{
+			&iotago.AddressUnlockCondition{Address: address},
+		},
+	})
+}
+
+// CheckDeposit returns an error that matches iotago.ErrStorageDepositNotCovered
+// if the base token amount of the given output doesn't cover its minimum storage deposit.
+func (c *Calculator) CheckDeposit(output iotago.Output) error {
+	if _, err := c.apiProvider.CommittedAPI().StorageScoreStructure().CoversMinDeposit(output, output.BaseTokenAmount()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func minDeposit(api iotago.API, output iotago.Output) (iotago.BaseToken, error) {
+	deposit, err := api.StorageScoreStructure().MinDeposit(output)
+	if err != nil {
+		return 0, ierrors.Wrap(err, "failed to compute minimum storage deposit")
+	}
+
+	return deposit, nil
+}
@@ -0,0 +1,58 @@
+package storagedeposit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// RouteMinDeposit is the route to compute the minimum storage deposit of the output in the request body.
+	RouteMinDeposit = "/api/storage-deposit/v1/min-deposit"
+)
+
+// MinDepositResponse is the response of the RouteMinDeposit route.
+type MinDepositResponse struct {
+	// MinDeposit is the minimum storage deposit of the output.
+	MinDeposit string `json:"minDeposit"`
+	// StorageScore is the storage score of the output.
+	StorageScore string `json:"storageScore"`
+	// Covered is true if the amount of the output covers the minimum storage deposit.
+	Covered bool `json:"covered"`
+}
+
+// RegisterRoutes registers the routes of the calculator at the given echo instance.
+func (c *Calculator) RegisterRoutes(e *echo.Echo) {
+	e.POST(RouteMinDeposit, c.HandleMinDeposit)
+}
+
+// HandleMinDeposit computes the minimum storage deposit of the output in the request body,
+// which can be JSON or binary encoded.
+func (c *Calculator) HandleMinDeposit(ctx echo.Context) error {
+	api := c.apiProvider.CommittedAPI()
+
+	output, err := httpserver.ParseRequestByHeader(ctx, api, func(bytes []byte) (iotago.TxEssenceOutput, int, error) {
+		var output iotago.TxEssenceOutput
+		consumed, err := api.Decode(bytes, &output)
+
+		return output, consumed, err
+	})
+	if err != nil {
+		return err
+	}
+
+	minDeposit, err := minDeposit(api, output)
+	if err != nil {
+		return err
+	}
+
+	return httpserver.JSONResponse(ctx, http.StatusOK, &MinDepositResponse{
+		MinDeposit:   strconv.FormatUint(uint64(minDeposit), 10),
+		StorageScore: strconv.FormatUint(uint64(output.StorageScore(api.StorageScoreStructure(), nil)), 10),
+		Covered:      output.BaseTokenAmount() >= minDeposit,
+	})
+}
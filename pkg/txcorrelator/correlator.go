@@ -0,0 +1,296 @@
+package txcorrelator
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultMaxPendingSlots is the default amount of slots an accepted transaction may be committed after the slot it was accepted in.
+	DefaultMaxPendingSlots = 10
+)
+
+var (
+	// ErrTransactionDropped is returned if an accepted transaction was not committed within the maximum amount of pending slots.
+	ErrTransactionDropped = ierrors.New("accepted transaction was not committed")
+)
+
+// CommittedTransaction is an accepted transaction whose slot was committed.
+type CommittedTransaction struct {
+	// API is the API of the slot of the commitment.
+	API iotago.API
+	// TransactionID is the ID of the transaction.
+	TransactionID iotago.TransactionID
+	// AcceptedSlot is the slot in which the transaction was accepted.
+	AcceptedSlot iotago.SlotIndex
+	// CommitmentID is the ID of the commitment that contains the transaction.
+	CommitmentID iotago.CommitmentID
+	// Consumed are the outputs consumed by the transaction, as contained in the ledger update.
+	Consumed []*nodebridge.Output
+	// Created are the outputs created by the transaction, as contained in the ledger update.
+	Created []*nodebridge.Output
+}
+
+// Events are the events of the Correlator.
+type Events struct {
+	// TransactionCommitted is triggered if the slot of an accepted transaction was committed.
+	TransactionCommitted *event.Event1[*CommittedTransaction]
+	// TransactionDropped is triggered if an accepted transaction was not committed within the maximum amount of pending slots,
+	// e.g. because it was orphaned after its acceptance.
+	TransactionDropped *event.Event1[*nodebridge.AcceptedTransaction]
+}
+
+// Correlator joins the accepted transactions with the ledger updates of the committed slots,
+// so consumers get a single event when a transaction moves from accepted to committed.
+// The streams are independent, so the transactions of the ledger updates of the latest slots are kept
+// to correlate accepted transactions that are received after the ledger update of their commitment.
+// Transactions that were accepted before the correlator started are not correlated.
+type Correlator struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge      nodebridge.NodeBridge
+	events          *Events
+	maxPendingSlots iotago.SlotIndex
+
+	mutex   sync.Mutex
+	pending map[iotago.TransactionID]*nodebridge.AcceptedTransaction
+	waiters map[iotago.TransactionID][]chan *CommittedTransaction
+	// recentlyCommitted are the transactions of the ledger updates of the latest slots that were not accepted yet.
+	recentlyCommitted map[iotago.SlotIndex]map[iotago.TransactionID]*CommittedTransaction
+}
+
+// WithMaxPendingSlots sets the amount of slots an accepted transaction may be committed after the slot it was accepted in,
+// before the TransactionDropped event is triggered.
+func WithMaxPendingSlots(maxPendingSlots iotago.SlotIndex) options.Option[Correlator] {
+	return func(c *Correlator) {
+		c.maxPendingSlots = maxPendingSlots
+	}
+}
+
+// New creates a new Correlator.
+func New(logger log.Logger, nodeBridge nodebridge.NodeBridge, opts ...options.Option[Correlator]) *Correlator {
+	return options.Apply(&Correlator{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		events: &Events{
+			TransactionCommitted: event.New1[*CommittedTransaction](),
+			TransactionDropped:   event.New1[*nodebridge.AcceptedTransaction](),
+		},
+		maxPendingSlots:   DefaultMaxPendingSlots,
+		pending:           make(map[iotago.TransactionID]*nodebridge.AcceptedTransaction),
+		waiters:           make(map[iotago.TransactionID][]chan *CommittedTransaction),
+		recentlyCommitted: make(map[iotago.SlotIndex]map[iotago.TransactionID]*CommittedTransaction),
+	}, opts)
+}
+
+// Events returns the events of the Correlator.
+func (c *Correlator) Events() *Events {
+	return c.events
+}
+
+// Run correlates the accepted transactions with the ledger updates until the given context is done.
+func (c *Correlator) Run(ctx context.Context) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		return c.nodeBridge.ListenToAcceptedTransactions(groupCtx, func(tx *nodebridge.AcceptedTransaction) error {
+			c.ApplyAcceptedTransaction(tx)
+
+			return nil
+		})
+	})
+
+	group.Go(func() error {
+		return c.nodeBridge.ListenToLedgerUpdates(groupCtx, 0, 0, func(update *nodebridge.LedgerUpdate) error {
+			c.ApplyLedgerUpdate(update)
+
+			return nil
+		})
+	})
+
+	if err := group.Wait(); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyAcceptedTransaction adds the given accepted transaction to the transactions that wait for their commitment.
+// If the ledger update of the commitment of the transaction was already applied, the TransactionCommitted event is triggered.
+func (c *Correlator) ApplyAcceptedTransaction(tx *nodebridge.AcceptedTransaction) {
+	c.mutex.Lock()
+
+	for _, committedTransactions := range c.recentlyCommitted {
+		committedTransaction, isCommitted := committedTransactions[tx.TransactionID]
+		if !isCommitted {
+			continue
+		}
+		delete(committedTransactions, tx.TransactionID)
+
+		committedTransaction.AcceptedSlot = tx.Slot
+		waiters := c.waiters[tx.TransactionID]
+		delete(c.waiters, tx.TransactionID)
+
+		c.mutex.Unlock()
+
+		c.notifyCommitted(committedTransaction, waiters)
+
+		return
+	}
+
+	c.pending[tx.TransactionID] = tx
+
+	c.mutex.Unlock()
+}
+
+// ApplyLedgerUpdate triggers the TransactionCommitted event for every pending transaction that is contained in the given ledger update,
+// and the TransactionDropped event for every pending transaction that exceeded the maximum amount of pending slots.
+// The transactions of the ledger update that were not accepted yet are kept for the maximum amount of pending slots.
+func (c *Correlator) ApplyLedgerUpdate(update *nodebridge.LedgerUpdate) {
+	consumedByTransaction := make(map[iotago.TransactionID][]*nodebridge.Output)
+	for _, output := range update.Consumed {
+		if output.Metadata == nil || output.Metadata.Spent == nil {
+			continue
+		}
+		transactionID := output.Metadata.Spent.TransactionID
+		consumedByTransaction[transactionID] = append(consumedByTransaction[transactionID], output)
+	}
+
+	createdByTransaction := make(map[iotago.TransactionID][]*nodebridge.Output)
+	for _, output := range update.Created {
+		transactionID := output.OutputID.TransactionID()
+		createdByTransaction[transactionID] = append(createdByTransaction[transactionID], output)
+	}
+
+	committedSlot := update.CommitmentID.Slot()
+
+	var committed []*CommittedTransaction
+	var dropped []*nodebridge.AcceptedTransaction
+	waiters := make(map[*CommittedTransaction][]chan *CommittedTransaction)
+
+	c.mutex.Lock()
+
+	notAccepted := make(map[iotago.TransactionID]*CommittedTransaction)
+
+	// every committed transaction created at least one output
+	for transactionID, created := range createdByTransaction {
+		committedTransaction := &CommittedTransaction{
+			API:           update.API,
+			TransactionID: transactionID,
+			CommitmentID:  update.CommitmentID,
+			Consumed:      consumedByTransaction[transactionID],
+			Created:       created,
+		}
+
+		tx, isPending := c.pending[transactionID]
+		if !isPending {
+			// the accepted transaction may be received after the ledger update
+			notAccepted[transactionID] = committedTransaction
+
+			continue
+		}
+		delete(c.pending, transactionID)
+
+		committedTransaction.AcceptedSlot = tx.Slot
+		committed = append(committed, committedTransaction)
+
+		waiters[committedTransaction] = c.waiters[transactionID]
+		delete(c.waiters, transactionID)
+	}
+
+	c.recentlyCommitted[committedSlot] = notAccepted
+	for slot := range c.recentlyCommitted {
+		if slot+c.maxPendingSlots < committedSlot {
+			delete(c.recentlyCommitted, slot)
+		}
+	}
+
+	for transactionID, tx := range c.pending {
+		if tx.Slot+c.maxPendingSlots >= committedSlot {
+			continue
+		}
+		delete(c.pending, transactionID)
+		dropped = append(dropped, tx)
+
+		// the waiters are notified with nil, which means the transaction was dropped
+		waiters[nil] = append(waiters[nil], c.waiters[transactionID]...)
+		delete(c.waiters, transactionID)
+	}
+
+	c.mutex.Unlock()
+
+	for _, committedTransaction := range committed {
+		c.notifyCommitted(committedTransaction, waiters[committedTransaction])
+	}
+	for _, waiter := range waiters[nil] {
+		waiter <- nil
+	}
+	for _, tx := range dropped {
+		c.LogDebugf("accepted transaction %s of slot %d was not committed until slot %d", tx.TransactionID, tx.Slot, committedSlot)
+		c.events.TransactionDropped.Trigger(tx)
+	}
+}
+
+// notifyCommitted passes the committed transaction to the given waiters and triggers the TransactionCommitted event.
+func (c *Correlator) notifyCommitted(committedTransaction *CommittedTransaction, waiters []chan *CommittedTransaction) {
+	for _, waiter := range waiters {
+		waiter <- committedTransaction
+	}
+
+	c.events.TransactionCommitted.Trigger(committedTransaction)
+}
+
+// WaitForCommitment waits until the slot of the given accepted transaction was committed.
+// It can be called before the transaction was accepted, e.g. right after it was submitted.
+// It returns ErrTransactionDropped if the transaction was accepted, but not committed within the maximum amount of pending slots.
+func (c *Correlator) WaitForCommitment(ctx context.Context, transactionID iotago.TransactionID) (*CommittedTransaction, error) {
+	waiter := make(chan *CommittedTransaction, 1)
+
+	c.mutex.Lock()
+	c.waiters[transactionID] = append(c.waiters[transactionID], waiter)
+	c.mutex.Unlock()
+
+	select {
+	case committedTransaction := <-waiter:
+		if committedTransaction == nil {
+			return nil, ierrors.Wrapf(ErrTransactionDropped, "transaction %s", transactionID)
+		}
+
+		return committedTransaction, nil
+
+	case <-ctx.Done():
+		c.removeWaiter(transactionID, waiter)
+
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Correlator) removeWaiter(transactionID iotago.TransactionID, waiter chan *CommittedTransaction) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	transactionWaiters := c.waiters[transactionID]
+	for i, transactionWaiter := range transactionWaiters {
+		if transactionWaiter == waiter {
+			transactionWaiters = append(transactionWaiters[:i], transactionWaiters[i+1:]...)
+
+			break
+		}
+	}
+
+	if len(transactionWaiters) == 0 {
+		delete(c.waiters, transactionID)
+	} else {
+		c.waiters[transactionID] = transactionWaiters
+	}
+}
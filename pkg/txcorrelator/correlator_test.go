@@ -0,0 +1,255 @@
+package txcorrelator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+const testMaxPendingSlots = 3
+
+// correlatorHarness feeds accepted transactions and ledger updates of a fixed set of transactions into a Correlator,
+// and records the events per transaction.
+type correlatorHarness struct {
+	correlator     *Correlator
+	transactionIDs []iotago.TransactionID
+
+	committed map[iotago.TransactionID][]*CommittedTransaction
+	dropped   map[iotago.TransactionID]int
+}
+
+func newCorrelatorHarness(transactionCount int) *correlatorHarness {
+	h := &correlatorHarness{
+		correlator: New(log.NewLogger(log.WithName("correlator")), nil, WithMaxPendingSlots(testMaxPendingSlots)),
+		committed:  make(map[iotago.TransactionID][]*CommittedTransaction),
+		dropped:    make(map[iotago.TransactionID]int),
+	}
+
+	for range transactionCount {
+		h.transactionIDs = append(h.transactionIDs, tpkg.RandTransactionID())
+	}
+
+	h.correlator.Events().TransactionCommitted.Hook(func(committedTransaction *CommittedTransaction) {
+		h.committed[committedTransaction.TransactionID] = append(h.committed[committedTransaction.TransactionID], committedTransaction)
+	})
+	h.correlator.Events().TransactionDropped.Hook(func(tx *nodebridge.AcceptedTransaction) {
+		h.dropped[tx.TransactionID]++
+	})
+
+	return h
+}
+
+func (h *correlatorHarness) accept(transactionIndex int, slot iotago.SlotIndex) {
+	h.correlator.ApplyAcceptedTransaction(&nodebridge.AcceptedTransaction{
+		Slot:          slot,
+		TransactionID: h.transactionIDs[transactionIndex],
+	})
+}
+
+// commit applies the ledger update of the given slot, in which every given transaction consumes and creates an output.
+func (h *correlatorHarness) commit(slot iotago.SlotIndex, transactionIndexes ...int) {
+	update := &nodebridge.LedgerUpdate{
+		CommitmentID: iotago.NewCommitmentID(slot, tpkg.Rand32ByteArray()),
+	}
+
+	for _, transactionIndex := range transactionIndexes {
+		transactionID := h.transactionIDs[transactionIndex]
+		consumedOutputID := tpkg.RandOutputID(0)
+
+		update.Consumed = append(update.Consumed, &nodebridge.Output{
+			OutputID: consumedOutputID,
+			Metadata: &api.OutputMetadata{
+				OutputID: consumedOutputID,
+				Spent:    &api.OutputConsumptionMetadata{Slot: slot, TransactionID: transactionID},
+			},
+		})
+		update.Created = append(update.Created, &nodebridge.Output{
+			OutputID: iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0),
+		})
+	}
+
+	h.correlator.ApplyLedgerUpdate(update)
+}
+
+func TestCorrelator(t *testing.T) {
+	t.Parallel()
+
+	type expectedTransaction struct {
+		// the slot the transaction was accepted in, if it was committed.
+		acceptedSlot iotago.SlotIndex
+		// the slot of the commitment of the transaction, or zero if it was not committed.
+		committedSlot iotago.SlotIndex
+		dropped       bool
+	}
+
+	tests := []struct {
+		name     string
+		steps    func(h *correlatorHarness)
+		expected []expectedTransaction
+	}{
+		{
+			name: "accepted then committed",
+			steps: func(h *correlatorHarness) {
+				h.accept(0, 5)
+				h.commit(5, 0)
+			},
+			expected: []expectedTransaction{
+				{acceptedSlot: 5, committedSlot: 5},
+			},
+		},
+		{
+			name: "committed then accepted",
+			steps: func(h *correlatorHarness) {
+				h.commit(5, 0)
+				h.accept(0, 5)
+				h.commit(6)
+			},
+			expected: []expectedTransaction{
+				{acceptedSlot: 5, committedSlot: 5},
+			},
+		},
+		{
+			name: "committed in a later slot",
+			steps: func(h *correlatorHarness) {
+				h.accept(0, 5)
+				h.accept(1, 5)
+				h.commit(5, 0)
+				h.commit(6)
+				h.commit(7, 1)
+			},
+			expected: []expectedTransaction{
+				{acceptedSlot: 5, committedSlot: 5},
+				{acceptedSlot: 5, committedSlot: 7},
+			},
+		},
+		{
+			name: "accepted but never committed",
+			steps: func(h *correlatorHarness) {
+				h.accept(0, 5)
+				for slot := iotago.SlotIndex(5); slot <= 5+testMaxPendingSlots+2; slot++ {
+					h.commit(slot)
+				}
+			},
+			expected: []expectedTransaction{
+				{dropped: true},
+			},
+		},
+		{
+			name: "committed and accepted after the pending slots",
+			steps: func(h *correlatorHarness) {
+				h.commit(5, 0)
+				for slot := iotago.SlotIndex(6); slot <= 5+testMaxPendingSlots+1; slot++ {
+					h.commit(slot)
+				}
+				// the ledger update of the transaction is not kept anymore, so the transaction is dropped eventually
+				h.accept(0, 5)
+				h.commit(5 + testMaxPendingSlots + 2)
+			},
+			expected: []expectedTransaction{
+				{dropped: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := newCorrelatorHarness(len(test.expected))
+			test.steps(h)
+
+			for i, expected := range test.expected {
+				transactionID := h.transactionIDs[i]
+				committed := h.committed[transactionID]
+
+				if expected.committedSlot == 0 {
+					if len(committed) != 0 {
+						t.Errorf("transaction %d: expected no commitment, got %d", i, len(committed))
+					}
+				} else {
+					if len(committed) != 1 {
+						t.Fatalf("transaction %d: expected exactly one commitment, got %d", i, len(committed))
+					}
+					if committed[0].CommitmentID.Slot() != expected.committedSlot {
+						t.Errorf("transaction %d: expected commitment of slot %d, got %d", i, expected.committedSlot, committed[0].CommitmentID.Slot())
+					}
+					if committed[0].AcceptedSlot != expected.acceptedSlot {
+						t.Errorf("transaction %d: expected accepted slot %d, got %d", i, expected.acceptedSlot, committed[0].AcceptedSlot)
+					}
+					if len(committed[0].Consumed) != 1 || len(committed[0].Created) != 1 {
+						t.Errorf("transaction %d: expected one consumed and one created output, got %d and %d", i, len(committed[0].Consumed), len(committed[0].Created))
+					}
+				}
+
+				expectedDropped := 0
+				if expected.dropped {
+					expectedDropped = 1
+				}
+				if h.dropped[transactionID] != expectedDropped {
+					t.Errorf("transaction %d: expected %d drops, got %d", i, expectedDropped, h.dropped[transactionID])
+				}
+			}
+		})
+	}
+}
+
+func TestCorrelatorWaitForCommitment(t *testing.T) {
+	t.Parallel()
+
+	h := newCorrelatorHarness(2)
+
+	type result struct {
+		committedTransaction *CommittedTransaction
+		err                  error
+	}
+
+	wait := func(transactionIndex int) <-chan *result {
+		results := make(chan *result, 1)
+		go func() {
+			committedTransaction, err := h.correlator.WaitForCommitment(context.Background(), h.transactionIDs[transactionIndex])
+			results <- &result{committedTransaction: committedTransaction, err: err}
+		}()
+
+		// wait until the waiter is registered, so the ledger updates can't be applied before
+		for {
+			h.correlator.mutex.Lock()
+			registered := len(h.correlator.waiters[h.transactionIDs[transactionIndex]]) > 0
+			h.correlator.mutex.Unlock()
+
+			if registered {
+				return results
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	committedResult := wait(0)
+	droppedResult := wait(1)
+
+	h.accept(0, 5)
+	h.accept(1, 5)
+	h.commit(5, 0)
+	for slot := iotago.SlotIndex(6); slot <= 5+testMaxPendingSlots+1; slot++ {
+		h.commit(slot)
+	}
+
+	committed := <-committedResult
+	if committed.err != nil {
+		t.Fatal(committed.err)
+	}
+	if committed.committedTransaction.TransactionID != h.transactionIDs[0] {
+		t.Error("waiter got the wrong transaction")
+	}
+
+	dropped := <-droppedResult
+	if !ierrors.Is(dropped.err, ErrTransactionDropped) {
+		t.Errorf("expected %s, got %v", ErrTransactionDropped, dropped.err)
+	}
+}
@@ -0,0 +1,101 @@
+package txsender
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/ledgermirror"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// InputSource returns the unspent basic outputs of an address that can be used as inputs.
+type InputSource interface {
+	// UnspentOutputs returns the unspent basic outputs of the given address.
+	UnspentOutputs(ctx context.Context, address iotago.Address) ([]*nodebridge.Output, error)
+}
+
+// InputSourceFunc is a function that implements InputSource.
+type InputSourceFunc func(ctx context.Context, address iotago.Address) ([]*nodebridge.Output, error)
+
+// UnspentOutputs calls the function.
+func (f InputSourceFunc) UnspentOutputs(ctx context.Context, address iotago.Address) ([]*nodebridge.Output, error) {
+	return f(ctx, address)
+}
+
+// IndexerInputSource returns an InputSource that queries the basic outputs of an address from the indexer plugin of the node.
+func IndexerInputSource(nodeBridge nodebridge.NodeBridge) InputSource {
+	return InputSourceFunc(func(ctx context.Context, address iotago.Address) ([]*nodebridge.Output, error) {
+		indexer, err := nodeBridge.Indexer(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		hrp := nodeBridge.APIProvider().CommittedAPI().ProtocolParameters().Bech32HRP()
+		falseCondition := false
+		resultSet, err := indexer.Outputs(ctx, &api.BasicOutputsQuery{
+			IndexerTimelockParams:       api.IndexerTimelockParams{HasTimelock: &falseCondition},
+			IndexerExpirationParams:     api.IndexerExpirationParams{HasExpiration: &falseCondition},
+			IndexerStorageDepositParams: api.IndexerStorageDepositParams{HasStorageDepositReturn: &falseCondition},
+			AddressBech32:               address.Bech32(hrp),
+		})
+		if err != nil {
+			return nil, ierrors.Wrap(err, "failed to query basic outputs")
+		}
+
+		outputs := make([]*nodebridge.Output, 0)
+		for resultSet.Next() {
+			outputIDs, err := resultSet.Response.Items.OutputIDs()
+			if err != nil {
+				return nil, ierrors.Wrap(err, "invalid output IDs in indexer response")
+			}
+
+			for _, outputID := range outputIDs {
+				output, err := nodeBridge.Output(ctx, outputID)
+				if err != nil {
+					return nil, ierrors.Wrapf(err, "failed to read output %s", outputID.ToHex())
+				}
+				outputs = append(outputs, output)
+			}
+		}
+		if resultSet.Error != nil {
+			return nil, ierrors.Wrap(resultSet.Error, "failed to query basic outputs")
+		}
+
+		return outputs, nil
+	})
+}
+
+// MirrorInputSource returns an InputSource that reads the unspent outputs of an address from the ledger mirror.
+func MirrorInputSource(nodeBridge nodebridge.NodeBridge, mirror *ledgermirror.Mirror) InputSource {
+	return InputSourceFunc(func(ctx context.Context, address iotago.Address) ([]*nodebridge.Output, error) {
+		hrp := nodeBridge.APIProvider().CommittedAPI().ProtocolParameters().Bech32HRP()
+
+		outputIDs, err := mirror.Storage().UnspentOutputIDs(ctx, address.Bech32(hrp))
+		if err != nil {
+			return nil, ierrors.Wrap(err, "failed to read unspent outputs")
+		}
+
+		outputs := make([]*nodebridge.Output, 0, len(outputIDs))
+		for _, outputID := range outputIDs {
+			record, err := mirror.Storage().Output(ctx, outputID)
+			if err != nil {
+				return nil, ierrors.Wrapf(err, "failed to read output %s", outputID.ToHex())
+			}
+
+			var output iotago.TxEssenceOutput
+			if _, err := nodeBridge.APIProvider().APIForSlot(record.SlotBooked).Decode(record.RawOutputData, &output); err != nil {
+				return nil, ierrors.Wrapf(err, "failed to decode output %s", outputID.ToHex())
+			}
+
+			outputs = append(outputs, &nodebridge.Output{
+				OutputID:      outputID,
+				Output:        output,
+				RawOutputData: record.RawOutputData,
+			})
+		}
+
+		return outputs, nil
+	})
+}
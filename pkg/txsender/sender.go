@@ -0,0 +1,217 @@
+package txsender
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+var (
+	// ErrInsufficientFunds is returned if the unspent outputs of the signer don't cover the outputs and the remainder.
+	ErrInsufficientFunds = ierrors.New("insufficient funds")
+)
+
+// Signer unlocks the inputs of a transaction.
+type Signer struct {
+	// Address is the address whose unspent outputs are used as inputs and that receives the remainder.
+	Address iotago.Address
+	// AddressSigner signs the transaction for the address.
+	AddressSigner iotago.AddressSigner
+}
+
+// NewEd25519Signer creates a Signer for the Ed25519 address of the given private key.
+func NewEd25519Signer(privateKey ed25519.PrivateKey) *Signer {
+	return &Signer{
+		Address:       iotago.Ed25519AddressFromPubKey(privateKey.Public().(ed25519.PublicKey)),
+		AddressSigner: iotago.NewInMemoryAddressSignerFromEd25519PrivateKeys(privateKey),
+	}
+}
+
+// SendResult is the result of SendTransaction.
+type SendResult struct {
+	// BlockID is the ID of the block that contains the transaction.
+	BlockID iotago.BlockID
+	// TransactionID is the ID of the transaction.
+	TransactionID iotago.TransactionID
+	// SignedTransaction is the submitted transaction.
+	SignedTransaction *iotago.SignedTransaction
+}
+
+// Sender builds transactions from the unspent outputs of a signer and submits them in blocks of a block issuer account.
+type Sender struct {
+	nodeBridge  nodebridge.NodeBridge
+	inputSource InputSource
+	clock       clock.Clock
+
+	// the account that issues the blocks and receives the required mana.
+	accountID iotago.AccountID
+	// the block issuer key of the account.
+	blockIssuerKey ed25519.PrivateKey
+}
+
+// WithInputSource sets the source of the inputs, the indexer of the node is used by default.
+func WithInputSource(inputSource InputSource) options.Option[Sender] {
+	return func(s *Sender) {
+		s.inputSource = inputSource
+	}
+}
+
+// WithClock sets the clock that is used for the issuing time, e.g. a clock.Mock in tests.
+func WithClock(timeSource clock.Clock) options.Option[Sender] {
+	return func(s *Sender) {
+		s.clock = timeSource
+	}
+}
+
+// New creates a new Sender that issues the blocks for the given account, signed with the given block issuer key.
+func New(nodeBridge nodebridge.NodeBridge, accountID iotago.AccountID, blockIssuerKey ed25519.PrivateKey, opts ...options.Option[Sender]) *Sender {
+	return options.Apply(&Sender{
+		nodeBridge:     nodeBridge,
+		inputSource:    IndexerInputSource(nodeBridge),
+		clock:          clock.System,
+		accountID:      accountID,
+		blockIssuerKey: blockIssuerKey,
+	}, opts)
+}
+
+// SendTransaction sends the given outputs with the unspent basic outputs of the signer as inputs.
+// The inputs are selected until they cover the outputs and a remainder output to the address of the signer,
+// that keeps at least the minimum storage deposit. The block issuer account is allotted the mana
+// required by the reference mana cost of the latest commitment, the remaining mana is stored in the remainder.
+func (s *Sender) SendTransaction(ctx context.Context, outputs []iotago.Output, signer *Signer) (*SendResult, error) {
+	blockIssuance, err := s.nodeBridge.BlockIssuance(ctx, nodebridge.UniformBlockIssuanceParents(iotago.BasicBlockMaxParents))
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to request block issuance")
+	}
+
+	commitmentID, err := blockIssuance.LatestCommitment.ID()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to compute commitment ID")
+	}
+
+	issuingTime := s.clock.Now()
+	apiForTime := s.nodeBridge.APIProvider().APIForTime(issuingTime)
+	creationSlot := apiForTime.TimeProvider().SlotFromTime(issuingTime)
+	referenceManaCost := blockIssuance.LatestCommitment.ReferenceManaCost
+
+	inputs, err := s.selectInputs(ctx, apiForTime, outputs, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionBuilder := builder.NewTransactionBuilder(apiForTime, signer.AddressSigner).
+		AddCommitmentInput(&iotago.CommitmentInput{CommitmentID: commitmentID}).
+		SetCreationSlot(creationSlot)
+
+	var remainder iotago.BaseToken
+	for _, input := range inputs {
+		remainder += input.Output.BaseTokenAmount()
+		transactionBuilder.AddInput(&builder.TxInput{
+			UnlockTarget: signer.Address,
+			InputID:      input.OutputID,
+			Input:        input.Output,
+		})
+	}
+	for _, output := range outputs {
+		remainder -= output.BaseTokenAmount()
+		transactionBuilder.AddOutput(output)
+	}
+
+	remainderIndex := len(outputs)
+	transactionBuilder.AddOutput(remainderOutput(signer.Address, remainder))
+
+	signedTransaction, err := transactionBuilder.
+		AllotMinRequiredManaAndStoreRemainingManaInOutput(creationSlot, referenceManaCost, s.accountID, remainderIndex).
+		Build()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to build transaction")
+	}
+
+	transactionID, err := signedTransaction.Transaction.ID()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to compute transaction ID")
+	}
+
+	block, err := builder.NewBasicBlockBuilder(apiForTime).
+		IssuingTime(issuingTime).
+		SlotCommitmentID(commitmentID).
+		LatestFinalizedSlot(blockIssuance.LatestFinalizedSlot).
+		StrongParents(blockIssuance.StrongParents).
+		WeakParents(blockIssuance.WeakParents).
+		ShallowLikeParents(blockIssuance.ShallowLikeParents).
+		Payload(signedTransaction).
+		CalculateAndSetMaxBurnedMana(referenceManaCost).
+		Sign(s.accountID, s.blockIssuerKey).
+		Build()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to build block")
+	}
+
+	blockID, err := s.nodeBridge.SubmitBlock(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendResult{
+		BlockID:           blockID,
+		TransactionID:     transactionID,
+		SignedTransaction: signedTransaction,
+	}, nil
+}
+
+// selectInputs selects plain basic outputs without native tokens of the signer until they cover the given outputs and the remainder.
+func (s *Sender) selectInputs(ctx context.Context, apiForTime iotago.API, outputs []iotago.Output, signer *Signer) ([]*nodebridge.Output, error) {
+	var required iotago.BaseToken
+	for _, output := range outputs {
+		required += output.BaseTokenAmount()
+	}
+
+	minRemainder, err := apiForTime.StorageScoreStructure().MinDeposit(remainderOutput(signer.Address, 0))
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to compute minimum storage deposit")
+	}
+	required += minRemainder
+
+	unspentOutputs, err := s.inputSource.UnspentOutputs(ctx, signer.Address)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to read unspent outputs")
+	}
+
+	var available iotago.BaseToken
+	inputs := make([]*nodebridge.Output, 0)
+	for _, output := range unspentOutputs {
+		// only plain basic outputs can be spent without further unlock conditions,
+		// and outputs with native tokens are skipped, because the remainder doesn't carry them and they would be burned
+		basicOutput, isBasic := output.Output.(*iotago.BasicOutput)
+		if !isBasic || len(basicOutput.UnlockConditions) != 1 || basicOutput.FeatureSet().NativeToken() != nil {
+			continue
+		}
+
+		inputs = append(inputs, output)
+		available += output.Output.BaseTokenAmount()
+		if available >= required || len(inputs) == iotago.MaxInputsCount {
+			break
+		}
+	}
+
+	if available < required {
+		return nil, ierrors.Wrapf(ErrInsufficientFunds, "required %d, available %d", required, available)
+	}
+
+	return inputs, nil
+}
+
+func remainderOutput(address iotago.Address, amount iotago.BaseToken) *iotago.BasicOutput {
+	return &iotago.BasicOutput{
+		Amount: amount,
+		UnlockConditions: iotago.BasicOutputUnlockConditions{
+			&iotago.AddressUnlockCondition{Address: address},
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package validator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultPerformanceRetentionEpochs is the default amount of epochs the performance is kept for.
+	DefaultPerformanceRetentionEpochs = 2
+)
+
+// SlotPerformance is the performance of the validator in a committed slot.
+type SlotPerformance struct {
+	// Slot is the committed slot.
+	Slot iotago.SlotIndex
+	// CommitteeMember is true if the validator was a member of the committee in the slot.
+	CommitteeMember bool
+	// ValidationBlocks is the amount of validation blocks of the validator that were observed in the slot.
+	ValidationBlocks uint64
+}
+
+// Missed returns true if the validator was a member of the committee but no validation block was observed.
+func (p *SlotPerformance) Missed() bool {
+	return p.CommitteeMember && p.ValidationBlocks == 0
+}
+
+// PerformanceTrackerEvents are the events of the PerformanceTracker.
+type PerformanceTrackerEvents struct {
+	// ValidationBlockObserved is triggered for every observed validation block of the validator.
+	ValidationBlockObserved *event.Event1[*iotago.Block]
+	// SlotCommitted is triggered with the performance of the validator in every committed slot.
+	SlotCommitted *event.Event1[*SlotPerformance]
+	// CommitteeCheckFailed is triggered if the committee membership of a committed slot could not be read.
+	CommitteeCheckFailed *event.Event1[error]
+}
+
+// PerformanceTracker counts the validation blocks issued by a validator per slot and epoch.
+type PerformanceTracker struct {
+	nodeBridge nodebridge.NodeBridge
+	accountID  iotago.AccountID
+	events     *PerformanceTrackerEvents
+
+	retentionEpochs iotago.EpochIndex
+
+	mutex            sync.RWMutex
+	slotBlocks       map[iotago.SlotIndex]uint64
+	epochBlocks      map[iotago.EpochIndex]uint64
+	latestEpochSeen  iotago.EpochIndex
+	pruningThreshold iotago.EpochIndex
+}
+
+// WithPerformanceRetentionEpochs sets the amount of epochs the performance is kept for.
+func WithPerformanceRetentionEpochs(epochs iotago.EpochIndex) options.Option[PerformanceTracker] {
+	return func(t *PerformanceTracker) {
+		t.retentionEpochs = epochs
+	}
+}
+
+// NewPerformanceTracker creates a new PerformanceTracker for the validation blocks of the given account.
+func NewPerformanceTracker(nodeBridge nodebridge.NodeBridge, accountID iotago.AccountID, opts ...options.Option[PerformanceTracker]) *PerformanceTracker {
+	return options.Apply(&PerformanceTracker{
+		nodeBridge: nodeBridge,
+		accountID:  accountID,
+		events: &PerformanceTrackerEvents{
+			ValidationBlockObserved: event.New1[*iotago.Block](),
+			SlotCommitted:           event.New1[*SlotPerformance](),
+			CommitteeCheckFailed:    event.New1[error](),
+		},
+		retentionEpochs: DefaultPerformanceRetentionEpochs,
+		slotBlocks:      make(map[iotago.SlotIndex]uint64),
+		epochBlocks:     make(map[iotago.EpochIndex]uint64),
+	}, opts)
+}
+
+// NewPerformanceTracker creates a new PerformanceTracker for the validator account.
+func (v *Validator) NewPerformanceTracker(opts ...options.Option[PerformanceTracker]) *PerformanceTracker {
+	return NewPerformanceTracker(v.nodeBridge, v.accountID, opts...)
+}
+
+// Events returns the events of the PerformanceTracker.
+func (t *PerformanceTracker) Events() *PerformanceTrackerEvents {
+	return t.events
+}
+
+// Run counts the validation blocks of the validator and reports the performance of every committed slot
+// until the given context is done.
+func (t *PerformanceTracker) Run(ctx context.Context) error {
+	commitments := t.nodeBridge.Events().SubscribeLatestCommitment(10)
+	defer commitments.Unsubscribe()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case commitment, ok := <-commitments.Values():
+				if !ok {
+					return
+				}
+				t.reportSlot(ctx, commitment.CommitmentID.Slot())
+			}
+		}
+	}()
+
+	if err := t.nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, _ []byte) error {
+		t.ApplyBlock(block)
+
+		return nil
+	}); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyBlock counts the given block if it is a validation block of the validator.
+func (t *PerformanceTracker) ApplyBlock(block *iotago.Block) {
+	if _, isValidationBlock := block.Body.(*iotago.ValidationBlockBody); !isValidationBlock || block.Header.IssuerID != t.accountID {
+		return
+	}
+
+	slot := block.Slot()
+	epoch := block.API.TimeProvider().EpochFromSlot(slot)
+
+	t.mutex.Lock()
+	if epoch >= t.pruningThreshold {
+		t.slotBlocks[slot]++
+		t.epochBlocks[epoch]++
+		t.prune(block.API, epoch)
+	}
+	t.mutex.Unlock()
+
+	t.events.ValidationBlockObserved.Trigger(block)
+}
+
+// SlotValidationBlocks returns the amount of validation blocks of the validator that were observed in the given slot.
+func (t *PerformanceTracker) SlotValidationBlocks(slot iotago.SlotIndex) uint64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.slotBlocks[slot]
+}
+
+// EpochValidationBlocks returns the amount of validation blocks of the validator that were observed in the given epoch.
+func (t *PerformanceTracker) EpochValidationBlocks(epoch iotago.EpochIndex) uint64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.epochBlocks[epoch]
+}
+
+// reportSlot checks the committee membership of the given committed slot and triggers SlotCommitted.
+func (t *PerformanceTracker) reportSlot(ctx context.Context, slot iotago.SlotIndex) {
+	committeeMember, err := t.nodeBridge.ReadIsCommitteeMember(ctx, t.accountID, slot)
+	if err != nil {
+		if ctx.Err() == nil {
+			t.events.CommitteeCheckFailed.Trigger(err)
+		}
+
+		return
+	}
+
+	t.events.SlotCommitted.Trigger(&SlotPerformance{
+		Slot:             slot,
+		CommitteeMember:  committeeMember,
+		ValidationBlocks: t.SlotValidationBlocks(slot),
+	})
+}
+
+// prune removes the performance of the epochs outside the retention window, the mutex must be held by the caller.
+func (t *PerformanceTracker) prune(api iotago.API, epoch iotago.EpochIndex) {
+	if epoch <= t.latestEpochSeen || epoch < t.retentionEpochs {
+		t.latestEpochSeen = max(t.latestEpochSeen, epoch)
+
+		return
+	}
+	t.latestEpochSeen = epoch
+	t.pruningThreshold = epoch - t.retentionEpochs + 1
+
+	for prunedEpoch := range t.epochBlocks {
+		if prunedEpoch < t.pruningThreshold {
+			delete(t.epochBlocks, prunedEpoch)
+		}
+	}
+	for slot := range t.slotBlocks {
+		if api.TimeProvider().EpochFromSlot(slot) < t.pruningThreshold {
+			delete(t.slotBlocks, slot)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/clock"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+// Validator contains the helpers for validator extensions of a single validator account.
+type Validator struct {
+	nodeBridge nodebridge.NodeBridge
+	clock      clock.Clock
+
+	// the validator account.
+	accountID iotago.AccountID
+	// the block issuer key of the validator account.
+	privateKey ed25519.PrivateKey
+}
+
+// WithClock sets the clock that is used for the issuing time, e.g. a clock.Mock in tests.
+func WithClock(timeSource clock.Clock) options.Option[Validator] {
+	return func(v *Validator) {
+		v.clock = timeSource
+	}
+}
+
+// New creates a new Validator for the given account, blocks are signed with the given block issuer key.
+func New(nodeBridge nodebridge.NodeBridge, accountID iotago.AccountID, privateKey ed25519.PrivateKey, opts ...options.Option[Validator]) *Validator {
+	return options.Apply(&Validator{
+		nodeBridge: nodeBridge,
+		clock:      clock.System,
+		accountID:  accountID,
+		privateKey: privateKey,
+	}, opts)
+}
+
+// AccountID returns the ID of the validator account.
+func (v *Validator) AccountID() iotago.AccountID {
+	return v.accountID
+}
+
+// CandidacyAnnouncementBlock builds a basic block with a candidacy announcement payload on fresh parents.
+func (v *Validator) CandidacyAnnouncementBlock(ctx context.Context) (*iotago.Block, error) {
+	blockIssuance, err := v.nodeBridge.BlockIssuance(ctx, nodebridge.UniformBlockIssuanceParents(iotago.BasicBlockMaxParents))
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to request block issuance")
+	}
+
+	commitmentID, err := blockIssuance.LatestCommitment.ID()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to compute commitment ID")
+	}
+
+	issuingTime := v.clock.Now()
+
+	block, err := builder.NewBasicBlockBuilder(v.nodeBridge.APIProvider().APIForTime(issuingTime)).
+		IssuingTime(issuingTime).
+		SlotCommitmentID(commitmentID).
+		LatestFinalizedSlot(blockIssuance.LatestFinalizedSlot).
+		StrongParents(blockIssuance.StrongParents).
+		WeakParents(blockIssuance.WeakParents).
+		ShallowLikeParents(blockIssuance.ShallowLikeParents).
+		Payload(&iotago.CandidacyAnnouncement{}).
+		CalculateAndSetMaxBurnedMana(blockIssuance.LatestCommitment.ReferenceManaCost).
+		Sign(v.accountID, v.privateKey).
+		Build()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to build candidacy announcement block")
+	}
+
+	return block, nil
+}
+
+// AnnounceCandidacy submits a candidacy announcement for the validator account.
+func (v *Validator) AnnounceCandidacy(ctx context.Context) (iotago.BlockID, error) {
+	block, err := v.CandidacyAnnouncementBlock(ctx)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	return v.nodeBridge.SubmitBlock(ctx, block)
+}
+
+// IsCandidate returns true if the validator account is a candidate for the committee in the given slot.
+func (v *Validator) IsCandidate(ctx context.Context, slot iotago.SlotIndex) (bool, error) {
+	return v.nodeBridge.ReadIsCandidate(ctx, v.accountID, slot)
+}
+
+// IsCommitteeMember returns true if the validator account is a member of the committee in the given slot.
+func (v *Validator) IsCommitteeMember(ctx context.Context, slot iotago.SlotIndex) (bool, error) {
+	return v.nodeBridge.ReadIsCommitteeMember(ctx, v.accountID, slot)
+}
+
+// IsValidatorAccount returns true if the account is a registered validator in the given slot.
+func (v *Validator) IsValidatorAccount(ctx context.Context, slot iotago.SlotIndex) (bool, error) {
+	return v.nodeBridge.ReadIsValidatorAccount(ctx, v.accountID, slot)
+}
@@ -0,0 +1,430 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/sink"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultWorkers is the default amount of notifications that are delivered in parallel.
+	DefaultWorkers = 4
+	// DefaultQueueSize is the default amount of notifications that are queued for delivery.
+	DefaultQueueSize = 1000
+	// DefaultMaxRetries is the default maximum amount of retries of a failed delivery.
+	DefaultMaxRetries = 5
+	// DefaultRetryBackoff is the default backoff before the first retry, it is doubled with every retry.
+	DefaultRetryBackoff = time.Second
+	// DefaultMaxRetryBackoff is the default maximum backoff between two retries.
+	DefaultMaxRetryBackoff = time.Minute
+	// DefaultDeliveryTimeout is the default timeout of a single delivery attempt.
+	DefaultDeliveryTimeout = 10 * time.Second
+)
+
+var (
+	// ErrDeliveryQueueFull is returned if a notification was dropped because the delivery queue is full.
+	ErrDeliveryQueueFull = ierrors.New("delivery queue full")
+	// ErrDeliveryRejected is returned if the receiver rejected a notification with a client error.
+	ErrDeliveryRejected = ierrors.New("delivery rejected")
+	// ErrDeliveryFailed is returned if a notification could not be delivered after all retries.
+	ErrDeliveryFailed = ierrors.New("delivery failed")
+)
+
+// Notification is the JSON body that is POSTed to the URL of a subscription.
+type Notification struct {
+	// ID is the ID of the notification, it stays the same for all delivery attempts.
+	ID string `json:"id"`
+	// SubscriptionID is the ID of the notified subscription.
+	SubscriptionID string `json:"subscriptionId"`
+	// EventType is the type of the event.
+	EventType EventType `json:"eventType"`
+	// Slot is the slot of the event.
+	Slot iotago.SlotIndex `json:"slot"`
+	// Data is the JSON representation of the output, transaction or block like in the core API.
+	Data json.RawMessage `json:"data"`
+}
+
+// DispatcherEvents are the events triggered by the Dispatcher.
+type DispatcherEvents struct {
+	// DeliveryFailed is triggered if a notification was dropped or could not be delivered after all retries.
+	DeliveryFailed *event.Event2[*Notification, error]
+}
+
+type delivery struct {
+	subscription *subscription
+	notification *Notification
+	body         []byte
+}
+
+// Dispatcher watches the streams of the node bridge and POSTs signed notifications about
+// matching events to the URLs of the registered subscriptions, retrying failed deliveries with a backoff.
+// Subscriptions can be registered and unregistered while the Dispatcher is running.
+type Dispatcher struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge nodebridge.NodeBridge
+	httpClient *http.Client
+	events     *DispatcherEvents
+
+	workers         int
+	queueSize       int
+	maxRetries      int
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	deliveryTimeout time.Duration
+
+	subscriptionsMutex sync.RWMutex
+	subscriptions      map[string]*subscription
+	deliveries         chan *delivery
+}
+
+// WithWorkers sets the amount of notifications that are delivered in parallel.
+func WithWorkers(workers int) options.Option[Dispatcher] {
+	return func(d *Dispatcher) {
+		d.workers = workers
+	}
+}
+
+// WithQueueSize sets the amount of notifications that are queued for delivery.
+// Notifications are dropped if the queue is full, so slow receivers don't stall the streams.
+func WithQueueSize(queueSize int) options.Option[Dispatcher] {
+	return func(d *Dispatcher) {
+		d.queueSize = queueSize
+	}
+}
+
+// WithMaxRetries sets the maximum amount of retries of a failed delivery.
+func WithMaxRetries(maxRetries int) options.Option[Dispatcher] {
+	return func(d *Dispatcher) {
+		d.maxRetries = maxRetries
+	}
+}
+
+// WithRetryBackoff sets the backoff before the first retry and the maximum backoff between two retries.
+func WithRetryBackoff(retryBackoff time.Duration, maxRetryBackoff time.Duration) options.Option[Dispatcher] {
+	return func(d *Dispatcher) {
+		d.retryBackoff = retryBackoff
+		d.maxRetryBackoff = maxRetryBackoff
+	}
+}
+
+// WithDeliveryTimeout sets the timeout of a single delivery attempt.
+func WithDeliveryTimeout(timeout time.Duration) options.Option[Dispatcher] {
+	return func(d *Dispatcher) {
+		d.deliveryTimeout = timeout
+	}
+}
+
+// WithHTTPClient sets the HTTP client that is used for the deliveries.
+func WithHTTPClient(httpClient *http.Client) options.Option[Dispatcher] {
+	return func(d *Dispatcher) {
+		d.httpClient = httpClient
+	}
+}
+
+// NewDispatcher creates a new Dispatcher.
+func NewDispatcher(logger log.Logger, nodeBridge nodebridge.NodeBridge, opts ...options.Option[Dispatcher]) *Dispatcher {
+	d := options.Apply(&Dispatcher{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		httpClient: http.DefaultClient,
+		events: &DispatcherEvents{
+			DeliveryFailed: event.New2[*Notification, error](),
+		},
+		workers:         DefaultWorkers,
+		queueSize:       DefaultQueueSize,
+		maxRetries:      DefaultMaxRetries,
+		retryBackoff:    DefaultRetryBackoff,
+		maxRetryBackoff: DefaultMaxRetryBackoff,
+		deliveryTimeout: DefaultDeliveryTimeout,
+		subscriptions:   make(map[string]*subscription),
+	}, opts)
+
+	d.deliveries = make(chan *delivery, d.queueSize)
+
+	return d
+}
+
+// Events returns the events of the Dispatcher.
+func (d *Dispatcher) Events() *DispatcherEvents {
+	return d.events
+}
+
+// Register registers the given subscription and returns its ID.
+// An existing subscription with the same ID is replaced.
+func (d *Dispatcher) Register(sub *Subscription) (string, error) {
+	if sub.ID == "" {
+		id, err := randomID()
+		if err != nil {
+			return "", err
+		}
+		sub.ID = id
+	}
+
+	s, err := newSubscription(sub)
+	if err != nil {
+		return "", err
+	}
+
+	d.subscriptionsMutex.Lock()
+	defer d.subscriptionsMutex.Unlock()
+
+	d.subscriptions[sub.ID] = s
+
+	return sub.ID, nil
+}
+
+// Unregister removes the subscription with the given ID and returns whether it existed.
+// Notifications that are already queued are still delivered.
+func (d *Dispatcher) Unregister(id string) bool {
+	d.subscriptionsMutex.Lock()
+	defer d.subscriptionsMutex.Unlock()
+
+	if _, exists := d.subscriptions[id]; !exists {
+		return false
+	}
+	delete(d.subscriptions, id)
+
+	return true
+}
+
+// Subscriptions returns all registered subscriptions.
+func (d *Dispatcher) Subscriptions() []*Subscription {
+	d.subscriptionsMutex.RLock()
+	defer d.subscriptionsMutex.RUnlock()
+
+	subscriptions := make([]*Subscription, 0, len(d.subscriptions))
+	for _, s := range d.subscriptions {
+		subscriptions = append(subscriptions, s.Subscription)
+	}
+
+	return subscriptions
+}
+
+// Run watches the streams and delivers the notifications until the given context is done or one of the streams failed.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	for range d.workers {
+		group.Go(func() error {
+			for {
+				select {
+				case <-groupCtx.Done():
+					return nil
+				case delivery := <-d.deliveries:
+					d.deliver(groupCtx, delivery)
+				}
+			}
+		})
+	}
+
+	group.Go(func() error {
+		return d.nodeBridge.ListenToLedgerUpdates(groupCtx, 0, 0, func(update *nodebridge.LedgerUpdate) error {
+			slot := update.CommitmentID.Slot()
+
+			for _, output := range update.Consumed {
+				if err := d.dispatchOutput(EventTypeOutputConsumed, slot, update.API, output); err != nil {
+					return err
+				}
+			}
+			for _, output := range update.Created {
+				if err := d.dispatchOutput(EventTypeOutputCreated, slot, update.API, output); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
+
+	group.Go(func() error {
+		return d.nodeBridge.ListenToAcceptedTransactions(groupCtx, func(tx *nodebridge.AcceptedTransaction) error {
+			subjects := newEventSubjects()
+			for _, output := range tx.Consumed {
+				subjects.addOutput(output)
+			}
+			for _, output := range tx.Created {
+				subjects.addOutput(output)
+			}
+
+			return d.dispatch(EventTypeTransactionAccepted, tx.Slot, subjects, func() ([]byte, error) {
+				return sink.EncodeAcceptedTransactionJSON(tx)
+			})
+		})
+	})
+
+	group.Go(func() error {
+		return d.nodeBridge.ListenToBlocks(groupCtx, func(block *iotago.Block, _ []byte) error {
+			blockID, err := block.ID()
+			if err != nil {
+				return err
+			}
+
+			subjects := newEventSubjects()
+			if err := subjects.addBlock(block); err != nil {
+				return err
+			}
+
+			return d.dispatch(EventTypeBlock, blockID.Slot(), subjects, func() ([]byte, error) {
+				return block.API.JSONEncode(block)
+			})
+		})
+	})
+
+	return group.Wait()
+}
+
+func (d *Dispatcher) dispatchOutput(eventType EventType, slot iotago.SlotIndex, apiForSlot iotago.API, output *nodebridge.Output) error {
+	subjects := newEventSubjects()
+	subjects.addOutput(output)
+
+	return d.dispatch(eventType, slot, subjects, func() ([]byte, error) {
+		return sink.EncodeOutputJSON(apiForSlot, output)
+	})
+}
+
+// dispatch queues a notification for every subscription that matches the event.
+// The data of the event is only encoded if at least one subscription matches.
+func (d *Dispatcher) dispatch(eventType EventType, slot iotago.SlotIndex, subjects *eventSubjects, encode func() ([]byte, error)) error {
+	d.subscriptionsMutex.RLock()
+	var matching []*subscription
+	for _, s := range d.subscriptions {
+		if s.matches(eventType, subjects) {
+			matching = append(matching, s)
+		}
+	}
+	d.subscriptionsMutex.RUnlock()
+
+	if len(matching) == 0 {
+		return nil
+	}
+
+	data, err := encode()
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to encode %s event", eventType)
+	}
+
+	for _, s := range matching {
+		id, err := randomID()
+		if err != nil {
+			return err
+		}
+
+		notification := &Notification{
+			ID:             id,
+			SubscriptionID: s.ID,
+			EventType:      eventType,
+			Slot:           slot,
+			Data:           data,
+		}
+
+		body, err := json.Marshal(notification)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to encode notification %s", id)
+		}
+
+		select {
+		case d.deliveries <- &delivery{subscription: s, notification: notification, body: body}:
+		default:
+			d.events.DeliveryFailed.Trigger(notification, ErrDeliveryQueueFull)
+		}
+	}
+
+	return nil
+}
+
+// deliver POSTs the notification and retries with an exponential backoff until it was accepted,
+// the receiver rejected it, the retries are exhausted or the context is done.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *delivery) {
+	backoff := d.retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := d.post(ctx, delivery)
+		if err == nil {
+			return
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if ierrors.Is(err, ErrDeliveryRejected) || attempt >= d.maxRetries {
+			d.LogDebugf("failed to deliver notification %s to %s: %s", delivery.notification.ID, delivery.subscription.URL, err)
+			d.events.DeliveryFailed.Trigger(delivery.notification, ierrors.Join(ErrDeliveryFailed, err))
+
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		backoff = min(2*backoff, d.maxRetryBackoff)
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery *delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, d.deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.subscription.URL, bytes.NewReader(delivery.body))
+	if err != nil {
+		return ierrors.Join(ErrDeliveryRejected, err)
+	}
+
+	// the signature covers the timestamp, so a captured notification can't be replayed later
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderNotificationID, delivery.notification.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.subscription.Secret, timestamp, delivery.body))
+
+	res, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// drain the body, so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return ierrors.Errorf("receiver responded with status %d", res.StatusCode)
+	default:
+		return ierrors.Wrapf(ErrDeliveryRejected, "receiver responded with status %d", res.StatusCode)
+	}
+}
+
+func randomID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", ierrors.Wrap(err, "failed to generate ID")
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// eventSubjects are the addresses, tags and accounts an event is about.
+type eventSubjects struct {
+	addresses  map[string]struct{}
+	tags       map[string]struct{}
+	accountIDs map[iotago.AccountID]struct{}
+}
+
+func newEventSubjects() *eventSubjects {
+	return &eventSubjects{
+		addresses:  make(map[string]struct{}),
+		tags:       make(map[string]struct{}),
+		accountIDs: make(map[iotago.AccountID]struct{}),
+	}
+}
+
+func (s *eventSubjects) addAddress(address iotago.Address) {
+	s.addresses[address.Key()] = struct{}{}
+
+	if accountAddress, isAccountAddress := address.(*iotago.AccountAddress); isAccountAddress {
+		s.accountIDs[accountAddress.AccountID()] = struct{}{}
+	}
+}
+
+// addOutput adds the addresses in the unlock conditions, the tag and the account ID of the output.
+func (s *eventSubjects) addOutput(output *nodebridge.Output) {
+	unlockConditions := output.Output.UnlockConditionSet()
+
+	if addressUnlock := unlockConditions.Address(); addressUnlock != nil {
+		s.addAddress(addressUnlock.Address)
+	}
+	if stateControllerUnlock := unlockConditions.StateControllerAddress(); stateControllerUnlock != nil {
+		s.addAddress(stateControllerUnlock.Address)
+	}
+	if governorUnlock := unlockConditions.GovernorAddress(); governorUnlock != nil {
+		s.addAddress(governorUnlock.Address)
+	}
+	if immutableAccountUnlock := unlockConditions.ImmutableAccount(); immutableAccountUnlock != nil {
+		s.addAddress(immutableAccountUnlock.Address)
+	}
+	if storageDepositReturnUnlock := unlockConditions.StorageDepositReturn(); storageDepositReturnUnlock != nil {
+		s.addAddress(storageDepositReturnUnlock.ReturnAddress)
+	}
+	if expirationUnlock := unlockConditions.Expiration(); expirationUnlock != nil {
+		s.addAddress(expirationUnlock.ReturnAddress)
+	}
+
+	if tagFeature := output.Output.FeatureSet().Tag(); tagFeature != nil {
+		s.tags[string(tagFeature.Tag)] = struct{}{}
+	}
+
+	if accountOutput, isAccountOutput := output.Output.(*iotago.AccountOutput); isAccountOutput {
+		accountID := accountOutput.AccountID
+		if accountID.Empty() {
+			// the account was created by this output
+			accountID = iotago.AccountIDFromOutputID(output.OutputID)
+		}
+		s.accountIDs[accountID] = struct{}{}
+	}
+}
+
+// addBlock adds the issuer, the tag of a tagged data payload and the outputs of a transaction of the block.
+func (s *eventSubjects) addBlock(block *iotago.Block) error {
+	s.accountIDs[block.Header.IssuerID] = struct{}{}
+
+	body, isBasicBlock := block.Body.(*iotago.BasicBlockBody)
+	if !isBasicBlock {
+		return nil
+	}
+
+	var payload iotago.Payload = body.Payload
+	if signedTransaction, isSignedTransaction := body.Payload.(*iotago.SignedTransaction); isSignedTransaction {
+		transactionID, err := signedTransaction.Transaction.ID()
+		if err != nil {
+			return err
+		}
+
+		for index, output := range signedTransaction.Transaction.Outputs {
+			s.addOutput(&nodebridge.Output{
+				OutputID: iotago.OutputIDFromTransactionIDAndIndex(transactionID, uint16(index)),
+				Output:   output,
+			})
+		}
+		payload = signedTransaction.Transaction.Payload
+	}
+
+	if taggedData, isTaggedData := payload.(*iotago.TaggedData); isTaggedData {
+		s.tags[string(taggedData.Tag)] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// HeaderNotificationID is the header that contains the ID of the notification.
+	// It stays the same for all delivery attempts, so receivers can deduplicate retried notifications.
+	HeaderNotificationID = "X-Webhook-ID"
+	// HeaderTimestamp is the header that contains the unix timestamp in seconds of the delivery attempt.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is the header that contains the HMAC-SHA256 signature of the delivery attempt.
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// EventType is the type of an event a subscription is notified about.
+type EventType string
+
+const (
+	// EventTypeOutputCreated is the event of an output that was created by a committed slot.
+	EventTypeOutputCreated EventType = "output.created"
+	// EventTypeOutputConsumed is the event of an output that was consumed by a committed slot.
+	EventTypeOutputConsumed EventType = "output.consumed"
+	// EventTypeTransactionAccepted is the event of an accepted transaction.
+	EventTypeTransactionAccepted EventType = "transaction.accepted"
+	// EventTypeBlock is the event of a block that was received by the node.
+	EventTypeBlock EventType = "block"
+)
+
+var (
+	// ErrInvalidSubscription is returned if a subscription can't be registered.
+	ErrInvalidSubscription = ierrors.New("invalid subscription")
+)
+
+// Subscription is a URL that is notified about the events that match its filters.
+// An event matches if its type is one of the EventTypes and it matches one of the Addresses, Tags or AccountIDs.
+// Empty EventTypes match all event types, and if no Addresses, Tags and AccountIDs are given, all events match.
+type Subscription struct {
+	// ID is the ID of the subscription, it is generated if it is empty.
+	ID string
+	// URL is the URL the notifications are POSTed to.
+	URL string
+	// Secret is the secret the notifications are signed with.
+	Secret []byte
+	// EventTypes are the types of the events the subscription is notified about.
+	EventTypes []EventType
+	// Addresses are the bech32 addresses of the outputs, transactions and blocks the subscription is notified about.
+	Addresses []string
+	// Tags are the tags of the outputs and tagged data payloads the subscription is notified about.
+	Tags [][]byte
+	// AccountIDs are the accounts the subscription is notified about, as owner of outputs or issuer of blocks.
+	AccountIDs []iotago.AccountID
+}
+
+// subscription is a registered Subscription with its parsed filters.
+type subscription struct {
+	*Subscription
+
+	eventTypes map[EventType]struct{}
+	addresses  map[string]struct{}
+	tags       map[string]struct{}
+	accountIDs map[iotago.AccountID]struct{}
+}
+
+func newSubscription(sub *Subscription) (*subscription, error) {
+	targetURL, err := url.Parse(sub.URL)
+	if err != nil {
+		return nil, ierrors.Wrapf(ErrInvalidSubscription, "invalid URL %s: %s", sub.URL, err)
+	}
+	if targetURL.Scheme != "http" && targetURL.Scheme != "https" {
+		return nil, ierrors.Wrapf(ErrInvalidSubscription, "invalid URL %s: scheme must be http or https", sub.URL)
+	}
+
+	if len(sub.Secret) == 0 {
+		return nil, ierrors.Wrap(ErrInvalidSubscription, "secret is required to sign the notifications")
+	}
+
+	s := &subscription{
+		Subscription: sub,
+		eventTypes:   make(map[EventType]struct{}, len(sub.EventTypes)),
+		addresses:    make(map[string]struct{}, len(sub.Addresses)),
+		tags:         make(map[string]struct{}, len(sub.Tags)),
+		accountIDs:   make(map[iotago.AccountID]struct{}, len(sub.AccountIDs)),
+	}
+
+	for _, eventType := range sub.EventTypes {
+		switch eventType {
+		case EventTypeOutputCreated, EventTypeOutputConsumed, EventTypeTransactionAccepted, EventTypeBlock:
+			s.eventTypes[eventType] = struct{}{}
+		default:
+			return nil, ierrors.Wrapf(ErrInvalidSubscription, "unknown event type %s", eventType)
+		}
+	}
+
+	for _, bech32Address := range sub.Addresses {
+		_, address, err := iotago.ParseBech32(bech32Address)
+		if err != nil {
+			return nil, ierrors.Wrapf(ErrInvalidSubscription, "invalid address %s: %s", bech32Address, err)
+		}
+		s.addresses[address.Key()] = struct{}{}
+	}
+
+	for _, tag := range sub.Tags {
+		s.tags[string(tag)] = struct{}{}
+	}
+
+	for _, accountID := range sub.AccountIDs {
+		s.accountIDs[accountID] = struct{}{}
+	}
+
+	return s, nil
+}
+
+// matches returns whether the subscription is notified about an event of the given type and subjects.
+func (s *subscription) matches(eventType EventType, subjects *eventSubjects) bool {
+	if len(s.eventTypes) > 0 {
+		if _, wanted := s.eventTypes[eventType]; !wanted {
+			return false
+		}
+	}
+
+	if len(s.addresses) == 0 && len(s.tags) == 0 && len(s.accountIDs) == 0 {
+		return true
+	}
+
+	for addressKey := range subjects.addresses {
+		if _, wanted := s.addresses[addressKey]; wanted {
+			return true
+		}
+	}
+
+	for tag := range subjects.tags {
+		if _, wanted := s.tags[tag]; wanted {
+			return true
+		}
+	}
+
+	for accountID := range subjects.accountIDs {
+		if _, wanted := s.accountIDs[accountID]; wanted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sign returns the signature of a notification that is sent in the HeaderSignature header.
+// The signature is the hex encoded HMAC-SHA256 of the timestamp and the body, separated by a dot.
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature returns whether the signature of a received notification is valid.
+// Receivers should also reject notifications with an outdated timestamp to prevent replays.
+func VerifySignature(secret []byte, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,141 @@
+package websockethub
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// client is a single websocket connection of the hub.
+type client struct {
+	hub        *Hub
+	conn       *websocket.Conn
+	remoteAddr string
+
+	sendQueue chan []byte
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	topicsMutex sync.RWMutex
+	topics      map[string]struct{}
+}
+
+func newClient(hub *Hub, conn *websocket.Conn) *client {
+	return &client{
+		hub:        hub,
+		conn:       conn,
+		remoteAddr: conn.RemoteAddr().String(),
+		sendQueue:  make(chan []byte, hub.clientSendQueueSize),
+		closed:     make(chan struct{}),
+		topics:     make(map[string]struct{}),
+	}
+}
+
+// run serves the client until the connection is closed.
+func (c *client) run() {
+	defer c.hub.unregister(c)
+
+	go c.readLoop()
+	c.writeLoop()
+}
+
+func (c *client) isSubscribed(topic string) bool {
+	c.topicsMutex.RLock()
+	defer c.topicsMutex.RUnlock()
+
+	_, subscribed := c.topics[topic]
+
+	return subscribed
+}
+
+// drop disconnects a client that can't keep up with the messages.
+func (c *client) drop() {
+	// only the call that closes the client reports the drop, later broadcasts might still find the queue full
+	if !c.close() {
+		return
+	}
+
+	c.hub.LogWarnf("dropping websocket client %s, send queue is full", c.remoteAddr)
+	c.hub.events.ClientDropped.Trigger(c.remoteAddr)
+}
+
+// close closes the client and returns whether it was closed by this call.
+func (c *client) close() (closed bool) {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		closed = true
+	})
+
+	return closed
+}
+
+// isClosed returns whether the client was closed.
+func (c *client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// readLoop handles the subscription requests of the client.
+func (c *client) readLoop() {
+	defer c.close()
+
+	c.conn.SetReadLimit(c.hub.maxClientMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * c.hub.pingInterval))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(2 * c.hub.pingInterval))
+	})
+
+	for {
+		var request SubscriptionRequest
+		if err := c.conn.ReadJSON(&request); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				c.hub.LogDebugf("websocket client %s read failed: %s", c.remoteAddr, err)
+			}
+
+			return
+		}
+
+		c.topicsMutex.Lock()
+		switch request.Type {
+		case SubscriptionRequestTypeSubscribe:
+			c.topics[request.Topic] = struct{}{}
+		case SubscriptionRequestTypeUnsubscribe:
+			delete(c.topics, request.Topic)
+		}
+		c.topicsMutex.Unlock()
+	}
+}
+
+// writeLoop sends the queued messages and pings to the client.
+func (c *client) writeLoop() {
+	pingTicker := time.NewTicker(c.hub.pingInterval)
+	defer func() {
+		pingTicker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.closed:
+			_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(c.hub.writeTimeout))
+			return
+
+		case message := <-c.sendQueue:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				c.hub.LogDebugf("websocket client %s write failed: %s", c.remoteAddr, err)
+				return
+			}
+
+		case <-pingTicker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.hub.writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
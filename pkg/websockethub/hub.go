@@ -0,0 +1,285 @@
+package websockethub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultMaxClients is the default maximum amount of connected clients.
+	DefaultMaxClients = 100
+	// DefaultClientSendQueueSize is the default amount of messages that are queued per client.
+	DefaultClientSendQueueSize = 256
+	// DefaultWriteTimeout is the default timeout for writing a message to a client.
+	DefaultWriteTimeout = 10 * time.Second
+	// DefaultPingInterval is the default interval in which the clients are pinged.
+	DefaultPingInterval = 30 * time.Second
+	// DefaultMaxClientMessageSize is the default maximum size of a message sent by a client.
+	DefaultMaxClientMessageSize = 1024
+)
+
+var (
+	// ErrMaxClientsReached is returned if a client connects while the maximum amount of clients is reached.
+	ErrMaxClientsReached = ierrors.New("maximum amount of clients reached")
+	// ErrHubShutdown is returned if a client connects after the hub was shut down.
+	ErrHubShutdown = ierrors.New("hub is shut down")
+)
+
+// SubscriptionRequestType is the type of a subscription request sent by a client.
+type SubscriptionRequestType string
+
+const (
+	// SubscriptionRequestTypeSubscribe subscribes the client to a topic.
+	SubscriptionRequestTypeSubscribe SubscriptionRequestType = "subscribe"
+	// SubscriptionRequestTypeUnsubscribe unsubscribes the client from a topic.
+	SubscriptionRequestTypeUnsubscribe SubscriptionRequestType = "unsubscribe"
+)
+
+// SubscriptionRequest is sent by a client to subscribe or unsubscribe a topic.
+type SubscriptionRequest struct {
+	Type  SubscriptionRequestType `json:"type"`
+	Topic string                  `json:"topic"`
+}
+
+// Message is sent to the clients that subscribed to the topic of the message.
+type Message struct {
+	Topic string          `json:"topic"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Events are the events triggered by the Hub.
+type Events struct {
+	// ClientConnected is triggered with the remote address of a client that connected.
+	ClientConnected *event.Event1[string]
+	// ClientDisconnected is triggered with the remote address of a client that disconnected.
+	ClientDisconnected *event.Event1[string]
+	// ClientDropped is triggered with the remote address of a client that was disconnected because it could not keep up.
+	ClientDropped *event.Event1[string]
+}
+
+// Hub pushes messages to connected websocket clients based on their topic subscriptions.
+type Hub struct {
+	// the logger used to log events.
+	log.Logger
+
+	events   *Events
+	upgrader *websocket.Upgrader
+
+	maxClients           int
+	clientSendQueueSize  int
+	writeTimeout         time.Duration
+	pingInterval         time.Duration
+	maxClientMessageSize int64
+	dropSlowClients      bool
+
+	clientsMutex sync.RWMutex
+	clients      map[*client]struct{}
+	shutdown     bool
+	clientsWg    sync.WaitGroup
+}
+
+// WithMaxClients sets the maximum amount of connected clients.
+func WithMaxClients(maxClients int) options.Option[Hub] {
+	return func(h *Hub) {
+		h.maxClients = maxClients
+	}
+}
+
+// WithClientSendQueueSize sets the amount of messages that are queued per client.
+func WithClientSendQueueSize(size int) options.Option[Hub] {
+	return func(h *Hub) {
+		h.clientSendQueueSize = size
+	}
+}
+
+// WithWriteTimeout sets the timeout for writing a message to a client.
+func WithWriteTimeout(timeout time.Duration) options.Option[Hub] {
+	return func(h *Hub) {
+		h.writeTimeout = timeout
+	}
+}
+
+// WithPingInterval sets the interval in which the clients are pinged.
+func WithPingInterval(interval time.Duration) options.Option[Hub] {
+	return func(h *Hub) {
+		h.pingInterval = interval
+	}
+}
+
+// WithMaxClientMessageSize sets the maximum size of a message sent by a client.
+func WithMaxClientMessageSize(size int64) options.Option[Hub] {
+	return func(h *Hub) {
+		h.maxClientMessageSize = size
+	}
+}
+
+// WithDropSlowClients sets whether clients with a full send queue are disconnected.
+// If false, messages for clients with a full send queue are dropped instead.
+func WithDropSlowClients(drop bool) options.Option[Hub] {
+	return func(h *Hub) {
+		h.dropSlowClients = drop
+	}
+}
+
+// WithCheckOrigin sets the function that validates the origin of the websocket handshake.
+// If nil, the origin must match the host of the request.
+func WithCheckOrigin(checkOrigin func(r *http.Request) bool) options.Option[Hub] {
+	return func(h *Hub) {
+		h.upgrader.CheckOrigin = checkOrigin
+	}
+}
+
+// New creates a new Hub.
+func New(logger log.Logger, opts ...options.Option[Hub]) *Hub {
+	return options.Apply(&Hub{
+		Logger: logger,
+		events: &Events{
+			ClientConnected:    event.New1[string](),
+			ClientDisconnected: event.New1[string](),
+			ClientDropped:      event.New1[string](),
+		},
+		upgrader: &websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+		maxClients:           DefaultMaxClients,
+		clientSendQueueSize:  DefaultClientSendQueueSize,
+		writeTimeout:         DefaultWriteTimeout,
+		pingInterval:         DefaultPingInterval,
+		maxClientMessageSize: DefaultMaxClientMessageSize,
+		dropSlowClients:      true,
+		clients:              make(map[*client]struct{}),
+	}, opts)
+}
+
+// Events returns the events of the Hub.
+func (h *Hub) Events() *Events {
+	return h.events
+}
+
+// ClientsCount returns the amount of connected clients.
+func (h *Hub) ClientsCount() int {
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	return len(h.clients)
+}
+
+// ServeHTTP upgrades the request to a websocket connection and serves the client until it disconnects.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.clientsMutex.RLock()
+	shutdown := h.shutdown
+	clientsCount := len(h.clients)
+	h.clientsMutex.RUnlock()
+
+	switch {
+	case shutdown:
+		http.Error(w, ErrHubShutdown.Error(), http.StatusServiceUnavailable)
+		return
+	case h.maxClients > 0 && clientsCount >= h.maxClients:
+		http.Error(w, ErrMaxClientsReached.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		h.LogDebugf("websocket upgrade failed: %s", err)
+		return
+	}
+
+	c := newClient(h, conn)
+	if err := h.register(c); err != nil {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error()), time.Now().Add(h.writeTimeout))
+		_ = conn.Close()
+
+		return
+	}
+
+	c.run()
+}
+
+// Broadcast sends the given JSON encoded data to all clients that subscribed to the given topic.
+func (h *Hub) Broadcast(topic string, data []byte) error {
+	message, err := json.Marshal(&Message{Topic: topic, Data: data})
+	if err != nil {
+		return ierrors.Wrapf(err, "failed to encode message for topic %s", topic)
+	}
+
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	for c := range h.clients {
+		// closed clients are skipped until they are unregistered
+		if c.isClosed() || !c.isSubscribed(topic) {
+			continue
+		}
+
+		select {
+		case c.sendQueue <- message:
+		default:
+			if h.dropSlowClients {
+				c.drop()
+			}
+		}
+	}
+
+	return nil
+}
+
+// Run blocks until the given context is done and disconnects all clients afterwards.
+func (h *Hub) Run(ctx context.Context) {
+	<-ctx.Done()
+
+	h.clientsMutex.Lock()
+	h.shutdown = true
+	for c := range h.clients {
+		c.close()
+	}
+	h.clientsMutex.Unlock()
+
+	h.clientsWg.Wait()
+}
+
+func (h *Hub) register(c *client) error {
+	h.clientsMutex.Lock()
+	defer h.clientsMutex.Unlock()
+
+	if h.shutdown {
+		return ErrHubShutdown
+	}
+	if h.maxClients > 0 && len(h.clients) >= h.maxClients {
+		return ErrMaxClientsReached
+	}
+
+	h.clients[c] = struct{}{}
+	h.clientsWg.Add(1)
+
+	h.events.ClientConnected.Trigger(c.remoteAddr)
+
+	return nil
+}
+
+func (h *Hub) unregister(c *client) {
+	h.clientsMutex.Lock()
+	_, exists := h.clients[c]
+	delete(h.clients, c)
+	h.clientsMutex.Unlock()
+
+	if !exists {
+		return
+	}
+
+	h.events.ClientDisconnected.Trigger(c.remoteAddr)
+	h.clientsWg.Done()
+}
@@ -0,0 +1,64 @@
+package websockethub
+
+import (
+	"context"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// TopicCommitmentsLatest is the topic of the latest commitments.
+	TopicCommitmentsLatest = api.EventAPITopicCommitmentsLatest
+	// TopicCommitmentsFinalized is the topic of the latest finalized commitments.
+	TopicCommitmentsFinalized = api.EventAPITopicCommitmentsFinalized
+	// TopicBlockMetadataConfirmed is the topic of the metadata of confirmed blocks.
+	TopicBlockMetadataConfirmed = api.EventAPITopicBlockMetadataConfirmed
+)
+
+// HookCommitments broadcasts the latest and finalized commitments of the node bridge.
+// The returned function unhooks the events.
+func (h *Hub) HookCommitments(nodeBridge nodebridge.NodeBridge) (unhook func()) {
+	broadcastCommitment := func(topic string, commitment *nodebridge.Commitment) {
+		apiForSlot := nodeBridge.APIProvider().APIForSlot(commitment.CommitmentID.Slot())
+		if err := h.BroadcastJSON(apiForSlot, topic, commitment.Commitment); err != nil {
+			h.LogWarnf("failed to broadcast commitment %s: %s", commitment.CommitmentID, err)
+		}
+	}
+
+	latestHook := nodeBridge.Events().LatestCommitmentChanged.Hook(func(commitment *nodebridge.Commitment) {
+		broadcastCommitment(TopicCommitmentsLatest, commitment)
+	})
+	finalizedHook := nodeBridge.Events().LatestFinalizedCommitmentChanged.Hook(func(commitment *nodebridge.Commitment) {
+		broadcastCommitment(TopicCommitmentsFinalized, commitment)
+	})
+
+	return func() {
+		latestHook.Unhook()
+		finalizedHook.Unhook()
+	}
+}
+
+// BroadcastConfirmedBlocks broadcasts the metadata of confirmed blocks until the given context is done.
+func (h *Hub) BroadcastConfirmedBlocks(ctx context.Context, nodeBridge nodebridge.NodeBridge) error {
+	return nodeBridge.ListenToBlockMetadata(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+		if blockMetadata.BlockState != api.BlockStateConfirmed {
+			return nil
+		}
+
+		apiForSlot := nodeBridge.APIProvider().APIForSlot(blockMetadata.BlockID.Slot())
+
+		return h.BroadcastJSON(apiForSlot, TopicBlockMetadataConfirmed, blockMetadata)
+	})
+}
+
+// BroadcastJSON encodes the given object with the given API and sends it to all clients that subscribed to the given topic.
+func (h *Hub) BroadcastJSON(apiForSlot iotago.API, topic string, obj any) error {
+	data, err := apiForSlot.JSONEncode(obj)
+	if err != nil {
+		return err
+	}
+
+	return h.Broadcast(topic, data)
+}
@@ -0,0 +1,137 @@
+package workscore
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// RouteWorkScore is the route of the debug endpoint that computes the work score of the block in the request body.
+	RouteWorkScore = "/debug/workscore"
+
+	// the maximum size of a block in the request body.
+	maxRequestBodySize = iotago.MaxBlockSize
+)
+
+// Calculator computes the work scores and mana costs of blocks with the work score parameters of the protocol parameters.
+// The protocol parameters are taken from the APIProvider on every call, so the results follow protocol parameter changes.
+type Calculator struct {
+	apiProvider iotago.APIProvider
+}
+
+// New creates a new Calculator, e.g. with the APIProvider of the node bridge.
+func New(apiProvider iotago.APIProvider) *Calculator {
+	return &Calculator{
+		apiProvider: apiProvider,
+	}
+}
+
+// WorkScore returns the work score of the given block under the committed API.
+func (c *Calculator) WorkScore(block *iotago.Block) (iotago.WorkScore, error) {
+	return WorkScoreForAPI(block, c.apiProvider.CommittedAPI())
+}
+
+// WorkScoreForSlot returns the work score of the given block under the API of the given slot,
+// e.g. the slot in which the block is going to be issued.
+func (c *Calculator) WorkScoreForSlot(block *iotago.Block, slot iotago.SlotIndex) (iotago.WorkScore, error) {
+	return WorkScoreForAPI(block, c.apiProvider.APIForSlot(slot))
+}
+
+// LatestWorkScore returns the work score of the given block under the API of the latest supported protocol version,
+// which might not be active yet.
+func (c *Calculator) LatestWorkScore(block *iotago.Block) (iotago.WorkScore, error) {
+	return WorkScoreForAPI(block, c.apiProvider.LatestAPI())
+}
+
+// ManaCost returns the mana cost of the given block under the committed API for the given reference mana cost.
+func (c *Calculator) ManaCost(block *iotago.Block, referenceManaCost iotago.Mana) (iotago.Mana, error) {
+	workScore, err := c.WorkScore(block)
+	if err != nil {
+		return 0, err
+	}
+
+	return iotago.ManaCost(referenceManaCost, workScore)
+}
+
+// WorkScoreForAPI returns the work score of the given block under the work score parameters of the given API.
+func WorkScoreForAPI(block *iotago.Block, api iotago.API) (iotago.WorkScore, error) {
+	workScore, err := block.Body.WorkScore(api.ProtocolParameters().WorkScoreParameters())
+	if err != nil {
+		return 0, ierrors.Wrap(err, "failed to compute work score")
+	}
+
+	return workScore, nil
+}
+
+// WorkScoreResponse is the response of the RouteWorkScore endpoint.
+type WorkScoreResponse struct {
+	// WorkScore is the work score of the block under the committed API.
+	WorkScore iotago.WorkScore `json:"workScore"`
+	// LatestWorkScore is the work score of the block under the API of the latest supported protocol version.
+	LatestWorkScore iotago.WorkScore `json:"latestWorkScore"`
+	// ManaCost is the mana cost of the block for the reference mana cost given in the "rmc" query parameter.
+	ManaCost string `json:"manaCost,omitempty"`
+}
+
+// Handler returns the HTTP handler of the debug endpoint, which computes the work score of the binary encoded block
+// in the request body. If the "rmc" query parameter is given, the mana cost of the block is computed as well.
+func (c *Calculator) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		blockBytes, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize+1))
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+
+			return
+		}
+
+		block, _, err := iotago.BlockFromBytes(c.apiProvider)(blockBytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		response := &WorkScoreResponse{}
+		if response.WorkScore, err = c.WorkScore(block); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+		if response.LatestWorkScore, err = c.LatestWorkScore(block); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if rmcParam := r.URL.Query().Get("rmc"); rmcParam != "" {
+			referenceManaCost, err := strconv.ParseUint(rmcParam, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid rmc query parameter", http.StatusBadRequest)
+
+				return
+			}
+
+			manaCost, err := iotago.ManaCost(iotago.Mana(referenceManaCost), response.WorkScore)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+			response.ManaCost = strconv.FormatUint(uint64(manaCost), 10)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}